@@ -15,20 +15,24 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"slices"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
-	"github.com/meyrevived/mpc-dev-env/internal/build"
 	"github.com/meyrevived/mpc-dev-env/internal/cluster"
 	"github.com/meyrevived/mpc-dev-env/internal/config"
 	"github.com/meyrevived/mpc-dev-env/internal/daemon/api"
 	"github.com/meyrevived/mpc-dev-env/internal/daemon/git"
 	"github.com/meyrevived/mpc-dev-env/internal/daemon/state"
+	"github.com/meyrevived/mpc-dev-env/internal/deploy"
 	"github.com/meyrevived/mpc-dev-env/internal/logger"
 )
 
@@ -40,23 +44,28 @@ func main() {
 		os.Exit(1)
 	}
 
-	if envLevel := os.Getenv("LOG_LEVEL"); envLevel != "" {
-		cfg.LogLevel = envLevel
-	}
+	// Route the standard log package and slog through one handler in the configured
+	// format, so every daemon record can be filtered programmatically in json mode
+	slog.SetDefault(slog.New(newLogHandler(os.Stderr, cfg.LogFormat, cfg.LogLevel)))
 	logger.Init(cfg.LogLevel)
+	for _, warning := range cfg.Warnings {
+		logger.Info("configuration warning", "warning", warning)
+	}
 
 	logger.Info("starting MPC Dev Studio daemon")
 	logger.Info("configuration loaded",
 		"mpcRepoPath", cfg.GetMpcRepoPath(),
 		"mpcDevEnvPath", cfg.GetMpcDevEnvPath(),
 		"tempDir", cfg.GetTempDir(),
-		"logLevel", cfg.LogLevel)
+		"clusterName", cfg.GetClusterName(),
+		"logLevel", cfg.LogLevel,
+		"logFormat", cfg.LogFormat)
 
-	kubeconfigPath := filepath.Join(os.Getenv("HOME"), ".kube", "config")
+	kubeconfigPath := cfg.GetKubeconfigPath()
 
 	// Step 1: Instantiate GitManager
 	logger.Info("initializing GitManager")
-	gitManager := git.NewGitManager()
+	gitManager := git.NewGitManager(cfg.GetUpstreamURLs(), cfg.GetTimeouts().GitCommand)
 
 	// Step 2: Instantiate ClusterManager
 	logger.Info("initializing ClusterManager")
@@ -66,15 +75,30 @@ func main() {
 	logger.Info("initializing StateManager")
 
 	// Configure repository paths using Config
-	repoPaths := map[string]string{
-		"multi-platform-controller": cfg.GetMpcRepoPath(),
-	}
+	repoPaths := cfg.GetRepoPaths()
 
 	stateManagerConfig := &state.StateManagerConfig{
 		GitManager:     gitManager,
 		ClusterManager: clusterManager,
 		RepoPaths:      repoPaths,
 		KubeconfigPath: kubeconfigPath,
+		KubeContext:    cfg.GetKubeContext(),
+		ClusterName:    cfg.GetClusterName(),
+		WebhookURL:     cfg.GetWebhookURL(),
+	}
+
+	// Track the cluster and repositories of each named profile as well
+	for _, name := range cfg.GetProfileNames() {
+		profileCfg, _ := cfg.ForProfile(name)
+		if stateManagerConfig.Profiles == nil {
+			stateManagerConfig.Profiles = make(map[string]state.ProfileConfig)
+		}
+		stateManagerConfig.Profiles[name] = state.ProfileConfig{
+			ClusterManager: cluster.NewManager(profileCfg),
+			ClusterName:    profileCfg.GetClusterName(),
+			KubeContext:    profileCfg.GetKubeContext(),
+			RepoPaths:      profileCfg.GetRepoPaths(),
+		}
 	}
 
 	stateManager, err := state.NewStateManager(stateManagerConfig)
@@ -87,11 +111,16 @@ func main() {
 	// Step 4: Instantiate API handlers and router
 	logger.Info("setting up API handlers and router")
 	handlers := api.NewHandlers(stateManager, cfg)
-	router := api.NewRouter(handlers)
+	var router http.Handler = api.NewRouter(handlers)
+	if token := cfg.GetDaemonToken(); token != "" {
+		logger.Info("API bearer-token authentication enabled")
+		router = api.RequireToken(token, router)
+	}
+	router = api.RequireLocalOrigin(cfg.GetAllowedHosts(), router)
 
 	// Step 5: Create and configure HTTP server
 	server := &http.Server{
-		Addr:    "localhost:8765",
+		Addr:    fmt.Sprintf("localhost:%d", config.DaemonPort),
 		Handler: router,
 	}
 
@@ -106,34 +135,38 @@ func main() {
 
 	// Step 7: Start background Git sync ticker
 	// This replaces the Python UpstreamChangeDetector
-	syncTicker := time.NewTicker(60 * time.Minute)
-	defer syncTicker.Stop()
+	if syncInterval := cfg.GetGitSyncInterval(); syncInterval > 0 {
+		syncTicker := time.NewTicker(syncInterval)
+		defer syncTicker.Stop()
 
-	go func() {
-		logger.Info("starting background Git sync worker", "interval", "60m")
-
-		// Perform initial sync on startup
-		for repoName, repoPath := range repoPaths {
-			logger.Info("performing initial sync for repository", "repo", repoName)
-			if err := gitManager.Sync(repoPath); err != nil {
-				logger.Error(err, "failed to sync repository", "repo", repoName)
-			} else {
-				logger.Info("synced repository", "repo", repoName)
-			}
-		}
+		go func() {
+			logger.Info("starting background Git sync worker", "interval", syncInterval.String())
 
-		// Periodic sync
-		for range syncTicker.C {
-			logger.Info("running periodic Git sync")
+			// Perform initial sync on startup
 			for repoName, repoPath := range repoPaths {
+				logger.Info("performing initial sync for repository", "repo", repoName)
 				if err := gitManager.Sync(repoPath); err != nil {
 					logger.Error(err, "failed to sync repository", "repo", repoName)
 				} else {
 					logger.Info("synced repository", "repo", repoName)
 				}
 			}
-		}
-	}()
+
+			// Periodic sync
+			for range syncTicker.C {
+				logger.Info("running periodic Git sync")
+				for repoName, repoPath := range repoPaths {
+					if err := gitManager.Sync(repoPath); err != nil {
+						logger.Error(err, "failed to sync repository", "repo", repoName)
+					} else {
+						logger.Info("synced repository", "repo", repoName)
+					}
+				}
+			}
+		}()
+	} else {
+		logger.Info("background Git sync disabled (MPC_GIT_SYNC_INTERVAL)")
+	}
 
 	// Step 8: Start file watcher for hot reload (replaces detector.py)
 	// Watch the multi-platform-controller directory for changes
@@ -147,17 +180,31 @@ func main() {
 		}()
 
 		// Watch the MPC repository directory
-		if err := addRecursiveWatch(watcher, cfg.GetMpcRepoPath()); err != nil {
+		watchCfg := cfg.GetWatchConfig()
+		if err := addRecursiveWatch(watcher, cfg.GetMpcRepoPath(), watchCfg.IgnoreDirs); err != nil {
 			logger.Error(err, "failed to add watch", "path", cfg.GetMpcRepoPath())
 		} else {
-			logger.Info("file watcher active", "path", cfg.GetMpcRepoPath())
+			logger.Info("file watcher active",
+				"path", cfg.GetMpcRepoPath(),
+				"debounce", watchCfg.Debounce.String(),
+				"include", watchCfg.IncludePatterns)
 
 			// Start file watcher goroutine with debouncing
-			go fileWatcherLoop(watcher, handlers, 2*time.Second)
+			go fileWatcherLoop(watcher, watchCfg, func() { triggerRebuild(handlers) })
 		}
 	}
 
-	// Step 9: Implement graceful shutdown
+	// Step 9: Reload the configuration on SIGHUP, without restarting the daemon
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			logger.Info("received SIGHUP, reloading configuration")
+			reloadConfig(handlers)
+		}
+	}()
+
+	// Step 10: Implement graceful shutdown
 	// Set up channel to listen for interrupt signals
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -166,10 +213,20 @@ func main() {
 	sig := <-quit
 	logger.Info("received signal, shutting down gracefully", "signal", sig)
 
-	// Create a context with timeout for the shutdown
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	// Create a context with timeout for the shutdown: the grace period in-flight
+	// operations get to finish, plus time for the cancelled ones to return
+	gracePeriod := handlers.Config().GetTimeouts().ShutdownGrace
+	ctx, cancel := context.WithTimeout(context.Background(), gracePeriod+10*time.Second)
 	defer cancel()
 
+	// Drain in-flight background operations (builds, deploys, TaskRuns), cancelling those
+	// still running after the grace period, before the server stops, so they do not
+	// keep running against a daemon that is going away
+	logger.Info("waiting for in-flight operations to finish", "gracePeriod", gracePeriod)
+	if err := handlers.Shutdown(ctx, gracePeriod); err != nil {
+		logger.Error(err, "background operations did not stop before shutdown deadline")
+	}
+
 	// Attempt to gracefully shutdown the server
 	if err := server.Shutdown(ctx); err != nil {
 		logger.Error(err, "server forced to shutdown")
@@ -180,23 +237,36 @@ func main() {
 	logger.Info("MPC Dev Studio daemon stopped")
 }
 
+// newLogHandler returns a slog handler writing to w in the given format
+// (config.LogFormatJSON or text) at the given level. Unknown levels log at info.
+func newLogHandler(w io.Writer, format, level string) slog.Handler {
+	var slogLevel slog.Level
+	if err := slogLevel.UnmarshalText([]byte(level)); err != nil {
+		slogLevel = slog.LevelInfo
+	}
+
+	opts := &slog.HandlerOptions{Level: slogLevel}
+	if format == config.LogFormatJSON {
+		return slog.NewJSONHandler(w, opts)
+	}
+	return slog.NewTextHandler(w, opts)
+}
+
 // addRecursiveWatch adds a file system watcher recursively to all subdirectories
-// under the given root path. It skips common ignore patterns like .git, node_modules,
-// and IDE directories to reduce overhead.
+// under the given root path. It skips directories named in ignoreDirs (by default
+// .git, node_modules, IDE directories and the like) to reduce overhead.
 //
 // The watcher is used for hot reload functionality - when source files change in the
 // MPC repository, the daemon can automatically rebuild and redeploy.
-func addRecursiveWatch(watcher *fsnotify.Watcher, root string) error {
+func addRecursiveWatch(watcher *fsnotify.Watcher, root string, ignoreDirs []string) error {
 	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 
-		// Skip hidden directories and common ignore patterns
+		// Skip ignored directories, but never the root itself
 		if info.IsDir() {
-			name := filepath.Base(path)
-			if name == ".git" || name == "__pycache__" || name == ".pytest_cache" ||
-				name == "node_modules" || name == ".vscode" || name == ".idea" {
+			if path != root && slices.Contains(ignoreDirs, filepath.Base(path)) {
 				return filepath.SkipDir
 			}
 
@@ -211,12 +281,15 @@ func addRecursiveWatch(watcher *fsnotify.Watcher, root string) error {
 }
 
 // fileWatcherLoop processes file system events with debouncing to implement hot reload.
-// It listens for Write and Create events on source files and triggers a rebuild after
-// a debounce period (default 2 seconds) to avoid multiple rebuilds for rapid file changes.
+// It listens for Write and Create events on source files and calls rebuild after
+// the configured debounce period (default 2 seconds) to avoid multiple rebuilds for rapid
+// file changes.
 //
-// The loop ignores temporary files (.swp, .log), hidden files, and non-code files to
-// prevent unnecessary rebuild triggers.
-func fileWatcherLoop(watcher *fsnotify.Watcher, handlers *api.Handlers, debounceDuration time.Duration) {
+// The loop ignores temporary files (.swp, .log), hidden files, and files outside the
+// configured include patterns to prevent unnecessary rebuild triggers. Directories
+// created or removed while it runs are watched or unwatched (see updateDirectoryWatches).
+func fileWatcherLoop(watcher *fsnotify.Watcher, watchCfg config.WatchConfig, rebuild func()) {
+	debounceDuration := watchCfg.Debounce
 	var debounceTimer *time.Timer
 	var lastChangeTime time.Time
 
@@ -230,8 +303,13 @@ func fileWatcherLoop(watcher *fsnotify.Watcher, handlers *api.Handlers, debounce
 				return
 			}
 
+			// New directories only need a watch; the files written into them trigger the rebuild
+			if updateDirectoryWatches(watcher, event, watchCfg.IgnoreDirs) {
+				continue
+			}
+
 			// Ignore certain file types and operations
-			if shouldIgnoreEvent(event) {
+			if shouldIgnoreEvent(event, watchCfg) {
 				continue
 			}
 
@@ -248,7 +326,7 @@ func fileWatcherLoop(watcher *fsnotify.Watcher, handlers *api.Handlers, debounce
 				// Check if enough time has passed since last change
 				if time.Since(lastChangeTime) >= debounceDuration {
 					logger.Info("file changes detected, triggering rebuild")
-					triggerRebuild(handlers)
+					rebuild()
 				}
 			})
 
@@ -262,24 +340,55 @@ func fileWatcherLoop(watcher *fsnotify.Watcher, handlers *api.Handlers, debounce
 	}
 }
 
+// updateDirectoryWatches keeps the watch list in sync with directories created or removed
+// after addRecursiveWatch ran. A created directory is watched recursively unless its name
+// is in ignoreDirs; a removed or renamed directory has its watches, and those of its
+// subdirectories, dropped. It reports whether event created a directory, which on its
+// own is not a source change.
+func updateDirectoryWatches(watcher *fsnotify.Watcher, event fsnotify.Event, ignoreDirs []string) bool {
+	switch {
+	case event.Has(fsnotify.Create):
+		info, err := os.Stat(event.Name)
+		if err != nil || !info.IsDir() {
+			return false
+		}
+		if slices.Contains(ignoreDirs, filepath.Base(event.Name)) {
+			return true
+		}
+		if err := addRecursiveWatch(watcher, event.Name, ignoreDirs); err != nil {
+			logger.Error(err, "failed to watch new directory", "path", event.Name)
+		} else {
+			logger.Debug("watching new directory", "path", event.Name)
+		}
+		return true
+
+	case event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename):
+		prefix := event.Name + string(filepath.Separator)
+		for _, path := range watcher.WatchList() {
+			if path == event.Name || strings.HasPrefix(path, prefix) {
+				// The watch may already be gone with the directory itself
+				_ = watcher.Remove(path)
+			}
+		}
+	}
+	return false
+}
+
 // shouldIgnoreEvent returns true if the file system event should be ignored for hot reload.
 // It filters out events that don't indicate meaningful source code changes:
 //   - Non-Write/Create operations (Rename, Remove, Chmod)
-//   - Temporary files (.swp, .log, .tmp, etc.)
+//   - Files with an ignored extension (by default .swp, .log, .tmp, etc.)
 //   - Hidden files (starting with .)
-func shouldIgnoreEvent(event fsnotify.Event) bool {
+//   - Files matching none of the include patterns, when any are configured
+func shouldIgnoreEvent(event fsnotify.Event, watchCfg config.WatchConfig) bool {
 	// Only watch Write and Create events
 	if event.Op != fsnotify.Write && event.Op != fsnotify.Create {
 		return true
 	}
 
 	// Ignore certain file extensions
-	ext := filepath.Ext(event.Name)
-	ignoreExtensions := []string{".swp", ".swo", ".pyc", ".pyo", ".log", ".tmp"}
-	for _, ignoreExt := range ignoreExtensions {
-		if ext == ignoreExt {
-			return true
-		}
+	if slices.Contains(watchCfg.IgnoreExtensions, filepath.Ext(event.Name)) {
+		return true
 	}
 
 	// Ignore hidden files
@@ -288,41 +397,156 @@ func shouldIgnoreEvent(event fsnotify.Event) bool {
 		return true
 	}
 
+	// Only watch files matching an include pattern, if any are configured
+	if len(watchCfg.IncludePatterns) > 0 {
+		for _, pattern := range watchCfg.IncludePatterns {
+			if matched, _ := filepath.Match(pattern, base); matched {
+				return false
+			}
+		}
+		return true
+	}
+
 	return false
 }
 
-// triggerRebuild triggers an MPC rebuild and redeploy operation.
-// This function is used by both the file watcher (hot reload) and the HTTP API handler
-// to ensure consistent rebuild behavior across both code paths.
+// loadConfig loads the configuration on reload, replaced in tests.
+var loadConfig = config.LoadConfig
+
+// restartOnlySettings are settings also used by components created at daemon startup
+// (the logger, API middleware, file watcher, background git sync, git manager, and
+// cluster and state managers, which also send the completion webhook). A reload
+// reports changes to them, but they only take full effect after a restart.
+// MPC_GIT_COMMAND_TIMEOUT is read at startup too, by the git manager, but it is not
+// listed: its changes are reported together with the other timeouts as MPC_*_TIMEOUT,
+// and those others take effect on reload.
+var restartOnlySettings = []string{
+	"MPC_REPO_PATH",
+	"MPC_TRACKED_REPOS",
+	"MPC_LOG_LEVEL",
+	"MPC_LOG_FORMAT",
+	"MPC_CONTAINER_RUNTIME",
+	"MPC_CLUSTER_NAME",
+	"MPC_KIND_CONFIG_PATH",
+	"MPC_KUBECONFIG",
+	"MPC_GIT_SYNC_INTERVAL",
+	"MPC_UPSTREAM_URLS",
+	"MPC_DAEMON_TOKEN",
+	"MPC_ALLOWED_HOSTS",
+	"MPC_WEBHOOK_URL",
+	"MPC_WATCH_*",
+	"MPC_PROFILE*",
+}
+
+// reloadConfig re-reads and validates the configuration, e.g. on SIGHUP, and swaps it
+// into handlers. Requests and operations that already started keep the configuration
+// they started with. If the new configuration is invalid, the current one is kept.
+func reloadConfig(handlers *api.Handlers) {
+	cfg, err := loadConfig()
+	if err != nil {
+		logger.Error(err, "configuration reload failed, keeping the current configuration")
+		return
+	}
+	for _, warning := range cfg.Warnings {
+		logger.Info("configuration warning", "warning", warning)
+	}
+
+	changed := config.ChangedSettings(handlers.SetConfig(cfg), cfg)
+	if len(changed) == 0 {
+		logger.Info("configuration reloaded, nothing changed")
+		return
+	}
+	logger.Info("configuration reloaded", "changed", changed)
+
+	var needRestart []string
+	for _, setting := range changed {
+		if slices.Contains(restartOnlySettings, setting) {
+			needRestart = append(needRestart, setting)
+		}
+	}
+	if len(needRestart) > 0 {
+		logger.Info("some changed settings take full effect only after a daemon restart", "settings", needRestart)
+	}
+}
+
+// Hot-reload steps, replaced in tests.
+var (
+	buildImages = (*api.Handlers).BuildImages
+	deployMPC   = (*api.Handlers).DeployMPC
+)
+
+// triggerRebuild triggers an MPC rebuild, and with MPC_WATCH_REDEPLOY a redeploy,
+// after a file change. It is called by the file watcher (hot reload).
+//
+// Nothing happens while hot reload is disabled (see api.Handlers.HotReloadEnabled).
 //
-// The rebuild process:
-//  1. Sets operation status to "rebuilding"
+// The rebuild runs as a tracked operation ("hot_reload"), so it is serialized with
+// the API's builds and deploys and shows up in GET /api/operations:
+//  1. Sets operation status to "rebuilding" (or "rebuilding_and_redeploying")
 //  2. Builds the MPC container image from local source
-//  3. Updates operation status to "idle" on completion or error
+//  3. With redeploy enabled, deploys the MPC pinned to the images just built
+//  4. Updates operation status to "idle" on completion or error
 //
-// The operation runs with a 15-minute timeout to handle long build times.
+// The operation runs with the build timeout, plus the deploy timeout when redeploying.
 func triggerRebuild(handlers *api.Handlers) {
-	// Atomically transition idle → rebuilding. If the daemon is busy with another
-	// operation (e.g., running a TaskRun), the transition fails and we skip the rebuild.
-	// Hot reload is a development convenience — it must never interrupt in-flight operations.
-	if ok, actual := handlers.StateManager.TrySetOperationStatus("idle", "rebuilding", nil); !ok {
-		logger.Info("skipping hot-reload rebuild, daemon is busy", "status", actual)
+	// Hot reload can be paused at runtime via POST /api/hotreload
+	if !handlers.HotReloadEnabled() {
+		logger.Info("skipping hot-reload rebuild, hot reload is disabled")
 		return
 	}
 
-	// Create a context with timeout for the rebuild (builds can take several minutes)
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
-	defer cancel()
+	cfg := handlers.Config()
+	redeploy := cfg.GetWatchConfig().Redeploy
+	timeouts := cfg.GetTimeouts()
+	status, timeout := "rebuilding", timeouts.Build
+	if redeploy {
+		status, timeout = "rebuilding_and_redeploying", timeouts.Build+timeouts.Deploy
+	}
 
-	logger.Info("starting rebuild (triggered by file watcher)")
+	err := handlers.StartOperation("hot_reload", timeout, func(ctx context.Context, output io.Writer) error {
+		// Atomically transition idle → rebuilding. If the daemon is busy with work that
+		// is not a tracked operation (e.g., running a TaskRun), the transition fails and
+		// we skip the rebuild. Hot reload is a development convenience — it must never
+		// interrupt in-flight operations.
+		if ok, actual := handlers.StateManager.TrySetOperationStatus("idle", status, nil); !ok {
+			logger.Info("skipping hot-reload rebuild, daemon is busy", "status", actual)
+			return api.ErrOperationSkipped
+		}
 
-	// Execute native Go build
-	if err := build.BuildMPCImage(ctx, handlers.Config); err != nil {
-		logger.Error(err, "rebuild failed")
+		logger.Info("starting rebuild (triggered by file watcher)", "redeploy", redeploy)
+		err := runHotReload(ctx, handlers, cfg, output)
+		if err != nil {
+			logger.Error(err, "hot reload failed")
+		} else {
+			logger.Info("hot reload completed successfully", "redeploy", redeploy)
+		}
 		handlers.StateManager.SetOperationStatus("idle", err)
-		return
+		return err
+	})
+	if err != nil {
+		logger.Info("skipping hot-reload rebuild, daemon is busy", "reason", err.Error())
 	}
+}
 
-	logger.Info("rebuild completed successfully")
-	handlers.StateManager.SetOperationStatus("idle", nil)
+// runHotReload builds the MPC images and, with MPC_WATCH_REDEPLOY, deploys them the way
+// POST /api/mpc/rebuild-and-redeploy does.
+func runHotReload(ctx context.Context, handlers *api.Handlers, cfg *config.Config, output io.Writer) error {
+	gitHash, err := buildImages(handlers, ctx, cfg, false, output)
+	if err != nil {
+		return fmt.Errorf("rebuild failed: %w", err)
+	}
+	if !cfg.GetWatchConfig().Redeploy {
+		return nil
+	}
+
+	opts := deploy.DeployOptions{
+		SourceGitHash: gitHash,
+		IBMEnabled:    handlers.StateManager.GetState().Features.IBMEnabled,
+		Output:        output,
+	}
+	if err := deployMPC(handlers, ctx, cfg, opts); err != nil {
+		return fmt.Errorf("redeploy failed: %w", err)
+	}
+	handlers.StateManager.SetMPCSourceGitHash(gitHash)
+	return nil
 }
@@ -1,13 +1,28 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/fsnotify/fsnotify"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+
+	"github.com/meyrevived/mpc-dev-env/internal/config"
+	"github.com/meyrevived/mpc-dev-env/internal/daemon/api"
+	"github.com/meyrevived/mpc-dev-env/internal/daemon/state"
+	"github.com/meyrevived/mpc-dev-env/internal/deploy"
 )
 
 func TestMain(t *testing.T) {
@@ -15,31 +30,61 @@ func TestMain(t *testing.T) {
 	RunSpecs(t, "Main Daemon Suite")
 }
 
+// scrapeMetrics returns the daemon's metrics served by GET /metrics.
+func scrapeMetrics(handlers *api.Handlers) string {
+	rr := httptest.NewRecorder()
+	api.NewRouter(handlers).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	Expect(rr.Code).To(Equal(http.StatusOK))
+	return rr.Body.String()
+}
+
 var _ = Describe("Main Daemon File Watcher", func() {
 	Describe("shouldIgnoreEvent", func() {
+		defaults := config.DefaultWatchConfig()
+
 		It("should ignore non-write and non-create events", func() {
-			Expect(shouldIgnoreEvent(fsnotify.Event{Name: "test.go", Op: fsnotify.Remove})).To(BeTrue())
-			Expect(shouldIgnoreEvent(fsnotify.Event{Name: "test.go", Op: fsnotify.Rename})).To(BeTrue())
-			Expect(shouldIgnoreEvent(fsnotify.Event{Name: "test.go", Op: fsnotify.Chmod})).To(BeTrue())
+			Expect(shouldIgnoreEvent(fsnotify.Event{Name: "test.go", Op: fsnotify.Remove}, defaults)).To(BeTrue())
+			Expect(shouldIgnoreEvent(fsnotify.Event{Name: "test.go", Op: fsnotify.Rename}, defaults)).To(BeTrue())
+			Expect(shouldIgnoreEvent(fsnotify.Event{Name: "test.go", Op: fsnotify.Chmod}, defaults)).To(BeTrue())
 		})
 
 		It("should not ignore write or create events for regular files", func() {
-			Expect(shouldIgnoreEvent(fsnotify.Event{Name: "test.go", Op: fsnotify.Write})).To(BeFalse())
-			Expect(shouldIgnoreEvent(fsnotify.Event{Name: "path/to/file.txt", Op: fsnotify.Create})).To(BeFalse())
+			Expect(shouldIgnoreEvent(fsnotify.Event{Name: "test.go", Op: fsnotify.Write}, defaults)).To(BeFalse())
+			Expect(shouldIgnoreEvent(fsnotify.Event{Name: "path/to/file.txt", Op: fsnotify.Create}, defaults)).To(BeFalse())
 		})
 
 		It("should ignore temporary file extensions", func() {
 			for _, ext := range []string{".swp", ".swo", ".pyc", ".pyo", ".log", ".tmp"} {
 				event := fsnotify.Event{Name: "file" + ext, Op: fsnotify.Write}
-				Expect(shouldIgnoreEvent(event)).To(BeTrue(), "Failed for extension "+ext)
+				Expect(shouldIgnoreEvent(event, defaults)).To(BeTrue(), "Failed for extension "+ext)
 			}
 		})
 
 		It("should ignore hidden files", func() {
 			event := fsnotify.Event{Name: ".test.go", Op: fsnotify.Write}
-			Expect(shouldIgnoreEvent(event)).To(BeTrue())
+			Expect(shouldIgnoreEvent(event, defaults)).To(BeTrue())
 			event = fsnotify.Event{Name: "path/to/.env", Op: fsnotify.Write}
-			Expect(shouldIgnoreEvent(event)).To(BeTrue())
+			Expect(shouldIgnoreEvent(event, defaults)).To(BeTrue())
+		})
+
+		It("should use custom ignored extensions instead of the defaults", func() {
+			watchCfg := config.DefaultWatchConfig()
+			watchCfg.IgnoreExtensions = []string{".md", ".yaml"}
+
+			Expect(shouldIgnoreEvent(fsnotify.Event{Name: "docs/README.md", Op: fsnotify.Write}, watchCfg)).To(BeTrue())
+			Expect(shouldIgnoreEvent(fsnotify.Event{Name: "deploy/operator.yaml", Op: fsnotify.Write}, watchCfg)).To(BeTrue())
+			Expect(shouldIgnoreEvent(fsnotify.Event{Name: "build.log", Op: fsnotify.Write}, watchCfg)).To(BeFalse())
+		})
+
+		It("should only watch files matching an include pattern when any are set", func() {
+			watchCfg := config.DefaultWatchConfig()
+			watchCfg.IncludePatterns = []string{"*.go", "Dockerfile*"}
+
+			Expect(shouldIgnoreEvent(fsnotify.Event{Name: "pkg/controller/main.go", Op: fsnotify.Write}, watchCfg)).To(BeFalse())
+			Expect(shouldIgnoreEvent(fsnotify.Event{Name: "Dockerfile.otp", Op: fsnotify.Create}, watchCfg)).To(BeFalse())
+			Expect(shouldIgnoreEvent(fsnotify.Event{Name: "docs/README.md", Op: fsnotify.Write}, watchCfg)).To(BeTrue())
+			// Ignored extensions and hidden files still win over include patterns
+			Expect(shouldIgnoreEvent(fsnotify.Event{Name: ".main.go", Op: fsnotify.Write}, watchCfg)).To(BeTrue())
 		})
 	})
 
@@ -68,7 +113,7 @@ var _ = Describe("Main Daemon File Watcher", func() {
 			dir2 := filepath.Join(tempDir, "dir1", "dir2")
 			Expect(os.MkdirAll(dir2, 0755)).To(Succeed())
 
-			err := addRecursiveWatch(watcher, tempDir)
+			err := addRecursiveWatch(watcher, tempDir, config.DefaultWatchIgnoreDirs)
 			Expect(err).NotTo(HaveOccurred())
 
 			watchList := watcher.WatchList()
@@ -88,7 +133,7 @@ var _ = Describe("Main Daemon File Watcher", func() {
 			Expect(os.MkdirAll(nodeModulesDir, 0755)).To(Succeed())
 			Expect(os.MkdirAll(subdir, 0755)).To(Succeed())
 
-			err := addRecursiveWatch(watcher, tempDir)
+			err := addRecursiveWatch(watcher, tempDir, config.DefaultWatchIgnoreDirs)
 			Expect(err).NotTo(HaveOccurred())
 
 			watchList := watcher.WatchList()
@@ -98,5 +143,363 @@ var _ = Describe("Main Daemon File Watcher", func() {
 			Expect(watchList).NotTo(ContainElement(ideaDir))
 			Expect(watchList).NotTo(ContainElement(nodeModulesDir))
 		})
+
+		It("should skip custom ignored directories instead of the defaults", func() {
+			vendorDir := filepath.Join(tempDir, "vendor")
+			ideaDir := filepath.Join(tempDir, ".idea")
+
+			Expect(os.MkdirAll(vendorDir, 0755)).To(Succeed())
+			Expect(os.MkdirAll(ideaDir, 0755)).To(Succeed())
+
+			err := addRecursiveWatch(watcher, tempDir, []string{"vendor"})
+			Expect(err).NotTo(HaveOccurred())
+
+			watchList := watcher.WatchList()
+			Expect(watchList).NotTo(ContainElement(vendorDir))
+			Expect(watchList).To(ContainElement(ideaDir))
+		})
+	})
+
+	Describe("fileWatcherLoop", func() {
+		var (
+			watcher  *fsnotify.Watcher
+			tempDir  string
+			rebuilds chan struct{}
+		)
+
+		BeforeEach(func() {
+			var err error
+			watcher, err = fsnotify.NewWatcher()
+			Expect(err).NotTo(HaveOccurred())
+
+			tempDir, err = os.MkdirTemp("", "watch-loop-test-*")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(addRecursiveWatch(watcher, tempDir, config.DefaultWatchIgnoreDirs)).To(Succeed())
+
+			watchCfg := config.DefaultWatchConfig()
+			watchCfg.Debounce = 50 * time.Millisecond
+			rebuilds = make(chan struct{}, 10)
+			go fileWatcherLoop(watcher, watchCfg, func() { rebuilds <- struct{}{} })
+		})
+
+		AfterEach(func() {
+			_ = watcher.Close()
+			_ = os.RemoveAll(tempDir)
+		})
+
+		It("should watch new directories and rebuild for files created in them", func() {
+			newPkg := filepath.Join(tempDir, "pkg", "newpkg")
+			Expect(os.MkdirAll(newPkg, 0755)).To(Succeed())
+			Eventually(watcher.WatchList).Should(ContainElement(newPkg))
+
+			// Creating the directories alone is not a source change
+			Consistently(rebuilds, 200*time.Millisecond).ShouldNot(Receive())
+
+			Expect(os.WriteFile(filepath.Join(newPkg, "handler.go"), []byte("package newpkg\n"), 0644)).To(Succeed())
+			Eventually(rebuilds).Should(Receive())
+		})
+
+		It("should not watch new directories in the ignore list", func() {
+			nodeModules := filepath.Join(tempDir, "node_modules")
+			Expect(os.Mkdir(nodeModules, 0755)).To(Succeed())
+
+			Consistently(watcher.WatchList, 200*time.Millisecond).ShouldNot(ContainElement(nodeModules))
+		})
+
+		It("should drop the watches of removed directories", func() {
+			oldPkg := filepath.Join(tempDir, "oldpkg")
+			Expect(os.Mkdir(oldPkg, 0755)).To(Succeed())
+			Eventually(watcher.WatchList).Should(ContainElement(oldPkg))
+
+			Expect(os.RemoveAll(oldPkg)).To(Succeed())
+			Eventually(watcher.WatchList).ShouldNot(ContainElement(oldPkg))
+		})
+	})
+
+	Describe("triggerRebuild", func() {
+		var (
+			fakeState *fakeStateManager
+			cfg       *config.Config
+			handlers  *api.Handlers
+
+			stepsMu    sync.Mutex
+			steps      []string
+			buildErr   error
+			deployOpts deploy.DeployOptions
+		)
+
+		recordedSteps := func() []string {
+			stepsMu.Lock()
+			defer stepsMu.Unlock()
+			return append([]string(nil), steps...)
+		}
+
+		BeforeEach(func() {
+			fakeState = &fakeStateManager{}
+			cfg = &config.Config{}
+			handlers = api.NewHandlers(fakeState, cfg)
+			DeferCleanup(func() { _ = handlers.Shutdown(context.Background(), 0) })
+
+			steps, buildErr, deployOpts = nil, nil, deploy.DeployOptions{}
+			originalBuild, originalDeploy := buildImages, deployMPC
+			DeferCleanup(func() { buildImages, deployMPC = originalBuild, originalDeploy })
+			buildImages = func(h *api.Handlers, ctx context.Context, cfg *config.Config, force bool, output io.Writer) (string, error) {
+				stepsMu.Lock()
+				defer stepsMu.Unlock()
+				steps = append(steps, "build")
+				return "0123456789abcdef", buildErr
+			}
+			deployMPC = func(h *api.Handlers, ctx context.Context, c *config.Config, opts deploy.DeployOptions) error {
+				stepsMu.Lock()
+				defer stepsMu.Unlock()
+				steps = append(steps, "deploy:"+opts.SourceGitHash)
+				deployOpts = opts
+				return nil
+			}
+		})
+
+		It("should not start a rebuild while hot reload is disabled", func() {
+			handlers.SetHotReloadEnabled(false)
+
+			triggerRebuild(handlers)
+
+			Consistently(fakeState.claims, 100*time.Millisecond).Should(BeZero())
+			Expect(recordedSteps()).To(BeEmpty())
+		})
+
+		It("should only rebuild by default", func() {
+			triggerRebuild(handlers)
+
+			Eventually(fakeState.statuses).Should(Equal([]string{"rebuilding", "idle"}))
+			Expect(recordedSteps()).To(Equal([]string{"build"}))
+			Expect(fakeState.sourceGitHash()).To(BeEmpty())
+		})
+
+		It("should rebuild and then redeploy the built images when redeploy is enabled", func() {
+			cfg.Watch.Redeploy = true
+
+			triggerRebuild(handlers)
+
+			Eventually(fakeState.statuses).Should(Equal([]string{"rebuilding_and_redeploying", "idle"}))
+			Expect(recordedSteps()).To(Equal([]string{"build", "deploy:0123456789abcdef"}))
+			Expect(fakeState.sourceGitHash()).To(Equal("0123456789abcdef"))
+			Expect(fakeState.lastError()).NotTo(HaveOccurred())
+		})
+
+		It("should keep the IBM platforms of an environment with IBM enabled when redeploying", func() {
+			cfg.Watch.Redeploy = true
+			fakeState.ibmEnabled = true
+
+			triggerRebuild(handlers)
+
+			Eventually(recordedSteps).Should(Equal([]string{"build", "deploy:0123456789abcdef"}))
+			stepsMu.Lock()
+			defer stepsMu.Unlock()
+			Expect(deployOpts.SourceGitHash).To(Equal("0123456789abcdef"))
+			Expect(deployOpts.IBMEnabled).To(BeTrue())
+			Expect(deployOpts.Output).NotTo(BeNil())
+		})
+
+		It("should not redeploy when the rebuild fails", func() {
+			cfg.Watch.Redeploy = true
+			buildErr = errors.New("podman build failed")
+
+			triggerRebuild(handlers)
+
+			Eventually(fakeState.statuses).Should(Equal([]string{"rebuilding_and_redeploying", "idle"}))
+			Expect(recordedSteps()).To(Equal([]string{"build"}))
+			Expect(fakeState.lastError()).To(MatchError(ContainSubstring("podman build failed")))
+		})
+
+		It("should skip the rebuild while another operation is running", func() {
+			release := make(chan struct{})
+			Expect(handlers.StartOperation("deploy", time.Minute, func(ctx context.Context, output io.Writer) error {
+				<-release
+				return nil
+			})).To(Succeed())
+			DeferCleanup(func() { close(release) })
+
+			triggerRebuild(handlers)
+
+			Consistently(fakeState.claims, 100*time.Millisecond).Should(BeZero())
+			Expect(recordedSteps()).To(BeEmpty())
+		})
+
+		It("should skip the rebuild while the daemon is busy with a TaskRun", func() {
+			fakeState.busy = true
+
+			triggerRebuild(handlers)
+
+			Eventually(fakeState.claims).Should(Equal(1))
+			Consistently(recordedSteps, 100*time.Millisecond).Should(BeEmpty())
+			Expect(scrapeMetrics(handlers)).NotTo(ContainSubstring(`operation="hot_reload"`))
+		})
+
+		It("should record hot reloads in the operation metrics", func() {
+			triggerRebuild(handlers)
+			Eventually(func() string { return scrapeMetrics(handlers) }).Should(
+				ContainSubstring(`mpc_daemon_operations_total{operation="hot_reload",outcome="succeeded"} 1`))
+			Eventually(func() *api.OperationInfo {
+				rr := httptest.NewRecorder()
+				handlers.OperationsHandler(rr, httptest.NewRequest(http.MethodGet, "/api/operations", nil))
+				var operations api.OperationsResponse
+				Expect(json.NewDecoder(rr.Body).Decode(&operations)).To(Succeed())
+				return operations.Current
+			}).Should(BeNil())
+
+			stepsMu.Lock()
+			buildErr = errors.New("podman build failed")
+			stepsMu.Unlock()
+			triggerRebuild(handlers)
+			Eventually(func() string { return scrapeMetrics(handlers) }).Should(
+				ContainSubstring(`mpc_daemon_operations_total{operation="hot_reload",outcome="failed"} 1`))
+			Expect(scrapeMetrics(handlers)).To(ContainSubstring(`mpc_daemon_operation_duration_seconds_count{operation="hot_reload"} 2`))
+		})
+	})
+
+	Describe("reloadConfig", func() {
+		var (
+			original *config.Config
+			handlers *api.Handlers
+		)
+
+		BeforeEach(func() {
+			original = &config.Config{ClusterName: "konflux", Timeouts: config.DefaultTimeouts()}
+			handlers = api.NewHandlers(&fakeStateManager{}, original)
+			DeferCleanup(func() { _ = handlers.Shutdown(context.Background(), 0) })
+
+			originalLoad := loadConfig
+			DeferCleanup(func() { loadConfig = originalLoad })
+		})
+
+		It("should swap in the reloaded configuration", func() {
+			reloaded := &config.Config{ClusterName: "konflux", Timeouts: config.TimeoutConfig{Build: time.Hour}}
+			loadConfig = func() (*config.Config, error) { return reloaded, nil }
+
+			reloadConfig(handlers)
+
+			Expect(handlers.Config()).To(BeIdenticalTo(reloaded))
+		})
+
+		It("should keep the current configuration when the reload fails", func() {
+			loadConfig = func() (*config.Config, error) {
+				return nil, errors.New("configuration validation failed: MPC_REPO_PATH does not exist")
+			}
+
+			reloadConfig(handlers)
+
+			Expect(handlers.Config()).To(BeIdenticalTo(original))
+		})
+	})
+
+	Describe("newLogHandler", func() {
+		It("should write one parseable JSON record per line in json mode", func() {
+			var buf bytes.Buffer
+			log := slog.New(newLogHandler(&buf, config.LogFormatJSON, "info"))
+
+			log.Info("operation finished", "operation", "build", "duration", "1m2s")
+			log.Error("operation failed", "operation", "deploy", "error", "timeout")
+
+			lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+			Expect(lines).To(HaveLen(2))
+
+			var record map[string]any
+			Expect(json.Unmarshal(lines[0], &record)).To(Succeed())
+			Expect(record).To(HaveKey("time"))
+			Expect(record).To(HaveKeyWithValue("level", "INFO"))
+			Expect(record).To(HaveKeyWithValue("msg", "operation finished"))
+			Expect(record).To(HaveKeyWithValue("operation", "build"))
+			Expect(record).To(HaveKeyWithValue("duration", "1m2s"))
+
+			Expect(json.Unmarshal(lines[1], &record)).To(Succeed())
+			Expect(record).To(HaveKeyWithValue("level", "ERROR"))
+		})
+
+		It("should write text records by default", func() {
+			var buf bytes.Buffer
+			slog.New(newLogHandler(&buf, config.LogFormatText, "info")).Info("hello", "operation", "build")
+
+			Expect(buf.String()).To(ContainSubstring(`msg=hello operation=build`))
+			Expect(json.Valid(buf.Bytes())).To(BeFalse())
+		})
+
+		It("should honor the level and fall back to info for unknown levels", func() {
+			var buf bytes.Buffer
+			slog.New(newLogHandler(&buf, config.LogFormatJSON, "warn")).Info("dropped")
+			Expect(buf.Len()).To(BeZero())
+
+			slog.New(newLogHandler(&buf, config.LogFormatJSON, "verbose")).Debug("dropped")
+			Expect(buf.Len()).To(BeZero())
+
+			slog.New(newLogHandler(&buf, config.LogFormatJSON, "debug")).Debug("kept")
+			Expect(buf.String()).To(ContainSubstring(`"msg":"kept"`))
+		})
 	})
 })
+
+// fakeStateManager is an api.StateManager for triggerRebuild. Claiming the operation
+// status fails while busy is set, as it does during a TaskRun; otherwise the claimed
+// and subsequent statuses are recorded. Its other methods are not expected to be called.
+type fakeStateManager struct {
+	api.StateManager
+	busy       bool
+	ibmEnabled bool
+
+	mu        sync.Mutex
+	claimed   int
+	statusLog []string
+	err       error
+	gitHash   string
+}
+
+func (m *fakeStateManager) TrySetOperationStatus(expectedCurrent, newStatus string, err error) (bool, string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.claimed++
+	if m.busy {
+		return false, "running_taskrun"
+	}
+	m.statusLog = append(m.statusLog, newStatus)
+	return true, expectedCurrent
+}
+
+func (m *fakeStateManager) SetOperationStatus(status string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.statusLog = append(m.statusLog, status)
+	m.err = err
+}
+
+func (m *fakeStateManager) GetState() state.DevEnvironment {
+	return state.DevEnvironment{Features: state.FeatureState{IBMEnabled: m.ibmEnabled}}
+}
+
+func (m *fakeStateManager) SetMPCSourceGitHash(gitHash string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.gitHash = gitHash
+}
+
+func (m *fakeStateManager) claims() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.claimed
+}
+
+func (m *fakeStateManager) statuses() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.statusLog...)
+}
+
+func (m *fakeStateManager) lastError() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.err
+}
+
+func (m *fakeStateManager) sourceGitHash() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.gitHash
+}
@@ -7,14 +7,17 @@ package api
 
 // ClusterStatusResponse represents the JSON response for GET /api/cluster/status.
 //
-// The Status field indicates the current state of the Kind cluster:
-//   - "running": Cluster is active and accessible
-//   - "not_running": Cluster doesn't exist or is stopped
-//   - "unknown": Status could not be determined
+// The Status field indicates the current state of the Kind cluster, as one of the
+// cluster.Status* constants:
+//   - "Running": Cluster is active and accessible
+//   - "Initializing": Cluster exists but is not accessible yet
+//   - "Paused": Cluster exists but its node containers are stopped
+//   - "Not Running": Cluster doesn't exist
+//   - "Error": Status could not be determined
 //
 // The Error field is populated only when status checking fails.
 type ClusterStatusResponse struct {
-	Status string `json:"status"` // One of: "Running", "Not Running", "Error"
+	Status string `json:"status"` // One of: "Running", "Initializing", "Paused", "Not Running", "Error"
 	Error  string `json:"error,omitempty"`
 }
 
@@ -23,6 +26,8 @@ type ClusterStatusResponse struct {
 // Used by:
 //   - POST /api/cluster/start
 //   - POST /api/cluster/stop
+//   - POST /api/cluster/pause
+//   - POST /api/cluster/resume
 //
 // These endpoints return 202 Accepted immediately with a status of "accepted" and a
 // message instructing the client to poll GET /api/cluster/status to check progress.
@@ -30,3 +35,22 @@ type ClusterOperationResponse struct {
 	Status  string `json:"status"`
 	Message string `json:"message"`
 }
+
+// ClusterEnsureResponse represents the JSON response for POST /api/cluster/ensure.
+//
+// Action is the path the daemon took: "already_running" (200 OK), "creating" or
+// "in_progress" (202 Accepted), or "paused" (409 Conflict).
+type ClusterEnsureResponse struct {
+	Status  string `json:"status"` // "ok", "accepted" or "error"
+	Action  string `json:"action"`
+	Message string `json:"message"`
+}
+
+// ClusterKubeconfigResponse represents the JSON response for GET /api/cluster/kubeconfig.
+//
+// Kubeconfig holds the raw kubeconfig YAML exported by kind, and Context is the
+// kubectl context name for the cluster (e.g., "kind-konflux").
+type ClusterKubeconfigResponse struct {
+	Kubeconfig string `json:"kubeconfig"`
+	Context    string `json:"context"`
+}
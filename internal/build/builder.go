@@ -3,7 +3,7 @@
 //
 // The package automatically detects which container runtime is available and uses it
 // to build the MPC image from the local source repository. After building, the image
-// is loaded into the Kind cluster for deployment.
+// is loaded into the Kind cluster for deployment, or pushed to the configured registry.
 //
 // The build process streams output to logs and supports context-based cancellation
 // for long-running builds.
@@ -11,23 +11,80 @@ package build
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/meyrevived/mpc-dev-env/internal/config"
 	"github.com/meyrevived/mpc-dev-env/internal/logger"
+	"golang.org/x/sync/errgroup"
 )
 
 // Builder handles building MPC container images using Docker or Podman.
 // It encapsulates the build configuration and runtime detection logic.
 type Builder struct {
 	config *config.Config
+
+	// logFile and output optionally receive a copy of the build output (see
+	// BuildOptions). logMu serializes writes from the concurrent stdout and stderr streams.
+	logFile io.Writer
+	output  io.Writer
+	logMu   sync.Mutex
+}
+
+// BuildOptions holds optional settings for BuildMPCImage.
+type BuildOptions struct {
+	// LogFile, when set, receives a copy of the build output in addition to the
+	// daemon logs. The file and its parent directory are created if needed.
+	LogFile string
+
+	// Output, when set, receives each line of build output as it is produced,
+	// e.g. so the API can serve the progress of a running build.
+	Output io.Writer
+
+	// BuiltInputs holds the InputsHash each image reference was last built from.
+	// An image whose inputs still hash the same is not rebuilt: the existing image
+	// is only re-tagged and loaded into the cluster again.
+	BuiltInputs map[string]string
+
+	// Force rebuilds every image regardless of BuiltInputs.
+	Force bool
+
+	// OnImageBuilt, when set, is called with the image reference and its InputsHash
+	// for each image that was built, so the caller can record it for the next build.
+	// The calls are made one at a time once all builds have finished, never
+	// concurrently, so the callback needs no locking.
+	OnImageBuilt func(image, inputsHash string)
+}
+
+// BuildResult describes the images produced by a successful BuildMPCImage.
+type BuildResult struct {
+	// SourceGitHash is the full git hash the images were built from, or "" if it could
+	// not be resolved.
+	SourceGitHash string
+
+	// Images holds the size of each image, in build order. An image whose size could
+	// not be read is left out.
+	Images []ImageInfo
+}
+
+// ImageInfo is the size of a built image, e.g. to spot accidental image bloat.
+type ImageInfo struct {
+	Image     string `json:"image"`
+	SizeBytes int64  `json:"size_bytes"`
+	Layers    int    `json:"layers"`
 }
 
 // NewBuilder creates a new Builder instance with the provided configuration.
@@ -41,52 +98,300 @@ func NewBuilder(cfg *config.Config) *Builder {
 
 // BuildMPCImage builds both the multi-platform-controller and multi-platform-otp
 // container images. It automatically detects whether to use docker or podman,
-// builds both images, and streams build output to the daemon logs.
+// builds both images concurrently (up to config.GetBuildConcurrency() at once),
+// loads them into the Kind cluster once both are built (or, with a registry
+// configured, pushes them there instead), and streams build output
+// to the daemon logs (and to opts.LogFile and opts.Output, when set), each line
+// prefixed with the name of the image it belongs to.
 //
 // Both images are required for the MPC stack to function:
 //   - multi-platform-controller: The main controller that manages builds
 //   - multi-platform-otp: The OTP server for secure access to build hosts
 //
+// The images are tagged with config.GetControllerImage() and config.GetOTPImage(),
+// the same references the deploy package patches the deployments with. When the
+// MPC repository's current commit can be resolved, each image is additionally
+// tagged with config.RevisionImage so a deployment can be traced back to the
+// exact source it was built from.
+//
+// Unless opts.Force is set, an image whose InputsHash matches opts.BuiltInputs is
+// not rebuilt, e.g. the OTP image when only controller code changed.
+//
+// Once published, each image's size and layer count are read with
+// "<runtime> image inspect" and returned in the BuildResult.
+//
 // Args:
 //
 //	ctx: Context for cancellation and timeout
 //	config: Configuration containing MPC repository path
+//	opts: Optional settings such as a log file to capture the build output
 //
 // Returns:
 //
+//	BuildResult: The git hash the images were built from and their sizes
 //	error: An error if the build fails, nil otherwise
-func BuildMPCImage(ctx context.Context, cfg *config.Config) error {
+func BuildMPCImage(ctx context.Context, cfg *config.Config, opts BuildOptions) (BuildResult, error) {
 	builder := NewBuilder(cfg)
+	builder.output = opts.Output
+
+	if opts.LogFile != "" {
+		logFile, err := createLogFile(opts.LogFile)
+		if err != nil {
+			return BuildResult{}, err
+		}
+		// Closed on every return path so partial output of a failed build is kept
+		defer func() {
+			if err := logFile.Close(); err != nil {
+				logger.Error(err, "failed to close build log file", "path", opts.LogFile)
+			}
+		}()
+		builder.logFile = logFile
+		logger.Info("writing build output to log file", "path", opts.LogFile)
+	}
 
-	// Build the main controller image
-	if err := builder.buildImage(ctx, "Dockerfile", "multi-platform-controller:latest"); err != nil {
-		return fmt.Errorf("failed to build controller image: %w", err)
+	// A missing hash only costs the extra tag, so it never fails the build
+	gitHash, err := builder.resolveSourceGitHash(ctx)
+	if err != nil {
+		logger.Info("could not resolve MPC source git hash, skipping revision tags", "error", err.Error())
 	}
 
-	// Build the OTP server image
-	if err := builder.buildImage(ctx, "Dockerfile.otp", "multi-platform-otp:latest"); err != nil {
-		return fmt.Errorf("failed to build OTP image: %w", err)
+	// The images are tagged exactly as the deploy step expects them
+	images := []struct {
+		name       string
+		dockerfile string
+		tags       []string
+	}{
+		{"controller", "Dockerfile", imageTags(cfg.GetControllerImage(), gitHash)},
+		{"OTP", "Dockerfile.otp", imageTags(cfg.GetOTPImage(), gitHash)},
 	}
 
+	// The builds are independent, so they run concurrently up to MPC_BUILD_CONCURRENCY.
+	// A failed build does not cancel the others: they are awaited and their errors
+	// reported together, and the images that did build are recorded for the next build.
+	buildErrs := make([]error, len(images))
+	builtInputs := make([]string, len(images))
+	var builds errgroup.Group
+	builds.SetLimit(cfg.GetBuildConcurrency())
+	for i, image := range images {
+		builds.Go(func() error {
+			inputsHash, err := builder.buildIfChanged(ctx, opts, image.dockerfile, image.tags...)
+			if err != nil {
+				buildErrs[i] = fmt.Errorf("failed to build %s image: %w", image.name, err)
+			}
+			builtInputs[i] = inputsHash
+			return nil
+		})
+	}
+	_ = builds.Wait()
+	if opts.OnImageBuilt != nil {
+		for i, image := range images {
+			if builtInputs[i] != "" {
+				opts.OnImageBuilt(image.tags[0], builtInputs[i])
+			}
+		}
+	}
+	if err := errors.Join(buildErrs...); err != nil {
+		return BuildResult{}, err
+	}
+
+	// Publish the images only once all of them are built
+	result := BuildResult{SourceGitHash: gitHash}
+	for _, image := range images {
+		if cfg.GetRegistryURL() != "" {
+			if err := builder.pushImage(ctx, image.tags...); err != nil {
+				return BuildResult{}, fmt.Errorf("failed to push %s image: %w", image.name, err)
+			}
+		} else if err := builder.loadImageIntoKind(ctx, image.tags...); err != nil {
+			return BuildResult{}, fmt.Errorf("failed to load %s image into Kind cluster: %w", image.name, err)
+		}
+
+		// The size is informational, so failing to read it never fails the build
+		info, err := builder.inspectImage(ctx, image.tags[0])
+		if err != nil {
+			logger.Info("could not read image size", "image", image.tags[0], "error", err.Error())
+			continue
+		}
+		logger.Info("image size", "image", info.Image, "sizeBytes", info.SizeBytes, "layers", info.Layers)
+		result.Images = append(result.Images, info)
+	}
+
+	return result, nil
+}
+
+// inspectImage reads the size and layer count of a built image with
+// "<runtime> image inspect --format '{{.Size}} {{len .RootFS.Layers}}'".
+func (b *Builder) inspectImage(ctx context.Context, image string) (ImageInfo, error) {
+	containerRuntime, err := b.detectContainerRuntime()
+	if err != nil {
+		return ImageInfo{}, fmt.Errorf("failed to detect container runtime: %w", err)
+	}
+
+	output, err := exec.CommandContext(ctx, containerRuntime, "image", "inspect", "--format", "{{.Size}} {{len .RootFS.Layers}}", image).Output()
+	if err != nil {
+		return ImageInfo{}, fmt.Errorf("image inspect failed: %w", err)
+	}
+
+	info := ImageInfo{Image: image}
+	if _, err := fmt.Sscan(string(output), &info.SizeBytes, &info.Layers); err != nil {
+		return ImageInfo{}, fmt.Errorf("unexpected image inspect output %q: %w", strings.TrimSpace(string(output)), err)
+	}
+	return info, nil
+}
+
+// buildIfChanged builds an image with buildImage unless its inputs are unchanged
+// since it was last built (see BuildOptions.BuiltInputs), in which case the existing
+// image is reused instead. A reuse that fails falls back to a full build. It returns
+// the InputsHash of an image it built, or "" if the image was reused or its inputs
+// could not be hashed.
+func (b *Builder) buildIfChanged(ctx context.Context, opts BuildOptions, dockerfileName string, imageTags ...string) (string, error) {
+	image := imageTags[0]
+
+	// A hash that cannot be computed only costs the skip, so it never fails the build
+	inputsHash, err := b.inputsHash(dockerfileName)
+	if err != nil {
+		logger.Info("could not hash image inputs, rebuilding", "image", image, "error", err.Error())
+	} else if !opts.Force && opts.BuiltInputs[image] == inputsHash {
+		err := b.reuseImage(ctx, imageTags...)
+		if err == nil {
+			return "", nil
+		}
+		logger.Info("could not reuse previously built image, rebuilding", "image", image, "error", err.Error())
+	}
+
+	if err := b.buildImage(ctx, dockerfileName, imageTags...); err != nil {
+		return "", err
+	}
+	return inputsHash, nil
+}
+
+// inputsHash returns the InputsHash of dockerfileName, combined with the configured
+// build args so that changing them also rebuilds the image.
+func (b *Builder) inputsHash(dockerfileName string) (string, error) {
+	inputsHash, err := InputsHash(b.config.GetMpcRepoPath(), dockerfileName)
+	if err != nil || len(b.config.GetBuildArgs()) == 0 {
+		return inputsHash, err
+	}
+
+	hash := sha256.New()
+	_, _ = fmt.Fprintf(hash, "%s\x00%s", inputsHash, strings.Join(b.buildArgs(), "\x00"))
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// buildArgs returns the configured build args as KEY=VALUE strings, sorted by key
+// so the build command is deterministic.
+func (b *Builder) buildArgs() []string {
+	buildArgs := b.config.GetBuildArgs()
+	keys := make([]string, 0, len(buildArgs))
+	for key := range buildArgs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	args := make([]string, 0, len(keys))
+	for _, key := range keys {
+		args = append(args, key+"="+buildArgs[key])
+	}
+	return args
+}
+
+// reuseImage prepares an image whose inputs are unchanged to be loaded without a
+// build: it checks the image still exists and adds any further tags (e.g. a new
+// revision tag). The image is still loaded into the Kind cluster (or pushed)
+// afterwards, as the cluster may have been recreated since the last build.
+func (b *Builder) reuseImage(ctx context.Context, imageTags ...string) error {
+	image := imageTags[0]
+
+	containerRuntime, err := b.detectContainerRuntime()
+	if err != nil {
+		return fmt.Errorf("failed to detect container runtime: %w", err)
+	}
+
+	if output, err := exec.CommandContext(ctx, containerRuntime, "image", "inspect", image).CombinedOutput(); err != nil {
+		return fmt.Errorf("image %s not found: %w: %s", image, err, strings.TrimSpace(string(output)))
+	}
+
+	for _, tag := range imageTags[1:] {
+		if output, err := exec.CommandContext(ctx, containerRuntime, "tag", image, tag).CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to tag %s as %s: %w: %s", image, tag, err, strings.TrimSpace(string(output)))
+		}
+	}
+
+	logger.Info("image inputs unchanged since its last build, skipping build", "image", image)
+	b.logLine("BUILD", image, fmt.Sprintf("Inputs of %s are unchanged since its last build, skipping build (force a rebuild with force=true)", image))
 	return nil
 }
 
+// imageTags returns the configured image reference followed by its revision tag
+// when a git hash is known.
+func imageTags(image, gitHash string) []string {
+	if gitHash == "" {
+		return []string{image}
+	}
+	return []string{image, config.RevisionImage(image, gitHash)}
+}
+
+// imageName returns the repository name of an image reference without its registry,
+// path, tag or digest, e.g. "multi-platform-otp" for "localhost/multi-platform-otp:latest".
+func imageName(image string) string {
+	name := image[strings.LastIndex(image, "/")+1:]
+	if i := strings.IndexAny(name, ":@"); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+// createLogFile creates the build log file, including its parent directory.
+func createLogFile(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return nil, fmt.Errorf("failed to create build log directory: %w", err)
+	}
+
+	logFile, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create build log file: %w", err)
+	}
+
+	return logFile, nil
+}
+
+// resolveSourceGitHash returns the commit currently checked out in the MPC
+// repository using "git -C <repo> rev-parse HEAD".
+func (b *Builder) resolveSourceGitHash(ctx context.Context) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", "-C", b.config.GetMpcRepoPath(), "rev-parse", "HEAD")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git rev-parse HEAD failed: %w", err)
+	}
+
+	gitHash := strings.TrimSpace(string(output))
+	if gitHash == "" {
+		return "", errors.New("git rev-parse HEAD returned an empty hash")
+	}
+
+	return gitHash, nil
+}
+
 // buildImage performs the actual build operation for a container image.
 // It executes the following steps:
 //  1. Detects container runtime (Docker or Podman)
 //  2. Verifies Dockerfile exists in MPC repository
-//  3. Builds the image with the specified tag
+//  3. Builds the image with the specified tags, retrying once on a suspected OOM kill
 //  4. Streams build output to daemon logs
-//  5. Loads the built image into the Kind cluster
+//
+// Loading the image into the Kind cluster is left to the caller (see loadImageIntoKind).
 //
 // Args:
 //
 //	ctx: Context for cancellation and timeout
 //	dockerfileName: Name of the Dockerfile (e.g., "Dockerfile" or "Dockerfile.otp")
-//	imageTag: Tag for the built image (e.g., "multi-platform-controller:latest")
+//	imageTags: Tags for the built image (e.g., "localhost/multi-platform-controller:latest")
 //
 // The build runs in the MPC repository directory and respects context cancellation.
-func (b *Builder) buildImage(ctx context.Context, dockerfileName, imageTag string) error {
+func (b *Builder) buildImage(ctx context.Context, dockerfileName string, imageTags ...string) error {
+	if len(imageTags) == 0 {
+		return errors.New("at least one image tag is required")
+	}
+	imageTag := imageTags[0]
 	logger.Info("starting image build", "image", imageTag)
 
 	// Step 1: Determine container runtime (docker or podman)
@@ -111,25 +416,45 @@ func (b *Builder) buildImage(ctx context.Context, dockerfileName, imageTag strin
 	logger.Info("dockerfile", "path", dockerfile)
 
 	// Step 4: Construct build command
-	// Format: <runtime> build --platform <platform> -t <tag> -f <dockerfile> <context>
+	// Format: <runtime> build --platform <platform> -t <tag> [-t <tag>...] [--build-arg <key>=<value>...] -f <dockerfile> <context>
 	// The --platform flag ensures we build for the host's native architecture.
 	// This prevents cross-compilation issues (e.g., ARM64 Mac trying to build amd64)
 	// which can cause OOM kills during Go compilation.
 	platform := "linux/" + runtime.GOARCH
 	logger.Info("building for platform", "platform", platform)
 
-	buildArgs := []string{
-		"build",
-		"--platform", platform,
-		"-t", imageTag,
-		"-f", dockerfile,
-		buildContext,
+	buildArgs := []string{"build", "--platform", platform}
+	for _, tag := range imageTags {
+		buildArgs = append(buildArgs, "-t", tag)
+	}
+	for _, arg := range b.buildArgs() {
+		buildArgs = append(buildArgs, "--build-arg", arg)
 	}
+	buildArgs = append(buildArgs, "-f", dockerfile, buildContext)
 
+	// Step 5: Run the build, retrying once if it looks like the compiler was OOM-killed
+	if err := b.runBuild(ctx, containerRuntime, buildContext, imageTag, buildArgs); err != nil {
+		if !isOOMKill(ctx, err) {
+			return err
+		}
+
+		logger.Info("build was killed, retrying once due to a suspected OOM", "image", imageTag, "error", err.Error())
+		if retryErr := b.runBuild(ctx, containerRuntime, buildContext, imageTag, buildArgs); retryErr != nil {
+			return fmt.Errorf("%w (retry also failed: %v); the build was likely OOM-killed, "+
+				"try increasing the memory available to the container runtime (e.g. podman machine set --memory)", err, retryErr)
+		}
+	}
+
+	logger.Info("image build completed successfully", "image", imageTag)
+	return nil
+}
+
+// runBuild runs a single build command for image in buildContext, streaming its
+// output to the daemon logs (and the build log file, if configured).
+func (b *Builder) runBuild(ctx context.Context, containerRuntime, buildContext, image string, buildArgs []string) error {
 	cmd := exec.CommandContext(ctx, containerRuntime, buildArgs...)
 	cmd.Dir = buildContext
 
-	// Step 5: Set up streaming output
 	// Capture both stdout and stderr and stream to logs
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
@@ -146,36 +471,86 @@ func (b *Builder) buildImage(ctx context.Context, dockerfileName, imageTag strin
 		return fmt.Errorf("failed to start build command: %w", err)
 	}
 
-	// Stream stdout
-	go b.streamOutput(stdout, "BUILD")
-
-	// Stream stderr
-	go b.streamOutput(stderr, "BUILD-ERR")
+	// Stream stdout and stderr. Both streams must be drained before cmd.Wait,
+	// which closes the pipes and would otherwise drop trailing output.
+	var streams sync.WaitGroup
+	streams.Add(2)
+	go func() {
+		defer streams.Done()
+		b.streamOutput(stdout, "BUILD", image)
+	}()
+	go func() {
+		defer streams.Done()
+		b.streamOutput(stderr, "BUILD-ERR", image)
+	}()
+	streams.Wait()
 
 	// Wait for command to complete
 	if err := cmd.Wait(); err != nil {
 		return fmt.Errorf("build command failed: %w", err)
 	}
 
-	logger.Info("image build completed successfully", "image", imageTag)
+	return nil
+}
 
-	// Step 6: Load image into Kind cluster
-	if err := b.loadImageIntoKind(ctx, imageTag); err != nil {
-		return fmt.Errorf("failed to load image into Kind cluster: %w", err)
+// isOOMKill reports whether a failed build looks like it was killed by the
+// kernel OOM killer: either the runtime itself died from SIGKILL or it exited
+// with 137 (128 + SIGKILL), which is how it reports a killed build container.
+//
+// A kill caused by ctx being cancelled is not an OOM and is never retried.
+func isOOMKill(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
 	}
 
-	return nil
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return false
+	}
+
+	if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() && status.Signal() == syscall.SIGKILL {
+		return true
+	}
+	return exitErr.ExitCode() == 137
 }
 
 // detectContainerRuntime determines whether to use docker or podman.
+// See ContainerRuntime.
+func (b *Builder) detectContainerRuntime() (string, error) {
+	return ContainerRuntime(b.config)
+}
+
+// ContainerRuntime returns the container runtime command for image builds and the
+// Kind cluster: MPC_CONTAINER_RUNTIME when set, otherwise DetectContainerRuntime's
+// choice. Builds and cluster operations both use it, so images are always built
+// with the runtime the cluster runs on.
+func ContainerRuntime(cfg *config.Config) (string, error) {
+	if runtime := cfg.GetContainerRuntime(); runtime != "" {
+		if _, err := exec.LookPath(runtime); err != nil {
+			return "", fmt.Errorf("MPC_CONTAINER_RUNTIME is %s, but it was not found in PATH: %w", runtime, err)
+		}
+		return runtime, nil
+	}
+	return DetectContainerRuntime()
+}
+
+// IsPodman reports whether a runtime command returned by ContainerRuntime is podman,
+// also when DOCKER_CLI gives it as a full path.
+func IsPodman(runtime string) bool {
+	return strings.Contains(filepath.Base(runtime), config.ContainerRuntimePodman)
+}
+
+// DetectContainerRuntime determines whether to use docker or podman.
 // It checks in the following order:
 //  1. Check DOCKER_CLI environment variable (allows manual override)
 //  2. Check if podman is available (preferred on RHEL/Fedora)
 //  3. Check if docker is available (fallback)
 //  4. Return error if neither is found
 //
-// Returns the runtime command name ("docker" or "podman") or an error if none are available.
-func (b *Builder) detectContainerRuntime() (string, error) {
+// Returns the runtime command ("docker", "podman", or the DOCKER_CLI value as given)
+// or an error if none are available. It is exported so the prerequisite check can
+// version-check the same runtime that builds will use.
+func DetectContainerRuntime() (string, error) {
 	// Check environment variable first
 	if dockerCli := os.Getenv("DOCKER_CLI"); dockerCli != "" {
 		// Verify the specified CLI exists
@@ -186,33 +561,44 @@ func (b *Builder) detectContainerRuntime() (string, error) {
 	}
 
 	// Try podman first (it's often preferred in RHEL/Fedora environments)
-	if _, err := exec.LookPath("podman"); err == nil {
-		// Verify podman is working
-		cmd := exec.Command("podman", "--version")
-		if err := cmd.Run(); err == nil {
-			return "podman", nil
-		}
+	if runtimeWorks("podman") {
+		return "podman", nil
 	}
 
 	// Try docker
-	if _, err := exec.LookPath("docker"); err == nil {
-		// Verify docker is working
-		cmd := exec.Command("docker", "--version")
-		if err := cmd.Run(); err == nil {
-			return "docker", nil
-		}
+	if runtimeWorks("docker") {
+		return "docker", nil
 	}
 
 	return "", errors.New("neither docker nor podman found in PATH")
 }
 
+// runtimeProbeTimeout limits the "--version" command DetectContainerRuntime runs to
+// verify a runtime works, so a hanging CLI counts as not working instead of blocking.
+const runtimeProbeTimeout = 5 * time.Second
+
+// runtimeWorks reports whether the runtime command is in PATH and its "--version"
+// succeeds within runtimeProbeTimeout.
+func runtimeWorks(runtime string) bool {
+	if _, err := exec.LookPath(runtime); err != nil {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), runtimeProbeTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, runtime, "--version")
+	cmd.WaitDelay = time.Second
+	return cmd.Run() == nil
+}
+
 // streamOutput reads from an io.Reader and logs each line with a prefix.
 // This function is designed to run in a goroutine and stream build output
 // (stdout or stderr) to the daemon logs in real-time.
 //
 // Lines are buffered until a newline is encountered, then logged with the
-// specified prefix (e.g., "BUILD" or "BUILD-ERR").
-func (b *Builder) streamOutput(reader io.Reader, prefix string) {
+// specified prefix (e.g., "BUILD" or "BUILD-ERR") and copied to the build log
+// file and output writer, if configured. image identifies the build the output
+// belongs to, as concurrent builds share the log file and output writer.
+func (b *Builder) streamOutput(reader io.Reader, prefix, image string) {
 	buf := make([]byte, 1024)
 	var lineBuffer strings.Builder
 
@@ -226,7 +612,7 @@ func (b *Builder) streamOutput(reader io.Reader, prefix string) {
 					// Log the complete line
 					line := lineBuffer.String()
 					if line != "" {
-						logger.Debug("build output", "prefix", prefix, "line", line)
+						b.logLine(prefix, image, line)
 					}
 					lineBuffer.Reset()
 				} else {
@@ -238,7 +624,7 @@ func (b *Builder) streamOutput(reader io.Reader, prefix string) {
 		if err == io.EOF {
 			// Log any remaining content in the buffer
 			if lineBuffer.Len() > 0 {
-				logger.Debug("build output", "prefix", prefix, "line", lineBuffer.String())
+				b.logLine(prefix, image, lineBuffer.String())
 			}
 			break
 		}
@@ -250,14 +636,75 @@ func (b *Builder) streamOutput(reader io.Reader, prefix string) {
 	}
 }
 
-// loadImageIntoKind loads the built image into the Kind cluster named "konflux".
+// logLine writes a single line of build output for image to the daemon logs, the
+// build log file, and the output writer. In the file and the writer, the line is
+// prefixed with the image name (see imageName), e.g. "[multi-platform-otp] STEP 1/5".
+func (b *Builder) logLine(prefix, image, line string) {
+	logger.Debug("build output", "prefix", prefix, "image", image, "line", line)
+	line = "[" + imageName(image) + "] " + line
+
+	if b.logFile == nil && b.output == nil {
+		return
+	}
+
+	b.logMu.Lock()
+	defer b.logMu.Unlock()
+	if b.logFile != nil {
+		if _, err := io.WriteString(b.logFile, line+"\n"); err != nil {
+			logger.Error(err, "failed to write build log file", "prefix", prefix)
+		}
+	}
+	if b.output != nil {
+		if _, err := io.WriteString(b.output, line+"\n"); err != nil {
+			logger.Error(err, "failed to write build output", "prefix", prefix)
+		}
+	}
+}
+
+// pushImage pushes every tag of a built image to the configured registry with
+// "<runtime> push". Podman only pushes to a localhost registry over plain HTTP with
+// --tls-verify=false, which docker assumes for localhost on its own.
+func (b *Builder) pushImage(ctx context.Context, imageTags ...string) error {
+	containerRuntime, err := b.detectContainerRuntime()
+	if err != nil {
+		return fmt.Errorf("failed to detect container runtime: %w", err)
+	}
+
+	for _, tag := range imageTags {
+		logger.Info("pushing image", "image", tag)
+		pushArgs := []string{"push"}
+		if IsPodman(containerRuntime) && isLocalRegistry(b.config.GetRegistryURL()) {
+			pushArgs = append(pushArgs, "--tls-verify=false")
+		}
+		pushArgs = append(pushArgs, tag)
+
+		if output, err := exec.CommandContext(ctx, containerRuntime, pushArgs...).CombinedOutput(); err != nil {
+			return fmt.Errorf("%s push %s failed: %w: %s", containerRuntime, tag, err, strings.TrimSpace(string(output)))
+		}
+	}
+
+	logger.Info("image pushed to registry successfully", "registry", b.config.GetRegistryURL())
+	return nil
+}
+
+// isLocalRegistry reports whether registry is served from the local machine.
+func isLocalRegistry(registry string) bool {
+	host, _, _ := strings.Cut(registry, "/")
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return host == "localhost" || host == "127.0.0.1" || host == "::1" || host == "[::1]"
+}
+
+// loadImageIntoKind loads the built image into the configured Kind cluster.
 // It uses a pipe between the container runtime's "save" command and kind's
 // "load image-archive" command to efficiently transfer the image without creating
-// a temporary tar file.
+// a temporary tar file. All given tags are saved into the same archive so the
+// image is transferred only once.
 //
 // For Podman, sets KIND_EXPERIMENTAL_PROVIDER=podman environment variable.
 // The operation respects context cancellation.
-func (b *Builder) loadImageIntoKind(ctx context.Context, imageTag string) error {
+func (b *Builder) loadImageIntoKind(ctx context.Context, imageTags ...string) error {
 	logger.Info("loading image into kind cluster")
 
 	// Determine container runtime
@@ -267,12 +714,18 @@ func (b *Builder) loadImageIntoKind(ctx context.Context, imageTag string) error
 	}
 
 	// Use podman save to export image and pipe to kind load
-	// Format: podman save <image> | KIND_EXPERIMENTAL_PROVIDER=podman kind load image-archive /dev/stdin --name konflux
-	saveCmd := exec.CommandContext(ctx, containerRuntime, "save", imageTag)
-	loadCmd := exec.CommandContext(ctx, "kind", "load", "image-archive", "/dev/stdin", "--name", "konflux")
+	// Format: podman save <image> | KIND_EXPERIMENTAL_PROVIDER=podman kind load image-archive /dev/stdin --name <cluster>
+	// Podman only writes more than one image reference into an archive with --multi-image-archive
+	saveArgs := []string{"save"}
+	if IsPodman(containerRuntime) && len(imageTags) > 1 {
+		saveArgs = append(saveArgs, "--multi-image-archive")
+	}
+	saveArgs = append(saveArgs, imageTags...)
+	saveCmd := exec.CommandContext(ctx, containerRuntime, saveArgs...)
+	loadCmd := exec.CommandContext(ctx, "kind", "load", "image-archive", "/dev/stdin", "--name", b.config.GetClusterName())
 
 	// Set environment for kind if using podman
-	if containerRuntime == "podman" {
+	if IsPodman(containerRuntime) {
 		loadCmd.Env = append(os.Environ(), "KIND_EXPERIMENTAL_PROVIDER=podman")
 	}
 
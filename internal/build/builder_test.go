@@ -2,9 +2,11 @@ package build
 
 import (
 	"context"
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/meyrevived/mpc-dev-env/internal/config"
@@ -78,6 +80,34 @@ var _ = Describe("Builder", func() {
 			_, err := builder.detectContainerRuntime()
 			Expect(err).To(HaveOccurred())
 		})
+		It("should use MPC_CONTAINER_RUNTIME over DOCKER_CLI and detection", func() {
+			Expect(os.WriteFile(filepath.Join(tempDir, "docker"), []byte("#!/bin/sh\n"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(tempDir, "podman"), []byte("#!/bin/sh\n"), 0755)).To(Succeed())
+			_ = os.Setenv("PATH", tempDir)
+			_ = os.Setenv("DOCKER_CLI", "podman")
+			cfg.ContainerRuntime = config.ContainerRuntimeDocker
+
+			runtime, err := builder.detectContainerRuntime()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(runtime).To(Equal("docker"))
+		})
+
+		It("should return an error if the configured runtime is not installed", func() {
+			_ = os.Setenv("PATH", "/non-existent-path")
+			cfg.ContainerRuntime = config.ContainerRuntimePodman
+
+			_, err := builder.detectContainerRuntime()
+			Expect(err).To(MatchError(ContainSubstring("MPC_CONTAINER_RUNTIME is podman")))
+		})
+	})
+
+	Describe("IsPodman", func() {
+		It("should recognize podman by name or path", func() {
+			Expect(IsPodman("podman")).To(BeTrue())
+			Expect(IsPodman("/usr/bin/podman")).To(BeTrue())
+			Expect(IsPodman("docker")).To(BeFalse())
+			Expect(IsPodman("/usr/local/bin/docker")).To(BeFalse())
+		})
 	})
 
 	Describe("build", func() {
@@ -96,7 +126,7 @@ var _ = Describe("Builder", func() {
 			_ = os.Setenv("PATH", tempDir+":"+os.Getenv("PATH"))
 		})
 
-		It("should execute the build and load-to-kind process", func() {
+		It("should execute the build", func() {
 			// This test is more of an integration test for the build function's orchestration.
 			// It relies on a real container runtime being present.
 			runtime, err := builder.detectContainerRuntime()
@@ -111,4 +141,523 @@ var _ = Describe("Builder", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 	})
+
+	Describe("loadImageIntoKind", func() {
+		var originalPath string
+
+		BeforeEach(func() {
+			originalPath = os.Getenv("PATH")
+
+			// Fake container runtime that emits a dummy image archive on "save"
+			fakeRuntimePath := filepath.Join(tempDir, "fake-runtime")
+			Expect(os.WriteFile(fakeRuntimePath, []byte("#!/bin/sh\necho image-archive"), 0755)).To(Succeed())
+			_ = os.Setenv("DOCKER_CLI", fakeRuntimePath)
+
+			// Mock kind that drains stdin and records its arguments
+			mockKindScript := "#!/bin/sh\ncat > /dev/null\necho \"$@\" >> " + filepath.Join(tempDir, "kind_calls.log") + "\nexit 0"
+			Expect(os.WriteFile(filepath.Join(tempDir, "kind"), []byte(mockKindScript), 0755)).To(Succeed())
+			_ = os.Setenv("PATH", tempDir+":"+originalPath)
+		})
+
+		AfterEach(func() {
+			_ = os.Setenv("PATH", originalPath)
+		})
+
+		It("should load the image into the configured cluster", func() {
+			cfg.ClusterName = "mpc-dev-2"
+
+			err := builder.loadImageIntoKind(context.Background(), "multi-platform-controller:latest")
+			Expect(err).NotTo(HaveOccurred())
+
+			calls, err := os.ReadFile(filepath.Join(tempDir, "kind_calls.log"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(calls)).To(ContainSubstring("load image-archive /dev/stdin --name mpc-dev-2"))
+		})
+
+		It("should default to the konflux cluster", func() {
+			err := builder.loadImageIntoKind(context.Background(), "multi-platform-controller:latest")
+			Expect(err).NotTo(HaveOccurred())
+
+			calls, err := os.ReadFile(filepath.Join(tempDir, "kind_calls.log"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(calls)).To(ContainSubstring("--name konflux"))
+		})
+	})
+
+	Describe("BuildMPCImage", func() {
+		var originalPath string
+
+		BeforeEach(func() {
+			originalPath = os.Getenv("PATH")
+
+			for _, dockerfile := range []string{"Dockerfile", "Dockerfile.otp"} {
+				Expect(os.WriteFile(filepath.Join(tempDir, dockerfile), []byte("FROM scratch\n"), 0644)).To(Succeed())
+			}
+
+			// Fake container runtime that records its arguments
+			fakeRuntimePath := filepath.Join(tempDir, "fake-runtime")
+			fakeRuntimeScript := "#!/bin/sh\necho \"$@\" >> " + filepath.Join(tempDir, "runtime_calls.log") + "\nexit 0"
+			Expect(os.WriteFile(fakeRuntimePath, []byte(fakeRuntimeScript), 0755)).To(Succeed())
+			_ = os.Setenv("DOCKER_CLI", fakeRuntimePath)
+
+			mockKindScript := "#!/bin/sh\ncat > /dev/null\nexit 0"
+			Expect(os.WriteFile(filepath.Join(tempDir, "kind"), []byte(mockKindScript), 0755)).To(Succeed())
+
+			// Mock git that fails like rev-parse outside a repository; overridden where a hash is needed
+			Expect(os.WriteFile(filepath.Join(tempDir, "git"), []byte("#!/bin/sh\nexit 128\n"), 0755)).To(Succeed())
+			_ = os.Setenv("PATH", tempDir+":"+originalPath)
+		})
+
+		AfterEach(func() {
+			_ = os.Setenv("PATH", originalPath)
+		})
+
+		It("should tag and load the images configured for deployment", func() {
+			cfg.ControllerImage = "quay.io/test/multi-platform-controller:dev"
+			cfg.OTPImage = "quay.io/test/multi-platform-otp:dev"
+
+			_, err := BuildMPCImage(context.Background(), cfg, BuildOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			calls, err := os.ReadFile(filepath.Join(tempDir, "runtime_calls.log"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(calls)).To(ContainSubstring("-t " + cfg.GetControllerImage() + " -f " + filepath.Join(tempDir, "Dockerfile") + " "))
+			Expect(string(calls)).To(ContainSubstring("-t " + cfg.GetOTPImage() + " -f " + filepath.Join(tempDir, "Dockerfile.otp")))
+			Expect(string(calls)).To(ContainSubstring("save " + cfg.GetControllerImage()))
+			Expect(string(calls)).To(ContainSubstring("save " + cfg.GetOTPImage()))
+		})
+
+		It("should default to the localhost images the deploy step patches with", func() {
+			_, err := BuildMPCImage(context.Background(), cfg, BuildOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			calls, err := os.ReadFile(filepath.Join(tempDir, "runtime_calls.log"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(calls)).To(ContainSubstring("-t " + config.DefaultControllerImage))
+			Expect(string(calls)).To(ContainSubstring("-t " + config.DefaultOTPImage))
+		})
+
+		Context("when the MPC repository commit can be resolved", func() {
+			const gitHash = "0123456789abcdef0123456789abcdef01234567"
+
+			BeforeEach(func() {
+				mockGitScript := "#!/bin/sh\necho \"$@\" >> " + filepath.Join(tempDir, "git_calls.log") + "\necho " + gitHash + "\n"
+				Expect(os.WriteFile(filepath.Join(tempDir, "git"), []byte(mockGitScript), 0755)).To(Succeed())
+			})
+
+			It("should read the hash with git rev-parse and return it", func() {
+				result, err := BuildMPCImage(context.Background(), cfg, BuildOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.SourceGitHash).To(Equal(gitHash))
+
+				calls, err := os.ReadFile(filepath.Join(tempDir, "git_calls.log"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(calls)).To(ContainSubstring("-C " + tempDir + " rev-parse HEAD"))
+			})
+
+			It("should add the revision tags to the build args and the kind archive", func() {
+				_, err := BuildMPCImage(context.Background(), cfg, BuildOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				controllerRevision := "localhost/multi-platform-controller:0123456789ab"
+				otpRevision := "localhost/multi-platform-otp:0123456789ab"
+
+				calls, err := os.ReadFile(filepath.Join(tempDir, "runtime_calls.log"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(calls)).To(ContainSubstring("-t " + config.DefaultControllerImage + " -t " + controllerRevision + " -f "))
+				Expect(string(calls)).To(ContainSubstring("-t " + config.DefaultOTPImage + " -t " + otpRevision + " -f "))
+				Expect(string(calls)).To(ContainSubstring("save " + config.DefaultControllerImage + " " + controllerRevision))
+			})
+		})
+
+		Context("with a log file", func() {
+			var logFile string
+
+			BeforeEach(func() {
+				logFile = filepath.Join(tempDir, "logs", "build_20251127_143052.log")
+			})
+
+			It("should create the log file with the streamed build output", func() {
+				fakeRuntimeScript := "#!/bin/sh\necho \"$@\" >> " + filepath.Join(tempDir, "runtime_calls.log") +
+					"\n[ \"$1\" = build ] || exit 0\necho \"STEP 1/2: FROM scratch\"\necho \"warning: cache miss\" >&2\nprintf 'COMMIT done'\n"
+				Expect(os.WriteFile(os.Getenv("DOCKER_CLI"), []byte(fakeRuntimeScript), 0755)).To(Succeed())
+
+				_, err := BuildMPCImage(context.Background(), cfg, BuildOptions{LogFile: logFile})
+				Expect(err).NotTo(HaveOccurred())
+
+				content, err := os.ReadFile(logFile)
+				Expect(err).NotTo(HaveOccurred())
+				lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+				// Both images stream stdout, stderr, and the unterminated last line
+				Expect(lines).To(HaveLen(6))
+				Expect(lines).To(ContainElements(
+					"[multi-platform-controller] STEP 1/2: FROM scratch",
+					"[multi-platform-otp] warning: cache miss",
+					"[multi-platform-otp] COMMIT done",
+				))
+			})
+
+			It("should keep the output of a failed build", func() {
+				fakeRuntimeScript := "#!/bin/sh\necho \"error: compilation failed\" >&2\nexit 1\n"
+				Expect(os.WriteFile(os.Getenv("DOCKER_CLI"), []byte(fakeRuntimeScript), 0755)).To(Succeed())
+
+				_, err := BuildMPCImage(context.Background(), cfg, BuildOptions{LogFile: logFile})
+				Expect(err).To(HaveOccurred())
+
+				content, err := os.ReadFile(logFile)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(strings.Split(strings.TrimSpace(string(content)), "\n")).To(ConsistOf(
+					"[multi-platform-controller] error: compilation failed",
+					"[multi-platform-otp] error: compilation failed",
+				))
+			})
+		})
+
+		It("should copy the streamed build output to the output writer", func() {
+			fakeRuntimeScript := "#!/bin/sh\n[ \"$1\" = build ] || exit 0\necho \"STEP 1/2: FROM scratch\"\n"
+			Expect(os.WriteFile(os.Getenv("DOCKER_CLI"), []byte(fakeRuntimeScript), 0755)).To(Succeed())
+
+			var output strings.Builder
+			_, err := BuildMPCImage(context.Background(), cfg, BuildOptions{Output: &output})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(strings.Split(strings.TrimSpace(output.String()), "\n")).To(ConsistOf(
+				"[multi-platform-controller] STEP 1/2: FROM scratch",
+				"[multi-platform-otp] STEP 1/2: FROM scratch",
+			))
+		})
+
+		It("should build with only the configured tags when the commit cannot be resolved", func() {
+			result, err := BuildMPCImage(context.Background(), cfg, BuildOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.SourceGitHash).To(BeEmpty())
+
+			calls, err := os.ReadFile(filepath.Join(tempDir, "runtime_calls.log"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(calls)).To(ContainSubstring("-t " + config.DefaultControllerImage + " -f "))
+		})
+
+		It("should pass the configured build args sorted by name", func() {
+			cfg.BuildArgs = map[string]string{"HTTPS_PROXY": "http://proxy:3128", "GOFLAGS": "-mod=mod"}
+
+			_, err := BuildMPCImage(context.Background(), cfg, BuildOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			calls, err := os.ReadFile(filepath.Join(tempDir, "runtime_calls.log"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(calls)).To(ContainSubstring("-t " + config.DefaultControllerImage +
+				" --build-arg GOFLAGS=-mod=mod --build-arg HTTPS_PROXY=http://proxy:3128 -f " + filepath.Join(tempDir, "Dockerfile") + " "))
+			Expect(string(calls)).To(ContainSubstring("--build-arg GOFLAGS=-mod=mod --build-arg HTTPS_PROXY=http://proxy:3128 -f " + filepath.Join(tempDir, "Dockerfile.otp")))
+		})
+
+		It("should report the size and layer count of each published image", func() {
+			fakeRuntimeScript := "#!/bin/sh\necho \"$@\" >> " + filepath.Join(tempDir, "runtime_calls.log") + "\n" +
+				"case \"$*\" in \"image inspect --format\"*otp*) echo '52428800 3';; \"image inspect --format\"*) echo '104857600 12';; esac\n"
+			Expect(os.WriteFile(os.Getenv("DOCKER_CLI"), []byte(fakeRuntimeScript), 0755)).To(Succeed())
+
+			result, err := BuildMPCImage(context.Background(), cfg, BuildOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Images).To(Equal([]ImageInfo{
+				{Image: config.DefaultControllerImage, SizeBytes: 104857600, Layers: 12},
+				{Image: config.DefaultOTPImage, SizeBytes: 52428800, Layers: 3},
+			}))
+
+			calls, err := os.ReadFile(filepath.Join(tempDir, "runtime_calls.log"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(calls)).To(ContainSubstring("image inspect --format {{.Size}} {{len .RootFS.Layers}} " + config.DefaultControllerImage))
+		})
+
+		It("should leave out images whose size cannot be read", func() {
+			result, err := BuildMPCImage(context.Background(), cfg, BuildOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Images).To(BeEmpty())
+		})
+
+		It("should push the images instead of loading them when a registry is configured", func() {
+			Expect(os.WriteFile(filepath.Join(tempDir, "kind"), []byte("#!/bin/sh\ntouch "+filepath.Join(tempDir, "kind_called")+"\n"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(tempDir, "git"), []byte("#!/bin/sh\necho 0123456789abcdef0123456789abcdef01234567\n"), 0755)).To(Succeed())
+			cfg.RegistryURL = "localhost:5001"
+
+			_, err := BuildMPCImage(context.Background(), cfg, BuildOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			calls, err := os.ReadFile(filepath.Join(tempDir, "runtime_calls.log"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(calls)).To(ContainSubstring("-t localhost:5001/multi-platform-controller:latest -t localhost:5001/multi-platform-controller:0123456789ab -f "))
+			Expect(string(calls)).To(ContainSubstring("push localhost:5001/multi-platform-controller:latest\n"))
+			Expect(string(calls)).To(ContainSubstring("push localhost:5001/multi-platform-controller:0123456789ab\n"))
+			Expect(string(calls)).To(ContainSubstring("push localhost:5001/multi-platform-otp:latest\n"))
+			Expect(string(calls)).NotTo(ContainSubstring("save "))
+			Expect(filepath.Join(tempDir, "kind_called")).NotTo(BeAnExistingFile())
+		})
+
+		It("should push to a localhost registry over plain HTTP with podman", func() {
+			podman := filepath.Join(tempDir, "podman")
+			Expect(os.Rename(os.Getenv("DOCKER_CLI"), podman)).To(Succeed())
+			_ = os.Setenv("DOCKER_CLI", podman)
+			cfg.RegistryURL = "localhost:5001"
+
+			_, err := BuildMPCImage(context.Background(), cfg, BuildOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			calls, err := os.ReadFile(filepath.Join(tempDir, "runtime_calls.log"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(calls)).To(ContainSubstring("push --tls-verify=false localhost:5001/multi-platform-otp:latest"))
+
+			Expect(isLocalRegistry("quay.io/me")).To(BeFalse())
+			Expect(isLocalRegistry("127.0.0.1:5000/dev")).To(BeTrue())
+		})
+
+		Context("building the images concurrently", func() {
+			// writeRuntime installs a fake runtime that logs every call and runs buildScript for "build"
+			writeRuntime := func(buildScript string) {
+				script := "#!/bin/sh\necho \"$@\" >> " + filepath.Join(tempDir, "runtime_calls.log") +
+					"\n[ \"$1\" = build ] || exit 0\n" + buildScript
+				Expect(os.WriteFile(os.Getenv("DOCKER_CLI"), []byte(script), 0755)).To(Succeed())
+			}
+
+			It("should run both builds at the same time", func() {
+				// Each build waits up to 5s for the other one to start
+				started := filepath.Join(tempDir, "started")
+				writeRuntime("touch " + started + ".$$\nfor i in $(seq 50); do\n" +
+					"  [ $(ls " + started + ".* | wc -l) -ge 2 ] && exit 0\n  sleep 0.1\ndone\nexit 1\n")
+
+				_, err := BuildMPCImage(context.Background(), cfg, BuildOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				calls, err := os.ReadFile(filepath.Join(tempDir, "runtime_calls.log"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(calls)).To(ContainSubstring("-f " + filepath.Join(tempDir, "Dockerfile") + " "))
+				Expect(string(calls)).To(ContainSubstring("-f " + filepath.Join(tempDir, "Dockerfile.otp") + " "))
+			})
+
+			It("should build one image at a time with a concurrency of 1", func() {
+				events := filepath.Join(tempDir, "events.log")
+				writeRuntime("echo start >> " + events + "\nsleep 0.2\necho end >> " + events + "\n")
+				cfg.BuildConcurrency = 1
+
+				_, err := BuildMPCImage(context.Background(), cfg, BuildOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				content, err := os.ReadFile(events)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(content)).To(Equal("start\nend\nstart\nend\n"))
+			})
+
+			It("should report a failed build after awaiting the other and load nothing", func() {
+				controllerDone := filepath.Join(tempDir, "controller-done")
+				writeRuntime("case \"$*\" in *Dockerfile.otp*) echo 'error: otp failed' >&2; exit 1;; esac\n" +
+					"sleep 0.3\ntouch " + controllerDone + "\n")
+
+				_, err := BuildMPCImage(context.Background(), cfg, BuildOptions{})
+				Expect(err).To(MatchError(ContainSubstring("failed to build OTP image")))
+				Expect(err).NotTo(MatchError(ContainSubstring("controller")))
+				Expect(controllerDone).To(BeAnExistingFile())
+
+				calls, err := os.ReadFile(filepath.Join(tempDir, "runtime_calls.log"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(calls)).NotTo(ContainSubstring("save "))
+			})
+		})
+
+		Context("with the inputs of a previous build", func() {
+			var builtInputs map[string]string
+
+			// buildCalls returns the runtime invocations that built an image
+			buildCalls := func() []string {
+				calls, err := os.ReadFile(filepath.Join(tempDir, "runtime_calls.log"))
+				Expect(err).NotTo(HaveOccurred())
+				var builds []string
+				for _, call := range strings.Split(string(calls), "\n") {
+					if strings.HasPrefix(call, "build ") {
+						builds = append(builds, call)
+					}
+				}
+				return builds
+			}
+
+			BeforeEach(func() {
+				Expect(os.WriteFile(filepath.Join(tempDir, "Dockerfile"), []byte("FROM scratch\nCOPY go.mod pkg/ /src/\n"), 0644)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(tempDir, "Dockerfile.otp"), []byte("FROM scratch\nCOPY otp /src/otp\n"), 0644)).To(Succeed())
+				for _, dir := range []string{"pkg", "otp"} {
+					Expect(os.MkdirAll(filepath.Join(tempDir, dir), 0755)).To(Succeed())
+					Expect(os.WriteFile(filepath.Join(tempDir, dir, "main.go"), []byte("package main\n"), 0644)).To(Succeed())
+				}
+				Expect(os.WriteFile(filepath.Join(tempDir, "go.mod"), []byte("module test\n"), 0644)).To(Succeed())
+
+				builtInputs = map[string]string{}
+				_, err := BuildMPCImage(context.Background(), cfg, BuildOptions{
+					OnImageBuilt: func(image, inputsHash string) { builtInputs[image] = inputsHash },
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(builtInputs).To(HaveKey(config.DefaultControllerImage))
+				Expect(builtInputs).To(HaveKey(config.DefaultOTPImage))
+				Expect(os.Remove(filepath.Join(tempDir, "runtime_calls.log"))).To(Succeed())
+			})
+
+			It("should only rebuild the image whose inputs changed", func() {
+				Expect(os.WriteFile(filepath.Join(tempDir, "pkg", "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644)).To(Succeed())
+
+				var output strings.Builder
+				_, err := BuildMPCImage(context.Background(), cfg, BuildOptions{BuiltInputs: builtInputs, Output: &output})
+				Expect(err).NotTo(HaveOccurred())
+
+				builds := buildCalls()
+				Expect(builds).To(HaveLen(1))
+				Expect(builds[0]).To(ContainSubstring("-t " + config.DefaultControllerImage + " -f "))
+
+				// The skipped image is still loaded, as the cluster may have been recreated
+				calls, err := os.ReadFile(filepath.Join(tempDir, "runtime_calls.log"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(calls)).To(ContainSubstring("image inspect " + config.DefaultOTPImage))
+				Expect(string(calls)).To(ContainSubstring("save " + config.DefaultOTPImage))
+				Expect(output.String()).To(ContainSubstring("Inputs of " + config.DefaultOTPImage + " are unchanged"))
+			})
+
+			It("should rebuild every image when the build args changed", func() {
+				cfg.BuildArgs = map[string]string{"GOFLAGS": "-mod=mod"}
+
+				_, err := BuildMPCImage(context.Background(), cfg, BuildOptions{BuiltInputs: builtInputs})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(buildCalls()).To(HaveLen(2))
+			})
+
+			It("should rebuild every image when forced", func() {
+				_, err := BuildMPCImage(context.Background(), cfg, BuildOptions{BuiltInputs: builtInputs, Force: true})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(buildCalls()).To(HaveLen(2))
+			})
+
+			It("should tag a skipped image with the new revision", func() {
+				mockGitScript := "#!/bin/sh\necho 0123456789abcdef0123456789abcdef01234567\n"
+				Expect(os.WriteFile(filepath.Join(tempDir, "git"), []byte(mockGitScript), 0755)).To(Succeed())
+
+				_, err := BuildMPCImage(context.Background(), cfg, BuildOptions{BuiltInputs: builtInputs})
+				Expect(err).NotTo(HaveOccurred())
+
+				calls, err := os.ReadFile(filepath.Join(tempDir, "runtime_calls.log"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(calls)).NotTo(ContainSubstring("build "))
+				Expect(string(calls)).To(ContainSubstring("tag " + config.DefaultOTPImage + " localhost/multi-platform-otp:0123456789ab"))
+			})
+
+			It("should rebuild an unchanged image that no longer exists", func() {
+				fakeRuntimeScript := "#!/bin/sh\necho \"$@\" >> " + filepath.Join(tempDir, "runtime_calls.log") +
+					"\n[ \"$1\" = image ] && exit 1\nexit 0\n"
+				Expect(os.WriteFile(os.Getenv("DOCKER_CLI"), []byte(fakeRuntimeScript), 0755)).To(Succeed())
+
+				_, err := BuildMPCImage(context.Background(), cfg, BuildOptions{BuiltInputs: builtInputs})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(buildCalls()).To(HaveLen(2))
+			})
+		})
+	})
+
+	Describe("InputsHash", func() {
+		BeforeEach(func() {
+			Expect(os.MkdirAll(filepath.Join(tempDir, "cmd"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(tempDir, "cmd", "main.go"), []byte("package main\n"), 0644)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(tempDir, "README.md"), []byte("docs\n"), 0644)).To(Succeed())
+		})
+
+		It("should ignore files the Dockerfile does not copy", func() {
+			dockerfile := "FROM golang AS builder\n# COPY README.md /\nCOPY --chown=1001 \\\n  cmd /src/cmd\nFROM scratch\nCOPY --from=builder /src/README.md /\n"
+			Expect(os.WriteFile(filepath.Join(tempDir, "Dockerfile"), []byte(dockerfile), 0644)).To(Succeed())
+
+			before, err := InputsHash(tempDir, "Dockerfile")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(os.WriteFile(filepath.Join(tempDir, "README.md"), []byte("more docs\n"), 0644)).To(Succeed())
+			after, err := InputsHash(tempDir, "Dockerfile")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(after).To(Equal(before))
+
+			Expect(os.WriteFile(filepath.Join(tempDir, "cmd", "main.go"), []byte("package main // changed\n"), 0644)).To(Succeed())
+			after, err = InputsHash(tempDir, "Dockerfile")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(after).NotTo(Equal(before))
+		})
+
+		It("should treat the whole repository as input for the JSON form", func() {
+			Expect(os.WriteFile(filepath.Join(tempDir, "Dockerfile"), []byte("FROM scratch\nCOPY [\"cmd\", \"/src\"]\n"), 0644)).To(Succeed())
+
+			before, err := InputsHash(tempDir, "Dockerfile")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(os.WriteFile(filepath.Join(tempDir, "README.md"), []byte("more docs\n"), 0644)).To(Succeed())
+			after, err := InputsHash(tempDir, "Dockerfile")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(after).NotTo(Equal(before))
+		})
+
+		It("should return an error when the Dockerfile is missing", func() {
+			_, err := InputsHash(tempDir, "Dockerfile.missing")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("OOM retry", func() {
+		var originalPath string
+
+		// writeRuntime installs a fake runtime that logs every call and runs buildScript for "build"
+		writeRuntime := func(buildScript string) {
+			script := "#!/bin/sh\necho \"$@\" >> " + filepath.Join(tempDir, "runtime_calls.log") +
+				"\n[ \"$1\" = build ] || exit 0\n" + buildScript
+			fakeRuntimePath := filepath.Join(tempDir, "fake-runtime")
+			Expect(os.WriteFile(fakeRuntimePath, []byte(script), 0755)).To(Succeed())
+			_ = os.Setenv("DOCKER_CLI", fakeRuntimePath)
+		}
+
+		// buildCalls returns the number of times the fake runtime was asked to build
+		buildCalls := func() int {
+			calls, err := os.ReadFile(filepath.Join(tempDir, "runtime_calls.log"))
+			Expect(err).NotTo(HaveOccurred())
+			return strings.Count(string(calls), "build --platform")
+		}
+
+		BeforeEach(func() {
+			originalPath = os.Getenv("PATH")
+			Expect(os.WriteFile(filepath.Join(tempDir, "Dockerfile"), []byte("FROM scratch\n"), 0644)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(tempDir, "kind"), []byte("#!/bin/sh\ncat > /dev/null\nexit 0"), 0755)).To(Succeed())
+			_ = os.Setenv("PATH", tempDir+":"+originalPath)
+		})
+
+		AfterEach(func() {
+			_ = os.Setenv("PATH", originalPath)
+		})
+
+		It("should retry exactly once when the first build exits with 137", func() {
+			marker := filepath.Join(tempDir, "oom-once")
+			writeRuntime("if [ ! -f " + marker + " ]; then touch " + marker + "; exit 137; fi\nexit 0\n")
+
+			Expect(builder.buildImage(context.Background(), "Dockerfile", "multi-platform-controller:latest")).To(Succeed())
+			Expect(buildCalls()).To(Equal(2))
+		})
+
+		It("should return the original error with a memory hint when the retry also fails", func() {
+			writeRuntime("exit 137\n")
+
+			err := builder.buildImage(context.Background(), "Dockerfile", "multi-platform-controller:latest")
+			Expect(err).To(MatchError(ContainSubstring("exit status 137")))
+			Expect(err).To(MatchError(ContainSubstring("increasing the memory")))
+
+			var exitErr *exec.ExitError
+			Expect(errors.As(err, &exitErr)).To(BeTrue())
+			Expect(buildCalls()).To(Equal(2))
+		})
+
+		It("should retry when the runtime is killed by SIGKILL", func() {
+			marker := filepath.Join(tempDir, "oom-once")
+			writeRuntime("if [ ! -f " + marker + " ]; then touch " + marker + "; kill -9 $$; fi\nexit 0\n")
+
+			Expect(builder.buildImage(context.Background(), "Dockerfile", "multi-platform-controller:latest")).To(Succeed())
+			Expect(buildCalls()).To(Equal(2))
+		})
+
+		It("should not retry other build failures", func() {
+			writeRuntime("exit 1\n")
+
+			Expect(builder.buildImage(context.Background(), "Dockerfile", "multi-platform-controller:latest")).To(MatchError(ContainSubstring("exit status 1")))
+			Expect(buildCalls()).To(Equal(1))
+		})
+	})
 })
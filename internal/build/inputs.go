@@ -0,0 +1,176 @@
+package build
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// InputsHash returns a hash of everything an image built from dockerfileName in
+// repoPath depends on: the Dockerfile itself and the files its COPY and ADD
+// instructions take from the build context. Two builds with the same hash produce
+// the same image, so BuildMPCImage uses it to skip images whose inputs are unchanged.
+//
+// When the sources cannot be determined from an instruction (the JSON form), the
+// whole repository except .git counts as input. Remote ADD sources (URLs) are not
+// tracked; a forced build picks up changes to those.
+func InputsHash(repoPath, dockerfileName string) (string, error) {
+	dockerfile, err := os.ReadFile(filepath.Join(repoPath, dockerfileName))
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", dockerfileName, err)
+	}
+
+	hash := sha256.New()
+	_, _ = fmt.Fprintf(hash, "%s\x00%x\x00", dockerfileName, sha256.Sum256(dockerfile))
+
+	files := map[string]bool{}
+	for _, source := range dockerfileSources(string(dockerfile)) {
+		matches, err := filepath.Glob(filepath.Join(repoPath, source))
+		if err != nil {
+			return "", fmt.Errorf("invalid source %q in %s: %w", source, dockerfileName, err)
+		}
+		if len(matches) == 0 {
+			// The build fails on a missing source; record it so adding it later counts as a change
+			_, _ = fmt.Fprintf(hash, "missing:%s\x00", source)
+			continue
+		}
+		for _, match := range matches {
+			if err := collectFiles(repoPath, match, files); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		sum, err := fileHash(filepath.Join(repoPath, path))
+		if err != nil {
+			return "", err
+		}
+		_, _ = fmt.Fprintf(hash, "%s\x00%s\x00", filepath.ToSlash(path), sum)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// dockerfileSources returns the build context paths a Dockerfile's COPY and ADD
+// instructions copy from, relative to the context. Instructions copying from
+// another build stage (--from) are skipped, and "." stands for the whole context
+// when an instruction's sources cannot be parsed.
+func dockerfileSources(dockerfile string) []string {
+	var sources []string
+	for _, line := range dockerfileInstructions(dockerfile) {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		instruction := strings.ToUpper(fields[0])
+		if instruction != "COPY" && instruction != "ADD" {
+			continue
+		}
+
+		args := fields[1:]
+		fromStage := false
+		for len(args) > 0 && strings.HasPrefix(args[0], "--") {
+			if strings.HasPrefix(args[0], "--from") {
+				fromStage = true
+			}
+			args = args[1:]
+		}
+		if fromStage {
+			continue
+		}
+		if len(args) > 0 && strings.HasPrefix(args[0], "[") {
+			sources = append(sources, ".")
+			continue
+		}
+		if len(args) < 2 {
+			continue
+		}
+
+		// The last argument is the destination inside the image
+		for _, source := range args[:len(args)-1] {
+			if strings.Contains(source, "://") {
+				continue
+			}
+			sources = append(sources, filepath.Clean(strings.TrimPrefix(source, "/")))
+		}
+	}
+	return sources
+}
+
+// dockerfileInstructions splits a Dockerfile into instructions, joining lines
+// continued with a trailing backslash and dropping comments.
+func dockerfileInstructions(dockerfile string) []string {
+	var instructions []string
+	var current strings.Builder
+	for _, line := range strings.Split(dockerfile, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if strings.HasSuffix(trimmed, "\\") {
+			current.WriteString(strings.TrimSuffix(trimmed, "\\"))
+			current.WriteString(" ")
+			continue
+		}
+		current.WriteString(trimmed)
+		instructions = append(instructions, current.String())
+		current.Reset()
+	}
+	if current.Len() > 0 {
+		instructions = append(instructions, current.String())
+	}
+	return instructions
+}
+
+// collectFiles adds the regular files at or below path to files, keyed by their
+// path relative to repoPath. The repository's .git directory is skipped.
+func collectFiles(repoPath, path string, files map[string]bool) error {
+	return filepath.WalkDir(path, func(current string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return fmt.Errorf("failed to read build input %s: %w", current, err)
+		}
+		if entry.IsDir() {
+			if entry.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		relative, err := filepath.Rel(repoPath, current)
+		if err != nil {
+			return err
+		}
+		files[relative] = true
+		return nil
+	})
+}
+
+// fileHash returns the hex SHA-256 of a file's contents.
+func fileHash(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read build input %s: %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("failed to read build input %s: %w", path, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -1,25 +1,52 @@
 // Package cluster provides Kind cluster lifecycle management for the MPC Dev Environment.
 //
-// It handles creating, destroying, and checking the status of Kind (Kubernetes in Docker)
-// clusters. The package uses Podman as the container runtime provider for better SELinux
-// compatibility on RHEL/Fedora systems.
+// It handles creating, destroying, pausing, resuming, and checking the status of Kind
+// (Kubernetes in Docker) clusters. The cluster runs on the same container runtime that
+// builds use (see build.ContainerRuntime), so Podman is preferred for better SELinux
+// compatibility on RHEL/Fedora systems, and Docker is used when chosen or the only one found.
 //
-// All cluster operations use the cluster name "konflux" and execute commands through
-// bash to ensure proper environment handling and resource limits.
+// All cluster operations use the cluster name from config.Config (MPC_CLUSTER_NAME,
+// default "konflux") and execute commands through bash to ensure proper environment
+// handling and resource limits.
 package cluster
 
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os/exec"
 	"strings"
+	"time"
 
+	"github.com/meyrevived/mpc-dev-env/internal/build"
 	"github.com/meyrevived/mpc-dev-env/internal/config"
+	"github.com/meyrevived/mpc-dev-env/internal/lines"
 	"github.com/meyrevived/mpc-dev-env/internal/logger"
 )
 
-// Manager handles Kind cluster lifecycle operations (create, destroy, status).
+// ErrClusterNotFound is returned when an operation requires the Kind cluster to exist
+// but kind reports that it does not.
+var ErrClusterNotFound = errors.New("kind cluster not found")
+
+// Cluster statuses returned by Manager.Status. The daemon reports them unchanged in
+// GET /api/cluster/status and in the cluster section of GET /api/status.
+const (
+	StatusRunning      = "Running"      // The cluster exists and kubectl can reach it
+	StatusInitializing = "Initializing" // The cluster exists but kubectl cannot reach it yet
+	StatusPaused       = "Paused"       // The cluster exists but its node containers are stopped
+	StatusNotRunning   = "Not Running"  // The cluster does not exist
+	StatusError        = "Error"        // The status could not be determined
+)
+
+// Info describes an existing Kind cluster.
+type Info struct {
+	CreatedAt time.Time // When the control-plane node container was created
+	NodeCount int       // Number of node containers, control plane included
+}
+
+// Manager handles Kind cluster lifecycle operations (create, destroy, pause, resume, status).
 // It provides a Go-native interface to Kind cluster management, replacing
 // the Bash-based cluster management scripts.
 type Manager struct {
@@ -34,49 +61,111 @@ func NewManager(cfg *config.Config) *Manager {
 	}
 }
 
+// containerRuntime returns the container runtime the cluster runs on, the same one
+// image builds use.
+func (m *Manager) containerRuntime() (string, error) {
+	runtime, err := build.ContainerRuntime(m.config)
+	if err != nil {
+		return "", fmt.Errorf("failed to determine container runtime: %w", err)
+	}
+	return runtime, nil
+}
+
+// kindWaitDelay bounds how long runKind waits for kind's output after killing it; the
+// container runtime commands kind started may still hold its pipes open.
+const kindWaitDelay = 5 * time.Second
+
+// kindCommand returns a "kind <args>" command run through bash -c. For podman it sets
+// KIND_EXPERIMENTAL_PROVIDER=podman; kind uses docker by default.
+func kindCommand(ctx context.Context, runtime string, args ...string) *exec.Cmd {
+	cmdStr := "kind " + strings.Join(args, " ")
+	if build.IsPodman(runtime) {
+		cmdStr = "KIND_EXPERIMENTAL_PROVIDER=podman " + cmdStr
+	}
+	return exec.CommandContext(ctx, "bash", "-c", cmdStr)
+}
+
+// runKind runs a kind command created by kindCommand, logging its output line by line
+// as it is written, so the progress of a long or hung step is visible while it runs.
+// It returns what the command wrote to stderr, where kind reports both progress and errors.
+//
+// Once ctx is done the command is killed, and runKind returns within kindWaitDelay with
+// an error wrapping ctx.Err().
+func runKind(ctx context.Context, cmd *exec.Cmd) (string, error) {
+	var stderr bytes.Buffer
+	stdoutLines := newKindOutputLogger("stdout")
+	stderrLines := newKindOutputLogger("stderr")
+	cmd.Stdout = stdoutLines
+	cmd.Stderr = io.MultiWriter(&stderr, stderrLines)
+	cmd.WaitDelay = kindWaitDelay
+
+	err := cmd.Run()
+	stdoutLines.Flush()
+	stderrLines.Flush()
+
+	if err != nil && ctx.Err() != nil {
+		return stderr.String(), fmt.Errorf("kind was interrupted: %w", ctx.Err())
+	}
+	return stderr.String(), err
+}
+
+// newKindOutputLogger returns a lines.Writer that logs each non-blank line of kind's
+// output on stream ("stdout" or "stderr"), without its surrounding whitespace.
+func newKindOutputLogger(stream string) *lines.Writer {
+	return lines.NewWriter(func(line string) {
+		if line = strings.TrimSpace(line); line != "" {
+			logger.Info("kind output", "stream", stream, "line", line)
+		}
+	})
+}
+
 // Create creates a new Kind cluster.
-// It executes the "kind create cluster" command and streams output to logs.
+// It executes the "kind create cluster" command and streams output to logs line by line.
 //
 // The cluster creation uses the following approach:
-//   - Uses the cluster name "konflux" (hardcoded for now, can be made configurable)
-//   - If a kind-config.yaml exists in the MPC_DEV_ENV_PATH, it will be used
-//   - Streams stdout and stderr to logs for debugging
+//   - Uses the configured cluster name (MPC_CLUSTER_NAME, default "konflux")
+//   - Runs on the configured or detected container runtime (MPC_CONTAINER_RUNTIME)
+//   - If MPC_KIND_CONFIG_PATH is set, or a kind-config.yaml exists in the MPC_DEV_ENV_PATH,
+//     it is passed via --config (port mappings, extra nodes, etc.)
+//   - Streams stdout and stderr to logs as they are written, for debugging
 //
 // Parameters:
-//   - ctx: Context for cancellation and timeout control
+//   - ctx: Context for cancellation and timeout control; once it is done, kind is killed
+//     and Create returns promptly
 //
 // Returns:
-//   - error: An error if cluster creation fails, nil otherwise
+//   - error: An error if cluster creation fails or is cancelled, nil otherwise
 func (m *Manager) Create(ctx context.Context) error {
 	logger.Info("creating kind cluster")
 
-	clusterName := "konflux"
+	runtime, err := m.containerRuntime()
+	if err != nil {
+		return fmt.Errorf("failed to create Kind cluster: %w", err)
+	}
+	clusterName := m.config.GetClusterName()
 
 	// Build the kind create cluster command
-	// Note: For now, we use default kind settings
-	// The bash script shows it looks for kind-config.yaml in konflux-ci directory,
-	// but that directory is not yet part of our Config struct.
-	// Future enhancement: Add support for --config flag when kind-config.yaml is available
 	args := []string{"create", "cluster", "--name", clusterName}
 
-	// Build full command string with environment variable
-	cmdStr := "KIND_EXPERIMENTAL_PROVIDER=podman kind " + strings.Join(args, " ")
-	logger.Info("executing command", "command", cmdStr)
+	// Use a kind-config.yaml when available (e.g., to expose 9443 for the Konflux UI
+	// or to run a multi-node cluster). Without one, kind's defaults are used.
+	if kindConfigPath := m.config.GetKindConfigPath(); kindConfigPath != "" {
+		logger.Info("using kind config", "path", kindConfigPath)
+		args = append(args, "--config", kindConfigPath)
+	}
+
+	// kind writes the cluster's context into this kubeconfig
+	args = append(args, m.config.KubeconfigArgs()...)
 
 	// Execute via bash -c to ensure proper environment and resource limits
 	// This avoids issues with cgroup/systemd limits when run from daemon
-	cmd := exec.CommandContext(ctx, "bash", "-c", cmdStr)
-
-	// Run the command and capture combined output
-	output, err := cmd.CombinedOutput()
-
-	// Log the output regardless of success/failure
-	if len(output) > 0 {
-		logger.Debug("kind create output", "output", string(output))
-	}
+	cmd := kindCommand(ctx, runtime, args...)
+	logger.Info("executing command", "command", cmd.Args[2])
 
+	// Run the command, streaming its output to the logs
+	output, err := runKind(ctx, cmd)
 	if err != nil {
-		return fmt.Errorf("failed to create Kind cluster: %w (output: %s)", err, string(output))
+		return fmt.Errorf("failed to create Kind cluster: %w (output: %s)", err, output)
 	}
 
 	logger.Info("kind cluster created successfully")
@@ -88,46 +177,32 @@ func (m *Manager) Create(ctx context.Context) error {
 // This function handles cases where the cluster might not exist gracefully.
 //
 // Parameters:
-//   - ctx: Context for cancellation and timeout control
+//   - ctx: Context for cancellation and timeout control; once it is done, kind is killed
+//     and Destroy returns promptly
 //
 // Returns:
-//   - error: An error if cluster deletion fails (except for "cluster not found"), nil otherwise
+//   - error: An error if cluster deletion fails (except for "cluster not found") or is cancelled, nil otherwise
 func (m *Manager) Destroy(ctx context.Context) error {
 	logger.Info("destroying kind cluster")
 
-	clusterName := "konflux"
-
-	// Build the kind delete cluster command
-	args := []string{"delete", "cluster", "--name", clusterName}
-
-	// Build full command string with environment variable
-	cmdStr := "KIND_EXPERIMENTAL_PROVIDER=podman kind " + strings.Join(args, " ")
-	logger.Info("executing command", "command", cmdStr)
-
-	// Execute via bash -c
-	cmd := exec.CommandContext(ctx, "bash", "-c", cmdStr)
-
-	// Capture stdout and stderr
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	// Execute the command
-	err := cmd.Run()
-
-	// Log the output
-	if stdout.Len() > 0 {
-		logger.Debug("kind delete stdout", "output", stdout.String())
-	}
-	if stderr.Len() > 0 {
-		logger.Debug("kind delete stderr", "output", stderr.String())
+	runtime, err := m.containerRuntime()
+	if err != nil {
+		return fmt.Errorf("failed to delete Kind cluster: %w", err)
 	}
+	clusterName := m.config.GetClusterName()
+
+	// Execute "kind delete cluster" via bash -c
+	args := append([]string{"delete", "cluster", "--name", clusterName}, m.config.KubeconfigArgs()...)
+	cmd := kindCommand(ctx, runtime, args...)
+	logger.Info("executing command", "command", cmd.Args[2])
 
+	// Run the command, streaming its output to the logs
+	stderrStr, err := runKind(ctx, cmd)
 	if err != nil {
 		// Check if the error is because the cluster doesn't exist
 		// kind returns a non-zero exit code if the cluster is not found
-		stderrStr := stderr.String()
-		if strings.Contains(stderrStr, "not found") || strings.Contains(stderrStr, "No kind clusters found") {
+		notFound := strings.Contains(stderrStr, "not found") || strings.Contains(stderrStr, "No kind clusters found")
+		if notFound && ctx.Err() == nil {
 			logger.Info("cluster does not exist")
 			return nil
 		}
@@ -144,17 +219,24 @@ func (m *Manager) Destroy(ctx context.Context) error {
 // Parameters:
 //   - ctx: Context for cancellation and timeout control
 //
+// A cluster whose control-plane node container exists but is stopped (see Pause)
+// is reported as StatusPaused.
+//
 // Returns:
-//   - string: One of "Running", "Not Running", or "Error"
+//   - string: One of StatusRunning, StatusInitializing, StatusPaused, StatusNotRunning, or StatusError
 //   - error: An error if the status check fails, nil otherwise
 func (m *Manager) Status(ctx context.Context) (string, error) {
 	logger.Info("checking kind cluster status")
 
-	clusterName := "konflux"
+	runtime, err := m.containerRuntime()
+	if err != nil {
+		logger.Error(err, "failed to get cluster status")
+		return StatusError, fmt.Errorf("failed to get cluster status: %w", err)
+	}
+	clusterName := m.config.GetClusterName()
 
 	// Use "kind get clusters" to list all clusters
-	cmdStr := "KIND_EXPERIMENTAL_PROVIDER=podman kind get clusters"
-	cmd := exec.CommandContext(ctx, "bash", "-c", cmdStr)
+	cmd := kindCommand(ctx, runtime, "get", "clusters")
 
 	// Capture stdout and stderr
 	var stdout, stderr bytes.Buffer
@@ -162,7 +244,7 @@ func (m *Manager) Status(ctx context.Context) (string, error) {
 	cmd.Stderr = &stderr
 
 	// Execute the command
-	err := cmd.Run()
+	err = cmd.Run()
 
 	// Log the output
 	if stdout.Len() > 0 {
@@ -175,14 +257,14 @@ func (m *Manager) Status(ctx context.Context) (string, error) {
 	if err != nil {
 		// If kind command fails, return Error status
 		logger.Error(err, "failed to get cluster status")
-		return "Error", fmt.Errorf("failed to get cluster status: %w", err)
+		return StatusError, fmt.Errorf("failed to get cluster status: %w", err)
 	}
 
 	// Parse the output to check if our cluster exists
 	clusters := strings.TrimSpace(stdout.String())
 	if clusters == "" {
 		logger.Info("no kind clusters found")
-		return "Not Running", nil
+		return StatusNotRunning, nil
 	}
 
 	// Check if our cluster is in the list
@@ -196,21 +278,208 @@ func (m *Manager) Status(ctx context.Context) (string, error) {
 
 	if !clusterExists {
 		logger.Info("cluster not found", "name", clusterName)
-		return "Not Running", nil
+		return StatusNotRunning, nil
+	}
+
+	// A paused cluster is still listed by kind, but its node containers are stopped
+	if running, err := m.controlPlaneRunning(ctx, runtime); err == nil && !running {
+		logger.Info("cluster is paused", "name", clusterName)
+		return StatusPaused, nil
 	}
 
 	// Cluster exists, but we need to verify kubectl can access it
 	// This ensures the cluster is fully initialized and ready
 	logger.Info("cluster found, verifying kubectl accessibility", "name", clusterName)
-	kubectlCmd := exec.CommandContext(ctx, "kubectl", "cluster-info", "--context", "kind-"+clusterName)
+	kubectlArgs := append([]string{"cluster-info", "--context", m.ContextName()}, m.config.KubeconfigArgs()...)
+	kubectlCmd := exec.CommandContext(ctx, "kubectl", kubectlArgs...)
 	kubectlCmd.Stdout = &bytes.Buffer{}
 	kubectlCmd.Stderr = &bytes.Buffer{}
 
 	if err := kubectlCmd.Run(); err != nil {
 		logger.Info("cluster exists but kubectl cannot access it yet", "name", clusterName)
-		return "Initializing", nil
+		return StatusInitializing, nil
 	}
 
 	logger.Info("cluster is running and accessible via kubectl", "name", clusterName)
-	return "Running", nil
+	return StatusRunning, nil
+}
+
+// Info returns the creation time and node count of the Kind cluster, read from
+// the container runtime. It works for paused clusters too.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//
+// Returns:
+//   - Info: The cluster's creation time and node count
+//   - error: ErrClusterNotFound if the cluster does not exist, or another error if the runtime cannot be queried
+func (m *Manager) Info(ctx context.Context) (Info, error) {
+	runtime, err := m.containerRuntime()
+	if err != nil {
+		return Info{}, err
+	}
+	nodes, err := m.nodes(ctx, runtime)
+	if err != nil {
+		return Info{}, err
+	}
+
+	node := m.config.GetClusterName() + "-control-plane"
+	output, err := exec.CommandContext(ctx, runtime, "inspect", "--format", "{{.Created}}", node).Output()
+	if err != nil {
+		return Info{}, fmt.Errorf("failed to inspect %s: %w", node, err)
+	}
+
+	createdAt, err := parseContainerTime(strings.TrimSpace(string(output)))
+	if err != nil {
+		return Info{}, fmt.Errorf("unexpected creation time of %s: %w", node, err)
+	}
+
+	return Info{CreatedAt: createdAt, NodeCount: len(nodes)}, nil
+}
+
+// parseContainerTime parses a container's creation time as printed by inspect:
+// podman prints Go's time.Time format ("2025-11-27 14:30:52.123456789 +0000 UTC"),
+// docker prints RFC 3339.
+func parseContainerTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02 15:04:05.999999999 -0700 MST", value)
+}
+
+// Pause stops the Kind cluster's node containers without deleting them.
+// The cluster keeps its state and can be brought back with Resume, e.g. after a
+// laptop sleep or reboot.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//
+// Returns:
+//   - error: ErrClusterNotFound if the cluster does not exist, or another error if stopping fails
+func (m *Manager) Pause(ctx context.Context) error {
+	logger.Info("pausing kind cluster", "name", m.config.GetClusterName())
+	if err := m.runOnNodes(ctx, "stop"); err != nil {
+		return fmt.Errorf("failed to pause Kind cluster: %w", err)
+	}
+	logger.Info("kind cluster paused")
+	return nil
+}
+
+// Resume starts the node containers of a cluster paused with Pause.
+// The API server takes a few seconds to come back; until then Status reports
+// "Initializing".
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//
+// Returns:
+//   - error: ErrClusterNotFound if the cluster does not exist, or another error if starting fails
+func (m *Manager) Resume(ctx context.Context) error {
+	logger.Info("resuming kind cluster", "name", m.config.GetClusterName())
+	if err := m.runOnNodes(ctx, "start"); err != nil {
+		return fmt.Errorf("failed to resume Kind cluster: %w", err)
+	}
+	logger.Info("kind cluster resumed")
+	return nil
+}
+
+// runOnNodes runs "<runtime> <action>" (e.g. "podman stop") on all node containers
+// of the cluster.
+func (m *Manager) runOnNodes(ctx context.Context, action string) error {
+	runtime, err := m.containerRuntime()
+	if err != nil {
+		return err
+	}
+	nodes, err := m.nodes(ctx, runtime)
+	if err != nil {
+		return err
+	}
+
+	args := append([]string{action}, nodes...)
+	logger.Info("executing command", "command", runtime+" "+strings.Join(args, " "))
+	output, err := exec.CommandContext(ctx, runtime, args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s %s failed: %w (output: %s)", runtime, action, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// nodes returns the names of the cluster's node containers, e.g. "konflux-control-plane".
+// kind lists stopped containers too, so this also works for a paused cluster.
+func (m *Manager) nodes(ctx context.Context, runtime string) ([]string, error) {
+	clusterName := m.config.GetClusterName()
+
+	cmd := kindCommand(ctx, runtime, "get", "nodes", "--name", clusterName)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to list cluster nodes: %w (stderr: %s)", err, stderr.String())
+	}
+
+	nodes := strings.Fields(stdout.String())
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrClusterNotFound, clusterName)
+	}
+	return nodes, nil
+}
+
+// controlPlaneRunning reports whether the cluster's control-plane node container is
+// running, according to the container runtime's inspect.
+func (m *Manager) controlPlaneRunning(ctx context.Context, runtime string) (bool, error) {
+	node := m.config.GetClusterName() + "-control-plane"
+	output, err := exec.CommandContext(ctx, runtime, "inspect", "--format", "{{.State.Running}}", node).Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to inspect %s: %w", node, err)
+	}
+	return strings.TrimSpace(string(output)) == "true", nil
+}
+
+// ContextName returns the kubectl context name that kind creates for the cluster
+// (e.g., "kind-konflux").
+func (m *Manager) ContextName() string {
+	return m.config.GetKubeContext()
+}
+
+// GetKubeconfig returns the raw kubeconfig for the Kind cluster.
+// It executes "kind get kubeconfig --name <cluster>" and returns its stdout.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//
+// Returns:
+//   - string: The kubeconfig YAML
+//   - error: ErrClusterNotFound if the cluster does not exist, or another error if the command fails
+func (m *Manager) GetKubeconfig(ctx context.Context) (string, error) {
+	runtime, err := m.containerRuntime()
+	if err != nil {
+		return "", err
+	}
+	clusterName := m.config.GetClusterName()
+	logger.Info("exporting kind kubeconfig", "name", clusterName)
+
+	cmd := kindCommand(ctx, runtime, "get", "kubeconfig", "--name", clusterName)
+
+	// Capture stdout and stderr
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		// kind reports a missing cluster as "could not locate any control plane nodes"
+		stderrStr := stderr.String()
+		if strings.Contains(stderrStr, "could not locate") || strings.Contains(stderrStr, "not found") {
+			return "", fmt.Errorf("%w: %s", ErrClusterNotFound, clusterName)
+		}
+		return "", fmt.Errorf("failed to get kubeconfig: %w (stderr: %s)", err, stderrStr)
+	}
+
+	kubeconfig := stdout.String()
+	if strings.TrimSpace(kubeconfig) == "" {
+		return "", fmt.Errorf("%w: %s", ErrClusterNotFound, clusterName)
+	}
+
+	return kubeconfig, nil
 }
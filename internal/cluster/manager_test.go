@@ -2,6 +2,10 @@ package cluster
 
 import (
 	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -44,3 +48,518 @@ func TestStatusWithTimeout(t *testing.T) {
 	// We just want to verify it doesn't hang indefinitely
 	t.Logf("Status: %s, Error: %v", status, err)
 }
+
+// setupMockBinaries writes mock kind, kubectl and podman executables into a temp
+// directory, prepends it to PATH, and returns the path of the file the mocks append
+// their arguments to. The mock kind prints clusterList for "kind get clusters" and
+// only returns a kubeconfig and nodes for clusters in that list; it logs its
+// KIND_EXPERIMENTAL_PROVIDER, if any, before its arguments. The mock podman reports
+// running containers (see setupMockPodman).
+func setupMockBinaries(t *testing.T, clusterList string) string {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	callsLog := filepath.Join(tempDir, "calls.log")
+
+	kindScript := `#!/bin/sh
+if [ -n "$KIND_EXPERIMENTAL_PROVIDER" ]; then
+  echo "KIND_EXPERIMENTAL_PROVIDER=$KIND_EXPERIMENTAL_PROVIDER kind $@" >> ` + callsLog + `
+else
+  echo "kind $@" >> ` + callsLog + `
+fi
+if [ "$1" = "get" ] && [ "$2" = "clusters" ]; then
+  printf '%s\n' "` + clusterList + `"
+fi
+if [ "$1" = "get" ] && [ "$2" = "nodes" ]; then
+  if printf '%s\n' "` + clusterList + `" | grep -qx "$4"; then
+    echo "$4-control-plane"
+  else
+    echo "No kind nodes found for cluster \"$4\"." >&2
+  fi
+fi
+if [ "$1" = "get" ] && [ "$2" = "kubeconfig" ]; then
+  if printf '%s\n' "` + clusterList + `" | grep -qx "$4"; then
+    echo "apiVersion: v1"
+    echo "current-context: kind-$4"
+  else
+    echo "ERROR: could not locate any control plane nodes for cluster named '$4'" >&2
+    exit 1
+  fi
+fi
+exit 0
+`
+	if err := os.WriteFile(filepath.Join(tempDir, "kind"), []byte(kindScript), 0755); err != nil {
+		t.Fatalf("failed to write mock kind: %v", err)
+	}
+
+	kubectlScript := `#!/bin/sh
+echo "kubectl $@" >> ` + callsLog + `
+exit 0
+`
+	if err := os.WriteFile(filepath.Join(tempDir, "kubectl"), []byte(kubectlScript), 0755); err != nil {
+		t.Fatalf("failed to write mock kubectl: %v", err)
+	}
+
+	t.Setenv("PATH", tempDir+":"+os.Getenv("PATH"))
+	t.Setenv("DOCKER_CLI", "")
+	setupMockPodman(t, callsLog, "true")
+	return callsLog
+}
+
+// setupMockPodman writes a mock podman next to the mocks of setupMockBinaries. It
+// appends its arguments to callsLog and reports running as the containers' state
+// and a fixed creation time.
+func setupMockPodman(t *testing.T, callsLog, running string) {
+	t.Helper()
+	setupMockRuntime(t, callsLog, "podman", running)
+}
+
+// setupMockRuntime writes a mock container runtime named runtime ("podman" or
+// "docker") next to the mocks of setupMockBinaries, behaving like setupMockPodman's.
+func setupMockRuntime(t *testing.T, callsLog, runtime, running string) {
+	t.Helper()
+
+	runtimeScript := `#!/bin/sh
+echo "` + runtime + ` $@" >> ` + callsLog + `
+if [ "$1" = "inspect" ] && [ "$3" = "{{.Created}}" ]; then
+  echo "2025-11-27 14:30:52.123456789 +0000 UTC"
+elif [ "$1" = "inspect" ]; then
+  echo "` + running + `"
+fi
+exit 0
+`
+	if err := os.WriteFile(filepath.Join(filepath.Dir(callsLog), runtime), []byte(runtimeScript), 0755); err != nil {
+		t.Fatalf("failed to write mock %s: %v", runtime, err)
+	}
+}
+
+// readCalls returns the contents of the mock calls log
+func readCalls(t *testing.T, callsLog string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(callsLog)
+	if err != nil {
+		t.Fatalf("failed to read calls log: %v", err)
+	}
+	return string(data)
+}
+
+// TestCreateUsesConfiguredClusterName tests that Create passes the configured name to kind
+func TestCreateUsesConfiguredClusterName(t *testing.T) {
+	callsLog := setupMockBinaries(t, "")
+
+	manager := NewManager(&config.Config{ClusterName: "mpc-dev-2"})
+	if err := manager.Create(context.Background()); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	calls := readCalls(t, callsLog)
+	if !strings.Contains(calls, "kind create cluster --name mpc-dev-2") {
+		t.Errorf("expected create command with configured name, got: %s", calls)
+	}
+}
+
+// TestDestroyUsesConfiguredClusterName tests that Destroy passes the configured name to kind
+func TestDestroyUsesConfiguredClusterName(t *testing.T) {
+	callsLog := setupMockBinaries(t, "")
+
+	manager := NewManager(&config.Config{ClusterName: "mpc-dev-2"})
+	if err := manager.Destroy(context.Background()); err != nil {
+		t.Fatalf("Destroy failed: %v", err)
+	}
+
+	calls := readCalls(t, callsLog)
+	if !strings.Contains(calls, "kind delete cluster --name mpc-dev-2") {
+		t.Errorf("expected delete command with configured name, got: %s", calls)
+	}
+}
+
+// TestCreateCancelledContextAbortsKind tests that cancelling the context kills a hanging
+// "kind create cluster" and that Create returns promptly with the output kind wrote so far
+func TestCreateCancelledContextAbortsKind(t *testing.T) {
+	callsLog := setupMockBinaries(t, "")
+	hangingKind := `#!/bin/sh
+echo "kind $@" >> ` + callsLog + `
+echo " ✓ Ensuring node image (kindest/node:v1.31.0) 🖼" >&2
+echo " • Preparing nodes 📦" >&2
+exec sleep 60
+`
+	if err := os.WriteFile(filepath.Join(filepath.Dir(callsLog), "kind"), []byte(hangingKind), 0755); err != nil {
+		t.Fatalf("failed to write mock kind: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(500*time.Millisecond, cancel)
+
+	manager := NewManager(&config.Config{})
+	start := time.Now()
+	err := manager.Create(ctx)
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected an error wrapping context.Canceled, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "Preparing nodes") {
+		t.Errorf("expected the error to include kind's progress so far, got %v", err)
+	}
+	if elapsed > kindWaitDelay {
+		t.Errorf("expected Create to return promptly after cancellation, took %s", elapsed)
+	}
+}
+
+// TestStatusUsesConfiguredClusterName tests that Status looks for the configured name
+// and checks the matching kubectl context
+func TestStatusUsesConfiguredClusterName(t *testing.T) {
+	callsLog := setupMockBinaries(t, "konflux\nmpc-dev-2")
+
+	manager := NewManager(&config.Config{ClusterName: "mpc-dev-2"})
+	status, err := manager.Status(context.Background())
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if status != "Running" {
+		t.Errorf("expected status Running, got %s", status)
+	}
+
+	calls := readCalls(t, callsLog)
+	if !strings.Contains(calls, "kubectl cluster-info --context kind-mpc-dev-2") {
+		t.Errorf("expected cluster-info with configured context, got: %s", calls)
+	}
+}
+
+// TestKindProviderPodman tests that Create, Destroy and Status run kind with the
+// podman provider when podman is the detected runtime
+func TestKindProviderPodman(t *testing.T) {
+	callsLog := setupMockBinaries(t, "mpc-dev-2")
+
+	manager := NewManager(&config.Config{ClusterName: "mpc-dev-2"})
+	if err := manager.Create(context.Background()); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := manager.Status(context.Background()); err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if err := manager.Destroy(context.Background()); err != nil {
+		t.Fatalf("Destroy failed: %v", err)
+	}
+
+	calls := readCalls(t, callsLog)
+	for _, want := range []string{
+		"KIND_EXPERIMENTAL_PROVIDER=podman kind create cluster --name mpc-dev-2",
+		"KIND_EXPERIMENTAL_PROVIDER=podman kind get clusters",
+		"KIND_EXPERIMENTAL_PROVIDER=podman kind delete cluster --name mpc-dev-2",
+	} {
+		if !strings.Contains(calls, want) {
+			t.Errorf("expected %q, got: %s", want, calls)
+		}
+	}
+}
+
+// TestKindProviderDocker tests that Create, Destroy, Status and Pause use docker
+// without KIND_EXPERIMENTAL_PROVIDER when MPC_CONTAINER_RUNTIME selects it
+func TestKindProviderDocker(t *testing.T) {
+	callsLog := setupMockBinaries(t, "mpc-dev-2")
+	setupMockRuntime(t, callsLog, "docker", "true")
+
+	manager := NewManager(&config.Config{ClusterName: "mpc-dev-2", ContainerRuntime: config.ContainerRuntimeDocker})
+	if err := manager.Create(context.Background()); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := manager.Status(context.Background()); err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if err := manager.Pause(context.Background()); err != nil {
+		t.Fatalf("Pause failed: %v", err)
+	}
+	if err := manager.Destroy(context.Background()); err != nil {
+		t.Fatalf("Destroy failed: %v", err)
+	}
+
+	calls := readCalls(t, callsLog)
+	if strings.Contains(calls, "KIND_EXPERIMENTAL_PROVIDER") || strings.Contains(calls, "podman") {
+		t.Errorf("expected kind's default docker provider and no podman, got: %s", calls)
+	}
+	for _, want := range []string{
+		"kind create cluster --name mpc-dev-2",
+		"docker inspect",
+		"docker stop mpc-dev-2-control-plane",
+		"kind delete cluster --name mpc-dev-2",
+	} {
+		if !strings.Contains(calls, want) {
+			t.Errorf("expected %q, got: %s", want, calls)
+		}
+	}
+}
+
+// TestCreateConfiguredRuntimeNotFound tests that Create fails before running kind when
+// the configured runtime is not installed
+func TestCreateConfiguredRuntimeNotFound(t *testing.T) {
+	callsLog := setupMockBinaries(t, "")
+	// Only the mocks are found, so a docker installed on the machine does not count
+	t.Setenv("PATH", filepath.Dir(callsLog))
+
+	manager := NewManager(&config.Config{ContainerRuntime: config.ContainerRuntimeDocker})
+	err := manager.Create(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "MPC_CONTAINER_RUNTIME is docker") {
+		t.Fatalf("expected a missing runtime error, got %v", err)
+	}
+	if _, err := os.Stat(callsLog); !os.IsNotExist(err) {
+		t.Errorf("expected kind not to run, got: %s", readCalls(t, callsLog))
+	}
+}
+
+// TestStatusDefaultNameNotRunning tests that a cluster with another name is not reported as ours
+func TestStatusDefaultNameNotRunning(t *testing.T) {
+	setupMockBinaries(t, "mpc-dev-2")
+
+	manager := NewManager(&config.Config{})
+	status, err := manager.Status(context.Background())
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if status != "Not Running" {
+		t.Errorf("expected status Not Running, got %s", status)
+	}
+}
+
+// TestCreateUsesKindConfigWhenPresent tests that a kind-config.yaml in MpcDevEnvPath is passed via --config
+func TestCreateUsesKindConfigWhenPresent(t *testing.T) {
+	callsLog := setupMockBinaries(t, "")
+
+	devEnvPath := t.TempDir()
+	kindConfigPath := filepath.Join(devEnvPath, "kind-config.yaml")
+	if err := os.WriteFile(kindConfigPath, []byte("kind: Cluster\napiVersion: kind.x-k8s.io/v1alpha4\n"), 0644); err != nil {
+		t.Fatalf("failed to write kind config: %v", err)
+	}
+
+	manager := NewManager(&config.Config{MpcDevEnvPath: devEnvPath})
+	if err := manager.Create(context.Background()); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	calls := readCalls(t, callsLog)
+	if !strings.Contains(calls, "--config "+kindConfigPath) {
+		t.Errorf("expected --config flag with kind config path, got: %s", calls)
+	}
+}
+
+// TestCreateWithoutKindConfig tests that --config is omitted when no kind-config.yaml exists
+func TestCreateWithoutKindConfig(t *testing.T) {
+	callsLog := setupMockBinaries(t, "")
+
+	manager := NewManager(&config.Config{MpcDevEnvPath: t.TempDir()})
+	if err := manager.Create(context.Background()); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	calls := readCalls(t, callsLog)
+	if strings.Contains(calls, "--config") {
+		t.Errorf("expected no --config flag, got: %s", calls)
+	}
+}
+
+// TestConfiguredKubeconfig tests that a configured kubeconfig is passed to kind and kubectl
+func TestConfiguredKubeconfig(t *testing.T) {
+	callsLog := setupMockBinaries(t, "mpc-dev-2")
+
+	kubeconfigPath := filepath.Join(t.TempDir(), "kubeconfig")
+	manager := NewManager(&config.Config{ClusterName: "mpc-dev-2", KubeconfigPath: kubeconfigPath})
+	if err := manager.Create(context.Background()); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := manager.Status(context.Background()); err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if err := manager.Destroy(context.Background()); err != nil {
+		t.Fatalf("Destroy failed: %v", err)
+	}
+
+	calls := readCalls(t, callsLog)
+	for _, expected := range []string{
+		"kind create cluster --name mpc-dev-2 --kubeconfig " + kubeconfigPath,
+		"kubectl cluster-info --context kind-mpc-dev-2 --kubeconfig " + kubeconfigPath,
+		"kind delete cluster --name mpc-dev-2 --kubeconfig " + kubeconfigPath,
+	} {
+		if !strings.Contains(calls, expected) {
+			t.Errorf("expected %q, got: %s", expected, calls)
+		}
+	}
+}
+
+// TestGetKubeconfig tests that GetKubeconfig returns kind's kubeconfig for the configured cluster
+func TestGetKubeconfig(t *testing.T) {
+	callsLog := setupMockBinaries(t, "mpc-dev-2")
+
+	manager := NewManager(&config.Config{ClusterName: "mpc-dev-2"})
+	kubeconfig, err := manager.GetKubeconfig(context.Background())
+	if err != nil {
+		t.Fatalf("GetKubeconfig failed: %v", err)
+	}
+	if !strings.Contains(kubeconfig, "current-context: kind-mpc-dev-2") {
+		t.Errorf("unexpected kubeconfig: %s", kubeconfig)
+	}
+	if manager.ContextName() != "kind-mpc-dev-2" {
+		t.Errorf("expected context kind-mpc-dev-2, got %s", manager.ContextName())
+	}
+
+	calls := readCalls(t, callsLog)
+	if !strings.Contains(calls, "kind get kubeconfig --name mpc-dev-2") {
+		t.Errorf("expected get kubeconfig with configured name, got: %s", calls)
+	}
+}
+
+// TestGetKubeconfigClusterNotFound tests that a missing cluster maps to ErrClusterNotFound
+func TestGetKubeconfigClusterNotFound(t *testing.T) {
+	setupMockBinaries(t, "")
+
+	manager := NewManager(&config.Config{})
+	_, err := manager.GetKubeconfig(context.Background())
+	if !errors.Is(err, ErrClusterNotFound) {
+		t.Errorf("expected ErrClusterNotFound, got %v", err)
+	}
+}
+
+// TestPauseStopsNodeContainers tests that Pause stops the cluster's node containers
+func TestPauseStopsNodeContainers(t *testing.T) {
+	callsLog := setupMockBinaries(t, "mpc-dev-2")
+	setupMockPodman(t, callsLog, "true")
+
+	manager := NewManager(&config.Config{ClusterName: "mpc-dev-2"})
+	if err := manager.Pause(context.Background()); err != nil {
+		t.Fatalf("Pause failed: %v", err)
+	}
+
+	calls := readCalls(t, callsLog)
+	if !strings.Contains(calls, "kind get nodes --name mpc-dev-2") {
+		t.Errorf("expected get nodes with configured name, got: %s", calls)
+	}
+	if !strings.Contains(calls, "podman stop mpc-dev-2-control-plane") {
+		t.Errorf("expected podman stop of the control-plane node, got: %s", calls)
+	}
+	if strings.Contains(calls, "kind delete") {
+		t.Errorf("expected the cluster to be kept, got: %s", calls)
+	}
+}
+
+// TestResumeStartsNodeContainers tests that Resume starts the cluster's node containers
+func TestResumeStartsNodeContainers(t *testing.T) {
+	callsLog := setupMockBinaries(t, "mpc-dev-2")
+	setupMockPodman(t, callsLog, "false")
+
+	manager := NewManager(&config.Config{ClusterName: "mpc-dev-2"})
+	if err := manager.Resume(context.Background()); err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+
+	calls := readCalls(t, callsLog)
+	if !strings.Contains(calls, "podman start mpc-dev-2-control-plane") {
+		t.Errorf("expected podman start of the control-plane node, got: %s", calls)
+	}
+}
+
+// TestPauseClusterNotFound tests that pausing a missing cluster maps to ErrClusterNotFound
+func TestPauseClusterNotFound(t *testing.T) {
+	callsLog := setupMockBinaries(t, "")
+	setupMockPodman(t, callsLog, "true")
+
+	manager := NewManager(&config.Config{})
+	if err := manager.Pause(context.Background()); !errors.Is(err, ErrClusterNotFound) {
+		t.Errorf("expected ErrClusterNotFound, got %v", err)
+	}
+	if strings.Contains(readCalls(t, callsLog), "podman stop") {
+		t.Error("expected no podman stop for a missing cluster")
+	}
+}
+
+// TestStatusPaused tests that a cluster with a stopped control-plane container is reported as paused
+func TestStatusPaused(t *testing.T) {
+	callsLog := setupMockBinaries(t, "mpc-dev-2")
+	setupMockPodman(t, callsLog, "false")
+
+	manager := NewManager(&config.Config{ClusterName: "mpc-dev-2"})
+	status, err := manager.Status(context.Background())
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if status != "Paused" {
+		t.Errorf("expected status Paused, got %s", status)
+	}
+
+	calls := readCalls(t, callsLog)
+	if !strings.Contains(calls, "podman inspect --format {{.State.Running}} mpc-dev-2-control-plane") {
+		t.Errorf("expected inspect of the control-plane node, got: %s", calls)
+	}
+	if strings.Contains(calls, "kubectl cluster-info") {
+		t.Errorf("expected no kubectl check for a paused cluster, got: %s", calls)
+	}
+}
+
+// TestStatusRunningWithRunningNode tests that a running control-plane container does not report paused
+func TestStatusRunningWithRunningNode(t *testing.T) {
+	callsLog := setupMockBinaries(t, "mpc-dev-2")
+	setupMockPodman(t, callsLog, "true")
+
+	manager := NewManager(&config.Config{ClusterName: "mpc-dev-2"})
+	status, err := manager.Status(context.Background())
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if status != "Running" {
+		t.Errorf("expected status Running, got %s", status)
+	}
+}
+
+// TestInfo tests that Info reads the creation time of the control-plane container and counts the nodes
+func TestInfo(t *testing.T) {
+	callsLog := setupMockBinaries(t, "mpc-dev-2")
+	setupMockPodman(t, callsLog, "true")
+
+	manager := NewManager(&config.Config{ClusterName: "mpc-dev-2"})
+	info, err := manager.Info(context.Background())
+	if err != nil {
+		t.Fatalf("Info failed: %v", err)
+	}
+
+	want := time.Date(2025, 11, 27, 14, 30, 52, 123456789, time.UTC)
+	if !info.CreatedAt.Equal(want) {
+		t.Errorf("expected creation time %s, got %s", want, info.CreatedAt)
+	}
+	if info.NodeCount != 1 {
+		t.Errorf("expected 1 node, got %d", info.NodeCount)
+	}
+
+	calls := readCalls(t, callsLog)
+	if !strings.Contains(calls, "podman inspect --format {{.Created}} mpc-dev-2-control-plane") {
+		t.Errorf("expected inspect of the control-plane node, got: %s", calls)
+	}
+}
+
+// TestInfoClusterNotFound tests that Info maps a missing cluster to ErrClusterNotFound
+func TestInfoClusterNotFound(t *testing.T) {
+	callsLog := setupMockBinaries(t, "")
+	setupMockPodman(t, callsLog, "true")
+
+	manager := NewManager(&config.Config{})
+	if _, err := manager.Info(context.Background()); !errors.Is(err, ErrClusterNotFound) {
+		t.Errorf("expected ErrClusterNotFound, got %v", err)
+	}
+}
+
+// TestParseContainerTime tests both the podman and the docker creation time formats
+func TestParseContainerTime(t *testing.T) {
+	want := time.Date(2025, 11, 27, 14, 30, 52, 0, time.UTC)
+	for _, value := range []string{"2025-11-27 14:30:52 +0000 UTC", "2025-11-27T14:30:52Z"} {
+		got, err := parseContainerTime(value)
+		if err != nil {
+			t.Errorf("parseContainerTime(%q) failed: %v", value, err)
+			continue
+		}
+		if !got.Equal(want) {
+			t.Errorf("parseContainerTime(%q) = %s, want %s", value, got, want)
+		}
+	}
+
+	if _, err := parseContainerTime("yesterday"); err == nil {
+		t.Error("expected an error for an unparseable time")
+	}
+}
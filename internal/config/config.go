@@ -7,11 +7,363 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// DefaultClusterName is the Kind cluster name used when MPC_CLUSTER_NAME is not set.
+const DefaultClusterName = "konflux"
+
+// Defaults for the Konflux UI deployed by POST /api/deploy/konflux, matching the port
+// konflux-ci's kind config exposes and the demo user of its test resources.
+const (
+	DefaultKonfluxUIURL    = "https://localhost:9443"
+	DefaultKonfluxUsername = "user2@konflux.dev"
+)
+
+// Default image references for the locally built MPC images. The explicit "localhost/"
+// prefix matches what Podman stores for unqualified tags, so the reference the builder
+// tags is byte-for-byte what the deployment is patched with and verified against.
+const (
+	DefaultControllerImage = "localhost/multi-platform-controller:latest"
+	DefaultOTPImage        = "localhost/multi-platform-otp:latest"
+)
+
+// Default versions of the Tekton Pipelines and cert-manager releases the minimal stack
+// installs. Tekton's matches the Tekton client library the daemon is built with.
+const (
+	DefaultTektonVersion      = "v1.6.0"
+	DefaultCertManagerVersion = "v1.16.2"
+)
+
+// ReleaseLatest is the release version that selects the latest release of Tekton
+// Pipelines or cert-manager, which makes deployments non-reproducible.
+const ReleaseLatest = "latest"
+
+// DefaultAllowedHosts are the host names the daemon API accepts in the Host and Origin
+// headers of mutating requests when MPC_ALLOWED_HOSTS is not set.
+var DefaultAllowedHosts = []string{"localhost", "127.0.0.1", "::1"}
+
+// DefaultMPCPathPrefixes are the repository path prefixes whose changes can affect the
+// MPC deployment when MPC_RELEVANT_PATH_PREFIXES is not set.
+var DefaultMPCPathPrefixes = []string{"pkg/", "cmd/"}
+
+// Log output formats accepted in MPC_LOG_FORMAT.
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+// Container runtimes accepted in MPC_CONTAINER_RUNTIME.
+const (
+	ContainerRuntimeDocker = "docker"
+	ContainerRuntimePodman = "podman"
+)
+
+// Strategies accepted in MPC_GIT_SYNC_STRATEGY for bringing a repository up to date
+// in POST /api/git/sync with its sync target: upstream/main, or origin/<branch> when
+// the repository has no 'upstream' remote.
+const (
+	// GitSyncStrategyFFOnly only fast-forwards, failing when the branch has diverged.
+	GitSyncStrategyFFOnly = "ff-only"
+
+	// GitSyncStrategyRebase rebases local commits onto the sync target, keeping
+	// uncommitted changes. This is the default since it never discards work.
+	GitSyncStrategyRebase = "rebase"
+
+	// GitSyncStrategyReset hard-resets to the sync target and removes untracked files
+	// whenever fast-forwarding is not possible, discarding local commits and changes.
+	GitSyncStrategyReset = "reset"
+)
+
+// Backends accepted in MPC_DEPLOY_BACKEND for reading and changing the MPC deployments.
+const (
+	// DeployBackendKubectl runs kubectl for every deployment step. This is the default.
+	DeployBackendKubectl = "kubectl"
+
+	// DeployBackendClient waits for, patches, restarts and verifies the MPC deployments
+	// through the Kubernetes API with client-go instead of kubectl. It covers those
+	// deployment steps only: the kustomize manifests, the host-config ConfigMap, the
+	// secrets and Undeploy still run kubectl, so kubectl stays a prerequisite.
+	DeployBackendClient = "client"
+)
+
+// DefaultGitSyncInterval is the background git sync period used when
+// MPC_GIT_SYNC_INTERVAL is not set.
+const DefaultGitSyncInterval = 60 * time.Minute
+
+// minGitSyncInterval is the shortest accepted background git sync period.
+const minGitSyncInterval = time.Minute
+
+// Default maximum durations of the daemon's long-running operations, used when the
+// corresponding MPC_*_TIMEOUT env var is unset or invalid.
+const (
+	DefaultBuildTimeout         = 15 * time.Minute
+	DefaultDeployTimeout        = 15 * time.Minute
+	DefaultKonfluxTimeout       = 30 * time.Minute
+	DefaultKonfluxScriptTimeout = 20 * time.Minute
+	DefaultMinimalStackTimeout  = 10 * time.Minute
+	DefaultSecretsTimeout       = 5 * time.Minute
+	DefaultTaskRunTimeout       = 30 * time.Minute
+	DefaultGitCommandTimeout    = 60 * time.Second
+	DefaultShutdownGracePeriod  = 30 * time.Second
+)
+
+// DefaultBuildConcurrency is how many images BuildMPCImage builds at once when
+// MPC_BUILD_CONCURRENCY is unset or invalid: both the controller and the OTP image.
+const DefaultBuildConcurrency = 2
+
+// DefaultKubectlAttempts is how often the deployment steps run a kubectl command that
+// fails with a transient error, e.g. while the API server of a new cluster comes up,
+// when MPC_KUBECTL_ATTEMPTS is unset or invalid.
+const DefaultKubectlAttempts = 3
+
+// ConfigFileName is the optional YAML file in MPC_DEV_ENV_PATH whose values fill in
+// env vars that are not set.
+const ConfigFileName = "config.yaml"
+
+// DaemonPort is the localhost TCP port the daemon API listens on.
+const DaemonPort = 8765
+
+// DefaultCheckPorts are the TCP ports the prerequisite check expects to be free when
+// MPC_CHECK_PORTS is not set: the daemon API and the Konflux UI.
+var DefaultCheckPorts = []int{DaemonPort, 9443}
+
+// Default resource minimums checked by the prerequisite checker, used when the
+// corresponding env var is unset or invalid.
+const (
+	DefaultMinDiskSpaceGB = 20
+	DefaultMinMemoryGB    = 8
 )
 
+// DefaultWatchDebounce is how long the hot-reload file watcher waits after the last
+// change before triggering a rebuild, used when MPC_WATCH_DEBOUNCE is unset or invalid.
+const DefaultWatchDebounce = 2 * time.Second
+
+// DefaultWatchIgnoreExtensions are the file extensions of editor, build, and log
+// artifacts that never trigger a hot reload when MPC_WATCH_IGNORE_EXTENSIONS is not set.
+var DefaultWatchIgnoreExtensions = []string{".swp", ".swo", ".pyc", ".pyo", ".log", ".tmp"}
+
+// DefaultWatchIgnoreDirs are the directory names the hot-reload file watcher does not
+// descend into when MPC_WATCH_IGNORE_DIRS is not set.
+var DefaultWatchIgnoreDirs = []string{".git", "__pycache__", ".pytest_cache", "node_modules", ".vscode", ".idea"}
+
+// Defaults of the generated minimal host-config, used when the corresponding
+// MPC_HOST_CONFIG_* env var is not set.
+const (
+	DefaultHostConfigRegion            = "us-east-1"
+	DefaultHostConfigARM64AMI          = "ami-03d8261904652a19c"
+	DefaultHostConfigAMD64AMI          = "ami-0c02fb55b1a47c3c8"
+	DefaultHostConfigARM64InstanceType = "m6g.large"
+	DefaultHostConfigAMD64InstanceType = "m6a.large"
+	DefaultHostConfigMaxInstances      = 10
+
+	DefaultHostConfigIBMRegion = "us-east"
+	DefaultHostConfigIBMZone   = "us-east-2"
+	DefaultHostConfigIBMKey    = "mpc-dev-env"
+)
+
+// shortGitHashLength is the number of hex digits of a commit hash used in image tags.
+const shortGitHashLength = 12
+
+// TimeoutConfig holds the maximum duration of each long-running daemon operation.
+type TimeoutConfig struct {
+	// Build bounds an image build. Read from MPC_BUILD_TIMEOUT.
+	Build time.Duration
+
+	// Deploy bounds an MPC deployment. Read from MPC_DEPLOY_TIMEOUT.
+	Deploy time.Duration
+
+	// Konflux bounds a full Konflux deployment. Read from MPC_KONFLUX_TIMEOUT.
+	Konflux time.Duration
+
+	// KonfluxScript bounds each konflux-ci script of a Konflux deployment, so a hung
+	// script fails on its own instead of using up Konflux. Read from MPC_KONFLUX_SCRIPT_TIMEOUT.
+	KonfluxScript time.Duration
+
+	// MinimalStack bounds a minimal MPC stack deployment. Read from MPC_MINIMAL_STACK_TIMEOUT.
+	MinimalStack time.Duration
+
+	// Secrets bounds a secrets deployment, including one enabling a feature. Read from
+	// MPC_SECRETS_TIMEOUT.
+	Secrets time.Duration
+
+	// TaskRun bounds how long a TaskRun is monitored. Read from MPC_TASKRUN_TIMEOUT.
+	TaskRun time.Duration
+
+	// GitCommand bounds each git command the daemon runs to track repository state,
+	// e.g. a fetch from an unreachable remote. Read from MPC_GIT_COMMAND_TIMEOUT.
+	GitCommand time.Duration
+
+	// ShutdownGrace is how long the daemon lets in-flight operations finish on their own
+	// when it is stopped, before cancelling them. Read from MPC_SHUTDOWN_GRACE_PERIOD.
+	ShutdownGrace time.Duration
+}
+
+// WatchConfig configures the hot-reload file watcher on the MPC repository.
+type WatchConfig struct {
+	// Debounce is how long to wait after the last change before rebuilding.
+	// Read from MPC_WATCH_DEBOUNCE.
+	Debounce time.Duration
+
+	// IgnoreExtensions are file extensions, including the leading dot, whose changes
+	// are ignored. Read from MPC_WATCH_IGNORE_EXTENSIONS.
+	IgnoreExtensions []string
+
+	// IgnoreDirs are directory names that are not watched, wherever they appear.
+	// Read from MPC_WATCH_IGNORE_DIRS.
+	IgnoreDirs []string
+
+	// IncludePatterns are filepath.Match globs matched against file names. When set,
+	// only changes to matching files trigger a rebuild. Read from MPC_WATCH_INCLUDE.
+	IncludePatterns []string
+
+	// Redeploy makes a hot reload deploy the rebuilt images to the cluster too, so saved
+	// changes take effect without a manual deploy. Read from MPC_WATCH_REDEPLOY.
+	Redeploy bool
+}
+
+// HostConfigSettings are the values the minimal host-config is generated with when
+// neither host-config.yaml nor temp/host-config.yaml exists.
+type HostConfigSettings struct {
+	// Region is the AWS region of the dynamic platforms. Read from MPC_HOST_CONFIG_REGION.
+	Region string
+
+	// ARM64AMI and AMD64AMI are the AMIs of the arm64 and amd64 dynamic platforms;
+	// AMIs are region-specific. Read from MPC_HOST_CONFIG_ARM64_AMI and MPC_HOST_CONFIG_AMD64_AMI.
+	ARM64AMI string
+	AMD64AMI string
+
+	// ARM64InstanceType and AMD64InstanceType are the EC2 instance types of the arm64
+	// and amd64 dynamic platforms. Read from MPC_HOST_CONFIG_ARM64_INSTANCE_TYPE and
+	// MPC_HOST_CONFIG_AMD64_INSTANCE_TYPE.
+	ARM64InstanceType string
+	AMD64InstanceType string
+
+	// MaxInstances is how many instances each dynamic platform may run at once.
+	// Read from MPC_HOST_CONFIG_MAX_INSTANCES.
+	MaxInstances int
+
+	// IBMEnabled adds the IBM Cloud dynamic platforms: s390x on IBM Z ("ibmz") and
+	// ppc64le on IBM Power ("ibmp"). Set by the deploy manager when the IBM secrets
+	// have been deployed, or from MPC_HOST_CONFIG_IBM ("true").
+	IBMEnabled bool
+
+	// IBMRegion and IBMZone are the IBM Cloud region and VPC zone of the IBM platforms.
+	// Read from MPC_HOST_CONFIG_IBM_REGION and MPC_HOST_CONFIG_IBM_ZONE.
+	IBMRegion string
+	IBMZone   string
+
+	// IBMKey is the name of the IBM Cloud SSH key the instances are created with.
+	// Read from MPC_HOST_CONFIG_IBM_KEY.
+	IBMKey string
+
+	// IBMS390XImage and IBMPPC64LEImage are the images of the s390x and ppc64le
+	// platforms. Read from MPC_HOST_CONFIG_IBM_S390X_IMAGE and MPC_HOST_CONFIG_IBM_PPC64LE_IMAGE;
+	// they are account-specific and have no default.
+	IBMS390XImage   string
+	IBMPPC64LEImage string
+
+	// IBMPowerWorkspace is the CRN of the Power Virtual Server workspace of the ppc64le
+	// platform. Read from MPC_HOST_CONFIG_IBM_POWER_WORKSPACE; it has no default.
+	IBMPowerWorkspace string
+
+	// TemplatePath is a Go text/template file rendered with these settings instead of
+	// the built-in host-config. Read from MPC_HOST_CONFIG_TEMPLATE.
+	TemplatePath string
+}
+
+// DefaultHostConfigSettings returns the HostConfigSettings used when no
+// MPC_HOST_CONFIG_* env var is set.
+func DefaultHostConfigSettings() HostConfigSettings {
+	return HostConfigSettings{
+		Region:            DefaultHostConfigRegion,
+		ARM64AMI:          DefaultHostConfigARM64AMI,
+		AMD64AMI:          DefaultHostConfigAMD64AMI,
+		ARM64InstanceType: DefaultHostConfigARM64InstanceType,
+		AMD64InstanceType: DefaultHostConfigAMD64InstanceType,
+		MaxInstances:      DefaultHostConfigMaxInstances,
+		IBMRegion:         DefaultHostConfigIBMRegion,
+		IBMZone:           DefaultHostConfigIBMZone,
+		IBMKey:            DefaultHostConfigIBMKey,
+	}
+}
+
+// IBMS390XEnabled reports whether the generated host-config has the s390x platform on
+// IBM Z: IBM is enabled and the s390x image is set.
+func (s HostConfigSettings) IBMS390XEnabled() bool {
+	return s.IBMEnabled && s.IBMS390XImage != ""
+}
+
+// IBMPPC64LEEnabled reports whether the generated host-config has the ppc64le platform
+// on IBM Power: IBM is enabled and both the ppc64le image and the workspace are set.
+func (s HostConfigSettings) IBMPPC64LEEnabled() bool {
+	return s.IBMEnabled && s.IBMPPC64LEImage != "" && s.IBMPowerWorkspace != ""
+}
+
+// MissingIBMSettings describes each IBM platform left out of the generated host-config
+// although IBM is enabled, and the unset MPC_HOST_CONFIG_IBM_* env vars it needs.
+// It returns nil when IBM is disabled or every IBM platform is configured.
+func (s HostConfigSettings) MissingIBMSettings() []string {
+	if !s.IBMEnabled {
+		return nil
+	}
+
+	var problems []string
+	if !s.IBMS390XEnabled() {
+		problems = append(problems, "leaving out the linux-ibm/s390x platform: MPC_HOST_CONFIG_IBM_S390X_IMAGE is not set")
+	}
+	if !s.IBMPPC64LEEnabled() {
+		var missing []string
+		if s.IBMPPC64LEImage == "" {
+			missing = append(missing, "MPC_HOST_CONFIG_IBM_PPC64LE_IMAGE")
+		}
+		if s.IBMPowerWorkspace == "" {
+			missing = append(missing, "MPC_HOST_CONFIG_IBM_POWER_WORKSPACE")
+		}
+		verb := "is"
+		if len(missing) > 1 {
+			verb = "are"
+		}
+		problems = append(problems, fmt.Sprintf("leaving out the linux-ibm/ppc64le platform: %s %s not set", strings.Join(missing, " and "), verb))
+	}
+	return problems
+}
+
+// DefaultWatchConfig returns the WatchConfig used when no MPC_WATCH_* env var is set.
+func DefaultWatchConfig() WatchConfig {
+	return WatchConfig{
+		Debounce:         DefaultWatchDebounce,
+		IgnoreExtensions: DefaultWatchIgnoreExtensions,
+		IgnoreDirs:       DefaultWatchIgnoreDirs,
+	}
+}
+
+// DefaultTimeouts returns the TimeoutConfig used when no MPC_*_TIMEOUT env var is set.
+func DefaultTimeouts() TimeoutConfig {
+	return TimeoutConfig{
+		Build:         DefaultBuildTimeout,
+		Deploy:        DefaultDeployTimeout,
+		Konflux:       DefaultKonfluxTimeout,
+		KonfluxScript: DefaultKonfluxScriptTimeout,
+		MinimalStack:  DefaultMinimalStackTimeout,
+		Secrets:       DefaultSecretsTimeout,
+		TaskRun:       DefaultTaskRunTimeout,
+		GitCommand:    DefaultGitCommandTimeout,
+		ShutdownGrace: DefaultShutdownGracePeriod,
+	}
+}
+
 // Config holds all environment-dependent paths and settings required
 // by the MPC Dev Studio daemon.
 type Config struct {
@@ -29,18 +381,291 @@ type Config struct {
 	SessionLogDir string
 
 	// LogLevel is the logging verbosity level (e.g., "debug", "info", "warn", "error").
-	// Read from LOG_LEVEL env var, defaults to "info".
+	// Read from MPC_LOG_LEVEL env var, falling back to LOG_LEVEL, defaults to "info".
 	LogLevel string
+
+	// LogFormat is the daemon's log output format, LogFormatText or LogFormatJSON.
+	// Read from MPC_LOG_FORMAT env var, defaults to LogFormatText.
+	LogFormat string
+
+	// ContainerRuntime is the container runtime used for image builds and the Kind
+	// cluster, ContainerRuntimeDocker or ContainerRuntimePodman. Empty means detected
+	// (see build.ContainerRuntime).
+	// Read from MPC_CONTAINER_RUNTIME env var, empty by default.
+	ContainerRuntime string
+
+	// ClusterName is the name of the Kind cluster managed by the daemon.
+	// Read from MPC_CLUSTER_NAME env var, defaults to "konflux".
+	ClusterName string
+
+	// KindConfigPath is an explicit path to a kind-config.yaml used when creating the cluster.
+	// Read from MPC_KIND_CONFIG_PATH env var. When empty, MpcDevEnvPath/kind-config.yaml
+	// is used if it exists.
+	KindConfigPath string
+
+	// KubeconfigPath is the kubeconfig file kubectl, kind and the Kubernetes clients use.
+	// Read from MPC_KUBECONFIG env var, falling back to KUBECONFIG (its first file when it
+	// lists several). When empty, ~/.kube/config is used.
+	KubeconfigPath string
+
+	// TektonVersion is the Tekton Pipelines release the minimal stack installs, e.g.
+	// "v1.6.0", or ReleaseLatest.
+	// Read from MPC_TEKTON_VERSION env var, defaults to DefaultTektonVersion.
+	TektonVersion string
+
+	// CertManagerVersion is the cert-manager release the minimal stack installs, e.g.
+	// "v1.16.2", or ReleaseLatest.
+	// Read from MPC_CERT_MANAGER_VERSION env var, defaults to DefaultCertManagerVersion.
+	CertManagerVersion string
+
+	// TaskRunsDir is the directory POST /api/taskrun/run may read yaml_path files from.
+	// Read from MPC_TASKRUNS_DIR env var. When empty, MpcDevEnvPath/taskruns is used.
+	TaskRunsDir string
+
+	// ControllerImage is the image reference the controller is built as and deployed with.
+	// Read from MPC_CONTROLLER_IMAGE env var, defaults to DefaultControllerImage.
+	ControllerImage string
+
+	// OTPImage is the image reference the OTP server is built as and deployed with.
+	// Read from MPC_OTP_IMAGE env var, defaults to DefaultOTPImage.
+	OTPImage string
+
+	// RegistryURL, when set, is the registry (host[:port][/path]) the images are pushed
+	// to instead of being loaded into the Kind cluster. It replaces the registry and path
+	// of the controller and OTP image references (see GetControllerImage), and the
+	// deployments then pull the images with imagePullPolicy Always.
+	// Read from MPC_REGISTRY_URL env var, e.g. "localhost:5001", empty by default.
+	RegistryURL string
+
+	// BuildArgs are passed to both image builds as --build-arg KEY=VALUE, e.g. to set
+	// GOFLAGS, proxy settings, or override a base image.
+	// Read from MPC_BUILD_ARGS env var ("KEY=VALUE,KEY=VALUE"), empty by default.
+	BuildArgs map[string]string
+
+	// GitSyncInterval is the period of the daemon's background git sync. Zero disables it.
+	// Read from MPC_GIT_SYNC_INTERVAL env var, defaults to DefaultGitSyncInterval.
+	GitSyncInterval time.Duration
+
+	// GitSyncStrategy is how a git sync brings a repository up to date with upstream/main
+	// (origin/<branch> without an 'upstream' remote), GitSyncStrategyFFOnly,
+	// GitSyncStrategyRebase or GitSyncStrategyReset.
+	// Read from MPC_GIT_SYNC_STRATEGY env var, defaults to GitSyncStrategyRebase.
+	GitSyncStrategy string
+
+	// DeployBackend is how deployments read and change the MPC deployments,
+	// DeployBackendKubectl or DeployBackendClient.
+	// Read from MPC_DEPLOY_BACKEND env var, defaults to DeployBackendKubectl.
+	DeployBackend string
+
+	// DisableGitSyncBackup turns off the backup branch GitSyncStrategyReset creates
+	// before discarding local commits or changes.
+	// Read from MPC_GIT_SYNC_BACKUP env var ("false" disables), backups are on by default.
+	DisableGitSyncBackup bool
+
+	// UpstreamURLs overrides the upstream URL, keyed by repository name, used when a
+	// repository's 'upstream' remote has to be added.
+	// Read from MPC_UPSTREAM_URLS env var ("name=url,name=url"), empty by default.
+	UpstreamURLs map[string]string
+
+	// TrackedRepos are repositories the daemon tracks and syncs besides the MPC
+	// repository, keyed by repository name, e.g. konflux-ci or infra-deployments.
+	// Read from MPC_TRACKED_REPOS env var ("name=path,name=path"), empty by default.
+	TrackedRepos map[string]string
+
+	// MPCPathPrefixes are the repository path prefixes whose upstream changes are
+	// flagged as potentially affecting MPC by GET /api/git/changes.
+	// Read from MPC_RELEVANT_PATH_PREFIXES env var (comma-separated), defaults to
+	// DefaultMPCPathPrefixes.
+	MPCPathPrefixes []string
+
+	// DaemonToken is the bearer token the daemon API requires when set. Empty disables auth.
+	// Read from MPC_DAEMON_TOKEN env var, empty by default.
+	DaemonToken string
+
+	// AllowedHosts are the host names accepted in the Host and Origin headers of
+	// non-GET API requests.
+	// Read from MPC_ALLOWED_HOSTS env var (comma-separated), defaults to DefaultAllowedHosts.
+	AllowedHosts []string
+
+	// WebhookURL, when set, receives a JSON POST each time an operation finishes.
+	// Read from MPC_WEBHOOK_URL env var, empty (no notifications) by default.
+	WebhookURL string
+
+	// KonfluxUIURL is where the Konflux UI is reachable once Konflux is deployed.
+	// Read from MPC_KONFLUX_UI_URL env var, defaults to DefaultKonfluxUIURL.
+	KonfluxUIURL string
+
+	// KonfluxUsername is the user to log in to the Konflux UI with.
+	// Read from MPC_KONFLUX_USERNAME env var, defaults to DefaultKonfluxUsername.
+	KonfluxUsername string
+
+	// KonfluxCIPath is the konflux-ci repository whose scripts deploy Konflux.
+	// Read from MPC_KONFLUX_CI_PATH env var. When empty, the konflux-ci directory next
+	// to MpcDevEnvPath is used.
+	KonfluxCIPath string
+
+	// Timeouts are the maximum durations of the daemon's long-running operations.
+	// Read from the MPC_*_TIMEOUT env vars, defaults to DefaultTimeouts().
+	Timeouts TimeoutConfig
+
+	// BuildConcurrency is how many images are built at once; 1 builds them one after
+	// another, e.g. on machines with too little memory for concurrent Go compilations.
+	// Read from MPC_BUILD_CONCURRENCY env var, defaults to DefaultBuildConcurrency.
+	BuildConcurrency int
+
+	// KubectlAttempts is how often the deployment steps run a kubectl command that fails
+	// with a transient error, such as a refused connection, before giving up; 1 disables
+	// retries. Read from MPC_KUBECTL_ATTEMPTS env var, defaults to DefaultKubectlAttempts.
+	KubectlAttempts int
+
+	// MinDiskSpaceGB is the free disk space, in GB, below which the prerequisite check
+	// warns. Read from MPC_MIN_DISK_GB env var, defaults to DefaultMinDiskSpaceGB.
+	MinDiskSpaceGB int
+
+	// MinMemoryGB is the total system memory, in GB, below which the prerequisite check
+	// warns. Read from MPC_MIN_MEMORY_GB env var, defaults to DefaultMinMemoryGB.
+	MinMemoryGB int
+
+	// CheckPorts are the TCP ports the prerequisite check expects to be free.
+	// Read from MPC_CHECK_PORTS env var (comma-separated), defaults to DefaultCheckPorts.
+	CheckPorts []int
+
+	// Watch configures the hot-reload file watcher.
+	// Read from the MPC_WATCH_* env vars, defaults to DefaultWatchConfig().
+	Watch WatchConfig
+
+	// HostConfig customizes the generated minimal host-config.
+	// Read from the MPC_HOST_CONFIG_* env vars, defaults to DefaultHostConfigSettings().
+	HostConfig HostConfigSettings
+
+	// Profiles are the named environments managed next to the default one, keyed by name.
+	// Read from the MPC_PROFILES and MPC_PROFILE_<NAME>_* env vars (see ParseProfiles),
+	// empty by default.
+	Profiles map[string]Profile
+
+	// Profile is the name of the profile this configuration belongs to, empty for the
+	// default environment. It is only set by ForProfile.
+	Profile string
+
+	// TaskRunNamespace is the namespace TaskRuns are created in when a request names
+	// none; empty means taskrun.DefaultNamespace. It is only set by ForProfile.
+	TaskRunNamespace string
+
+	// Warnings are non-fatal problems found while loading the configuration, such as
+	// invalid timeouts that fell back to their defaults. LoadConfig runs before the
+	// logger is initialized, so the daemon logs them once it is.
+	Warnings []string
 }
 
 // LoadConfig reads environment variables and constructs the Config struct.
 // It validates that critical paths exist and creates necessary directories.
 //
+// Every env var below except MPC_DEV_ENV_PATH, which locates it, can also be set in
+// $MPC_DEV_ENV_PATH/config.yaml (see LoadConfigFile); env vars take precedence.
+//
 // Environment variables (with auto-detection fallback):
 //   - MPC_REPO_PATH: Path to the multi-platform-controller repository
 //     Auto-detected: Looks for "multi-platform-controller" as sibling to working directory
 //   - MPC_DEV_ENV_PATH: Path to the mpc_dev_env repository
 //     Auto-detected: Uses current working directory
+//   - MPC_LOG_LEVEL: Log level, "debug", "info", "warn" or "error" (default: LOG_LEVEL, then "info")
+//   - MPC_LOG_FORMAT: Log output format, "text" or "json" (default: "text")
+//   - MPC_CONTAINER_RUNTIME: Container runtime for builds and the Kind cluster, "docker"
+//     or "podman" (default: detected, preferring DOCKER_CLI, then podman, then docker)
+//   - MPC_CLUSTER_NAME: Name of the Kind cluster (default: "konflux")
+//   - MPC_KIND_CONFIG_PATH: Path to a kind-config.yaml for cluster creation (optional)
+//   - MPC_KUBECONFIG: kubeconfig file of the clusters the daemon manages (default: the
+//     first file of KUBECONFIG, else ~/.kube/config)
+//   - MPC_TEKTON_VERSION: Tekton Pipelines release of the minimal stack, e.g. "v1.6.0"
+//     or "latest" (default: "v1.6.0")
+//   - MPC_CERT_MANAGER_VERSION: cert-manager release of the minimal stack, e.g. "v1.16.2"
+//     or "latest" (default: "v1.16.2")
+//   - MPC_TASKRUNS_DIR: Directory TaskRun YAML files passed by path must be in
+//     (default: MPC_DEV_ENV_PATH/taskruns)
+//   - MPC_CONTROLLER_IMAGE: Controller image reference (default: "localhost/multi-platform-controller:latest")
+//   - MPC_OTP_IMAGE: OTP server image reference (default: "localhost/multi-platform-otp:latest")
+//   - MPC_REGISTRY_URL: Registry, e.g. "localhost:5001", the images are pushed to and
+//     deployed from instead of being loaded into Kind (optional)
+//   - MPC_BUILD_ARGS: Comma-separated KEY=VALUE build args passed to both image builds (optional)
+//   - MPC_GIT_SYNC_INTERVAL: Background git sync period as a Go duration, at least 1m;
+//     "0" or "off" disables it (default: "60m")
+//   - MPC_GIT_SYNC_STRATEGY: How POST /api/git/sync updates a repository, "ff-only",
+//     "rebase" or "reset" (default: "rebase")
+//   - MPC_GIT_SYNC_BACKUP: "false" to stop the reset strategy from saving local commits
+//     and changes to an mpc-dev-backup/<timestamp> branch before discarding them
+//     (default: "true")
+//   - MPC_DEPLOY_BACKEND: How deployments wait for, patch, restart and verify the MPC
+//     deployments, "kubectl" or "client" (client-go) (default: "kubectl")
+//   - MPC_UPSTREAM_URLS: Comma-separated name=url pairs overriding the upstream remote
+//     URLs of known repositories (optional)
+//   - MPC_TRACKED_REPOS: Comma-separated name=path pairs of repositories to track and
+//     sync besides the MPC repository (optional)
+//   - MPC_RELEVANT_PATH_PREFIXES: Comma-separated path prefixes whose upstream changes
+//     potentially affect MPC (default: "pkg/,cmd/")
+//   - MPC_DAEMON_TOKEN: Bearer token required by the daemon API (optional, auth is off when unset)
+//   - MPC_ALLOWED_HOSTS: Comma-separated host names accepted in the Host and Origin headers
+//     of mutating API requests (default: "localhost,127.0.0.1,::1")
+//   - MPC_WEBHOOK_URL: http(s) URL that receives a JSON POST each time an operation
+//     finishes (optional)
+//   - MPC_KONFLUX_UI_URL: http(s) URL of the deployed Konflux UI (default:
+//     "https://localhost:9443")
+//   - MPC_KONFLUX_USERNAME: User to log in to the Konflux UI with (default:
+//     "user2@konflux.dev")
+//   - MPC_KONFLUX_CI_PATH: konflux-ci repository used to deploy Konflux (default: the
+//     konflux-ci directory next to MPC_DEV_ENV_PATH)
+//   - MPC_BUILD_TIMEOUT, MPC_DEPLOY_TIMEOUT, MPC_KONFLUX_TIMEOUT, MPC_MINIMAL_STACK_TIMEOUT,
+//     MPC_SECRETS_TIMEOUT, MPC_TASKRUN_TIMEOUT: Per-operation timeouts as Go durations
+//     (defaults: 15m, 15m, 30m, 10m, 5m, 30m); invalid values fall back to the default
+//     and are reported in Config.Warnings
+//   - MPC_GIT_COMMAND_TIMEOUT: Timeout of each git command run to track repository
+//     state, e.g. fetching upstream, as a Go duration (default: "60s"); invalid values
+//     fall back to the default and are reported in Config.Warnings
+//   - MPC_SHUTDOWN_GRACE_PERIOD: How long in-flight operations may run on after SIGINT or
+//     SIGTERM before they are cancelled, as a Go duration (default: "30s"); invalid values
+//     fall back to the default and are reported in Config.Warnings
+//   - MPC_KONFLUX_SCRIPT_TIMEOUT: Timeout of each konflux-ci script run to deploy Konflux,
+//     as a Go duration (default: "20m"); invalid values fall back to the default and are
+//     reported in Config.Warnings
+//   - MPC_BUILD_CONCURRENCY: How many images are built at once, at least 1 (default: 2);
+//     invalid values fall back to the default and are reported in Config.Warnings
+//   - MPC_KUBECTL_ATTEMPTS: How often a deployment step's kubectl command is run when it
+//     fails with a transient error, at least 1 (default: 3); invalid values fall back to
+//     the default and are reported in Config.Warnings
+//   - MPC_MIN_DISK_GB, MPC_MIN_MEMORY_GB: Free disk space and total memory, in GB, below
+//     which the prerequisite check warns (defaults: 20, 8); invalid values fall back to
+//     the default and are reported in Config.Warnings
+//   - MPC_CHECK_PORTS: Comma-separated TCP ports the prerequisite check expects to be free
+//     (default: "8765,9443")
+//   - MPC_WATCH_DEBOUNCE: Hot-reload debounce as a Go duration (default: "2s"); invalid
+//     values fall back to the default and are reported in Config.Warnings
+//   - MPC_WATCH_IGNORE_EXTENSIONS, MPC_WATCH_IGNORE_DIRS: Comma-separated file extensions
+//     and directory names the hot-reload watcher ignores (defaults: DefaultWatchIgnoreExtensions,
+//     DefaultWatchIgnoreDirs)
+//   - MPC_WATCH_INCLUDE: Comma-separated file name globs, e.g. "*.go"; when set, only
+//     matching files trigger a hot reload (optional); invalid globs are dropped and
+//     reported in Config.Warnings
+//   - MPC_WATCH_REDEPLOY: "true" to deploy the images rebuilt by a hot reload (default:
+//     "false"); invalid values are reported in Config.Warnings
+//   - MPC_HOST_CONFIG_REGION, MPC_HOST_CONFIG_ARM64_AMI, MPC_HOST_CONFIG_AMD64_AMI,
+//     MPC_HOST_CONFIG_ARM64_INSTANCE_TYPE, MPC_HOST_CONFIG_AMD64_INSTANCE_TYPE,
+//     MPC_HOST_CONFIG_MAX_INSTANCES: Values of the generated minimal host-config
+//     (defaults: DefaultHostConfigSettings); an invalid max-instances falls back to the
+//     default and is reported in Config.Warnings
+//   - MPC_HOST_CONFIG_IBM: "true" to add the IBM Cloud dynamic platforms to the generated
+//     host-config even before the IBM secrets are deployed (default: "false"); invalid
+//     values are reported in Config.Warnings
+//   - MPC_HOST_CONFIG_IBM_REGION, MPC_HOST_CONFIG_IBM_ZONE, MPC_HOST_CONFIG_IBM_KEY,
+//     MPC_HOST_CONFIG_IBM_S390X_IMAGE, MPC_HOST_CONFIG_IBM_PPC64LE_IMAGE,
+//     MPC_HOST_CONFIG_IBM_POWER_WORKSPACE: Values of the IBM Cloud platforms of the
+//     generated host-config (defaults: DefaultHostConfigSettings; the images and the
+//     workspace have none, and each IBM platform missing them is left out and reported
+//     in Config.Warnings when MPC_HOST_CONFIG_IBM is "true")
+//   - MPC_HOST_CONFIG_TEMPLATE: Go template file rendered with those values instead of
+//     the built-in minimal host-config (optional)
+//   - MPC_PROFILES: Comma-separated names of additional environments, selected with
+//     ?profile=<name> (optional); each is configured with MPC_PROFILE_<NAME>_CLUSTER_NAME
+//     (default: the profile name), MPC_PROFILE_<NAME>_REPO_PATH (default: MPC_REPO_PATH)
+//     and MPC_PROFILE_<NAME>_NAMESPACE (default: the TaskRun default namespace)
 //
 // Returns:
 //   - *Config: The populated configuration struct
@@ -57,8 +682,15 @@ func LoadConfig() (*Config, error) {
 		mpcDevEnvPath = cwd
 	}
 
+	// Config file: fills in the env vars that are not set
+	fileValues, err := LoadConfigFile(filepath.Join(mpcDevEnvPath, ConfigFileName))
+	if err != nil {
+		return nil, err
+	}
+	getenv := EnvWithFile(os.Getenv, fileValues)
+
 	// Auto-detect or read MPC_REPO_PATH
-	mpcRepoPath := os.Getenv("MPC_REPO_PATH")
+	mpcRepoPath := getenv("MPC_REPO_PATH")
 	if mpcRepoPath == "" {
 		// Auto-detect: look for multi-platform-controller as sibling
 		parentDir := filepath.Dir(mpcDevEnvPath)
@@ -75,60 +707,867 @@ func LoadConfig() (*Config, error) {
 	tempDir := filepath.Join(mpcDevEnvPath, "temp")
 
 	// Session log directory: from env var or fallback to logs/
-	sessionLogDir := os.Getenv("SESSION_LOG_DIR")
+	sessionLogDir := getenv("SESSION_LOG_DIR")
 	if sessionLogDir == "" {
 		sessionLogDir = filepath.Join(mpcDevEnvPath, "logs")
 	}
 
-	// Log level: from env var or default to "info"
-	logLevel := os.Getenv("LOG_LEVEL")
+	// Log level: from env var (the MPC_-prefixed one wins) or default to "info"
+	logLevel := getenv("MPC_LOG_LEVEL")
+	if logLevel == "" {
+		logLevel = getenv("LOG_LEVEL")
+	}
 	if logLevel == "" {
 		logLevel = "info"
 	}
 
+	// Log format: from env var or default to text
+	logFormat, err := ParseLogFormat(getenv("MPC_LOG_FORMAT"))
+	if err != nil {
+		return nil, err
+	}
+
+	// Container runtime: from env var or detected when needed
+	containerRuntime, err := ParseContainerRuntime(getenv("MPC_CONTAINER_RUNTIME"))
+	if err != nil {
+		return nil, err
+	}
+
+	// Cluster name: from env var or default to "konflux"
+	clusterName := getenv("MPC_CLUSTER_NAME")
+	if clusterName == "" {
+		clusterName = DefaultClusterName
+	}
+
+	// Kind config path: optional, validated below when set
+	kindConfigPath := getenv("MPC_KIND_CONFIG_PATH")
+
+	// Kubeconfig: optional, kubectl's default when unset
+	kubeconfigPath := ParseKubeconfigPath(getenv("MPC_KUBECONFIG"), getenv("KUBECONFIG"))
+
+	// Release versions of the minimal stack: from env vars or default to pinned ones
+	tektonVersion, err := ParseReleaseVersion("MPC_TEKTON_VERSION", getenv("MPC_TEKTON_VERSION"))
+	if err != nil {
+		return nil, err
+	}
+	certManagerVersion, err := ParseReleaseVersion("MPC_CERT_MANAGER_VERSION", getenv("MPC_CERT_MANAGER_VERSION"))
+	if err != nil {
+		return nil, err
+	}
+
+	// Image references: from env vars or default to the local images
+	controllerImage := getenv("MPC_CONTROLLER_IMAGE")
+	if controllerImage == "" {
+		controllerImage = DefaultControllerImage
+	}
+	otpImage := getenv("MPC_OTP_IMAGE")
+	if otpImage == "" {
+		otpImage = DefaultOTPImage
+	}
+
+	// Git sync interval: from env var or default to 60 minutes
+	gitSyncInterval, err := ParseGitSyncInterval(getenv("MPC_GIT_SYNC_INTERVAL"))
+	if err != nil {
+		return nil, err
+	}
+
+	// Git sync strategy: from env var or default to rebase, which keeps local work
+	gitSyncStrategy, err := ParseGitSyncStrategy(getenv("MPC_GIT_SYNC_STRATEGY"))
+	if err != nil {
+		return nil, err
+	}
+
+	// Deploy backend: from env var or default to kubectl
+	deployBackend, err := ParseDeployBackend(getenv("MPC_DEPLOY_BACKEND"))
+	if err != nil {
+		return nil, err
+	}
+
+	// Git sync backup: on unless explicitly disabled
+	gitSyncBackup, err := ParseGitSyncBackup(getenv("MPC_GIT_SYNC_BACKUP"))
+	if err != nil {
+		return nil, err
+	}
+
+	// Upstream URL overrides: optional
+	upstreamURLs, err := ParseUpstreamURLs(getenv("MPC_UPSTREAM_URLS"))
+	if err != nil {
+		return nil, err
+	}
+
+	// Repositories tracked besides MPC: optional
+	trackedRepos, err := ParseTrackedRepos(getenv("MPC_TRACKED_REPOS"))
+	if err != nil {
+		return nil, err
+	}
+
+	// Completion webhook: optional
+	webhookURL, err := ParseWebhookURL(getenv("MPC_WEBHOOK_URL"))
+	if err != nil {
+		return nil, err
+	}
+
+	// Konflux UI: from env vars or default to konflux-ci's
+	konfluxUIURL, err := ParseKonfluxUIURL(getenv("MPC_KONFLUX_UI_URL"))
+	if err != nil {
+		return nil, err
+	}
+	konfluxUsername := getenv("MPC_KONFLUX_USERNAME")
+	if konfluxUsername == "" {
+		konfluxUsername = DefaultKonfluxUsername
+	}
+
+	// Image registry: optional, images are loaded into Kind when unset
+	registryURL, err := ParseRegistryURL(getenv("MPC_REGISTRY_URL"))
+	if err != nil {
+		return nil, err
+	}
+
+	// Image build args: optional
+	buildArgs, err := ParseBuildArgs(getenv("MPC_BUILD_ARGS"))
+	if err != nil {
+		return nil, err
+	}
+
+	// Ports checked by the prerequisite check: optional
+	checkPorts, err := ParseCheckPorts(getenv("MPC_CHECK_PORTS"))
+	if err != nil {
+		return nil, err
+	}
+
+	// Operation timeouts: invalid values fall back to the defaults with a warning
+	timeouts, warnings := ParseTimeouts(getenv)
+
+	buildConcurrency, warning := ParseBuildConcurrency(getenv("MPC_BUILD_CONCURRENCY"))
+	if warning != "" {
+		warnings = append(warnings, warning)
+	}
+
+	kubectlAttempts, warning := ParseKubectlAttempts(getenv("MPC_KUBECTL_ATTEMPTS"))
+	if warning != "" {
+		warnings = append(warnings, warning)
+	}
+
+	// Resource minimums: invalid values fall back to the defaults with a warning
+	minDiskSpaceGB, warning := ParseMinimumGB("MPC_MIN_DISK_GB", getenv("MPC_MIN_DISK_GB"), DefaultMinDiskSpaceGB)
+	if warning != "" {
+		warnings = append(warnings, warning)
+	}
+	minMemoryGB, warning := ParseMinimumGB("MPC_MIN_MEMORY_GB", getenv("MPC_MIN_MEMORY_GB"), DefaultMinMemoryGB)
+	if warning != "" {
+		warnings = append(warnings, warning)
+	}
+
+	// Hot-reload watcher: invalid values fall back to the defaults with a warning
+	watch, watchWarnings := ParseWatchConfig(getenv)
+	warnings = append(warnings, watchWarnings...)
+
+	// Generated host-config: invalid values fall back to the defaults with a warning
+	hostConfig, hostConfigWarnings := ParseHostConfigSettings(getenv)
+	warnings = append(warnings, hostConfigWarnings...)
+
+	// Additional environments: optional
+	profiles, err := ParseProfiles(getenv)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create the Config struct
 	cfg := &Config{
-		MpcRepoPath:   mpcRepoPath,
-		MpcDevEnvPath: mpcDevEnvPath,
-		TempDir:       tempDir,
-		SessionLogDir: sessionLogDir,
-		LogLevel:      logLevel,
+		MpcRepoPath:          mpcRepoPath,
+		MpcDevEnvPath:        mpcDevEnvPath,
+		TempDir:              tempDir,
+		SessionLogDir:        sessionLogDir,
+		LogLevel:             logLevel,
+		LogFormat:            logFormat,
+		ContainerRuntime:     containerRuntime,
+		ClusterName:          clusterName,
+		KindConfigPath:       kindConfigPath,
+		KubeconfigPath:       kubeconfigPath,
+		TektonVersion:        tektonVersion,
+		CertManagerVersion:   certManagerVersion,
+		TaskRunsDir:          getenv("MPC_TASKRUNS_DIR"),
+		ControllerImage:      controllerImage,
+		OTPImage:             otpImage,
+		RegistryURL:          registryURL,
+		BuildArgs:            buildArgs,
+		GitSyncInterval:      gitSyncInterval,
+		GitSyncStrategy:      gitSyncStrategy,
+		DeployBackend:        deployBackend,
+		DisableGitSyncBackup: !gitSyncBackup,
+		UpstreamURLs:         upstreamURLs,
+		TrackedRepos:         trackedRepos,
+		MPCPathPrefixes:      splitList(getenv("MPC_RELEVANT_PATH_PREFIXES")),
+		DaemonToken:          getenv("MPC_DAEMON_TOKEN"),
+		AllowedHosts:         ParseAllowedHosts(getenv("MPC_ALLOWED_HOSTS")),
+		WebhookURL:           webhookURL,
+		KonfluxUIURL:         konfluxUIURL,
+		KonfluxUsername:      konfluxUsername,
+		KonfluxCIPath:        getenv("MPC_KONFLUX_CI_PATH"),
+		Timeouts:             timeouts,
+		BuildConcurrency:     buildConcurrency,
+		KubectlAttempts:      kubectlAttempts,
+		MinDiskSpaceGB:       minDiskSpaceGB,
+		MinMemoryGB:          minMemoryGB,
+		CheckPorts:           checkPorts,
+		Watch:                watch,
+		HostConfig:           hostConfig,
+		Profiles:             profiles,
+		Warnings:             warnings,
 	}
 
-	// Validate the configuration
+	// Validate the configuration; this also creates the temp directory
 	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("configuration validation failed: %w", err)
 	}
 
-	// Ensure the temp directory exists
-	if err := os.MkdirAll(tempDir, 0755); err != nil {
-		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	return cfg, nil
+}
+
+// LoadConfigFile reads the config file at path, a flat YAML mapping of env var names
+// to values:
+//
+//	MPC_REPO_PATH: /home/me/Work/multi-platform-controller
+//	MPC_WATCH_REDEPLOY: true
+//
+// A missing file is not an error and yields no values.
+func LoadConfigFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	return cfg, nil
+	var values map[string]string
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return values, nil
+}
+
+// EnvWithFile returns a getenv that reads through getenv (normally os.Getenv) and falls
+// back to the config file values for env vars that are unset or empty.
+func EnvWithFile(getenv func(string) string, values map[string]string) func(string) string {
+	return func(key string) string {
+		if value := getenv(key); value != "" {
+			return value
+		}
+		return values[key]
+	}
+}
+
+// ParseLogFormat parses an MPC_LOG_FORMAT value. An empty value yields LogFormatText;
+// otherwise it must be "text" or "json" (case-insensitive).
+func ParseLogFormat(value string) (string, error) {
+	switch format := strings.ToLower(strings.TrimSpace(value)); format {
+	case "":
+		return LogFormatText, nil
+	case LogFormatText, LogFormatJSON:
+		return format, nil
+	default:
+		return "", fmt.Errorf("invalid MPC_LOG_FORMAT %q: must be %q or %q", value, LogFormatText, LogFormatJSON)
+	}
+}
+
+// ParseContainerRuntime parses an MPC_CONTAINER_RUNTIME value. An empty value yields
+// "" (detect the runtime); otherwise it must be "docker" or "podman" (case-insensitive).
+func ParseContainerRuntime(value string) (string, error) {
+	switch runtime := strings.ToLower(strings.TrimSpace(value)); runtime {
+	case "", ContainerRuntimeDocker, ContainerRuntimePodman:
+		return runtime, nil
+	default:
+		return "", fmt.Errorf("invalid MPC_CONTAINER_RUNTIME %q: must be %q or %q", value, ContainerRuntimeDocker, ContainerRuntimePodman)
+	}
+}
+
+// releaseVersionPattern matches the versions of Tekton Pipelines and cert-manager
+// releases, with the leading "v" optional.
+var releaseVersionPattern = regexp.MustCompile(`^v?\d+\.\d+\.\d+$`)
+
+// ParseReleaseVersion parses the value of the release version env var name, such as
+// MPC_TEKTON_VERSION. An empty value yields "" (the default release), and "latest"
+// yields ReleaseLatest; otherwise it must be a version like "v1.6.0", whose "v" is
+// added when missing.
+func ParseReleaseVersion(name, value string) (string, error) {
+	version := strings.TrimSpace(value)
+	switch {
+	case version == "", version == ReleaseLatest:
+		return version, nil
+	case releaseVersionPattern.MatchString(version):
+		return "v" + strings.TrimPrefix(version, "v"), nil
+	default:
+		return "", fmt.Errorf("invalid %s %q: must be a version such as v1.2.3, or %q", name, value, ReleaseLatest)
+	}
+}
+
+// ParseGitSyncInterval parses an MPC_GIT_SYNC_INTERVAL value.
+//
+// An empty value yields DefaultGitSyncInterval, and "0" or "off" yield 0, which
+// disables the background sync. Any other value must be a Go duration string
+// (e.g., "15m", "2h") of at least one minute.
+func ParseGitSyncInterval(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	switch value {
+	case "":
+		return DefaultGitSyncInterval, nil
+	case "0", "off":
+		return 0, nil
+	}
+
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid MPC_GIT_SYNC_INTERVAL %q: %w", value, err)
+	}
+	if interval == 0 {
+		return 0, nil
+	}
+	if interval < minGitSyncInterval {
+		return 0, fmt.Errorf("invalid MPC_GIT_SYNC_INTERVAL %q: must be at least %s, or 0/off to disable", value, minGitSyncInterval)
+	}
+
+	return interval, nil
+}
+
+// ParseGitSyncStrategy parses an MPC_GIT_SYNC_STRATEGY value. An empty value yields
+// GitSyncStrategyRebase.
+func ParseGitSyncStrategy(value string) (string, error) {
+	switch strategy := strings.ToLower(strings.TrimSpace(value)); strategy {
+	case "":
+		return GitSyncStrategyRebase, nil
+	case GitSyncStrategyFFOnly, GitSyncStrategyRebase, GitSyncStrategyReset:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("invalid MPC_GIT_SYNC_STRATEGY %q: must be %q, %q or %q",
+			value, GitSyncStrategyFFOnly, GitSyncStrategyRebase, GitSyncStrategyReset)
+	}
+}
+
+// ParseDeployBackend parses an MPC_DEPLOY_BACKEND value. An empty value yields
+// DeployBackendKubectl.
+func ParseDeployBackend(value string) (string, error) {
+	switch backend := strings.ToLower(strings.TrimSpace(value)); backend {
+	case "":
+		return DeployBackendKubectl, nil
+	case DeployBackendKubectl, DeployBackendClient:
+		return backend, nil
+	default:
+		return "", fmt.Errorf("invalid MPC_DEPLOY_BACKEND %q: must be %q or %q",
+			value, DeployBackendKubectl, DeployBackendClient)
+	}
+}
+
+// ParseGitSyncBackup parses an MPC_GIT_SYNC_BACKUP value. An empty value yields true.
+func ParseGitSyncBackup(value string) (bool, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return true, nil
+	}
+	backup, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid MPC_GIT_SYNC_BACKUP %q: expected true or false", value)
+	}
+	return backup, nil
+}
+
+// ParseUpstreamURLs parses an MPC_UPSTREAM_URLS value of comma-separated
+// name=url pairs, e.g. "multi-platform-controller=https://github.com/me/mpc.git".
+// An empty value yields an empty map.
+func ParseUpstreamURLs(value string) (map[string]string, error) {
+	upstreamURLs := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		name, url, ok := strings.Cut(pair, "=")
+		name, url = strings.TrimSpace(name), strings.TrimSpace(url)
+		if !ok || name == "" || url == "" {
+			return nil, fmt.Errorf("invalid MPC_UPSTREAM_URLS entry %q: expected name=url", pair)
+		}
+		upstreamURLs[name] = url
+	}
+
+	return upstreamURLs, nil
+}
+
+// ParseTrackedRepos parses an MPC_TRACKED_REPOS value of comma-separated name=path
+// pairs, e.g. "konflux-ci=/src/konflux-ci,infra-deployments=/src/infra-deployments".
+// An empty value yields an empty map.
+func ParseTrackedRepos(value string) (map[string]string, error) {
+	trackedRepos := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		name, path, ok := strings.Cut(pair, "=")
+		name, path = strings.TrimSpace(name), strings.TrimSpace(path)
+		if !ok || name == "" || path == "" {
+			return nil, fmt.Errorf("invalid MPC_TRACKED_REPOS entry %q: expected name=path", pair)
+		}
+		trackedRepos[name] = path
+	}
+
+	return trackedRepos, nil
+}
+
+// ParseRegistryURL parses an MPC_REGISTRY_URL value, a registry host with an optional
+// port and path such as "localhost:5001" or "quay.io/me". A trailing slash is dropped;
+// a URL scheme is rejected, as image references never contain one. An empty value
+// yields "" (no registry).
+func ParseRegistryURL(value string) (string, error) {
+	registry := strings.TrimSuffix(strings.TrimSpace(value), "/")
+	if strings.Contains(registry, "://") || strings.ContainsAny(registry, " \t@") {
+		return "", fmt.Errorf("invalid MPC_REGISTRY_URL %q: expected a registry such as localhost:5001, without a scheme", value)
+	}
+	return registry, nil
 }
 
-// Validate checks that all required paths exist and are accessible.
+// ParseWebhookURL parses an MPC_WEBHOOK_URL value, which must be an absolute http or
+// https URL such as "https://hooks.example.com/mpc". An empty value yields "" (no webhook).
+func ParseWebhookURL(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+
+	parsed, err := url.Parse(value)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", fmt.Errorf("invalid MPC_WEBHOOK_URL %q: expected an http or https URL", value)
+	}
+	return value, nil
+}
+
+// ParseKonfluxUIURL parses an MPC_KONFLUX_UI_URL value, which must be an absolute http
+// or https URL such as "https://localhost:9443". An empty value yields DefaultKonfluxUIURL.
+func ParseKonfluxUIURL(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return DefaultKonfluxUIURL, nil
+	}
+
+	parsed, err := url.Parse(value)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", fmt.Errorf("invalid MPC_KONFLUX_UI_URL %q: expected an http or https URL", value)
+	}
+	return value, nil
+}
+
+// ParseBuildArgs parses an MPC_BUILD_ARGS value of comma-separated KEY=VALUE pairs,
+// e.g. "GOFLAGS=-mod=mod,HTTPS_PROXY=http://proxy:3128". Only the first "=" separates
+// the key from the value, and the value may be empty. An empty value yields an empty map.
+func ParseBuildArgs(value string) (map[string]string, error) {
+	buildArgs := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		key, arg, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("invalid MPC_BUILD_ARGS entry %q: expected KEY=VALUE", pair)
+		}
+		buildArgs[key] = strings.TrimSpace(arg)
+	}
+
+	return buildArgs, nil
+}
+
+// ParseAllowedHosts parses an MPC_ALLOWED_HOSTS value of comma-separated host names,
+// e.g. "localhost,127.0.0.1,devbox.local". Names are lower-cased; an empty value yields nil.
+func ParseAllowedHosts(value string) []string {
+	var hosts []string
+	for _, host := range strings.Split(value, ",") {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+// ParseTimeouts reads the per-operation timeouts through getenv (normally os.Getenv).
+//
+// Each value must be a positive Go duration string (e.g., "45m", "1h30m"). Unset
+// values yield the defaults from DefaultTimeouts. Invalid values also yield the
+// default, so a typo never leaves an operation unbounded or fails daemon startup;
+// each one is described in the returned warnings instead.
+func ParseTimeouts(getenv func(string) string) (TimeoutConfig, []string) {
+	timeouts := DefaultTimeouts()
+	var warnings []string
+
+	for _, t := range []struct {
+		envVar string
+		field  *time.Duration
+	}{
+		{"MPC_BUILD_TIMEOUT", &timeouts.Build},
+		{"MPC_DEPLOY_TIMEOUT", &timeouts.Deploy},
+		{"MPC_KONFLUX_TIMEOUT", &timeouts.Konflux},
+		{"MPC_KONFLUX_SCRIPT_TIMEOUT", &timeouts.KonfluxScript},
+		{"MPC_MINIMAL_STACK_TIMEOUT", &timeouts.MinimalStack},
+		{"MPC_SECRETS_TIMEOUT", &timeouts.Secrets},
+		{"MPC_TASKRUN_TIMEOUT", &timeouts.TaskRun},
+		{"MPC_GIT_COMMAND_TIMEOUT", &timeouts.GitCommand},
+		{"MPC_SHUTDOWN_GRACE_PERIOD", &timeouts.ShutdownGrace},
+	} {
+		value := strings.TrimSpace(getenv(t.envVar))
+		if value == "" {
+			continue
+		}
+
+		timeout, err := time.ParseDuration(value)
+		if err == nil && timeout <= 0 {
+			err = errors.New("must be positive")
+		}
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("invalid %s %q (%v), using default %s", t.envVar, value, err, *t.field))
+			continue
+		}
+		*t.field = timeout
+	}
+
+	return timeouts, warnings
+}
+
+// ParseCheckPorts parses an MPC_CHECK_PORTS value of comma-separated TCP ports,
+// e.g. "8765,9443,8443". An empty value yields nil.
+func ParseCheckPorts(value string) ([]int, error) {
+	var ports []int
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		port, err := strconv.Atoi(field)
+		if err != nil || port < 1 || port > 65535 {
+			return nil, fmt.Errorf("invalid MPC_CHECK_PORTS entry %q: expected a TCP port between 1 and 65535", field)
+		}
+		ports = append(ports, port)
+	}
+	return ports, nil
+}
+
+// ParseKubeconfigPath returns the kubeconfig file to use given the MPC_KUBECONFIG and
+// KUBECONFIG values: MPC_KUBECONFIG when set, else the first file KUBECONFIG lists, as
+// kubectl and kind write new contexts to that one. Both empty yields "".
+func ParseKubeconfigPath(mpcKubeconfig, kubeconfig string) string {
+	if path := strings.TrimSpace(mpcKubeconfig); path != "" {
+		return path
+	}
+	for _, path := range filepath.SplitList(kubeconfig) {
+		if path = strings.TrimSpace(path); path != "" {
+			return path
+		}
+	}
+	return ""
+}
+
+// ParseBuildConcurrency parses an MPC_BUILD_CONCURRENCY value. An empty value yields
+// DefaultBuildConcurrency; so does an invalid or non-positive one, together with a
+// warning describing it.
+func ParseBuildConcurrency(value string) (int, string) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return DefaultBuildConcurrency, ""
+	}
+
+	concurrency, err := strconv.Atoi(value)
+	if err != nil || concurrency <= 0 {
+		return DefaultBuildConcurrency, fmt.Sprintf("invalid MPC_BUILD_CONCURRENCY %q (expected a whole number of at least 1), using default %d",
+			value, DefaultBuildConcurrency)
+	}
+	return concurrency, ""
+}
+
+// ParseKubectlAttempts parses an MPC_KUBECTL_ATTEMPTS value. An empty value yields
+// DefaultKubectlAttempts; so does an invalid or non-positive one, together with a
+// warning describing it.
+func ParseKubectlAttempts(value string) (int, string) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return DefaultKubectlAttempts, ""
+	}
+
+	attempts, err := strconv.Atoi(value)
+	if err != nil || attempts <= 0 {
+		return DefaultKubectlAttempts, fmt.Sprintf("invalid MPC_KUBECTL_ATTEMPTS %q (expected a whole number of at least 1), using default %d",
+			value, DefaultKubectlAttempts)
+	}
+	return attempts, ""
+}
+
+// ParseMinimumGB parses a resource minimum in whole GB read from envVar. An empty
+// value yields fallback; so does an invalid or non-positive one, together with a
+// warning describing it.
+func ParseMinimumGB(envVar, value string, fallback int) (int, string) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return fallback, ""
+	}
+
+	minimum, err := strconv.Atoi(value)
+	if err != nil || minimum <= 0 {
+		return fallback, fmt.Sprintf("invalid %s %q (expected a whole number of GB), using default %d", envVar, value, fallback)
+	}
+	return minimum, ""
+}
+
+// ParseWatchConfig reads the hot-reload watcher settings through getenv (normally os.Getenv).
+//
+// Unset values yield the defaults from DefaultWatchConfig. An invalid or non-positive
+// MPC_WATCH_DEBOUNCE also yields the default, and invalid MPC_WATCH_INCLUDE globs are
+// dropped, and an invalid MPC_WATCH_REDEPLOY leaves redeploying off; each problem is
+// described in the returned warnings. Extensions are given a leading dot when it is missing.
+func ParseWatchConfig(getenv func(string) string) (WatchConfig, []string) {
+	watch := DefaultWatchConfig()
+	var warnings []string
+
+	if value := strings.TrimSpace(getenv("MPC_WATCH_DEBOUNCE")); value != "" {
+		debounce, err := time.ParseDuration(value)
+		if err == nil && debounce <= 0 {
+			err = errors.New("must be positive")
+		}
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("invalid MPC_WATCH_DEBOUNCE %q (%v), using default %s", value, err, watch.Debounce))
+		} else {
+			watch.Debounce = debounce
+		}
+	}
+
+	if extensions := splitList(getenv("MPC_WATCH_IGNORE_EXTENSIONS")); len(extensions) > 0 {
+		for i, ext := range extensions {
+			if !strings.HasPrefix(ext, ".") {
+				extensions[i] = "." + ext
+			}
+		}
+		watch.IgnoreExtensions = extensions
+	}
+
+	if dirs := splitList(getenv("MPC_WATCH_IGNORE_DIRS")); len(dirs) > 0 {
+		watch.IgnoreDirs = dirs
+	}
+
+	for _, pattern := range splitList(getenv("MPC_WATCH_INCLUDE")) {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			warnings = append(warnings, fmt.Sprintf("invalid MPC_WATCH_INCLUDE pattern %q (%v), ignoring it", pattern, err))
+			continue
+		}
+		watch.IncludePatterns = append(watch.IncludePatterns, pattern)
+	}
+
+	if value := strings.TrimSpace(getenv("MPC_WATCH_REDEPLOY")); value != "" {
+		redeploy, err := strconv.ParseBool(value)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("invalid MPC_WATCH_REDEPLOY %q (expected true or false), not redeploying", value))
+		}
+		watch.Redeploy = redeploy
+	}
+
+	return watch, warnings
+}
+
+// ParseHostConfigSettings reads the generated host-config settings through getenv
+// (normally os.Getenv). Unset values yield the defaults from DefaultHostConfigSettings.
+// An invalid or non-positive MPC_HOST_CONFIG_MAX_INSTANCES also yields the default,
+// an invalid MPC_HOST_CONFIG_IBM leaves the IBM platforms out, and so does enabling
+// them without their images and workspace (see MissingIBMSettings); each problem is
+// described by one of the returned warnings.
+func ParseHostConfigSettings(getenv func(string) string) (HostConfigSettings, []string) {
+	settings := DefaultHostConfigSettings()
+	for _, s := range []struct {
+		envVar string
+		field  *string
+	}{
+		{"MPC_HOST_CONFIG_REGION", &settings.Region},
+		{"MPC_HOST_CONFIG_ARM64_AMI", &settings.ARM64AMI},
+		{"MPC_HOST_CONFIG_AMD64_AMI", &settings.AMD64AMI},
+		{"MPC_HOST_CONFIG_ARM64_INSTANCE_TYPE", &settings.ARM64InstanceType},
+		{"MPC_HOST_CONFIG_AMD64_INSTANCE_TYPE", &settings.AMD64InstanceType},
+		{"MPC_HOST_CONFIG_IBM_REGION", &settings.IBMRegion},
+		{"MPC_HOST_CONFIG_IBM_ZONE", &settings.IBMZone},
+		{"MPC_HOST_CONFIG_IBM_KEY", &settings.IBMKey},
+		{"MPC_HOST_CONFIG_IBM_S390X_IMAGE", &settings.IBMS390XImage},
+		{"MPC_HOST_CONFIG_IBM_PPC64LE_IMAGE", &settings.IBMPPC64LEImage},
+		{"MPC_HOST_CONFIG_IBM_POWER_WORKSPACE", &settings.IBMPowerWorkspace},
+		{"MPC_HOST_CONFIG_TEMPLATE", &settings.TemplatePath},
+	} {
+		if value := strings.TrimSpace(getenv(s.envVar)); value != "" {
+			*s.field = value
+		}
+	}
+
+	var warnings []string
+	if value := strings.TrimSpace(getenv("MPC_HOST_CONFIG_MAX_INSTANCES")); value != "" {
+		maxInstances, err := strconv.Atoi(value)
+		if err != nil || maxInstances <= 0 {
+			warnings = append(warnings, fmt.Sprintf("invalid MPC_HOST_CONFIG_MAX_INSTANCES %q (expected a whole number of at least 1), using default %d",
+				value, settings.MaxInstances))
+		} else {
+			settings.MaxInstances = maxInstances
+		}
+	}
+
+	if value := strings.TrimSpace(getenv("MPC_HOST_CONFIG_IBM")); value != "" {
+		ibmEnabled, err := strconv.ParseBool(value)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("invalid MPC_HOST_CONFIG_IBM %q (expected true or false), leaving out the IBM platforms", value))
+		}
+		settings.IBMEnabled = ibmEnabled
+		warnings = append(warnings, settings.MissingIBMSettings()...)
+	}
+
+	return settings, warnings
+}
+
+// splitList splits a comma-separated value, trimming whitespace and dropping empty entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Validate checks that all required paths exist and are accessible: the MPC repository
+// must contain a Dockerfile and a deploy/operator directory, and the temp directory must
+// be writable (it is created if missing). Every problem found is reported, joined into
+// one error, so they can all be fixed in one go.
 func (c *Config) Validate() error {
-	// Check that MPC_REPO_PATH exists
-	if _, err := os.Stat(c.MpcRepoPath); err != nil {
-		if os.IsNotExist(err) {
-			return fmt.Errorf("MPC_REPO_PATH does not exist: %s", c.MpcRepoPath)
+	var problems []error
+
+	// Check that MPC_REPO_PATH looks like a multi-platform-controller checkout
+	if err := checkDir("MPC_REPO_PATH", c.MpcRepoPath); err != nil {
+		problems = append(problems, err)
+	} else {
+		if _, err := os.Stat(filepath.Join(c.MpcRepoPath, "Dockerfile")); err != nil {
+			problems = append(problems, fmt.Errorf("MPC_REPO_PATH has no Dockerfile, is it a multi-platform-controller checkout? %s", c.MpcRepoPath))
+		}
+		if info, err := os.Stat(filepath.Join(c.MpcRepoPath, "deploy", "operator")); err != nil || !info.IsDir() {
+			problems = append(problems, fmt.Errorf("MPC_REPO_PATH has no deploy/operator directory, is it a multi-platform-controller checkout? %s", c.MpcRepoPath))
 		}
-		return fmt.Errorf("cannot access MPC_REPO_PATH: %w", err)
 	}
 
 	// Check that MPC_DEV_ENV_PATH exists
-	if _, err := os.Stat(c.MpcDevEnvPath); err != nil {
-		if os.IsNotExist(err) {
-			return fmt.Errorf("MPC_DEV_ENV_PATH does not exist: %s", c.MpcDevEnvPath)
+	if err := checkDir("MPC_DEV_ENV_PATH", c.MpcDevEnvPath); err != nil {
+		problems = append(problems, err)
+	}
+
+	// Check that the temp directory can be written to, since builds and deploys stage files there
+	if c.TempDir != "" {
+		if err := checkWritableDir(c.TempDir); err != nil {
+			problems = append(problems, fmt.Errorf("temp directory %s is not writable: %w", c.TempDir, err))
+		}
+	}
+
+	// If a kind config was explicitly requested, it must be readable. Failing here
+	// is much clearer than a cryptic error from "kind create cluster" later on.
+	if c.KindConfigPath != "" {
+		f, err := os.Open(c.KindConfigPath)
+		if err != nil {
+			problems = append(problems, fmt.Errorf("cannot read MPC_KIND_CONFIG_PATH: %w", err))
+		} else {
+			_ = f.Close()
 		}
-		return fmt.Errorf("cannot access MPC_DEV_ENV_PATH: %w", err)
 	}
 
+	problems = append(problems, c.validateProfiles()...)
+
+	return errors.Join(problems...)
+}
+
+// checkDir returns an error naming envVar unless path is an existing directory.
+func checkDir(envVar, path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%s does not exist: %s", envVar, path)
+		}
+		return fmt.Errorf("cannot access %s: %w", envVar, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory: %s", envVar, path)
+	}
 	return nil
 }
 
+// checkWritableDir creates dir if its parent exists and verifies a file can be created in it.
+func checkWritableDir(dir string) error {
+	if err := os.Mkdir(dir, 0755); err != nil && !os.IsExist(err) {
+		return err
+	}
+	f, err := os.CreateTemp(dir, ".write-check-*")
+	if err != nil {
+		return err
+	}
+	_ = f.Close()
+	return os.Remove(f.Name())
+}
+
+// ChangedSettings returns the env var names of the settings that differ between
+// previous and current, e.g. to log what a configuration reload changed. Values are
+// not included since some, like MPC_DAEMON_TOKEN, are secrets.
+func ChangedSettings(previous, current *Config) []string {
+	settings := []struct {
+		name              string
+		previous, current any
+	}{
+		{"MPC_REPO_PATH", previous.MpcRepoPath, current.MpcRepoPath},
+		{"MPC_DEV_ENV_PATH", previous.MpcDevEnvPath, current.MpcDevEnvPath},
+		{"SESSION_LOG_DIR", previous.SessionLogDir, current.SessionLogDir},
+		{"MPC_LOG_LEVEL", previous.LogLevel, current.LogLevel},
+		{"MPC_LOG_FORMAT", previous.LogFormat, current.LogFormat},
+		{"MPC_CONTAINER_RUNTIME", previous.ContainerRuntime, current.ContainerRuntime},
+		{"MPC_CLUSTER_NAME", previous.ClusterName, current.ClusterName},
+		{"MPC_KIND_CONFIG_PATH", previous.KindConfigPath, current.KindConfigPath},
+		{"MPC_KUBECONFIG", previous.KubeconfigPath, current.KubeconfigPath},
+		{"MPC_TEKTON_VERSION", previous.TektonVersion, current.TektonVersion},
+		{"MPC_CERT_MANAGER_VERSION", previous.CertManagerVersion, current.CertManagerVersion},
+		{"MPC_TASKRUNS_DIR", previous.TaskRunsDir, current.TaskRunsDir},
+		{"MPC_CONTROLLER_IMAGE", previous.ControllerImage, current.ControllerImage},
+		{"MPC_OTP_IMAGE", previous.OTPImage, current.OTPImage},
+		{"MPC_REGISTRY_URL", previous.RegistryURL, current.RegistryURL},
+		{"MPC_BUILD_ARGS", previous.BuildArgs, current.BuildArgs},
+		{"MPC_GIT_SYNC_INTERVAL", previous.GitSyncInterval, current.GitSyncInterval},
+		{"MPC_GIT_SYNC_STRATEGY", previous.GitSyncStrategy, current.GitSyncStrategy},
+		{"MPC_DEPLOY_BACKEND", previous.DeployBackend, current.DeployBackend},
+		{"MPC_GIT_SYNC_BACKUP", !previous.DisableGitSyncBackup, !current.DisableGitSyncBackup},
+		{"MPC_UPSTREAM_URLS", previous.UpstreamURLs, current.UpstreamURLs},
+		{"MPC_TRACKED_REPOS", previous.TrackedRepos, current.TrackedRepos},
+		{"MPC_RELEVANT_PATH_PREFIXES", previous.MPCPathPrefixes, current.MPCPathPrefixes},
+		{"MPC_DAEMON_TOKEN", previous.DaemonToken, current.DaemonToken},
+		{"MPC_ALLOWED_HOSTS", previous.AllowedHosts, current.AllowedHosts},
+		{"MPC_WEBHOOK_URL", previous.WebhookURL, current.WebhookURL},
+		{"MPC_KONFLUX_UI_URL", previous.KonfluxUIURL, current.KonfluxUIURL},
+		{"MPC_KONFLUX_USERNAME", previous.KonfluxUsername, current.KonfluxUsername},
+		{"MPC_KONFLUX_CI_PATH", previous.KonfluxCIPath, current.KonfluxCIPath},
+		{"MPC_*_TIMEOUT", previous.Timeouts, current.Timeouts},
+		{"MPC_BUILD_CONCURRENCY", previous.BuildConcurrency, current.BuildConcurrency},
+		{"MPC_KUBECTL_ATTEMPTS", previous.KubectlAttempts, current.KubectlAttempts},
+		{"MPC_MIN_DISK_GB", previous.MinDiskSpaceGB, current.MinDiskSpaceGB},
+		{"MPC_MIN_MEMORY_GB", previous.MinMemoryGB, current.MinMemoryGB},
+		{"MPC_CHECK_PORTS", previous.CheckPorts, current.CheckPorts},
+		{"MPC_WATCH_*", previous.Watch, current.Watch},
+		{"MPC_HOST_CONFIG_*", previous.HostConfig, current.HostConfig},
+		{"MPC_PROFILE*", previous.Profiles, current.Profiles},
+	}
+
+	var changed []string
+	for _, setting := range settings {
+		if !reflect.DeepEqual(setting.previous, setting.current) {
+			changed = append(changed, setting.name)
+		}
+	}
+	return changed
+}
+
 // GetTempDir returns the path to the temp directory for daemon operations.
 func (c *Config) GetTempDir() string {
 	return c.TempDir
@@ -139,6 +1578,19 @@ func (c *Config) GetMpcRepoPath() string {
 	return c.MpcRepoPath
 }
 
+// GetRepoPaths returns the repositories tracked by the daemon, keyed by repository
+// name: the MPC repository plus TrackedRepos, where MPC_REPO_PATH wins over a tracked
+// multi-platform-controller entry. It is the single source for both the state
+// manager's repository checks and the git syncer.
+func (c *Config) GetRepoPaths() map[string]string {
+	repoPaths := make(map[string]string, len(c.TrackedRepos)+1)
+	for name, path := range c.TrackedRepos {
+		repoPaths[name] = path
+	}
+	repoPaths["multi-platform-controller"] = c.GetMpcRepoPath()
+	return repoPaths
+}
+
 // GetMpcDevEnvPath returns the path to the mpc_dev_env repository.
 func (c *Config) GetMpcDevEnvPath() string {
 	return c.MpcDevEnvPath
@@ -148,3 +1600,373 @@ func (c *Config) GetMpcDevEnvPath() string {
 func (c *Config) GetSessionLogDir() string {
 	return c.SessionLogDir
 }
+
+// GetClusterName returns the Kind cluster name, falling back to DefaultClusterName
+// when the field is unset (e.g., for configs constructed directly in tests).
+func (c *Config) GetClusterName() string {
+	if c.ClusterName == "" {
+		return DefaultClusterName
+	}
+	return c.ClusterName
+}
+
+// GetKubeContext returns the kubeconfig context of the Kind cluster, "kind-<cluster
+// name>". Every kubectl and client call selects it explicitly: creating any Kind
+// cluster, e.g. a profile's, switches the kubeconfig's current context to it.
+func (c *Config) GetKubeContext() string {
+	return "kind-" + c.GetClusterName()
+}
+
+// GetContainerRuntime returns the configured container runtime, or "" when it should
+// be detected.
+func (c *Config) GetContainerRuntime() string {
+	return c.ContainerRuntime
+}
+
+// GetControllerImage returns the controller image reference, falling back to
+// DefaultControllerImage when the field is unset. With a RegistryURL, the image is
+// moved into that registry (see RegistryImage).
+func (c *Config) GetControllerImage() string {
+	if c.ControllerImage == "" {
+		return RegistryImage(c.RegistryURL, DefaultControllerImage)
+	}
+	return RegistryImage(c.RegistryURL, c.ControllerImage)
+}
+
+// GetOTPImage returns the OTP server image reference, falling back to
+// DefaultOTPImage when the field is unset. With a RegistryURL, the image is moved
+// into that registry (see RegistryImage).
+func (c *Config) GetOTPImage() string {
+	if c.OTPImage == "" {
+		return RegistryImage(c.RegistryURL, DefaultOTPImage)
+	}
+	return RegistryImage(c.RegistryURL, c.OTPImage)
+}
+
+// GetRegistryURL returns the registry the images are pushed to, or "" when they are
+// loaded into the Kind cluster instead.
+func (c *Config) GetRegistryURL() string {
+	return c.RegistryURL
+}
+
+// RegistryImage returns image with its registry and path replaced by registry, e.g.
+// "localhost/multi-platform-controller:latest" in "localhost:5001" becomes
+// "localhost:5001/multi-platform-controller:latest". An empty registry returns image
+// unchanged.
+func RegistryImage(registry, image string) string {
+	if registry == "" {
+		return image
+	}
+	return registry + "/" + image[strings.LastIndex(image, "/")+1:]
+}
+
+// GetAllowedHosts returns the host names accepted in the Host and Origin headers of
+// mutating API requests, falling back to DefaultAllowedHosts if unset.
+func (c *Config) GetAllowedHosts() []string {
+	if len(c.AllowedHosts) == 0 {
+		return DefaultAllowedHosts
+	}
+	return c.AllowedHosts
+}
+
+// ShortGitHash abbreviates a full commit hash to the form used in image tags.
+// Hashes already shorter than that are returned unchanged.
+func ShortGitHash(gitHash string) string {
+	if len(gitHash) > shortGitHashLength {
+		return gitHash[:shortGitHashLength]
+	}
+	return gitHash
+}
+
+// RevisionImage returns image re-tagged with the short form of gitHash, e.g.
+// "localhost/multi-platform-controller:latest" becomes
+// "localhost/multi-platform-controller:<shorthash>". An empty gitHash returns
+// image unchanged.
+func RevisionImage(image, gitHash string) string {
+	if gitHash == "" {
+		return image
+	}
+
+	repository := image
+	if i := strings.Index(repository, "@"); i >= 0 {
+		repository = repository[:i]
+	}
+	// A colon after the last slash separates the tag; earlier colons belong to a registry port
+	if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		repository = repository[:i]
+	}
+
+	return repository + ":" + ShortGitHash(gitHash)
+}
+
+// GetGitSyncInterval returns the background git sync period. Unlike the other
+// getters there is no fallback: zero means the sync is disabled.
+func (c *Config) GetGitSyncInterval() time.Duration {
+	return c.GitSyncInterval
+}
+
+// GetDeployBackend returns how deployments read and change the MPC deployments,
+// falling back to DeployBackendKubectl when unset.
+func (c *Config) GetDeployBackend() string {
+	if c.DeployBackend == "" {
+		return DeployBackendKubectl
+	}
+	return c.DeployBackend
+}
+
+// GetGitSyncStrategy returns how a git sync updates a repository, falling back to
+// GitSyncStrategyRebase when unset.
+func (c *Config) GetGitSyncStrategy() string {
+	if c.GitSyncStrategy == "" {
+		return GitSyncStrategyRebase
+	}
+	return c.GitSyncStrategy
+}
+
+// GetGitSyncBackup reports whether the reset git sync strategy backs up local work
+// before discarding it.
+func (c *Config) GetGitSyncBackup() bool {
+	return !c.DisableGitSyncBackup
+}
+
+// GetBuildArgs returns the build args passed to both image builds, keyed by name.
+func (c *Config) GetBuildArgs() map[string]string {
+	return c.BuildArgs
+}
+
+// GetUpstreamURLs returns the configured upstream URL overrides, keyed by repository name.
+func (c *Config) GetUpstreamURLs() map[string]string {
+	return c.UpstreamURLs
+}
+
+// GetMPCPathPrefixes returns the path prefixes whose changes potentially affect MPC,
+// or DefaultMPCPathPrefixes if none are configured.
+func (c *Config) GetMPCPathPrefixes() []string {
+	if len(c.MPCPathPrefixes) == 0 {
+		return DefaultMPCPathPrefixes
+	}
+	return c.MPCPathPrefixes
+}
+
+// GetTimeouts returns the per-operation timeouts. Unset (zero) fields, e.g. in configs
+// constructed directly in tests, fall back to the values from DefaultTimeouts.
+func (c *Config) GetTimeouts() TimeoutConfig {
+	timeouts := c.Timeouts
+	defaults := DefaultTimeouts()
+	for _, t := range []struct {
+		field    *time.Duration
+		fallback time.Duration
+	}{
+		{&timeouts.Build, defaults.Build},
+		{&timeouts.Deploy, defaults.Deploy},
+		{&timeouts.Konflux, defaults.Konflux},
+		{&timeouts.KonfluxScript, defaults.KonfluxScript},
+		{&timeouts.MinimalStack, defaults.MinimalStack},
+		{&timeouts.Secrets, defaults.Secrets},
+		{&timeouts.TaskRun, defaults.TaskRun},
+		{&timeouts.GitCommand, defaults.GitCommand},
+		{&timeouts.ShutdownGrace, defaults.ShutdownGrace},
+	} {
+		if *t.field <= 0 {
+			*t.field = t.fallback
+		}
+	}
+	return timeouts
+}
+
+// GetBuildConcurrency returns how many images are built at once, falling back to
+// DefaultBuildConcurrency when unset.
+func (c *Config) GetBuildConcurrency() int {
+	if c.BuildConcurrency <= 0 {
+		return DefaultBuildConcurrency
+	}
+	return c.BuildConcurrency
+}
+
+// GetKubectlAttempts returns how often a deployment step's kubectl command is run when
+// it fails with a transient error, falling back to DefaultKubectlAttempts when unset.
+func (c *Config) GetKubectlAttempts() int {
+	if c.KubectlAttempts <= 0 {
+		return DefaultKubectlAttempts
+	}
+	return c.KubectlAttempts
+}
+
+// GetMinDiskSpaceGB returns the free disk space, in GB, below which the prerequisite
+// check warns, falling back to DefaultMinDiskSpaceGB when unset.
+func (c *Config) GetMinDiskSpaceGB() int {
+	if c.MinDiskSpaceGB <= 0 {
+		return DefaultMinDiskSpaceGB
+	}
+	return c.MinDiskSpaceGB
+}
+
+// GetMinMemoryGB returns the total memory, in GB, below which the prerequisite
+// check warns, falling back to DefaultMinMemoryGB when unset.
+func (c *Config) GetMinMemoryGB() int {
+	if c.MinMemoryGB <= 0 {
+		return DefaultMinMemoryGB
+	}
+	return c.MinMemoryGB
+}
+
+// GetCheckPorts returns the TCP ports the prerequisite check expects to be free,
+// falling back to DefaultCheckPorts if unset.
+func (c *Config) GetCheckPorts() []int {
+	if len(c.CheckPorts) == 0 {
+		return DefaultCheckPorts
+	}
+	return c.CheckPorts
+}
+
+// GetWatchConfig returns the hot-reload watcher settings. Unset fields, e.g. in configs
+// constructed directly in tests, fall back to the values from DefaultWatchConfig;
+// IncludePatterns stays empty, meaning every file is watched.
+func (c *Config) GetWatchConfig() WatchConfig {
+	watch := c.Watch
+	defaults := DefaultWatchConfig()
+	if watch.Debounce <= 0 {
+		watch.Debounce = defaults.Debounce
+	}
+	if len(watch.IgnoreExtensions) == 0 {
+		watch.IgnoreExtensions = defaults.IgnoreExtensions
+	}
+	if len(watch.IgnoreDirs) == 0 {
+		watch.IgnoreDirs = defaults.IgnoreDirs
+	}
+	return watch
+}
+
+// GetHostConfigSettings returns the settings of the generated host-config. Unset
+// fields, e.g. in configs constructed directly in tests, fall back to the values
+// from DefaultHostConfigSettings; the IBM images and workspace and TemplatePath stay
+// empty, the latter meaning the built-in template is used.
+func (c *Config) GetHostConfigSettings() HostConfigSettings {
+	settings := c.HostConfig
+	defaults := DefaultHostConfigSettings()
+	for _, s := range []struct {
+		field    *string
+		fallback string
+	}{
+		{&settings.Region, defaults.Region},
+		{&settings.ARM64AMI, defaults.ARM64AMI},
+		{&settings.AMD64AMI, defaults.AMD64AMI},
+		{&settings.ARM64InstanceType, defaults.ARM64InstanceType},
+		{&settings.AMD64InstanceType, defaults.AMD64InstanceType},
+		{&settings.IBMRegion, defaults.IBMRegion},
+		{&settings.IBMZone, defaults.IBMZone},
+		{&settings.IBMKey, defaults.IBMKey},
+	} {
+		if *s.field == "" {
+			*s.field = s.fallback
+		}
+	}
+	if settings.MaxInstances <= 0 {
+		settings.MaxInstances = defaults.MaxInstances
+	}
+	return settings
+}
+
+// GetDaemonToken returns the bearer token required by the daemon API, or an empty
+// string if authentication is disabled.
+func (c *Config) GetDaemonToken() string {
+	return c.DaemonToken
+}
+
+// GetWebhookURL returns the URL notified when an operation finishes, or an empty
+// string if notifications are disabled.
+func (c *Config) GetWebhookURL() string {
+	return c.WebhookURL
+}
+
+// GetKonfluxUIURL returns the URL of the Konflux UI, falling back to DefaultKonfluxUIURL
+// when the field is unset.
+func (c *Config) GetKonfluxUIURL() string {
+	if c.KonfluxUIURL == "" {
+		return DefaultKonfluxUIURL
+	}
+	return c.KonfluxUIURL
+}
+
+// GetKonfluxUsername returns the Konflux UI user, falling back to DefaultKonfluxUsername
+// when the field is unset.
+func (c *Config) GetKonfluxUsername() string {
+	if c.KonfluxUsername == "" {
+		return DefaultKonfluxUsername
+	}
+	return c.KonfluxUsername
+}
+
+// GetKonfluxCIPath returns the konflux-ci repository Konflux is deployed from: the
+// configured KonfluxCIPath, or the konflux-ci directory next to MpcDevEnvPath by default.
+func (c *Config) GetKonfluxCIPath() string {
+	if c.KonfluxCIPath != "" {
+		return c.KonfluxCIPath
+	}
+	return filepath.Join(filepath.Dir(c.MpcDevEnvPath), "konflux-ci")
+}
+
+// GetTaskRunsDir returns the directory TaskRun YAML files passed by path must be in:
+// the configured TaskRunsDir, or MpcDevEnvPath/taskruns by default.
+func (c *Config) GetTaskRunsDir() string {
+	if c.TaskRunsDir != "" {
+		return c.TaskRunsDir
+	}
+	return filepath.Join(c.MpcDevEnvPath, "taskruns")
+}
+
+// GetKindConfigPath returns the kind-config.yaml to use for cluster creation.
+// An explicitly configured path always wins. Otherwise MpcDevEnvPath/kind-config.yaml
+// is returned if it exists, and an empty string means kind's defaults should be used.
+func (c *Config) GetKindConfigPath() string {
+	if c.KindConfigPath != "" {
+		return c.KindConfigPath
+	}
+
+	defaultPath := filepath.Join(c.MpcDevEnvPath, "kind-config.yaml")
+	if _, err := os.Stat(defaultPath); err == nil {
+		return defaultPath
+	}
+	return ""
+}
+
+// GetTektonVersion returns the Tekton Pipelines release to install, falling back to
+// DefaultTektonVersion when the field is unset.
+func (c *Config) GetTektonVersion() string {
+	if c.TektonVersion == "" {
+		return DefaultTektonVersion
+	}
+	return c.TektonVersion
+}
+
+// GetCertManagerVersion returns the cert-manager release to install, falling back to
+// DefaultCertManagerVersion when the field is unset.
+func (c *Config) GetCertManagerVersion() string {
+	if c.CertManagerVersion == "" {
+		return DefaultCertManagerVersion
+	}
+	return c.CertManagerVersion
+}
+
+// GetKubeconfigPath returns the kubeconfig file of the managed clusters, falling back to
+// ~/.kube/config when none is configured.
+func (c *Config) GetKubeconfigPath() string {
+	if c.KubeconfigPath != "" {
+		return c.KubeconfigPath
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".kube", "config")
+	}
+	return filepath.Join(home, ".kube", "config")
+}
+
+// KubeconfigArgs returns the flags selecting the configured kubeconfig for a kubectl or
+// kind command, or none when no kubeconfig is configured, leaving the command its
+// default (~/.kube/config).
+func (c *Config) KubeconfigArgs() []string {
+	if c.KubeconfigPath == "" {
+		return nil
+	}
+	return []string{"--kubeconfig", c.KubeconfigPath}
+}
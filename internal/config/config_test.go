@@ -3,7 +3,9 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -35,6 +37,20 @@ var _ = Describe("Config", func() {
 		_ = os.Unsetenv("MPC_DEV_ENV_PATH")
 		_ = os.Unsetenv("MPC_REPO_PATH")
 		_ = os.Unsetenv("LOG_LEVEL")
+		_ = os.Unsetenv("MPC_LOG_LEVEL")
+		_ = os.Unsetenv("MPC_LOG_FORMAT")
+		_ = os.Unsetenv("MPC_CLUSTER_NAME")
+		_ = os.Unsetenv("MPC_KIND_CONFIG_PATH")
+		_ = os.Unsetenv("MPC_CONTROLLER_IMAGE")
+		_ = os.Unsetenv("MPC_GIT_SYNC_INTERVAL")
+		_ = os.Unsetenv("MPC_UPSTREAM_URLS")
+		_ = os.Unsetenv("MPC_DAEMON_TOKEN")
+		_ = os.Unsetenv("MPC_ALLOWED_HOSTS")
+		_ = os.Unsetenv("MPC_OTP_IMAGE")
+		_ = os.Unsetenv("MPC_BUILD_TIMEOUT")
+		_ = os.Unsetenv("MPC_KONFLUX_TIMEOUT")
+		_ = os.Unsetenv("MPC_WATCH_REDEPLOY")
+		_ = os.Unsetenv("MPC_CONTAINER_RUNTIME")
 	})
 
 	Describe("LoadConfig", func() {
@@ -46,7 +62,7 @@ var _ = Describe("Config", func() {
 			mpcRepoPath = filepath.Join(tempDir, "multi-platform-controller")
 
 			Expect(os.MkdirAll(mpcDevEnvPath, 0755)).To(Succeed())
-			Expect(os.MkdirAll(mpcRepoPath, 0755)).To(Succeed())
+			createMPCRepo(mpcRepoPath)
 		})
 
 		Context("with environment variables set", func() {
@@ -101,6 +117,46 @@ var _ = Describe("Config", func() {
 			})
 		})
 
+		Context("with a config file", func() {
+			writeConfigFile := func(content string) {
+				Expect(os.WriteFile(filepath.Join(mpcDevEnvPath, ConfigFileName), []byte(content), 0644)).To(Succeed())
+			}
+
+			BeforeEach(func() {
+				_ = os.Setenv("MPC_DEV_ENV_PATH", mpcDevEnvPath)
+			})
+
+			It("should use the file's values for unset env vars", func() {
+				writeConfigFile("MPC_REPO_PATH: " + mpcRepoPath + "\nMPC_CLUSTER_NAME: from-file\nMPC_BUILD_TIMEOUT: 20m\nMPC_WATCH_REDEPLOY: true\n")
+
+				cfg, err := LoadConfig()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.GetMpcRepoPath()).To(Equal(mpcRepoPath))
+				Expect(cfg.GetClusterName()).To(Equal("from-file"))
+				Expect(cfg.GetTimeouts().Build).To(Equal(20 * time.Minute))
+				Expect(cfg.GetWatchConfig().Redeploy).To(BeTrue())
+			})
+
+			It("should let env vars override the file", func() {
+				writeConfigFile("MPC_REPO_PATH: " + filepath.Join(tempDir, "non-existent") + "\nMPC_CLUSTER_NAME: from-file\n")
+				_ = os.Setenv("MPC_REPO_PATH", mpcRepoPath)
+				_ = os.Setenv("MPC_CLUSTER_NAME", "from-env")
+
+				cfg, err := LoadConfig()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.GetMpcRepoPath()).To(Equal(mpcRepoPath))
+				Expect(cfg.GetClusterName()).To(Equal("from-env"))
+			})
+
+			It("should fail on a malformed config file", func() {
+				writeConfigFile("MPC_CLUSTER_NAME: [unterminated\n")
+
+				_, err := LoadConfig()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("invalid config file"))
+			})
+		})
+
 		Context("with LOG_LEVEL set", func() {
 			It("should load the log level from environment", func() {
 				_ = os.Setenv("MPC_DEV_ENV_PATH", mpcDevEnvPath)
@@ -124,6 +180,1039 @@ var _ = Describe("Config", func() {
 				Expect(cfg.LogLevel).To(Equal("info"))
 			})
 		})
+
+		Context("with MPC_LOG_LEVEL and MPC_LOG_FORMAT set", func() {
+			BeforeEach(func() {
+				_ = os.Setenv("MPC_DEV_ENV_PATH", mpcDevEnvPath)
+				_ = os.Setenv("MPC_REPO_PATH", mpcRepoPath)
+			})
+
+			It("should prefer MPC_LOG_LEVEL over LOG_LEVEL", func() {
+				_ = os.Setenv("LOG_LEVEL", "debug")
+				_ = os.Setenv("MPC_LOG_LEVEL", "warn")
+
+				cfg, err := LoadConfig()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.LogLevel).To(Equal("warn"))
+			})
+
+			It("should load the log format from environment", func() {
+				_ = os.Setenv("MPC_LOG_FORMAT", "JSON")
+
+				cfg, err := LoadConfig()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.LogFormat).To(Equal(LogFormatJSON))
+			})
+
+			It("should default to the text format", func() {
+				cfg, err := LoadConfig()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.LogFormat).To(Equal(LogFormatText))
+			})
+
+			It("should reject an unknown log format", func() {
+				_ = os.Setenv("MPC_LOG_FORMAT", "xml")
+
+				_, err := LoadConfig()
+				Expect(err).To(MatchError(ContainSubstring("MPC_LOG_FORMAT")))
+			})
+		})
+
+		Context("with MPC_CONTAINER_RUNTIME set", func() {
+			BeforeEach(func() {
+				_ = os.Setenv("MPC_DEV_ENV_PATH", mpcDevEnvPath)
+				_ = os.Setenv("MPC_REPO_PATH", mpcRepoPath)
+			})
+
+			It("should load the container runtime from environment", func() {
+				_ = os.Setenv("MPC_CONTAINER_RUNTIME", "docker")
+
+				cfg, err := LoadConfig()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.GetContainerRuntime()).To(Equal(ContainerRuntimeDocker))
+			})
+
+			It("should reject an unknown container runtime", func() {
+				_ = os.Setenv("MPC_CONTAINER_RUNTIME", "containerd")
+
+				_, err := LoadConfig()
+				Expect(err).To(MatchError(ContainSubstring("MPC_CONTAINER_RUNTIME")))
+			})
+		})
+
+		Context("with MPC_CLUSTER_NAME set", func() {
+			It("should load the cluster name from environment", func() {
+				_ = os.Setenv("MPC_DEV_ENV_PATH", mpcDevEnvPath)
+				_ = os.Setenv("MPC_REPO_PATH", mpcRepoPath)
+				_ = os.Setenv("MPC_CLUSTER_NAME", "mpc-dev-2")
+
+				cfg, err := LoadConfig()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.GetClusterName()).To(Equal("mpc-dev-2"))
+			})
+		})
+
+		Context("without MPC_CLUSTER_NAME set", func() {
+			It("should default to konflux", func() {
+				_ = os.Setenv("MPC_DEV_ENV_PATH", mpcDevEnvPath)
+				_ = os.Setenv("MPC_REPO_PATH", mpcRepoPath)
+
+				cfg, err := LoadConfig()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.GetClusterName()).To(Equal(DefaultClusterName))
+			})
+		})
+
+		Context("with MPC_CONTROLLER_IMAGE and MPC_OTP_IMAGE set", func() {
+			It("should load the image references from environment", func() {
+				_ = os.Setenv("MPC_DEV_ENV_PATH", mpcDevEnvPath)
+				_ = os.Setenv("MPC_REPO_PATH", mpcRepoPath)
+				_ = os.Setenv("MPC_CONTROLLER_IMAGE", "quay.io/me/mpc:dev")
+				_ = os.Setenv("MPC_OTP_IMAGE", "quay.io/me/otp:dev")
+
+				cfg, err := LoadConfig()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.GetControllerImage()).To(Equal("quay.io/me/mpc:dev"))
+				Expect(cfg.GetOTPImage()).To(Equal("quay.io/me/otp:dev"))
+			})
+		})
+
+		Context("without image env vars set", func() {
+			It("should default to the local images", func() {
+				_ = os.Setenv("MPC_DEV_ENV_PATH", mpcDevEnvPath)
+				_ = os.Setenv("MPC_REPO_PATH", mpcRepoPath)
+
+				cfg, err := LoadConfig()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.GetControllerImage()).To(Equal("localhost/multi-platform-controller:latest"))
+				Expect(cfg.GetOTPImage()).To(Equal("localhost/multi-platform-otp:latest"))
+			})
+		})
+
+		Context("with MPC_GIT_SYNC_INTERVAL set", func() {
+			BeforeEach(func() {
+				_ = os.Setenv("MPC_DEV_ENV_PATH", mpcDevEnvPath)
+				_ = os.Setenv("MPC_REPO_PATH", mpcRepoPath)
+			})
+
+			It("should load the interval from environment", func() {
+				_ = os.Setenv("MPC_GIT_SYNC_INTERVAL", "15m")
+
+				cfg, err := LoadConfig()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.GetGitSyncInterval()).To(Equal(15 * time.Minute))
+			})
+
+			It("should reject an invalid interval", func() {
+				_ = os.Setenv("MPC_GIT_SYNC_INTERVAL", "30s")
+
+				_, err := LoadConfig()
+				Expect(err).To(MatchError(ContainSubstring("MPC_GIT_SYNC_INTERVAL")))
+			})
+		})
+
+		Context("without MPC_GIT_SYNC_INTERVAL set", func() {
+			It("should default to 60 minutes", func() {
+				_ = os.Setenv("MPC_DEV_ENV_PATH", mpcDevEnvPath)
+				_ = os.Setenv("MPC_REPO_PATH", mpcRepoPath)
+
+				cfg, err := LoadConfig()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.GetGitSyncInterval()).To(Equal(60 * time.Minute))
+			})
+		})
+
+		Context("with MPC_DAEMON_TOKEN", func() {
+			BeforeEach(func() {
+				_ = os.Setenv("MPC_DEV_ENV_PATH", mpcDevEnvPath)
+				_ = os.Setenv("MPC_REPO_PATH", mpcRepoPath)
+			})
+
+			It("should load the token from environment", func() {
+				_ = os.Setenv("MPC_DAEMON_TOKEN", "s3cret")
+
+				cfg, err := LoadConfig()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.GetDaemonToken()).To(Equal("s3cret"))
+			})
+
+			It("should leave auth disabled when unset", func() {
+				cfg, err := LoadConfig()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.GetDaemonToken()).To(BeEmpty())
+			})
+		})
+
+		Context("with MPC_*_TIMEOUT set", func() {
+			BeforeEach(func() {
+				_ = os.Setenv("MPC_DEV_ENV_PATH", mpcDevEnvPath)
+				_ = os.Setenv("MPC_REPO_PATH", mpcRepoPath)
+			})
+
+			It("should override the default timeouts", func() {
+				_ = os.Setenv("MPC_KONFLUX_TIMEOUT", "45m")
+
+				cfg, err := LoadConfig()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.GetTimeouts().Konflux).To(Equal(45 * time.Minute))
+				Expect(cfg.GetTimeouts().Build).To(Equal(DefaultBuildTimeout))
+				Expect(cfg.Warnings).To(BeEmpty())
+			})
+
+			It("should fall back to the default and warn for an invalid timeout", func() {
+				_ = os.Setenv("MPC_BUILD_TIMEOUT", "soon")
+
+				cfg, err := LoadConfig()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.GetTimeouts().Build).To(Equal(DefaultBuildTimeout))
+				Expect(cfg.Warnings).To(ConsistOf(ContainSubstring("MPC_BUILD_TIMEOUT")))
+			})
+		})
+	})
+
+	Describe("LoadConfigFile", func() {
+		It("should return no values when the file does not exist", func() {
+			values, err := LoadConfigFile(filepath.Join(tempDir, ConfigFileName))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(BeEmpty())
+		})
+
+		It("should read every value as a string", func() {
+			path := filepath.Join(tempDir, ConfigFileName)
+			Expect(os.WriteFile(path, []byte("MPC_WATCH_REDEPLOY: true\nMPC_MIN_DISK_GB: 30\nMPC_WATCH_INCLUDE: '*.go'\n"), 0644)).To(Succeed())
+
+			values, err := LoadConfigFile(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal(map[string]string{
+				"MPC_WATCH_REDEPLOY": "true",
+				"MPC_MIN_DISK_GB":    "30",
+				"MPC_WATCH_INCLUDE":  "*.go",
+			}))
+		})
+	})
+
+	Describe("EnvWithFile", func() {
+		It("should prefer set env vars and fall back to the file for unset ones", func() {
+			env := map[string]string{"MPC_CLUSTER_NAME": "from-env", "MPC_LOG_LEVEL": ""}
+			getenv := EnvWithFile(func(key string) string { return env[key] }, map[string]string{
+				"MPC_CLUSTER_NAME": "from-file",
+				"MPC_LOG_LEVEL":    "debug",
+			})
+
+			Expect(getenv("MPC_CLUSTER_NAME")).To(Equal("from-env"))
+			Expect(getenv("MPC_LOG_LEVEL")).To(Equal("debug"))
+			Expect(getenv("MPC_LOG_FORMAT")).To(BeEmpty())
+		})
+	})
+
+	Describe("ParseContainerRuntime", func() {
+		It("should leave the runtime to detection when empty", func() {
+			Expect(ParseContainerRuntime("")).To(BeEmpty())
+		})
+
+		It("should accept docker and podman case-insensitively", func() {
+			Expect(ParseContainerRuntime(" Docker ")).To(Equal(ContainerRuntimeDocker))
+			Expect(ParseContainerRuntime("podman")).To(Equal(ContainerRuntimePodman))
+		})
+
+		It("should reject other runtimes", func() {
+			_, err := ParseContainerRuntime("containerd")
+			Expect(err).To(MatchError(ContainSubstring(`invalid MPC_CONTAINER_RUNTIME "containerd"`)))
+		})
+	})
+
+	Describe("ParseReleaseVersion", func() {
+		It("should leave an empty version to the default", func() {
+			Expect(ParseReleaseVersion("MPC_TEKTON_VERSION", "")).To(BeEmpty())
+			Expect((&Config{}).GetTektonVersion()).To(Equal(DefaultTektonVersion))
+			Expect((&Config{}).GetCertManagerVersion()).To(Equal(DefaultCertManagerVersion))
+		})
+
+		It("should accept versions with or without the v, and latest", func() {
+			Expect(ParseReleaseVersion("MPC_TEKTON_VERSION", " v0.65.2 ")).To(Equal("v0.65.2"))
+			Expect(ParseReleaseVersion("MPC_CERT_MANAGER_VERSION", "1.17.1")).To(Equal("v1.17.1"))
+			Expect(ParseReleaseVersion("MPC_TEKTON_VERSION", "latest")).To(Equal(ReleaseLatest))
+		})
+
+		It("should reject anything else", func() {
+			_, err := ParseReleaseVersion("MPC_CERT_MANAGER_VERSION", "1.17")
+			Expect(err).To(MatchError(ContainSubstring(`invalid MPC_CERT_MANAGER_VERSION "1.17"`)))
+		})
+	})
+
+	Describe("ParseGitSyncStrategy", func() {
+		It("should default to rebasing", func() {
+			Expect(ParseGitSyncStrategy("")).To(Equal(GitSyncStrategyRebase))
+			Expect((&Config{}).GetGitSyncStrategy()).To(Equal(GitSyncStrategyRebase))
+		})
+
+		It("should accept every strategy case-insensitively", func() {
+			Expect(ParseGitSyncStrategy(" FF-Only ")).To(Equal(GitSyncStrategyFFOnly))
+			Expect(ParseGitSyncStrategy("rebase")).To(Equal(GitSyncStrategyRebase))
+			Expect(ParseGitSyncStrategy("reset")).To(Equal(GitSyncStrategyReset))
+		})
+
+		It("should reject other strategies", func() {
+			_, err := ParseGitSyncStrategy("merge")
+			Expect(err).To(MatchError(ContainSubstring(`invalid MPC_GIT_SYNC_STRATEGY "merge"`)))
+		})
+	})
+
+	Describe("ParseDeployBackend", func() {
+		It("should default to kubectl", func() {
+			Expect(ParseDeployBackend("")).To(Equal(DeployBackendKubectl))
+			Expect((&Config{}).GetDeployBackend()).To(Equal(DeployBackendKubectl))
+		})
+
+		It("should accept every backend case-insensitively", func() {
+			Expect(ParseDeployBackend(" Client ")).To(Equal(DeployBackendClient))
+			Expect(ParseDeployBackend("kubectl")).To(Equal(DeployBackendKubectl))
+		})
+
+		It("should reject other backends", func() {
+			_, err := ParseDeployBackend("helm")
+			Expect(err).To(MatchError(ContainSubstring(`invalid MPC_DEPLOY_BACKEND "helm"`)))
+		})
+	})
+
+	Describe("ParseGitSyncBackup", func() {
+		It("should back up by default", func() {
+			Expect(ParseGitSyncBackup("")).To(BeTrue())
+			Expect((&Config{}).GetGitSyncBackup()).To(BeTrue())
+		})
+
+		It("should turn backups off", func() {
+			Expect(ParseGitSyncBackup("false")).To(BeFalse())
+			Expect((&Config{DisableGitSyncBackup: true}).GetGitSyncBackup()).To(BeFalse())
+		})
+
+		It("should reject values that are not booleans", func() {
+			_, err := ParseGitSyncBackup("sometimes")
+			Expect(err).To(MatchError(ContainSubstring(`invalid MPC_GIT_SYNC_BACKUP "sometimes"`)))
+		})
+	})
+
+	Describe("ParseTimeouts", func() {
+		env := func(values map[string]string) func(string) string {
+			return func(key string) string { return values[key] }
+		}
+
+		It("should return the defaults when nothing is set", func() {
+			timeouts, warnings := ParseTimeouts(env(nil))
+			Expect(timeouts).To(Equal(DefaultTimeouts()))
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("should read every operation's timeout", func() {
+			timeouts, warnings := ParseTimeouts(env(map[string]string{
+				"MPC_BUILD_TIMEOUT":          "20m",
+				"MPC_DEPLOY_TIMEOUT":         "25m",
+				"MPC_KONFLUX_TIMEOUT":        "1h",
+				"MPC_MINIMAL_STACK_TIMEOUT":  " 7m ",
+				"MPC_SECRETS_TIMEOUT":        "90s",
+				"MPC_TASKRUN_TIMEOUT":        "2h",
+				"MPC_GIT_COMMAND_TIMEOUT":    "2m",
+				"MPC_KONFLUX_SCRIPT_TIMEOUT": "25m",
+				"MPC_SHUTDOWN_GRACE_PERIOD":  "1m",
+			}))
+			Expect(warnings).To(BeEmpty())
+			Expect(timeouts).To(Equal(TimeoutConfig{
+				Build:         20 * time.Minute,
+				Deploy:        25 * time.Minute,
+				Konflux:       time.Hour,
+				MinimalStack:  7 * time.Minute,
+				Secrets:       90 * time.Second,
+				TaskRun:       2 * time.Hour,
+				GitCommand:    2 * time.Minute,
+				KonfluxScript: 25 * time.Minute,
+				ShutdownGrace: time.Minute,
+			}))
+		})
+
+		It("should fall back to the default for invalid and non-positive values", func() {
+			timeouts, warnings := ParseTimeouts(env(map[string]string{
+				"MPC_BUILD_TIMEOUT":   "fifteen minutes",
+				"MPC_DEPLOY_TIMEOUT":  "0",
+				"MPC_SECRETS_TIMEOUT": "-5m",
+				"MPC_TASKRUN_TIMEOUT": "45m",
+			}))
+			Expect(timeouts.Build).To(Equal(DefaultBuildTimeout))
+			Expect(timeouts.Deploy).To(Equal(DefaultDeployTimeout))
+			Expect(timeouts.Secrets).To(Equal(DefaultSecretsTimeout))
+			Expect(timeouts.TaskRun).To(Equal(45 * time.Minute))
+			Expect(warnings).To(ConsistOf(
+				ContainSubstring("MPC_BUILD_TIMEOUT"),
+				ContainSubstring("MPC_DEPLOY_TIMEOUT"),
+				ContainSubstring("MPC_SECRETS_TIMEOUT"),
+			))
+		})
+	})
+
+	Describe("ParseWatchConfig", func() {
+		env := func(values map[string]string) func(string) string {
+			return func(key string) string { return values[key] }
+		}
+
+		It("should return the defaults when nothing is set", func() {
+			watch, warnings := ParseWatchConfig(env(nil))
+			Expect(watch).To(Equal(DefaultWatchConfig()))
+			Expect(watch.IncludePatterns).To(BeEmpty())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("should read every setting", func() {
+			watch, warnings := ParseWatchConfig(env(map[string]string{
+				"MPC_WATCH_DEBOUNCE":          "500ms",
+				"MPC_WATCH_IGNORE_EXTENSIONS": ".swp, md,,.yaml",
+				"MPC_WATCH_IGNORE_DIRS":       ".git,vendor",
+				"MPC_WATCH_INCLUDE":           "*.go, Dockerfile*",
+				"MPC_WATCH_REDEPLOY":          "true",
+			}))
+			Expect(warnings).To(BeEmpty())
+			Expect(watch).To(Equal(WatchConfig{
+				Debounce:         500 * time.Millisecond,
+				IgnoreExtensions: []string{".swp", ".md", ".yaml"},
+				IgnoreDirs:       []string{".git", "vendor"},
+				IncludePatterns:  []string{"*.go", "Dockerfile*"},
+				Redeploy:         true,
+			}))
+		})
+
+		It("should fall back to the default debounce and drop invalid globs with warnings", func() {
+			watch, warnings := ParseWatchConfig(env(map[string]string{
+				"MPC_WATCH_DEBOUNCE": "-1s",
+				"MPC_WATCH_INCLUDE":  "*.go,[",
+				"MPC_WATCH_REDEPLOY": "sometimes",
+			}))
+			Expect(watch.Debounce).To(Equal(DefaultWatchDebounce))
+			Expect(watch.IncludePatterns).To(Equal([]string{"*.go"}))
+			Expect(watch.Redeploy).To(BeFalse())
+			Expect(warnings).To(ConsistOf(
+				ContainSubstring("MPC_WATCH_DEBOUNCE"),
+				ContainSubstring("MPC_WATCH_INCLUDE"),
+				ContainSubstring("MPC_WATCH_REDEPLOY"),
+			))
+		})
+	})
+
+	Describe("GetWatchConfig", func() {
+		It("should fall back to the defaults for unset fields", func() {
+			cfg := &Config{Watch: WatchConfig{IncludePatterns: []string{"*.go"}}}
+			expected := DefaultWatchConfig()
+			expected.IncludePatterns = []string{"*.go"}
+			Expect(cfg.GetWatchConfig()).To(Equal(expected))
+		})
+	})
+
+	Describe("ParseHostConfigSettings", func() {
+		env := func(values map[string]string) func(string) string {
+			return func(key string) string { return values[key] }
+		}
+
+		It("should return the defaults when nothing is set", func() {
+			settings, warnings := ParseHostConfigSettings(env(nil))
+			Expect(settings).To(Equal(DefaultHostConfigSettings()))
+			Expect(warnings).To(BeEmpty())
+			Expect((&Config{}).GetHostConfigSettings()).To(Equal(DefaultHostConfigSettings()))
+		})
+
+		It("should read every setting", func() {
+			settings, warnings := ParseHostConfigSettings(env(map[string]string{
+				"MPC_HOST_CONFIG_REGION":              "eu-west-1",
+				"MPC_HOST_CONFIG_ARM64_AMI":           "ami-arm",
+				"MPC_HOST_CONFIG_AMD64_AMI":           "ami-amd",
+				"MPC_HOST_CONFIG_ARM64_INSTANCE_TYPE": "m7g.large",
+				"MPC_HOST_CONFIG_AMD64_INSTANCE_TYPE": "m7a.large",
+				"MPC_HOST_CONFIG_MAX_INSTANCES":       " 3 ",
+				"MPC_HOST_CONFIG_IBM":                 "true",
+				"MPC_HOST_CONFIG_IBM_REGION":          "eu-de",
+				"MPC_HOST_CONFIG_IBM_ZONE":            "eu-de-1",
+				"MPC_HOST_CONFIG_IBM_KEY":             "my-key",
+				"MPC_HOST_CONFIG_IBM_S390X_IMAGE":     "r010-s390x",
+				"MPC_HOST_CONFIG_IBM_PPC64LE_IMAGE":   "rhel-ppc64le",
+				"MPC_HOST_CONFIG_IBM_POWER_WORKSPACE": "crn:v1:workspace",
+				"MPC_HOST_CONFIG_TEMPLATE":            "/tmp/host-config.tmpl",
+			}))
+			Expect(warnings).To(BeEmpty())
+			Expect(settings).To(Equal(HostConfigSettings{
+				Region:            "eu-west-1",
+				ARM64AMI:          "ami-arm",
+				AMD64AMI:          "ami-amd",
+				ARM64InstanceType: "m7g.large",
+				AMD64InstanceType: "m7a.large",
+				MaxInstances:      3,
+				IBMEnabled:        true,
+				IBMRegion:         "eu-de",
+				IBMZone:           "eu-de-1",
+				IBMKey:            "my-key",
+				IBMS390XImage:     "r010-s390x",
+				IBMPPC64LEImage:   "rhel-ppc64le",
+				IBMPowerWorkspace: "crn:v1:workspace",
+				TemplatePath:      "/tmp/host-config.tmpl",
+			}))
+		})
+
+		It("should fall back and warn for an invalid max-instances", func() {
+			for _, value := range []string{"many", "0"} {
+				settings, warnings := ParseHostConfigSettings(env(map[string]string{"MPC_HOST_CONFIG_MAX_INSTANCES": value}))
+				Expect(settings.MaxInstances).To(Equal(DefaultHostConfigMaxInstances))
+				Expect(warnings).To(ConsistOf(ContainSubstring("MPC_HOST_CONFIG_MAX_INSTANCES")))
+			}
+		})
+
+		It("should warn about each IBM platform MPC_HOST_CONFIG_IBM enables without its images and workspace", func() {
+			settings, warnings := ParseHostConfigSettings(env(map[string]string{
+				"MPC_HOST_CONFIG_IBM":               "true",
+				"MPC_HOST_CONFIG_IBM_PPC64LE_IMAGE": "rhel-ppc64le",
+			}))
+			Expect(settings.IBMS390XImage).To(BeEmpty())
+			Expect(settings.IBMPowerWorkspace).To(BeEmpty())
+			Expect(settings.IBMS390XEnabled()).To(BeFalse())
+			Expect(settings.IBMPPC64LEEnabled()).To(BeFalse())
+			Expect(warnings).To(ConsistOf(
+				"leaving out the linux-ibm/s390x platform: MPC_HOST_CONFIG_IBM_S390X_IMAGE is not set",
+				"leaving out the linux-ibm/ppc64le platform: MPC_HOST_CONFIG_IBM_POWER_WORKSPACE is not set",
+			))
+		})
+
+		It("should not warn about the IBM images and workspace while IBM is disabled", func() {
+			_, warnings := ParseHostConfigSettings(env(map[string]string{"MPC_HOST_CONFIG_IBM": "false"}))
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("should leave out the IBM platforms and warn for an invalid MPC_HOST_CONFIG_IBM", func() {
+			settings, warnings := ParseHostConfigSettings(env(map[string]string{"MPC_HOST_CONFIG_IBM": "sure"}))
+			Expect(settings.IBMEnabled).To(BeFalse())
+			Expect(warnings).To(ConsistOf(ContainSubstring("MPC_HOST_CONFIG_IBM")))
+		})
+	})
+
+	Describe("ParseProfiles", func() {
+		env := func(values map[string]string) func(string) string {
+			return func(key string) string { return values[key] }
+		}
+
+		It("should return no profiles when MPC_PROFILES is unset", func() {
+			profiles, err := ParseProfiles(env(nil))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(profiles).To(BeEmpty())
+		})
+
+		It("should read each profile's settings", func() {
+			profiles, err := ParseProfiles(env(map[string]string{
+				"MPC_PROFILES":                       "stable, exp-2",
+				"MPC_PROFILE_STABLE_CLUSTER_NAME":    "konflux-stable",
+				"MPC_PROFILE_STABLE_NAMESPACE":       "mpc-stable",
+				"MPC_PROFILE_EXP_2_REPO_PATH":        "/src/mpc-experimental",
+				"MPC_PROFILE_EXPERIMENTAL_NAMESPACE": "ignored",
+			}))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(profiles).To(Equal(map[string]Profile{
+				"stable": {ClusterName: "konflux-stable", Namespace: "mpc-stable"},
+				"exp-2":  {MpcRepoPath: "/src/mpc-experimental"},
+			}))
+		})
+
+		It("should reject invalid and duplicate names", func() {
+			_, err := ParseProfiles(env(map[string]string{"MPC_PROFILES": "Stable"}))
+			Expect(err).To(MatchError(ContainSubstring(`invalid profile name "Stable"`)))
+
+			_, err = ParseProfiles(env(map[string]string{"MPC_PROFILES": "stable,stable"}))
+			Expect(err).To(MatchError(ContainSubstring(`duplicate profile "stable"`)))
+		})
+	})
+
+	Describe("ForProfile", func() {
+		var cfg *Config
+
+		BeforeEach(func() {
+			cfg = &Config{
+				ClusterName: "konflux",
+				MpcRepoPath: "/src/multi-platform-controller",
+				Profiles: map[string]Profile{
+					"stable":       {ClusterName: "konflux-stable", Namespace: "mpc-stable"},
+					"experimental": {MpcRepoPath: "/src/mpc-experimental"},
+				},
+			}
+		})
+
+		It("should return the configuration itself without a profile", func() {
+			profileCfg, err := cfg.ForProfile("")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(profileCfg).To(BeIdenticalTo(cfg))
+		})
+
+		It("should select the profile's cluster, repository and namespace", func() {
+			profileCfg, err := cfg.ForProfile("stable")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(profileCfg.Profile).To(Equal("stable"))
+			Expect(profileCfg.GetClusterName()).To(Equal("konflux-stable"))
+			Expect(profileCfg.GetMpcRepoPath()).To(Equal("/src/multi-platform-controller"))
+			Expect(profileCfg.TaskRunNamespace).To(Equal("mpc-stable"))
+
+			profileCfg, err = cfg.ForProfile("experimental")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(profileCfg.GetClusterName()).To(Equal("experimental"))
+			Expect(profileCfg.GetMpcRepoPath()).To(Equal("/src/mpc-experimental"))
+			Expect(profileCfg.TaskRunNamespace).To(BeEmpty())
+
+			Expect(cfg.GetClusterName()).To(Equal("konflux"), "the default configuration must be left unchanged")
+		})
+
+		It("should pin each environment to its own kubeconfig context", func() {
+			Expect(cfg.GetKubeContext()).To(Equal("kind-konflux"))
+
+			profileCfg, err := cfg.ForProfile("stable")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(profileCfg.GetKubeContext()).To(Equal("kind-konflux-stable"))
+		})
+
+		It("should reject an unknown profile", func() {
+			_, err := cfg.ForProfile("missing")
+			Expect(err).To(MatchError(ErrUnknownProfile))
+		})
+
+		It("should list the profile names sorted", func() {
+			Expect(cfg.GetProfileNames()).To(Equal([]string{"experimental", "stable"}))
+		})
+	})
+
+	Describe("ParseCheckPorts", func() {
+		It("should parse comma-separated ports", func() {
+			ports, err := ParseCheckPorts("8765, 9443,,8443")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ports).To(Equal([]int{8765, 9443, 8443}))
+		})
+
+		It("should reject values that are not TCP ports", func() {
+			for _, value := range []string{"http", "0", "65536"} {
+				_, err := ParseCheckPorts(value)
+				Expect(err).To(MatchError(ContainSubstring("MPC_CHECK_PORTS")))
+			}
+		})
+
+		It("should fall back to the defaults when unset", func() {
+			ports, err := ParseCheckPorts("")
+			Expect(err).NotTo(HaveOccurred())
+			Expect((&Config{CheckPorts: ports}).GetCheckPorts()).To(Equal(DefaultCheckPorts))
+		})
+	})
+
+	Describe("ParseBuildConcurrency", func() {
+		It("should parse a positive number of builds", func() {
+			concurrency, warning := ParseBuildConcurrency(" 1 ")
+			Expect(concurrency).To(Equal(1))
+			Expect(warning).To(BeEmpty())
+		})
+
+		It("should default to building both images at once", func() {
+			concurrency, warning := ParseBuildConcurrency("")
+			Expect(concurrency).To(Equal(DefaultBuildConcurrency))
+			Expect(warning).To(BeEmpty())
+			Expect((&Config{}).GetBuildConcurrency()).To(Equal(DefaultBuildConcurrency))
+		})
+
+		It("should fall back and warn for invalid values", func() {
+			for _, value := range []string{"two", "0", "-1"} {
+				concurrency, warning := ParseBuildConcurrency(value)
+				Expect(concurrency).To(Equal(DefaultBuildConcurrency))
+				Expect(warning).To(ContainSubstring("MPC_BUILD_CONCURRENCY"))
+			}
+		})
+	})
+
+	Describe("ParseKubectlAttempts", func() {
+		It("should parse a positive number of attempts", func() {
+			attempts, warning := ParseKubectlAttempts(" 5 ")
+			Expect(attempts).To(Equal(5))
+			Expect(warning).To(BeEmpty())
+			Expect((&Config{KubectlAttempts: attempts}).GetKubectlAttempts()).To(Equal(5))
+		})
+
+		It("should default to DefaultKubectlAttempts", func() {
+			attempts, warning := ParseKubectlAttempts("")
+			Expect(attempts).To(Equal(DefaultKubectlAttempts))
+			Expect(warning).To(BeEmpty())
+			Expect((&Config{}).GetKubectlAttempts()).To(Equal(DefaultKubectlAttempts))
+		})
+
+		It("should fall back and warn for invalid values", func() {
+			for _, value := range []string{"three", "0", "-2"} {
+				attempts, warning := ParseKubectlAttempts(value)
+				Expect(attempts).To(Equal(DefaultKubectlAttempts))
+				Expect(warning).To(ContainSubstring("MPC_KUBECTL_ATTEMPTS"))
+			}
+		})
+	})
+
+	Describe("ParseMinimumGB", func() {
+		It("should parse a whole number of GB", func() {
+			minimum, warning := ParseMinimumGB("MPC_MIN_DISK_GB", " 40 ", DefaultMinDiskSpaceGB)
+			Expect(minimum).To(Equal(40))
+			Expect(warning).To(BeEmpty())
+		})
+
+		It("should use the fallback when unset", func() {
+			minimum, warning := ParseMinimumGB("MPC_MIN_DISK_GB", "", DefaultMinDiskSpaceGB)
+			Expect(minimum).To(Equal(DefaultMinDiskSpaceGB))
+			Expect(warning).To(BeEmpty())
+		})
+
+		It("should fall back and warn for invalid values", func() {
+			for _, value := range []string{"8GB", "0", "-1"} {
+				minimum, warning := ParseMinimumGB("MPC_MIN_MEMORY_GB", value, DefaultMinMemoryGB)
+				Expect(minimum).To(Equal(DefaultMinMemoryGB))
+				Expect(warning).To(ContainSubstring("MPC_MIN_MEMORY_GB"))
+			}
+		})
+	})
+
+	Describe("GetTimeouts", func() {
+		It("should fall back to the defaults for unset fields", func() {
+			cfg := &Config{Timeouts: TimeoutConfig{Konflux: time.Hour}}
+			expected := DefaultTimeouts()
+			expected.Konflux = time.Hour
+			Expect(cfg.GetTimeouts()).To(Equal(expected))
+		})
+	})
+
+	Describe("ParseUpstreamURLs", func() {
+		It("should parse comma-separated name=url pairs", func() {
+			urls, err := ParseUpstreamURLs("multi-platform-controller=https://github.com/me/mpc.git, konflux-ci = /src/konflux-ci.git")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(urls).To(Equal(map[string]string{
+				"multi-platform-controller": "https://github.com/me/mpc.git",
+				"konflux-ci":                "/src/konflux-ci.git",
+			}))
+		})
+
+		It("should return an empty map for an empty value", func() {
+			urls, err := ParseUpstreamURLs("")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(urls).To(BeEmpty())
+		})
+
+		It("should reject entries without a name or url", func() {
+			_, err := ParseUpstreamURLs("multi-platform-controller")
+			Expect(err).To(MatchError(ContainSubstring("expected name=url")))
+
+			_, err = ParseUpstreamURLs("=https://github.com/me/mpc.git")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("ParseRegistryURL", func() {
+		It("should accept a registry host with a port and path", func() {
+			registry, err := ParseRegistryURL(" localhost:5001/dev/ ")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(registry).To(Equal("localhost:5001/dev"))
+		})
+
+		It("should reject a URL scheme", func() {
+			_, err := ParseRegistryURL("http://localhost:5001")
+			Expect(err).To(MatchError(ContainSubstring("without a scheme")))
+		})
+	})
+
+	Describe("ParseWebhookURL", func() {
+		It("should accept an empty value and an http or https URL", func() {
+			webhookURL, err := ParseWebhookURL("")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(webhookURL).To(BeEmpty())
+
+			webhookURL, err = ParseWebhookURL(" https://hooks.example.com/mpc ")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(webhookURL).To(Equal("https://hooks.example.com/mpc"))
+		})
+
+		It("should reject a URL without an http scheme or host", func() {
+			_, err := ParseWebhookURL("hooks.example.com/mpc")
+			Expect(err).To(MatchError(ContainSubstring("expected an http or https URL")))
+
+			_, err = ParseWebhookURL("ftp://hooks.example.com")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("ParseKonfluxUIURL", func() {
+		It("should default an empty value and accept an http or https URL", func() {
+			uiURL, err := ParseKonfluxUIURL("")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(uiURL).To(Equal(DefaultKonfluxUIURL))
+
+			uiURL, err = ParseKonfluxUIURL(" https://konflux.example.com:8443 ")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(uiURL).To(Equal("https://konflux.example.com:8443"))
+		})
+
+		It("should reject a URL without an http scheme or host", func() {
+			_, err := ParseKonfluxUIURL("localhost:9443")
+			Expect(err).To(MatchError(ContainSubstring("invalid MPC_KONFLUX_UI_URL")))
+		})
+
+		It("should fall back to the defaults for unset fields", func() {
+			cfg := &Config{}
+			Expect(cfg.GetKonfluxUIURL()).To(Equal(DefaultKonfluxUIURL))
+			Expect(cfg.GetKonfluxUsername()).To(Equal(DefaultKonfluxUsername))
+		})
+	})
+
+	Describe("RegistryImage", func() {
+		It("should move the image into the registry", func() {
+			Expect(RegistryImage("localhost:5001", DefaultControllerImage)).To(Equal("localhost:5001/multi-platform-controller:latest"))
+			Expect(RegistryImage("localhost:5001", "quay.io/me/multi-platform-otp:dev")).To(Equal("localhost:5001/multi-platform-otp:dev"))
+			Expect(RegistryImage("", DefaultOTPImage)).To(Equal(DefaultOTPImage))
+		})
+
+		It("should apply to the configured image references", func() {
+			cfg := &Config{RegistryURL: "localhost:5001", OTPImage: "quay.io/me/multi-platform-otp:dev"}
+			Expect(cfg.GetControllerImage()).To(Equal("localhost:5001/multi-platform-controller:latest"))
+			Expect(cfg.GetOTPImage()).To(Equal("localhost:5001/multi-platform-otp:dev"))
+		})
+	})
+
+	Describe("ParseBuildArgs", func() {
+		It("should parse comma-separated KEY=VALUE pairs, splitting at the first =", func() {
+			args, err := ParseBuildArgs("GOFLAGS=-mod=mod, HTTPS_PROXY = http://proxy:3128,EMPTY=")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(args).To(Equal(map[string]string{
+				"GOFLAGS":     "-mod=mod",
+				"HTTPS_PROXY": "http://proxy:3128",
+				"EMPTY":       "",
+			}))
+		})
+
+		It("should return an empty map for an empty value", func() {
+			args, err := ParseBuildArgs("")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(args).To(BeEmpty())
+		})
+
+		It("should reject entries without a key", func() {
+			_, err := ParseBuildArgs("GOFLAGS")
+			Expect(err).To(MatchError(ContainSubstring("expected KEY=VALUE")))
+
+			_, err = ParseBuildArgs("=value")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("ParseAllowedHosts", func() {
+		It("should parse and lower-case comma-separated host names", func() {
+			Expect(ParseAllowedHosts("localhost, DevBox.local,,127.0.0.1")).To(Equal([]string{"localhost", "devbox.local", "127.0.0.1"}))
+		})
+
+		It("should return nil for an empty value", func() {
+			Expect(ParseAllowedHosts("")).To(BeNil())
+		})
+	})
+
+	Describe("GetAllowedHosts", func() {
+		It("should fall back to the defaults when unset", func() {
+			Expect((&Config{}).GetAllowedHosts()).To(Equal(DefaultAllowedHosts))
+		})
+
+		It("should return the configured hosts", func() {
+			cfg := &Config{AllowedHosts: []string{"devbox.local"}}
+			Expect(cfg.GetAllowedHosts()).To(Equal([]string{"devbox.local"}))
+		})
+	})
+
+	DescribeTable("ParseGitSyncInterval",
+		func(value string, expected time.Duration, expectErr bool) {
+			interval, err := ParseGitSyncInterval(value)
+			if expectErr {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(interval).To(Equal(expected))
+		},
+		Entry("empty uses the default", "", DefaultGitSyncInterval, false),
+		Entry("a valid duration", "2h", 2*time.Hour, false),
+		Entry("exactly the minimum", "1m", time.Minute, false),
+		Entry("0 disables the sync", "0", time.Duration(0), false),
+		Entry("off disables the sync", "off", time.Duration(0), false),
+		Entry("a zero duration disables the sync", "0s", time.Duration(0), false),
+		Entry("below the minimum", "59s", time.Duration(0), true),
+		Entry("a negative duration", "-5m", time.Duration(0), true),
+		Entry("not a duration", "hourly", time.Duration(0), true),
+	)
+
+	Describe("GetKindConfigPath", func() {
+		It("should prefer an explicitly configured path", func() {
+			cfg := &Config{MpcDevEnvPath: tempDir, KindConfigPath: "/custom/kind-config.yaml"}
+			Expect(cfg.GetKindConfigPath()).To(Equal("/custom/kind-config.yaml"))
+		})
+
+		It("should use kind-config.yaml from MpcDevEnvPath when it exists", func() {
+			defaultPath := filepath.Join(tempDir, "kind-config.yaml")
+			Expect(os.WriteFile(defaultPath, []byte("kind: Cluster\n"), 0644)).To(Succeed())
+
+			cfg := &Config{MpcDevEnvPath: tempDir}
+			Expect(cfg.GetKindConfigPath()).To(Equal(defaultPath))
+		})
+
+		It("should return an empty string when no kind config exists", func() {
+			cfg := &Config{MpcDevEnvPath: tempDir}
+			Expect(cfg.GetKindConfigPath()).To(BeEmpty())
+		})
+	})
+
+	Describe("kubeconfig", func() {
+		It("should prefer MPC_KUBECONFIG over KUBECONFIG", func() {
+			Expect(ParseKubeconfigPath(" /ci/kubeconfig ", "/home/me/.kube/other")).To(Equal("/ci/kubeconfig"))
+		})
+
+		It("should use the first file KUBECONFIG lists", func() {
+			list := strings.Join([]string{"/home/me/.kube/kind", "/home/me/.kube/config"}, string(filepath.ListSeparator))
+			Expect(ParseKubeconfigPath("", list)).To(Equal("/home/me/.kube/kind"))
+			Expect(ParseKubeconfigPath("", "")).To(BeEmpty())
+		})
+
+		It("should pass a configured kubeconfig to kubectl and kind", func() {
+			cfg := &Config{KubeconfigPath: "/ci/kubeconfig"}
+			Expect(cfg.GetKubeconfigPath()).To(Equal("/ci/kubeconfig"))
+			Expect(cfg.KubeconfigArgs()).To(Equal([]string{"--kubeconfig", "/ci/kubeconfig"}))
+		})
+
+		It("should leave kubectl its default without a configured kubeconfig", func() {
+			home, err := os.UserHomeDir()
+			Expect(err).NotTo(HaveOccurred())
+
+			cfg := &Config{}
+			Expect(cfg.GetKubeconfigPath()).To(Equal(filepath.Join(home, ".kube", "config")))
+			Expect(cfg.KubeconfigArgs()).To(BeEmpty())
+		})
+	})
+
+	Describe("GetTaskRunsDir", func() {
+		It("should default to the taskruns directory of MpcDevEnvPath", func() {
+			cfg := &Config{MpcDevEnvPath: "/home/me/mpc-dev-env"}
+			Expect(cfg.GetTaskRunsDir()).To(Equal("/home/me/mpc-dev-env/taskruns"))
+
+			cfg.TaskRunsDir = "/home/me/taskruns"
+			Expect(cfg.GetTaskRunsDir()).To(Equal("/home/me/taskruns"))
+		})
+	})
+
+	Describe("GetKonfluxCIPath", func() {
+		It("should default to the konflux-ci directory next to MpcDevEnvPath", func() {
+			cfg := &Config{MpcDevEnvPath: "/home/me/mpc-dev-env"}
+			Expect(cfg.GetKonfluxCIPath()).To(Equal("/home/me/konflux-ci"))
+		})
+
+		It("should use the configured path as is", func() {
+			cfg := &Config{MpcDevEnvPath: "/home/me/mpc-dev-env", KonfluxCIPath: "/src/konflux/konflux-ci"}
+			Expect(cfg.GetKonfluxCIPath()).To(Equal("/src/konflux/konflux-ci"))
+		})
+	})
+
+	Describe("GetClusterName", func() {
+		It("should fall back to the default when the field is empty", func() {
+			cfg := &Config{}
+			Expect(cfg.GetClusterName()).To(Equal("konflux"))
+		})
+	})
+
+	Describe("GetControllerImage and GetOTPImage", func() {
+		It("should fall back to the defaults when the fields are empty", func() {
+			cfg := &Config{}
+			Expect(cfg.GetControllerImage()).To(Equal(DefaultControllerImage))
+			Expect(cfg.GetOTPImage()).To(Equal(DefaultOTPImage))
+		})
+	})
+
+	Describe("GetRepoPaths", func() {
+		It("should track the MPC repository", func() {
+			cfg := &Config{MpcRepoPath: "/src/multi-platform-controller"}
+			Expect(cfg.GetRepoPaths()).To(Equal(map[string]string{
+				"multi-platform-controller": "/src/multi-platform-controller",
+			}))
+		})
+
+		It("should add the tracked repositories", func() {
+			cfg := &Config{
+				MpcRepoPath: "/src/multi-platform-controller",
+				TrackedRepos: map[string]string{
+					"konflux-ci":                "/src/konflux-ci",
+					"multi-platform-controller": "/elsewhere/mpc",
+				},
+			}
+			Expect(cfg.GetRepoPaths()).To(Equal(map[string]string{
+				"multi-platform-controller": "/src/multi-platform-controller",
+				"konflux-ci":                "/src/konflux-ci",
+			}))
+		})
+	})
+
+	Describe("GetMPCPathPrefixes", func() {
+		It("should default to pkg/ and cmd/", func() {
+			Expect((&Config{}).GetMPCPathPrefixes()).To(Equal(DefaultMPCPathPrefixes))
+		})
+
+		It("should return the configured prefixes", func() {
+			cfg := &Config{MPCPathPrefixes: []string{"internal/", "Dockerfile"}}
+			Expect(cfg.GetMPCPathPrefixes()).To(Equal([]string{"internal/", "Dockerfile"}))
+		})
+	})
+
+	Describe("ParseTrackedRepos", func() {
+		It("should parse comma-separated name=path pairs", func() {
+			repos, err := ParseTrackedRepos("konflux-ci=/src/konflux-ci, infra-deployments = /src/infra-deployments")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(repos).To(Equal(map[string]string{
+				"konflux-ci":        "/src/konflux-ci",
+				"infra-deployments": "/src/infra-deployments",
+			}))
+		})
+
+		It("should return an empty map for an empty value", func() {
+			repos, err := ParseTrackedRepos("")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(repos).To(BeEmpty())
+		})
+
+		It("should reject entries without a name or path", func() {
+			_, err := ParseTrackedRepos("konflux-ci")
+			Expect(err).To(MatchError(ContainSubstring("expected name=path")))
+
+			_, err = ParseTrackedRepos("=/src/konflux-ci")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("RevisionImage", func() {
+		const gitHash = "0123456789abcdef0123456789abcdef01234567"
+
+		It("should replace the tag with the short git hash", func() {
+			Expect(RevisionImage(DefaultControllerImage, gitHash)).To(Equal("localhost/multi-platform-controller:0123456789ab"))
+		})
+
+		It("should keep a registry port and add a tag to untagged images", func() {
+			Expect(RevisionImage("registry:5000/mpc", gitHash)).To(Equal("registry:5000/mpc:0123456789ab"))
+		})
+
+		It("should return the image unchanged without a git hash", func() {
+			Expect(RevisionImage(DefaultOTPImage, "")).To(Equal(DefaultOTPImage))
+		})
+	})
+
+	Describe("ChangedSettings", func() {
+		It("should report nothing for equal configurations", func() {
+			previous := &Config{MpcRepoPath: "/repo", AllowedHosts: []string{"localhost"}, Timeouts: DefaultTimeouts()}
+			current := &Config{MpcRepoPath: "/repo", AllowedHosts: []string{"localhost"}, Timeouts: DefaultTimeouts()}
+
+			Expect(ChangedSettings(previous, current)).To(BeEmpty())
+		})
+
+		It("should report the env var names of the changed settings", func() {
+			previous := &Config{MpcRepoPath: "/repo", DaemonToken: "old", Timeouts: DefaultTimeouts()}
+			current := &Config{MpcRepoPath: "/other-repo", DaemonToken: "new", Timeouts: DefaultTimeouts()}
+			current.Timeouts.Build = time.Hour
+
+			Expect(ChangedSettings(previous, current)).To(Equal([]string{"MPC_REPO_PATH", "MPC_DAEMON_TOKEN", "MPC_*_TIMEOUT"}))
+		})
 	})
 
 	Describe("Validate", func() {
@@ -141,7 +1230,7 @@ var _ = Describe("Config", func() {
 
 		It("should return no error if all paths exist", func() {
 			Expect(os.MkdirAll(cfg.MpcDevEnvPath, 0755)).To(Succeed())
-			Expect(os.MkdirAll(cfg.MpcRepoPath, 0755)).To(Succeed())
+			createMPCRepo(cfg.MpcRepoPath)
 
 			err := cfg.Validate()
 			Expect(err).NotTo(HaveOccurred())
@@ -155,12 +1244,113 @@ var _ = Describe("Config", func() {
 			Expect(err.Error()).To(ContainSubstring("MPC_REPO_PATH does not exist"))
 		})
 
+		It("should return an error if KindConfigPath is set but unreadable", func() {
+			Expect(os.MkdirAll(cfg.MpcDevEnvPath, 0755)).To(Succeed())
+			createMPCRepo(cfg.MpcRepoPath)
+			cfg.KindConfigPath = filepath.Join(tempDir, "missing-kind-config.yaml")
+
+			err := cfg.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cannot read MPC_KIND_CONFIG_PATH"))
+		})
+
+		It("should accept a readable KindConfigPath", func() {
+			Expect(os.MkdirAll(cfg.MpcDevEnvPath, 0755)).To(Succeed())
+			createMPCRepo(cfg.MpcRepoPath)
+			cfg.KindConfigPath = filepath.Join(tempDir, "kind-config.yaml")
+			Expect(os.WriteFile(cfg.KindConfigPath, []byte("kind: Cluster\n"), 0644)).To(Succeed())
+
+			Expect(cfg.Validate()).To(Succeed())
+		})
+
 		It("should return an error if MpcDevEnvPath does not exist", func() {
-			Expect(os.MkdirAll(cfg.MpcRepoPath, 0755)).To(Succeed())
+			createMPCRepo(cfg.MpcRepoPath)
 
 			err := cfg.Validate()
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("MPC_DEV_ENV_PATH does not exist"))
 		})
+
+		It("should return an error if the MPC repository has no Dockerfile", func() {
+			Expect(os.MkdirAll(cfg.MpcDevEnvPath, 0755)).To(Succeed())
+			createMPCRepo(cfg.MpcRepoPath)
+			Expect(os.Remove(filepath.Join(cfg.MpcRepoPath, "Dockerfile"))).To(Succeed())
+
+			err := cfg.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("MPC_REPO_PATH has no Dockerfile"))
+			Expect(err.Error()).NotTo(ContainSubstring("deploy/operator"))
+		})
+
+		It("should return an error if the MPC repository has no deploy/operator directory", func() {
+			Expect(os.MkdirAll(cfg.MpcDevEnvPath, 0755)).To(Succeed())
+			createMPCRepo(cfg.MpcRepoPath)
+			Expect(os.RemoveAll(filepath.Join(cfg.MpcRepoPath, "deploy"))).To(Succeed())
+
+			err := cfg.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("MPC_REPO_PATH has no deploy/operator directory"))
+		})
+
+		It("should create the temp directory", func() {
+			Expect(os.MkdirAll(cfg.MpcDevEnvPath, 0755)).To(Succeed())
+			createMPCRepo(cfg.MpcRepoPath)
+			cfg.TempDir = filepath.Join(cfg.MpcDevEnvPath, "temp")
+
+			Expect(cfg.Validate()).To(Succeed())
+			Expect(cfg.TempDir).To(BeADirectory())
+		})
+
+		It("should return an error if the temp directory cannot be created", func() {
+			Expect(os.MkdirAll(cfg.MpcDevEnvPath, 0755)).To(Succeed())
+			createMPCRepo(cfg.MpcRepoPath)
+			cfg.TempDir = filepath.Join(tempDir, "missing-parent", "temp")
+
+			err := cfg.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("is not writable"))
+		})
+
+		It("should return an error if profiles share a Kind cluster", func() {
+			Expect(os.MkdirAll(cfg.MpcDevEnvPath, 0755)).To(Succeed())
+			createMPCRepo(cfg.MpcRepoPath)
+			cfg.Profiles = map[string]Profile{
+				"a":       {ClusterName: "shared"},
+				"b":       {ClusterName: "shared"},
+				"default": {ClusterName: "konflux"},
+			}
+
+			err := cfg.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`profile "b" uses the Kind cluster "shared" of profile "a"`))
+			Expect(err.Error()).To(ContainSubstring(`profile "default" uses the Kind cluster "konflux" of the default environment`))
+		})
+
+		It("should return an error if a profile's repository does not exist", func() {
+			Expect(os.MkdirAll(cfg.MpcDevEnvPath, 0755)).To(Succeed())
+			createMPCRepo(cfg.MpcRepoPath)
+			cfg.Profiles = map[string]Profile{"exp": {MpcRepoPath: filepath.Join(tempDir, "missing")}}
+
+			err := cfg.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("MPC_PROFILE_EXP_REPO_PATH does not exist"))
+		})
+
+		It("should report every problem at once", func() {
+			cfg.KindConfigPath = filepath.Join(tempDir, "missing-kind-config.yaml")
+
+			err := cfg.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("MPC_REPO_PATH does not exist"))
+			Expect(err.Error()).To(ContainSubstring("MPC_DEV_ENV_PATH does not exist"))
+			Expect(err.Error()).To(ContainSubstring("cannot read MPC_KIND_CONFIG_PATH"))
+		})
 	})
 })
+
+// createMPCRepo lays out the parts of a multi-platform-controller checkout that
+// Config.Validate looks for.
+func createMPCRepo(path string) {
+	Expect(os.MkdirAll(filepath.Join(path, "deploy", "operator"), 0755)).To(Succeed())
+	Expect(os.WriteFile(filepath.Join(path, "Dockerfile"), []byte("FROM scratch\n"), 0644)).To(Succeed())
+}
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ErrUnknownProfile is returned by ForProfile for a profile that is not configured.
+var ErrUnknownProfile = errors.New("unknown profile")
+
+// profileNamePattern matches valid profile names. They are also the default Kind
+// cluster names, so the same DNS label rules apply.
+var profileNamePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+// Profile is a named environment the daemon manages next to the default one, e.g. a
+// "stable" and an "experimental" environment, each with its own Kind cluster. API
+// requests select it with ?profile=<name> (see ForProfile).
+type Profile struct {
+	// ClusterName is the profile's Kind cluster name, the profile name when empty.
+	ClusterName string
+
+	// MpcRepoPath is the profile's multi-platform-controller repository, MPC_REPO_PATH when empty.
+	MpcRepoPath string
+
+	// Namespace is the namespace the profile's TaskRuns are created in, the TaskRun
+	// default when empty.
+	Namespace string
+}
+
+// ParseProfiles reads the profiles named in MPC_PROFILES (comma-separated) through
+// getenv. Each profile's settings are read from MPC_PROFILE_<NAME>_CLUSTER_NAME,
+// MPC_PROFILE_<NAME>_REPO_PATH and MPC_PROFILE_<NAME>_NAMESPACE, where <NAME> is the
+// profile name upper-cased with dashes replaced by underscores (see ProfileEnvVar).
+// Profile names must be lowercase DNS labels, e.g. "stable" or "exp-2", and unique.
+func ParseProfiles(getenv func(string) string) (map[string]Profile, error) {
+	names := splitList(getenv("MPC_PROFILES"))
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	profiles := make(map[string]Profile, len(names))
+	for _, name := range names {
+		if !profileNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid profile name %q in MPC_PROFILES: must consist of lowercase letters, digits and dashes", name)
+		}
+		if _, ok := profiles[name]; ok {
+			return nil, fmt.Errorf("duplicate profile %q in MPC_PROFILES", name)
+		}
+		profiles[name] = Profile{
+			ClusterName: getenv(ProfileEnvVar(name, "CLUSTER_NAME")),
+			MpcRepoPath: getenv(ProfileEnvVar(name, "REPO_PATH")),
+			Namespace:   getenv(ProfileEnvVar(name, "NAMESPACE")),
+		}
+	}
+	return profiles, nil
+}
+
+// ProfileEnvVar returns the name of the env var holding setting (e.g. "CLUSTER_NAME")
+// of the named profile, e.g. MPC_PROFILE_EXP_2_CLUSTER_NAME for "exp-2".
+func ProfileEnvVar(profile, setting string) string {
+	return "MPC_PROFILE_" + strings.ToUpper(strings.ReplaceAll(profile, "-", "_")) + "_" + setting
+}
+
+// GetProfileNames returns the names of the configured profiles, sorted.
+func (c *Config) GetProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ForProfile returns the configuration of the named profile: a copy of c that acts on
+// the profile's cluster and repository, with Profile and TaskRunNamespace set. An empty
+// name selects the default environment and returns c itself; a name that is not
+// configured returns an error wrapping ErrUnknownProfile.
+func (c *Config) ForProfile(name string) (*Config, error) {
+	if name == "" {
+		return c, nil
+	}
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownProfile, name)
+	}
+
+	profileCfg := *c
+	profileCfg.Profile = name
+	profileCfg.ClusterName = profile.ClusterName
+	if profileCfg.ClusterName == "" {
+		profileCfg.ClusterName = name
+	}
+	if profile.MpcRepoPath != "" {
+		profileCfg.MpcRepoPath = profile.MpcRepoPath
+	}
+	profileCfg.TaskRunNamespace = profile.Namespace
+	return &profileCfg, nil
+}
+
+// validateProfiles checks that each profile has its own Kind cluster, distinct from the
+// default one, and that its repository exists.
+func (c *Config) validateProfiles() []error {
+	var problems []error
+	clusters := map[string]string{c.GetClusterName(): "the default environment"}
+	for _, name := range c.GetProfileNames() {
+		profileCfg, _ := c.ForProfile(name)
+		if other, ok := clusters[profileCfg.ClusterName]; ok {
+			problems = append(problems, fmt.Errorf("profile %q uses the Kind cluster %q of %s", name, profileCfg.ClusterName, other))
+		} else {
+			clusters[profileCfg.ClusterName] = fmt.Sprintf("profile %q", name)
+		}
+
+		if repoPath := c.Profiles[name].MpcRepoPath; repoPath != "" {
+			if err := checkDir(ProfileEnvVar(name, "REPO_PATH"), repoPath); err != nil {
+				problems = append(problems, err)
+			}
+		}
+	}
+	return problems
+}
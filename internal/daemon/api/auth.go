@@ -0,0 +1,49 @@
+package api
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// unauthenticatedPaths lists the read-only endpoints that stay reachable without a
+// token, so health checks (e.g., daemon_is_running in scripts/api-client.sh) keep working.
+var unauthenticatedPaths = map[string]bool{
+	"/api/health":        true,
+	"/api/version":       true,
+	"/api/status":        true,
+	"/api/status/watch":  true,
+	"/api/prerequisites": true,
+}
+
+// RequireToken wraps next with bearer-token authentication.
+//
+// Every request must carry an "Authorization: Bearer <token>" header matching token,
+// except GET requests to the read-only endpoints in unauthenticatedPaths. Requests
+// without a valid token are rejected with 401 Unauthorized. This protects the API
+// from other local processes, including browser pages reaching localhost via DNS
+// rebinding.
+//
+// Example:
+//
+//	var handler http.Handler = NewRouter(handlers)
+//	if token := cfg.GetDaemonToken(); token != "" {
+//	    handler = RequireToken(token, handler)
+//	}
+func RequireToken(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && unauthenticatedPaths[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="mpc-daemon"`)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,89 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/meyrevived/mpc-dev-env/internal/daemon/api"
+)
+
+var _ = Describe("RequireToken", func() {
+	var (
+		handler http.Handler
+		reached bool
+	)
+
+	// serve sends a request through the middleware, optionally with an Authorization header
+	serve := func(method, path, authorization string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	BeforeEach(func() {
+		reached = false
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			reached = true
+			w.WriteHeader(http.StatusAccepted)
+		})
+		handler = api.RequireToken("s3cret", next)
+	})
+
+	It("should pass requests with the correct bearer token through", func() {
+		rr := serve(http.MethodPost, "/api/cluster/stop", "Bearer s3cret")
+
+		Expect(rr.Code).To(Equal(http.StatusAccepted))
+		Expect(reached).To(BeTrue())
+	})
+
+	It("should reject requests without an Authorization header", func() {
+		rr := serve(http.MethodPost, "/api/cluster/stop", "")
+
+		Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+		Expect(rr.Header().Get("WWW-Authenticate")).To(ContainSubstring("Bearer"))
+		Expect(reached).To(BeFalse())
+	})
+
+	It("should reject requests with a wrong token", func() {
+		rr := serve(http.MethodPost, "/api/features/enable", "Bearer wrong")
+
+		Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+		Expect(reached).To(BeFalse())
+	})
+
+	It("should reject tokens sent with another scheme", func() {
+		rr := serve(http.MethodPost, "/api/cluster/stop", "Basic s3cret")
+
+		Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+		Expect(reached).To(BeFalse())
+	})
+
+	It("should reject GET requests to endpoints that expose sensitive data", func() {
+		rr := serve(http.MethodGet, "/api/cluster/kubeconfig", "")
+
+		Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+		Expect(reached).To(BeFalse())
+	})
+
+	It("should leave the read-only status endpoints open", func() {
+		Expect(serve(http.MethodGet, "/api/health", "").Code).To(Equal(http.StatusAccepted))
+		Expect(serve(http.MethodGet, "/api/version", "").Code).To(Equal(http.StatusAccepted))
+		Expect(serve(http.MethodGet, "/api/status", "").Code).To(Equal(http.StatusAccepted))
+		Expect(serve(http.MethodGet, "/api/status/watch", "").Code).To(Equal(http.StatusAccepted))
+		Expect(serve(http.MethodGet, "/api/prerequisites", "").Code).To(Equal(http.StatusAccepted))
+	})
+
+	It("should not leave non-GET requests to the open endpoints unauthenticated", func() {
+		rr := serve(http.MethodPost, "/api/status", "")
+
+		Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+		Expect(reached).To(BeFalse())
+	})
+})
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// backgroundTasks tracks the goroutines handlers start for asynchronous work outside
+// the operationManager: cluster lifecycle changes, secrets, Konflux and minimal stack
+// deployments, git syncs and TaskRun workflows. Shutdown waits for them, so they are
+// not cut off halfway through changing the environment.
+type backgroundTasks struct {
+	mu      sync.Mutex
+	stopped bool
+	running sync.WaitGroup
+}
+
+// start runs fn in a tracked goroutine. Once stop has been called it returns
+// errShuttingDown instead, so no goroutine is added while wait is draining them.
+func (b *backgroundTasks) start(fn func()) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.stopped {
+		return errShuttingDown
+	}
+	b.running.Add(1)
+	go func() {
+		defer b.running.Done()
+		fn()
+	}()
+	return nil
+}
+
+// startBackground runs fn as tracked background work, like backgroundTasks.start, and
+// records the error it returns in the operation metrics under name.
+func (h *Handlers) startBackground(name string, fn func() error) error {
+	return h.background.start(func() {
+		start := time.Now()
+		h.metrics.observe(name, start, fn())
+	})
+}
+
+// stop rejects new goroutines. Those already running are left to finish.
+func (b *backgroundTasks) stop() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.stopped = true
+}
+
+// wait blocks until every tracked goroutine has returned or ctx is done. It must
+// only be called after stop.
+func (b *backgroundTasks) wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		b.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
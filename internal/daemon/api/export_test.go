@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/meyrevived/mpc-dev-env/internal/config"
+)
+
+// TrackTaskRun exposes trackTaskRun so tests can simulate a running TaskRun workflow.
+func (h *Handlers) TrackTaskRun(cancel context.CancelFunc) uint64 {
+	return h.trackTaskRun(cancel)
+}
+
+// SetTaskRunLogPollInterval overrides how often TaskRunLogsHandler polls the log file
+// and returns a func that restores the previous interval.
+func SetTaskRunLogPollInterval(d time.Duration) func() {
+	previous := taskRunLogPollInterval
+	taskRunLogPollInterval = d
+	return func() { taskRunLogPollInterval = previous }
+}
+
+// SetStateRefreshTimeout overrides how long StatusRefreshHandler waits for the re-scan
+// and returns a func that restores the previous timeout.
+func SetStateRefreshTimeout(d time.Duration) func() {
+	previous := stateRefreshTimeout
+	stateRefreshTimeout = d
+	return func() { stateRefreshTimeout = previous }
+}
+
+// ResolveTaskRunPath exposes resolveTaskRunPath for testing.
+var ResolveTaskRunPath = resolveTaskRunPath
+
+// SmokeTestTemplatePath exposes smokeTestTemplatePath for testing.
+var SmokeTestTemplatePath = smokeTestTemplatePath
+
+// SubmitOperation runs fn as a tracked operation so tests can simulate long-running work.
+func (h *Handlers) SubmitOperation(name string, enqueue bool, fn func(ctx context.Context)) error {
+	_, _, err := h.operations.submit(name, time.Minute, enqueue, fn)
+	return err
+}
+
+// StartBackground runs fn as untracked background work, like a TaskRun workflow, that
+// Shutdown drains.
+func (h *Handlers) StartBackground(fn func()) error {
+	return h.background.start(fn)
+}
+
+// OperationLog exposes the output buffer of the named operation.
+func (h *Handlers) OperationLog(name string) io.Writer {
+	return h.operations.log(name)
+}
+
+// NewOperationLog exposes newOperationLog for testing the ring buffer directly.
+func NewOperationLog(capacity int) *operationLog {
+	return newOperationLog(capacity)
+}
+
+// Snapshot exposes operationLog.snapshot.
+func (l *operationLog) Snapshot() ([]string, int) {
+	return l.snapshot()
+}
+
+// Reset exposes operationLog.reset.
+func (l *operationLog) Reset() {
+	l.reset()
+}
+
+// SetClusterManagerFactory overrides how the ClusterManager of a profile is created.
+func (h *Handlers) SetClusterManagerFactory(newClusterManager func(cfg *config.Config) ClusterManager) {
+	h.newClusterManager = newClusterManager
+}
+
+// SetReadinessProbeFactory overrides how the ReadinessProbe of GET /api/ready is created.
+func (h *Handlers) SetReadinessProbeFactory(newReadinessProbe func(cfg *config.Config, kubeContext string) ReadinessProbe) {
+	h.newReadinessProbe = newReadinessProbe
+}
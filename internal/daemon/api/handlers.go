@@ -11,12 +11,18 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -25,6 +31,7 @@ import (
 	"github.com/meyrevived/mpc-dev-env/internal/build"
 	"github.com/meyrevived/mpc-dev-env/internal/cluster"
 	"github.com/meyrevived/mpc-dev-env/internal/config"
+	daemongit "github.com/meyrevived/mpc-dev-env/internal/daemon/git"
 	"github.com/meyrevived/mpc-dev-env/internal/daemon/state"
 	"github.com/meyrevived/mpc-dev-env/internal/deploy"
 	"github.com/meyrevived/mpc-dev-env/internal/git"
@@ -32,6 +39,7 @@ import (
 	"github.com/meyrevived/mpc-dev-env/internal/logger"
 	"github.com/meyrevived/mpc-dev-env/internal/prereq"
 	"github.com/meyrevived/mpc-dev-env/internal/taskrun"
+	"github.com/meyrevived/mpc-dev-env/internal/version"
 )
 
 // StateManager abstracts state management operations for tracking the development
@@ -42,22 +50,85 @@ import (
 // in testing.
 type StateManager interface {
 	GetState() state.DevEnvironment
+	RefreshState() error
 	SetOperationStatus(status string, err error)
 	TrySetOperationStatus(expectedCurrent, newStatus string, err error) (ok bool, actualCurrent string)
 	SetTaskRunInfo(info *state.TaskRunInfo)
 	ClearTaskRunInfo()
+	SetTestResult(result *state.TestResult)
+	SetIBMEnabled(enabled bool)
+	ClearMPCDeployment()
+	SetMPCSourceGitHash(gitHash string)
+	SetPreviousMPCImages(images *state.PreviousMPCImages)
+	SetBuildInfo(info *state.BuildInfo)
+	SetDeployProgress(progress *state.DeployProgress)
+	SetMetricsConfig(metrics *state.MetricsConfig)
+	SetKonfluxInfo(info *state.KonfluxInfo)
+	SetGitSyncInfo(info *state.GitSyncInfo)
+	Subscribe() (<-chan state.DevEnvironment, func())
+}
+
+// ClusterManager abstracts the Kind cluster operations used by the cluster handlers.
+//
+// It is implemented by *cluster.Manager and allows tests to substitute a fake cluster.
+type ClusterManager interface {
+	Status(ctx context.Context) (string, error)
+	Create(ctx context.Context) error
+	Destroy(ctx context.Context) error
+	Pause(ctx context.Context) error
+	Resume(ctx context.Context) error
+	GetKubeconfig(ctx context.Context) (string, error)
+	ContextName() string
 }
 
 // Handlers holds dependencies and state for all HTTP API handlers.
 //
-// The opMutex ensures that only one build/deploy/rebuild operation can run at a time,
-// preventing race conditions and resource conflicts when multiple API calls are made
-// concurrently.
+// The operations manager ensures that only one build/deploy/rebuild operation can run
+// at a time, preventing race conditions and resource conflicts when multiple API calls
+// are made concurrently. Further operations are rejected or, on request, queued.
+//
+// All background work runs under operationCtx rather than the request context, so it
+// outlives the HTTP request but is still cancelled by Shutdown.
+//
+// The taskRunCancels map holds a cancel func for each running TaskRun workflow so that
+// POST /api/taskrun/cancel can stop it.
+//
+// Hot reload starts enabled and can be paused with POST /api/hotreload; the daemon's
+// file watcher checks HotReloadEnabled before every rebuild.
+//
+// Cluster creation is tracked separately from the operations manager, so that
+// POST /api/cluster/start and POST /api/cluster/ensure never create the cluster twice.
+//
+// ClusterManager manages the default environment's cluster. The cluster, status,
+// readiness, TaskRun and MPC logs endpoints act on a profile's cluster instead when
+// called with ?profile=<name> (see profileConfig), using a ClusterManager created by
+// newClusterManager. The endpoints that build, deploy or change the repositories
+// reject ?profile= (see defaultEnvironmentOnly).
 type Handlers struct {
 	StateManager   StateManager
-	Config         *config.Config
-	ClusterManager *cluster.Manager
-	opMutex        sync.Mutex // Prevents concurrent write operations
+	ClusterManager ClusterManager
+	operations     *operationManager // Serializes write operations
+	metrics        *operationMetrics // Served by GET /metrics
+
+	newClusterManager func(cfg *config.Config) ClusterManager                     // Creates the ClusterManager of a profile
+	newReadinessProbe func(cfg *config.Config, kubeContext string) ReadinessProbe // Creates the ReadinessProbe of GET /api/ready
+
+	clusterMutex    sync.Mutex      // Guards clusterCreating, and is held while ensure checks the cluster
+	clusterCreating map[string]bool // Whether a cluster creation is running, by profile ("" is the default environment)
+
+	configMutex sync.RWMutex   // Guards config
+	config      *config.Config // Swapped as a whole by SetConfig, never modified in place
+
+	operationCtx     context.Context    // Parent of every background operation's context
+	cancelOperations context.CancelFunc // Cancels operationCtx on shutdown
+	background       backgroundTasks    // Background goroutines outside operations, drained by Shutdown
+
+	taskRunMutex   sync.Mutex                    // Guards taskRunCancels and nextTaskRunID
+	taskRunCancels map[uint64]context.CancelFunc // Cancel funcs of running TaskRun workflows
+	nextTaskRunID  uint64
+
+	hotReloadMutex    sync.Mutex // Guards hotReloadDisabled
+	hotReloadDisabled bool       // Zero value keeps hot reload enabled
 }
 
 // NewHandlers creates a new Handlers instance with the provided dependencies.
@@ -65,15 +136,147 @@ type Handlers struct {
 // The StateManager is typically a *state.Manager instance from the main daemon,
 // and the Config contains all environment paths and configuration needed for operations.
 func NewHandlers(stateManager StateManager, cfg *config.Config) *Handlers {
+	operationCtx, cancelOperations := context.WithCancel(context.Background())
 	return &Handlers{
 		StateManager:   stateManager,
-		Config:         cfg,
+		config:         cfg,
 		ClusterManager: cluster.NewManager(cfg),
+		operations:     newOperationManager(operationCtx),
+		newClusterManager: func(cfg *config.Config) ClusterManager {
+			return cluster.NewManager(cfg)
+		},
+		newReadinessProbe: newReadinessProbe,
+		clusterCreating:   make(map[string]bool),
+		metrics:           newOperationMetrics(),
+		operationCtx:      operationCtx,
+		cancelOperations:  cancelOperations,
+	}
+}
+
+// Config returns the daemon's current configuration. Handlers read it once per request
+// and pass it down, so an operation keeps the configuration it started with even if
+// SetConfig swaps in a new one while it runs.
+func (h *Handlers) Config() *config.Config {
+	h.configMutex.RLock()
+	defer h.configMutex.RUnlock()
+	return h.config
+}
+
+// SetConfig replaces the configuration used by subsequent requests, e.g. after the
+// daemon reloads it on SIGHUP, and returns the previous one. cfg must not be modified
+// afterwards.
+func (h *Handlers) SetConfig(cfg *config.Config) *config.Config {
+	h.configMutex.Lock()
+	defer h.configMutex.Unlock()
+	previous := h.config
+	h.config = cfg
+	return previous
+}
+
+// profileConfig returns the configuration of the environment selected by the request's
+// ?profile= parameter (see config.Config.ForProfile): the daemon's current configuration
+// without it, and a copy acting on the profile's cluster and repository with it. For a
+// profile that is not configured, it writes a 400 Bad Request and returns false.
+func (h *Handlers) profileConfig(w http.ResponseWriter, r *http.Request) (*config.Config, bool) {
+	cfg, err := h.Config().ForProfile(r.URL.Query().Get("profile"))
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		response := map[string]string{
+			"status": "error",
+			"error":  err.Error(),
+		}
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			logger.Error(err, "failed to encode response")
+		}
+		return nil, false
+	}
+	return cfg, true
+}
+
+// defaultEnvironmentOnly writes a 400 Bad Request and returns false for a request with
+// ?profile=. It guards the endpoints that only act on the default environment, as what
+// they change (images, deployments, secrets, repositories) is only tracked for it.
+func defaultEnvironmentOnly(w http.ResponseWriter, r *http.Request) bool {
+	profile := r.URL.Query().Get("profile")
+	if profile == "" {
+		return true
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	response := map[string]string{
+		"status": "error",
+		"error":  fmt.Sprintf("%s does not support profiles (got profile %q); it only acts on the default environment", r.URL.Path, profile),
+	}
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.Error(err, "failed to encode response")
+	}
+	return false
+}
+
+// clusterManagerFor returns the ClusterManager of the environment cfg belongs to:
+// h.ClusterManager for the default environment, a new one for a profile.
+func (h *Handlers) clusterManagerFor(cfg *config.Config) ClusterManager {
+	if cfg.Profile == "" {
+		return h.ClusterManager
+	}
+	return h.newClusterManager(cfg)
+}
+
+// HotReloadEnabled reports whether file changes should trigger a rebuild.
+func (h *Handlers) HotReloadEnabled() bool {
+	h.hotReloadMutex.Lock()
+	defer h.hotReloadMutex.Unlock()
+	return !h.hotReloadDisabled
+}
+
+// SetHotReloadEnabled enables or pauses rebuilds triggered by file changes.
+func (h *Handlers) SetHotReloadEnabled(enabled bool) {
+	h.hotReloadMutex.Lock()
+	defer h.hotReloadMutex.Unlock()
+	h.hotReloadDisabled = !enabled
+}
+
+// OperationContext returns the context background operations should derive from.
+// It is not tied to any request and is cancelled by Shutdown.
+func (h *Handlers) OperationContext() context.Context {
+	return h.operationCtx
+}
+
+// Shutdown drops queued operations and rejects new ones, then drains the in-flight
+// ones: the running tracked operation (build, deploy, etc.) and the other background
+// work, such as cluster changes and TaskRun workflows, get gracePeriod to finish on
+// their own (none when it is not positive). Whatever is still running after that is
+// cancelled, and Shutdown waits for it to return, or for ctx to expire.
+func (h *Handlers) Shutdown(ctx context.Context, gracePeriod time.Duration) error {
+	h.operations.shutdown()
+	h.background.stop()
+
+	if gracePeriod > 0 {
+		drainCtx, cancel := context.WithTimeout(ctx, gracePeriod)
+		defer cancel()
+		if err := h.waitForOperations(drainCtx); err != nil && ctx.Err() == nil {
+			logger.Info("operations still running after the shutdown grace period, cancelling them", "gracePeriod", gracePeriod)
+		}
+	}
+
+	h.cancelOperations()
+	return h.waitForOperations(ctx)
+}
+
+// waitForOperations blocks until the tracked operation and every background goroutine
+// have returned, or ctx is done.
+func (h *Handlers) waitForOperations(ctx context.Context) error {
+	if err := h.operations.wait(ctx); err != nil {
+		return err
 	}
+	return h.background.wait(ctx)
 }
 
 // StatusHandler handles GET /api/status requests.
-// It returns the current development environment state as JSON.
+// It returns the current development environment state as JSON. With ?profile=<name>,
+// cluster and repositories are those of the named profile.
 func (h *Handlers) StatusHandler(w http.ResponseWriter, r *http.Request) {
 	// Only accept GET requests
 	if r.Method != http.MethodGet {
@@ -81,8 +284,18 @@ func (h *Handlers) StatusHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	cfg, ok := h.profileConfig(w, r)
+	if !ok {
+		return
+	}
+
 	// Get the current state from the StateManager
 	currentState := h.StateManager.GetState()
+	if cfg.Profile != "" {
+		profileState := currentState.Profiles[cfg.Profile]
+		currentState.Cluster = profileState.Cluster
+		currentState.Repositories = profileState.Repositories
+	}
 
 	// Set Content-Type header
 	w.Header().Set("Content-Type", "application/json")
@@ -94,23 +307,104 @@ func (h *Handlers) StatusHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// RebuildHandler handles POST /api/rebuild requests.
-// It triggers the MPC image rebuild asynchronously using native Go and returns 202 Accepted immediately.
-// If a rebuild is already in progress, it returns 409 Conflict.
-func (h *Handlers) RebuildHandler(w http.ResponseWriter, r *http.Request) {
+// StatusWatchHandler handles GET /api/status/watch requests.
+//
+// It streams the development environment state as Server-Sent Events, so clients can
+// follow it without polling GET /api/status. The current state is sent right away and
+// again each time it changes, as a "data:" event carrying the same JSON as GET
+// /api/status. A client that reads too slowly skips intermediate states but always
+// receives the latest one. Streaming stops when the client disconnects or the daemon
+// shuts down.
+func (h *Handlers) StatusWatchHandler(w http.ResponseWriter, r *http.Request) {
+	// Only accept GET requests
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	// Subscribe before reading the current state so no change in between is missed
+	updates, unsubscribe := h.StateManager.Subscribe()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	current := h.StateManager.GetState()
+	for {
+		data, err := json.Marshal(current)
+		if err != nil {
+			logger.Error(err, "failed to encode state")
+			return
+		}
+		writeSSEData(w, data)
+		flusher.Flush()
+
+		select {
+		case <-r.Context().Done():
+			// Client disconnected
+			return
+		case <-h.operationCtx.Done():
+			// Daemon shutting down
+			return
+		case next, ok := <-updates:
+			if !ok {
+				return
+			}
+			current = next
+		}
+	}
+}
+
+// stateRefreshTimeout bounds how long StatusRefreshHandler waits for the re-scan.
+var stateRefreshTimeout = 30 * time.Second
+
+// StatusRefreshHandler handles POST /api/status/refresh requests.
+// It re-scans the live environment (cluster, repositories and MPC deployment) with
+// StateManager.RefreshState and returns the updated state as JSON, like GET /api/status.
+// Use it after changing the environment outside the daemon, e.g. with kubectl or git.
+//
+// Returns 504 Gateway Timeout if the re-scan takes longer than stateRefreshTimeout; it
+// still completes in the background, and 500 Internal Server Error if it fails.
+func (h *Handlers) StatusRefreshHandler(w http.ResponseWriter, r *http.Request) {
 	// Only accept POST requests
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	// Try to acquire the lock. If we can't, a rebuild is already in progress.
-	if !h.opMutex.TryLock() {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusConflict)
+	// RefreshState takes no context, so wait for it with a timeout instead
+	done := make(chan error, 1)
+	go func() {
+		done <- h.StateManager.RefreshState()
+	}()
+
+	var err error
+	statusCode := http.StatusInternalServerError
+	select {
+	case err = <-done:
+	case <-time.After(stateRefreshTimeout):
+		err = fmt.Errorf("state refresh did not finish within %s", stateRefreshTimeout)
+		statusCode = http.StatusGatewayTimeout
+	case <-r.Context().Done():
+		// Client disconnected
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		logger.Error(err, "failed to refresh state")
+		w.WriteHeader(statusCode)
 		response := map[string]string{
-			"status": "conflict",
-			"error":  "A rebuild operation is already in progress",
+			"status": "error",
+			"error":  err.Error(),
 		}
 		if err := json.NewEncoder(w).Encode(response); err != nil {
 			logger.Error(err, "failed to encode response")
@@ -118,35 +412,173 @@ func (h *Handlers) RebuildHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Set the operation status to "rebuilding" before starting the goroutine
-	h.StateManager.SetOperationStatus("rebuilding", nil)
+	if err := json.NewEncoder(w).Encode(h.StateManager.GetState()); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
 
-	// Execute the rebuild asynchronously in a goroutine using native Go build
-	// This allows the HTTP request to return immediately
-	//nolint:contextcheck // Using Background context intentionally - request context would cancel when response is sent
-	go func() {
-		// Ensure we unlock the mutex when the goroutine completes
-		defer h.opMutex.Unlock()
+// HealthHandler handles GET /api/health requests.
+// It returns {"status":"ok"} without touching any state, as a cheap liveness check
+// for scripts waiting for the daemon to come up.
+func (h *Handlers) HealthHandler(w http.ResponseWriter, r *http.Request) {
+	// Only accept GET requests
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
 
-		logger.Info("starting background rebuild")
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]string{"status": "ok"}); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
 
-		// Create context with timeout (builds can take several minutes)
-		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
-		defer cancel()
+// VersionResponse represents the JSON response for GET /api/version.
+type VersionResponse struct {
+	Version   string `json:"version"`
+	GitCommit string `json:"git_commit"`
+	BuildTime string `json:"build_time"`
+}
+
+// VersionHandler handles GET /api/version requests.
+// It returns the daemon's build information (see package version), so scripts can
+// verify they are talking to the expected build.
+func (h *Handlers) VersionHandler(w http.ResponseWriter, r *http.Request) {
+	// Only accept GET requests
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	response := VersionResponse{
+		Version:   version.Version,
+		GitCommit: version.GitCommit,
+		BuildTime: version.BuildTime,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// BuildImages builds the MPC images with build.BuildMPCImage, capturing the build
+// output in build_<timestamp>.log in the session log directory and publishing the
+// build's progress, and then the built images' sizes, as BuildInfo in the state.
+// When output is non-nil, it also receives the build output, e.g. the buffer served
+// by GET /api/operations/logs.
+//
+// Images whose inputs are unchanged since the ImageInputs recorded by the previous
+// build are not rebuilt, unless force is set.
+//
+// Returns the source git hash the images were built from. Callers are responsible
+// for serializing builds (the operations manager or the operation status).
+func (h *Handlers) BuildImages(ctx context.Context, cfg *config.Config, force bool, output io.Writer) (string, error) {
+	var previousInputs map[string]string
+	if previous := h.StateManager.GetState().BuildInfo; previous != nil {
+		previousInputs = previous.ImageInputs
+	}
+
+	started := state.BuildInfo{
+		Status:      "Running",
+		LogFile:     filepath.Join(cfg.GetSessionLogDir(), timestampedLogFilename("build")),
+		StartTime:   time.Now().Format(time.RFC3339),
+		ImageInputs: previousInputs,
+	}
+	h.StateManager.SetBuildInfo(&started)
+
+	builtInputs := maps.Clone(previousInputs)
+	if builtInputs == nil {
+		builtInputs = map[string]string{}
+	}
+	result, err := build.BuildMPCImage(ctx, cfg, build.BuildOptions{
+		LogFile:     started.LogFile,
+		Output:      output,
+		BuiltInputs: previousInputs,
+		Force:       force,
+		OnImageBuilt: func(image, inputsHash string) {
+			builtInputs[image] = inputsHash
+		},
+	})
+
+	finished := started
+	finished.SourceGitHash = result.SourceGitHash
+	finished.ImageInputs = builtInputs
+	for _, image := range result.Images {
+		finished.Images = append(finished.Images, state.ImageInfo{Image: image.Image, SizeBytes: image.SizeBytes, Layers: image.Layers})
+	}
+	finished.Status = "Succeeded"
+	if err != nil {
+		finished.Status = "Failed"
+	}
+	h.StateManager.SetBuildInfo(&finished)
+
+	return result.SourceGitHash, err
+}
+
+// boolQueryParam parses the optional boolean query parameter name, which defaults
+// to false. On an invalid value it writes 400 Bad Request and returns ok false.
+func boolQueryParam(w http.ResponseWriter, r *http.Request, name string) (value, ok bool) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return false, true
+	}
+	parsed, err := strconv.ParseBool(raw)
+	if err != nil {
+		http.Error(w, name+" must be true or false", http.StatusBadRequest)
+		return false, false
+	}
+	return parsed, true
+}
+
+// RebuildHandler handles POST /api/rebuild requests.
+// It triggers the MPC image rebuild asynchronously using native Go and returns 202 Accepted immediately.
+// If another operation is in progress, it returns 409 Conflict, or queues the rebuild with ?queue=true.
+// With ?force=true every image is rebuilt, even if its inputs are unchanged.
+func (h *Handlers) RebuildHandler(w http.ResponseWriter, r *http.Request) {
+	// Only accept POST requests
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !defaultEnvironmentOnly(w, r) {
+		return
+	}
+
+	force, ok := boolQueryParam(w, r, "force")
+	if !ok {
+		return
+	}
+
+	cfg := h.Config()
+
+	// Execute the rebuild asynchronously as a tracked operation using native Go build
+	// This allows the HTTP request to return immediately (builds can take several minutes)
+	started := h.startOperation(w, r, "rebuild", cfg.GetTimeouts().Build, func(ctx context.Context) error {
+		h.StateManager.SetOperationStatus("rebuilding", nil)
+
+		logger.Info("starting background rebuild")
 
 		// Call the native Go build function
-		if err := build.BuildMPCImage(ctx, h.Config); err != nil {
+		if _, err := h.BuildImages(ctx, cfg, force, h.operations.log("rebuild")); err != nil {
 			logger.Error(err, "background rebuild failed")
 
 			// Update state to idle with error message
 			h.StateManager.SetOperationStatus("idle", err)
-			return
+			return err
 		}
 		logger.Info("background rebuild completed successfully")
 
 		// Update state to idle with no error
 		h.StateManager.SetOperationStatus("idle", nil)
-	}()
+		return nil
+	})
+	if !started {
+		return
+	}
 
 	// Immediately return 202 Accepted with a JSON response
 	w.Header().Set("Content-Type", "application/json")
@@ -163,23 +595,113 @@ func (h *Handlers) RebuildHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// HotReloadRequest represents the JSON request body for POST /api/hotreload.
+type HotReloadRequest struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// HotReloadResponse represents the JSON response for GET and POST /api/hotreload.
+type HotReloadResponse struct {
+	Enabled bool `json:"enabled"`
+}
+
+// HotReloadHandler handles GET and POST /api/hotreload requests.
+//
+// GET reports whether file changes in the MPC repository trigger a rebuild. POST with
+// {"enabled": false} pauses those rebuilds, e.g. during a large refactor, and
+// {"enabled": true} resumes them; the new setting is returned. A rebuild that is
+// already running is not affected, and POST /api/rebuild keeps working either way.
+//
+// Returns 400 Bad Request if the POST body has no "enabled" field.
+func (h *Handlers) HotReloadHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		var req HotReloadRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		if req.Enabled == nil {
+			http.Error(w, "enabled is required", http.StatusBadRequest)
+			return
+		}
+
+		h.SetHotReloadEnabled(*req.Enabled)
+		logger.Info("hot reload toggled", "enabled", *req.Enabled)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(HotReloadResponse{Enabled: h.HotReloadEnabled()}); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// smokeTestTemplate is the known-good TaskRun the smoke test runs, relative to the
+// mpc-dev-env repository. It runs on the localhost platform, so it exercises MPC
+// without requiring any cloud provider credentials.
+const smokeTestTemplate = "taskruns/localhost_test.yaml"
+
+// SmokeTestRequest represents the optional JSON request body for POST /api/smoke-test.
+//
+// TemplatePath overrides the TaskRun YAML used for the smoke test; like the yaml_path
+// of POST /api/taskrun/run it must be within the taskruns directory. When empty, the
+// bundled taskruns/localhost_test.yaml is used.
+type SmokeTestRequest struct {
+	TemplatePath string `json:"template_path,omitempty"`
+}
+
 // SmokeTestHandler handles POST /api/smoke-test requests.
-// TODO: Implement native Go smoke test functionality
-// Currently returns 501 Not Implemented as native Go implementation is pending
+//
+// It runs a known-good TaskRun through the same taskrun.Manager workflow as
+// POST /api/taskrun/run and records the pass/fail result, duration, and log path
+// in the state as SmokeTestResult. Returns 202 Accepted immediately, 400 Bad Request
+// for a template_path outside the taskruns directory, or 409 Conflict if another
+// operation is in progress (unless queued with ?queue=true).
 func (h *Handlers) SmokeTestHandler(w http.ResponseWriter, r *http.Request) {
 	// Only accept POST requests
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
+	if !defaultEnvironmentOnly(w, r) {
+		return
+	}
+
+	// The request body is optional
+	var req SmokeTestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	cfg := h.Config()
+	templatePath, err := smokeTestTemplatePath(cfg, req.TemplatePath)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid template_path: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	// The TaskRun monitor gives up after the TaskRun timeout, leave room for cleanup and pod startup
+	started := h.startOperation(w, r, "smoke_test", cfg.GetTimeouts().TaskRun+5*time.Minute, func(ctx context.Context) error {
+		h.StateManager.SetOperationStatus("running_smoke_test", nil)
+		return h.runSmokeTest(ctx, cfg, templatePath)
+	})
+	if !started {
+		return
+	}
 
-	// Return 501 Not Implemented
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusNotImplemented)
+	w.WriteHeader(http.StatusAccepted)
 
 	response := map[string]string{
-		"status":  "not_implemented",
-		"message": "Smoke test functionality is not yet implemented in native Go. Please run tests manually.",
+		"status":        "accepted",
+		"message":       "Smoke test started",
+		"template_path": templatePath,
 	}
 
 	if err := json.NewEncoder(w).Encode(response); err != nil {
@@ -187,23 +709,123 @@ func (h *Handlers) SmokeTestHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// smokeTestTemplatePath returns the TaskRun YAML to use for the smoke test: the
+// override if one was requested, resolved and confined to the taskruns directory by
+// resolveTaskRunPath, otherwise the bundled template in the mpc-dev-env repo.
+func smokeTestTemplatePath(cfg *config.Config, override string) (string, error) {
+	if override != "" {
+		return resolveTaskRunPath(cfg.GetTaskRunsDir(), override)
+	}
+	return filepath.Join(cfg.GetMpcDevEnvPath(), smokeTestTemplate), nil
+}
+
+// runSmokeTest runs the smoke test TaskRun to completion, records its result and
+// returns the reason it failed, if it did.
+func (h *Handlers) runSmokeTest(ctx context.Context, cfg *config.Config, templatePath string) error {
+	logPath := filepath.Join(cfg.GetSessionLogDir(), timestampedLogFilename("smoke_test"))
+	start := time.Now()
+
+	logger.Info("starting smoke test", "template", templatePath)
+	name, status, err := h.runSmokeTestTaskRun(ctx, cfg, templatePath, logPath)
+
+	result := &state.TestResult{
+		Passed:          err == nil && status == "Succeeded",
+		DurationSeconds: int(time.Since(start).Seconds()),
+		LogFile:         logPath,
+	}
+	if name != "" {
+		result.Output = fmt.Sprintf("TaskRun '%s' finished with status %s", name, status)
+	}
+
+	if err == nil && !result.Passed {
+		err = fmt.Errorf("smoke test TaskRun '%s' %s - check logs at %s", name, strings.ToLower(status), logPath)
+	}
+	if err != nil {
+		errMsg := err.Error()
+		result.Error = &errMsg
+		logger.Error(err, "smoke test failed")
+	} else {
+		logger.Info("smoke test passed", "name", name, "duration", result.DurationSeconds)
+	}
+
+	h.StateManager.SetTestResult(result)
+	h.StateManager.SetOperationStatus("idle", err)
+	return err
+}
+
+// runSmokeTestTaskRun validates the template and runs it through the regular TaskRun workflow.
+func (h *Handlers) runSmokeTestTaskRun(ctx context.Context, cfg *config.Config, templatePath, logPath string) (name, status string, err error) {
+	if _, err := os.Stat(templatePath); err != nil {
+		return "", "", fmt.Errorf("smoke test template not found: %w", err)
+	}
+
+	if err := os.MkdirAll(cfg.GetSessionLogDir(), 0750); err != nil {
+		return "", "", fmt.Errorf("failed to create session log directory: %w", err)
+	}
+
+	mgr, err := taskrun.NewManagerForContext(cfg.KubeconfigPath, cfg.GetKubeContext(), "")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create TaskRun manager: %w", err)
+	}
+	mgr.SetTimeout(cfg.GetTimeouts().TaskRun)
+
+	return mgr.RunTaskRunWorkflow(ctx, templatePath, logPath)
+}
+
 // DeployMetricsHandler handles POST /api/metrics/deploy requests.
-// TODO: Implement native Go metrics deployment functionality
-// Currently returns 501 Not Implemented as native Go implementation is pending
+// It deploys Prometheus and Grafana to the Kind cluster asynchronously and returns
+// 202 Accepted immediately. Once both are ready, their service URLs and retention
+// are recorded in the state's metrics field. If an operation is already in progress,
+// it returns 409 Conflict, or queues the deployment with ?queue=true.
 func (h *Handlers) DeployMetricsHandler(w http.ResponseWriter, r *http.Request) {
 	// Only accept POST requests
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
+	if !defaultEnvironmentOnly(w, r) {
+		return
+	}
+
+	cfg := h.Config()
+
+	// Execute the deployment asynchronously as a tracked operation
+	started := h.startOperation(w, r, "deploy_metrics", cfg.GetTimeouts().Deploy, func(ctx context.Context) error {
+		h.StateManager.SetOperationStatus("deploying_metrics", nil)
+
+		logger.Info("starting metrics deployment")
+
+		deployManager := deploy.NewManager(cfg)
+		deployManager.SetOutput(h.operations.log("deploy_metrics"))
+		stack, err := deployManager.DeployMetrics(ctx)
+		if err != nil {
+			logger.Error(err, "metrics deployment failed")
+			h.StateManager.SetOperationStatus("idle", err)
+			return err
+		}
+
+		logger.Info("metrics deployment completed successfully")
+		h.StateManager.SetMetricsConfig(&state.MetricsConfig{
+			PrometheusEnabled: true,
+			GrafanaEnabled:    true,
+			PrometheusURL:     stack.PrometheusURL,
+			GrafanaURL:        stack.GrafanaURL,
+			RetentionDays:     stack.RetentionDays,
+		})
+		h.StateManager.SetOperationStatus("idle", nil)
+		return nil
+	})
+	if !started {
+		return
+	}
 
-	// Return 501 Not Implemented
+	// Immediately return 202 Accepted
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusNotImplemented)
+	w.WriteHeader(http.StatusAccepted)
 
 	response := map[string]string{
-		"status":  "not_implemented",
-		"message": "Metrics deployment functionality is not yet implemented in native Go. Please deploy metrics manually.",
+		"status":  "accepted",
+		"message": "Metrics deployment initiated. Check GET /api/operations/logs?name=deploy_metrics for progress.",
 	}
 
 	if err := json.NewEncoder(w).Encode(response); err != nil {
@@ -213,22 +835,26 @@ func (h *Handlers) DeployMetricsHandler(w http.ResponseWriter, r *http.Request)
 
 // EnableFeatureRequest represents the JSON request body for POST /api/features/enable.
 //
-// Currently only "aws-secrets" is supported as a feature. The credentials map should
-// contain AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN (optional), and SSH_KEY_PATH.
+// Supported features and the credentials each expects:
+//   - "aws-secrets": AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN (optional), and SSH_KEY_PATH
+//   - "ibm-secrets": IBM_S390X_SSH_KEY_PATH, IBM_PPC64LE_SSH_KEY_PATH, and IBMCLOUD_API_KEY
 type EnableFeatureRequest struct {
 	FeatureName string            `json:"feature_name"`
 	Credentials map[string]string `json:"credentials"`
 }
 
 // EnableFeatureHandler handles POST /api/features/enable requests.
-// It enables a feature by configuring AWS secrets using native Go implementation.
-// The credentials should include AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, and SSH_KEY_PATH
+// It enables a feature by configuring AWS or IBM Cloud secrets using native Go implementation.
+// See EnableFeatureRequest for the credentials each feature expects.
 func (h *Handlers) EnableFeatureHandler(w http.ResponseWriter, r *http.Request) {
 	// Only accept POST requests
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
+	if !defaultEnvironmentOnly(w, r) {
+		return
+	}
 
 	// Parse request body
 	var req EnableFeatureRequest
@@ -243,43 +869,59 @@ func (h *Handlers) EnableFeatureHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Currently only support AWS secrets feature
-	if req.FeatureName != "aws-secrets" {
-		http.Error(w, fmt.Sprintf("Unsupported feature: %s. Only 'aws-secrets' is currently supported.", req.FeatureName), http.StatusBadRequest)
+	// Credentials in the request take precedence over the daemon's environment. They are
+	// passed to the deploy step directly: setting them in the process environment would
+	// leak them to every other goroutine and child process while the step runs.
+	lookup := func(key string) string {
+		if value, ok := req.Credentials[key]; ok {
+			return value
+		}
+		return os.Getenv(key)
+	}
+
+	// Map the feature to the deploy step that enables it
+	cfg := h.Config()
+	deployManager := deploy.NewManager(cfg)
+	var applyFeature func(ctx context.Context) error
+	switch req.FeatureName {
+	case "aws-secrets":
+		creds := deploy.AWSCredentialsFrom(lookup)
+		applyFeature = func(ctx context.Context) error {
+			return deployManager.ApplySecrets(ctx, creds)
+		}
+	case "ibm-secrets":
+		creds := deploy.IBMCredentialsFrom(lookup)
+		applyFeature = func(ctx context.Context) error {
+			if err := deployManager.ApplyIBMSecrets(ctx, creds); err != nil {
+				return err
+			}
+			h.StateManager.SetIBMEnabled(true)
+			return nil
+		}
+	default:
+		http.Error(w, fmt.Sprintf("Unsupported feature: %s. Supported features are 'aws-secrets' and 'ibm-secrets'.", req.FeatureName), http.StatusBadRequest)
 		return
 	}
 
 	// Execute the feature enablement asynchronously using native Go
-	//nolint:contextcheck // Using Background context intentionally - request context would cancel when response is sent
-	go func() {
+	//nolint:contextcheck // Using the daemon operation context intentionally - request context would cancel when response is sent
+	if err := h.startBackground("enable_feature", func() error {
 		logger.Info("enabling feature", "feature", req.FeatureName)
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		ctx, cancel := context.WithTimeout(h.operationCtx, cfg.GetTimeouts().Secrets)
 		defer cancel()
 
-		// Set environment variables from credentials
-		// This allows the ApplySecrets function to use them
-		for key, value := range req.Credentials {
-			_ = os.Setenv(key, value)
-		}
-
 		// Use the native Go secrets deployment
-		deployManager := deploy.NewManager(h.Config)
-		if err := deployManager.ApplySecrets(ctx); err != nil {
+		if err := applyFeature(ctx); err != nil {
 			logger.Error(err, "feature enablement failed", "feature", req.FeatureName)
-			// Clear environment variables on failure
-			for key := range req.Credentials {
-				_ = os.Unsetenv(key)
-			}
-			return
+			return err
 		}
 
 		logger.Info("feature enabled successfully", "feature", req.FeatureName)
-
-		// Clear environment variables after successful deployment for security
-		for key := range req.Credentials {
-			_ = os.Unsetenv(key)
-		}
-	}()
+		return nil
+	}); err != nil {
+		writeOperationConflict(w, err)
+		return
+	}
 
 	// Immediately return 202 Accepted
 	w.Header().Set("Content-Type", "application/json")
@@ -304,8 +946,9 @@ func (h *Handlers) PrerequisitesHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Create prerequisite checker
-	checker := prereq.NewChecker(h.Config)
+	// Create prerequisite checker; the daemon's own port is necessarily in use
+	checker := prereq.NewChecker(h.Config())
+	checker.SkipPort(config.DaemonPort)
 
 	// Create context with timeout
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
@@ -337,12 +980,18 @@ func (h *Handlers) ClusterStatusHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	cfg, ok := h.profileConfig(w, r)
+	if !ok {
+		return
+	}
+	clusterManager := h.clusterManagerFor(cfg)
+
 	// Create context with timeout
 	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
 	defer cancel()
 
 	// Get cluster status
-	status, err := h.ClusterManager.Status(ctx)
+	status, err := clusterManager.Status(ctx)
 
 	// Set Content-Type header
 	w.Header().Set("Content-Type", "application/json")
@@ -363,6 +1012,62 @@ func (h *Handlers) ClusterStatusHandler(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// ClusterKubeconfigHandler handles GET /api/cluster/kubeconfig requests.
+// It returns the raw kubeconfig and context name for the Kind cluster, or 404 if
+// the cluster does not exist.
+func (h *Handlers) ClusterKubeconfigHandler(w http.ResponseWriter, r *http.Request) {
+	// Only accept GET requests
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	cfg, ok := h.profileConfig(w, r)
+	if !ok {
+		return
+	}
+	clusterManager := h.clusterManagerFor(cfg)
+
+	// Create context with timeout
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	kubeconfig, err := clusterManager.GetKubeconfig(ctx)
+
+	// Set Content-Type header
+	w.Header().Set("Content-Type", "application/json")
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		message := err.Error()
+		if errors.Is(err, cluster.ErrClusterNotFound) {
+			statusCode = http.StatusNotFound
+			message = "Kind cluster is not running. Use POST /api/cluster/start to create it."
+		}
+
+		w.WriteHeader(statusCode)
+		response := map[string]string{
+			"status": "error",
+			"error":  message,
+		}
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			logger.Error(err, "failed to encode response")
+		}
+		return
+	}
+
+	response := api.ClusterKubeconfigResponse{
+		Kubeconfig: kubeconfig,
+		Context:    clusterManager.ContextName(),
+	}
+
+	// Return response as JSON
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
 // ClusterStartHandler handles POST /api/cluster/start requests.
 // It triggers cluster creation asynchronously and returns 202 Accepted immediately.
 func (h *Handlers) ClusterStartHandler(w http.ResponseWriter, r *http.Request) {
@@ -372,19 +1077,198 @@ func (h *Handlers) ClusterStartHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Execute cluster creation asynchronously in a goroutine
-	//nolint:contextcheck // Using Background context intentionally - request context would cancel when response is sent
-	go func() {
+	cfg, ok := h.profileConfig(w, r)
+	if !ok {
+		return
+	}
+
+	// A creation that is already running is not started twice
+	h.clusterMutex.Lock()
+	started, err := h.startClusterCreationLocked(cfg.Profile, h.clusterManagerFor(cfg))
+	h.clusterMutex.Unlock()
+	if err != nil {
+		writeOperationConflict(w, err)
+		return
+	}
+
+	message := "Cluster creation initiated. Use GET /api/cluster/status to check progress."
+	if !started {
+		message = "Cluster creation already in progress. Use GET /api/cluster/status to check progress."
+	}
+
+	// Immediately return 202 Accepted
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+
+	response := api.ClusterOperationResponse{
+		Status:  "accepted",
+		Message: message,
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.Error(err, "failed to encode response")
+	}
+}
+
+// ClusterEnsureHandler handles POST /api/cluster/ensure requests.
+// It makes sure the Kind cluster exists without racing concurrent callers, and reports
+// which action it took:
+//   - "already_running": the cluster is running (200 OK)
+//   - "creating": the cluster did not exist and its creation was started (202 Accepted)
+//   - "in_progress": the cluster is being created or is still initializing (202 Accepted)
+//
+// A paused cluster is left alone and reported as 409 Conflict, since resuming it is
+// up to the caller (POST /api/cluster/resume).
+func (h *Handlers) ClusterEnsureHandler(w http.ResponseWriter, r *http.Request) {
+	// Only accept POST requests
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	cfg, ok := h.profileConfig(w, r)
+	if !ok {
+		return
+	}
+	clusterManager := h.clusterManagerFor(cfg)
+
+	w.Header().Set("Content-Type", "application/json")
+	writeResponse := func(statusCode int, response api.ClusterEnsureResponse) {
+		w.WriteHeader(statusCode)
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			logger.Error(err, "failed to encode response")
+		}
+	}
+
+	// Hold the lock while checking, so concurrent callers see either this caller's
+	// creation or the running cluster, and only one of them creates it
+	h.clusterMutex.Lock()
+	defer h.clusterMutex.Unlock()
+
+	if h.clusterCreating[cfg.Profile] {
+		writeResponse(http.StatusAccepted, api.ClusterEnsureResponse{
+			Status:  "accepted",
+			Action:  "in_progress",
+			Message: "Cluster creation already in progress. Use GET /api/cluster/status to check progress.",
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	status, err := clusterManager.Status(ctx)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		response := map[string]string{
+			"status": "error",
+			"error":  err.Error(),
+		}
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			logger.Error(err, "failed to encode response")
+		}
+		return
+	}
+
+	switch status {
+	case cluster.StatusRunning:
+		writeResponse(http.StatusOK, api.ClusterEnsureResponse{
+			Status:  "ok",
+			Action:  "already_running",
+			Message: "Cluster is already running.",
+		})
+	case cluster.StatusInitializing:
+		writeResponse(http.StatusAccepted, api.ClusterEnsureResponse{
+			Status:  "accepted",
+			Action:  "in_progress",
+			Message: "Cluster exists and is still initializing. Use GET /api/cluster/status to check progress.",
+		})
+	case cluster.StatusPaused:
+		writeResponse(http.StatusConflict, api.ClusterEnsureResponse{
+			Status:  "error",
+			Action:  "paused",
+			Message: "Cluster is paused. Use POST /api/cluster/resume to start it.",
+		})
+	default:
+		if _, err := h.startClusterCreationLocked(cfg.Profile, clusterManager); err != nil {
+			writeOperationConflict(w, err)
+			return
+		}
+		writeResponse(http.StatusAccepted, api.ClusterEnsureResponse{
+			Status:  "accepted",
+			Action:  "creating",
+			Message: "Cluster creation initiated. Use GET /api/cluster/status to check progress.",
+		})
+	}
+}
+
+// startClusterCreationLocked creates the cluster of the named profile ("" for the
+// default environment) with clusterManager in the background, unless its creation is
+// already running, and reports whether it started one. It returns errShuttingDown
+// once the daemon is shutting down. h.clusterMutex must be held.
+func (h *Handlers) startClusterCreationLocked(profile string, clusterManager ClusterManager) (bool, error) {
+	if h.clusterCreating[profile] {
+		return false, nil
+	}
+	h.clusterCreating[profile] = true
+
+	//nolint:contextcheck // Using the daemon operation context intentionally - request context would cancel when response is sent
+	if err := h.startBackground("cluster_start", func() error {
+		defer func() {
+			h.clusterMutex.Lock()
+			delete(h.clusterCreating, profile)
+			h.clusterMutex.Unlock()
+		}()
+
 		logger.Info("starting cluster creation")
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		ctx, cancel := context.WithTimeout(h.operationCtx, 10*time.Minute)
 		defer cancel()
 
-		if err := h.ClusterManager.Create(ctx); err != nil {
+		if err := clusterManager.Create(ctx); err != nil {
 			logger.Error(err, "cluster creation failed")
-			return
+			return err
 		}
 		logger.Info("cluster created successfully")
-	}()
+		return nil
+	}); err != nil {
+		delete(h.clusterCreating, profile)
+		return false, err
+	}
+	return true, nil
+}
+
+// ClusterStopHandler handles POST /api/cluster/stop requests.
+// It triggers cluster destruction asynchronously and returns 202 Accepted immediately.
+func (h *Handlers) ClusterStopHandler(w http.ResponseWriter, r *http.Request) {
+	// Only accept POST requests
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	cfg, ok := h.profileConfig(w, r)
+	if !ok {
+		return
+	}
+	clusterManager := h.clusterManagerFor(cfg)
+
+	// Execute cluster destruction asynchronously in a goroutine
+	//nolint:contextcheck // Using the daemon operation context intentionally - request context would cancel when response is sent
+	if err := h.startBackground("cluster_stop", func() error {
+		logger.Info("starting cluster destruction")
+		ctx, cancel := context.WithTimeout(h.operationCtx, 5*time.Minute)
+		defer cancel()
+
+		if err := clusterManager.Destroy(ctx); err != nil {
+			logger.Error(err, "cluster destruction failed")
+			return err
+		}
+		logger.Info("cluster destroyed successfully")
+		return nil
+	}); err != nil {
+		writeOperationConflict(w, err)
+		return
+	}
 
 	// Immediately return 202 Accepted
 	w.Header().Set("Content-Type", "application/json")
@@ -392,7 +1276,7 @@ func (h *Handlers) ClusterStartHandler(w http.ResponseWriter, r *http.Request) {
 
 	response := api.ClusterOperationResponse{
 		Status:  "accepted",
-		Message: "Cluster creation initiated. Use GET /api/cluster/status to check progress.",
+		Message: "Cluster destruction initiated. Use GET /api/cluster/status to check progress.",
 	}
 
 	if err := json.NewEncoder(w).Encode(response); err != nil {
@@ -400,36 +1284,276 @@ func (h *Handlers) ClusterStartHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// ClusterStopHandler handles POST /api/cluster/stop requests.
-// It triggers cluster destruction asynchronously and returns 202 Accepted immediately.
-func (h *Handlers) ClusterStopHandler(w http.ResponseWriter, r *http.Request) {
+// ClusterPauseHandler handles POST /api/cluster/pause requests.
+// It stops the Kind node containers asynchronously, keeping the cluster and its state,
+// and returns 202 Accepted immediately. Use POST /api/cluster/resume to bring it back.
+func (h *Handlers) ClusterPauseHandler(w http.ResponseWriter, r *http.Request) {
+	// Only accept POST requests
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	cfg, ok := h.profileConfig(w, r)
+	if !ok {
+		return
+	}
+	clusterManager := h.clusterManagerFor(cfg)
+
+	// Execute the pause asynchronously in a goroutine
+	//nolint:contextcheck // Using the daemon operation context intentionally - request context would cancel when response is sent
+	if err := h.startBackground("cluster_pause", func() error {
+		logger.Info("starting cluster pause")
+		ctx, cancel := context.WithTimeout(h.operationCtx, 2*time.Minute)
+		defer cancel()
+
+		if err := clusterManager.Pause(ctx); err != nil {
+			logger.Error(err, "cluster pause failed")
+			return err
+		}
+		logger.Info("cluster paused successfully")
+		return nil
+	}); err != nil {
+		writeOperationConflict(w, err)
+		return
+	}
+
+	// Immediately return 202 Accepted
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+
+	response := api.ClusterOperationResponse{
+		Status:  "accepted",
+		Message: "Cluster pause initiated. GET /api/cluster/status reports \"Paused\" once the nodes are stopped.",
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.Error(err, "failed to encode response")
+	}
+}
+
+// ClusterResumeHandler handles POST /api/cluster/resume requests.
+// It starts the node containers of a paused Kind cluster asynchronously and returns
+// 202 Accepted immediately.
+func (h *Handlers) ClusterResumeHandler(w http.ResponseWriter, r *http.Request) {
+	// Only accept POST requests
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	cfg, ok := h.profileConfig(w, r)
+	if !ok {
+		return
+	}
+	clusterManager := h.clusterManagerFor(cfg)
+
+	// Execute the resume asynchronously in a goroutine
+	//nolint:contextcheck // Using the daemon operation context intentionally - request context would cancel when response is sent
+	if err := h.startBackground("cluster_resume", func() error {
+		logger.Info("starting cluster resume")
+		ctx, cancel := context.WithTimeout(h.operationCtx, 2*time.Minute)
+		defer cancel()
+
+		if err := clusterManager.Resume(ctx); err != nil {
+			logger.Error(err, "cluster resume failed")
+			return err
+		}
+		logger.Info("cluster resumed successfully")
+		return nil
+	}); err != nil {
+		writeOperationConflict(w, err)
+		return
+	}
+
+	// Immediately return 202 Accepted
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+
+	response := api.ClusterOperationResponse{
+		Status:  "accepted",
+		Message: "Cluster resume initiated. Use GET /api/cluster/status to check progress.",
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.Error(err, "failed to encode response")
+	}
+}
+
+// BuildHandler handles POST /api/mpc/build requests.
+// It triggers the MPC image build asynchronously and returns 202 Accepted immediately.
+// If another operation is in progress, it returns 409 Conflict, or queues the build with ?queue=true.
+// With ?force=true every image is rebuilt, even if its inputs are unchanged.
+func (h *Handlers) BuildHandler(w http.ResponseWriter, r *http.Request) {
+	// Only accept POST requests
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !defaultEnvironmentOnly(w, r) {
+		return
+	}
+
+	force, ok := boolQueryParam(w, r, "force")
+	if !ok {
+		return
+	}
+
+	cfg := h.Config()
+
+	// Execute the build asynchronously as a tracked operation (builds can take several minutes)
+	started := h.startOperation(w, r, "build", cfg.GetTimeouts().Build, func(ctx context.Context) error {
+		logger.Info("starting MPC image build")
+
+		// Call the build function
+		if _, err := h.BuildImages(ctx, cfg, force, h.operations.log("build")); err != nil {
+			logger.Error(err, "MPC image build failed")
+			return err
+		}
+
+		logger.Info("MPC image build completed successfully")
+		return nil
+	})
+	if !started {
+		return
+	}
+
+	// Immediately return 202 Accepted
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+
+	response := map[string]string{
+		"status":  "accepted",
+		"message": "MPC image build initiated. Check GET /api/operations/logs?name=build for build progress.",
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.Error(err, "failed to encode response")
+	}
+}
+
+// DeployResponse is the JSON body returned by POST /api/mpc/deploy.
+type DeployResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	DryRun  bool   `json:"dry_run"`
+}
+
+// DeployHandler handles POST /api/mpc/deploy requests.
+// It triggers the MPC deployment asynchronously and returns 202 Accepted immediately.
+// If another operation is in progress, it returns 409 Conflict, or queues the deployment with ?queue=true.
+//
+// With ?dry_run=true every change is only validated by the API server
+// (kubectl --dry-run=server) and the restart and verification steps are skipped.
+func (h *Handlers) DeployHandler(w http.ResponseWriter, r *http.Request) {
+	// Only accept POST requests
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !defaultEnvironmentOnly(w, r) {
+		return
+	}
+
+	dryRun, ok := boolQueryParam(w, r, "dry_run")
+	if !ok {
+		return
+	}
+
+	cfg := h.Config()
+
+	// Execute the deployment asynchronously as a tracked operation (deployments can take several minutes)
+	started := h.startOperation(w, r, "deploy", cfg.GetTimeouts().Deploy, func(ctx context.Context) error {
+		// Set operation status to "deploying_mpc" at the start
+		h.StateManager.SetOperationStatus("deploying_mpc", nil)
+
+		logger.Info("starting MPC deployment", "dryRun", dryRun)
+
+		// Call the deploy function with the configured image references
+		opts := deploy.DeployOptions{
+			DryRun:     dryRun,
+			IBMEnabled: h.StateManager.GetState().Features.IBMEnabled,
+			Output:     h.operations.log("deploy"),
+		}
+		if err := h.DeployMPC(ctx, cfg, opts); err != nil {
+			logger.Error(err, "MPC deployment failed")
+			h.StateManager.SetOperationStatus("idle", err)
+			return err
+		}
+
+		logger.Info("MPC deployment completed successfully")
+		h.StateManager.SetOperationStatus("idle", nil)
+		return nil
+	})
+	if !started {
+		return
+	}
+
+	// Immediately return 202 Accepted
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+
+	response := DeployResponse{
+		Status:  "accepted",
+		Message: "MPC deployment initiated. Check GET /api/mpc/deploy/status for its progress and GET /api/operations/logs?name=deploy for its output.",
+		DryRun:  dryRun,
+	}
+	if dryRun {
+		response.Message = "MPC deployment dry run initiated (kubectl --dry-run=server). Nothing will be changed; check daemon logs for the result."
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.Error(err, "failed to encode response")
+	}
+}
+
+// UndeployHandler handles POST /api/mpc/undeploy requests.
+// It removes the MPC operator, OTP server, and host-config ConfigMap asynchronously,
+// leaving Tekton and the cluster running. If an operation is already in progress,
+// it returns 409 Conflict, or queues the undeploy with ?queue=true.
+func (h *Handlers) UndeployHandler(w http.ResponseWriter, r *http.Request) {
 	// Only accept POST requests
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
+	if !defaultEnvironmentOnly(w, r) {
+		return
+	}
 
-	// Execute cluster destruction asynchronously in a goroutine
-	//nolint:contextcheck // Using Background context intentionally - request context would cancel when response is sent
-	go func() {
-		logger.Info("starting cluster destruction")
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
-		defer cancel()
+	cfg := h.Config()
 
-		if err := h.ClusterManager.Destroy(ctx); err != nil {
-			logger.Error(err, "cluster destruction failed")
-			return
+	// Execute the undeploy asynchronously as a tracked operation
+	started := h.startOperation(w, r, "undeploy", 5*time.Minute, func(ctx context.Context) error {
+		h.StateManager.SetOperationStatus("undeploying_mpc", nil)
+
+		logger.Info("starting MPC undeploy")
+
+		deployManager := deploy.NewManager(cfg)
+		deployManager.SetOutput(h.operations.log("undeploy"))
+		if err := deployManager.Undeploy(ctx); err != nil {
+			logger.Error(err, "MPC undeploy failed")
+			h.StateManager.SetOperationStatus("idle", err)
+			return err
 		}
-		logger.Info("cluster destroyed successfully")
-	}()
+
+		logger.Info("MPC undeploy completed successfully")
+		h.StateManager.ClearMPCDeployment()
+		h.StateManager.SetPreviousMPCImages(nil)
+		h.StateManager.SetOperationStatus("idle", nil)
+		return nil
+	})
+	if !started {
+		return
+	}
 
 	// Immediately return 202 Accepted
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusAccepted)
 
-	response := api.ClusterOperationResponse{
-		Status:  "accepted",
-		Message: "Cluster destruction initiated. Use GET /api/cluster/status to check progress.",
+	response := map[string]string{
+		"status":  "accepted",
+		"message": "MPC undeploy initiated. Check GET /api/operations/logs?name=undeploy for progress.",
 	}
 
 	if err := json.NewEncoder(w).Encode(response); err != nil {
@@ -437,50 +1561,51 @@ func (h *Handlers) ClusterStopHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// BuildHandler handles POST /api/mpc/build requests.
-// It triggers the MPC image build asynchronously and returns 202 Accepted immediately.
-// If a build is already in progress, it returns 409 Conflict.
-func (h *Handlers) BuildHandler(w http.ResponseWriter, r *http.Request) {
+// RestartHandler handles POST /api/mpc/restart requests.
+// It restarts the MPC deployments without rebuilding or changing their images, e.g.
+// so the controller picks up a changed ConfigMap, and waits for them to be ready.
+// ?component=controller or ?component=otp restarts only that deployment. If an
+// operation is already in progress, it returns 409 Conflict, or queues the restart
+// with ?queue=true.
+func (h *Handlers) RestartHandler(w http.ResponseWriter, r *http.Request) {
 	// Only accept POST requests
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
+	if !defaultEnvironmentOnly(w, r) {
+		return
+	}
 
-	// Try to acquire the lock. If we can't, a build is already in progress.
-	if !h.opMutex.TryLock() {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusConflict)
-		response := map[string]string{
-			"status": "conflict",
-			"error":  "A build or rebuild operation is already in progress",
-		}
-		if err := json.NewEncoder(w).Encode(response); err != nil {
-			logger.Error(err, "failed to encode response")
-		}
+	component := r.URL.Query().Get("component")
+	if component != "" && component != deploy.ComponentController && component != deploy.ComponentOTP {
+		http.Error(w, "component must be controller or otp", http.StatusBadRequest)
 		return
 	}
 
-	// Execute the build asynchronously in a goroutine
-	//nolint:contextcheck // Using Background context intentionally - request context would cancel when response is sent
-	go func() {
-		// Ensure we unlock the mutex when the goroutine completes
-		defer h.opMutex.Unlock()
+	cfg := h.Config()
 
-		logger.Info("starting MPC image build")
+	// Execute the restart asynchronously as a tracked operation
+	started := h.startOperation(w, r, "restart", cfg.GetTimeouts().Deploy, func(ctx context.Context) error {
+		h.StateManager.SetOperationStatus("restarting_mpc", nil)
 
-		// Create context with timeout (builds can take several minutes)
-		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
-		defer cancel()
+		logger.Info("starting MPC restart", "component", component)
 
-		// Call the build function
-		if err := build.BuildMPCImage(ctx, h.Config); err != nil {
-			logger.Error(err, "MPC image build failed")
-			return
+		deployManager := deploy.NewManager(cfg)
+		deployManager.SetOutput(h.operations.log("restart"))
+		if err := deployManager.Restart(ctx, component); err != nil {
+			logger.Error(err, "MPC restart failed")
+			h.StateManager.SetOperationStatus("idle", err)
+			return err
 		}
 
-		logger.Info("MPC image build completed successfully")
-	}()
+		logger.Info("MPC restart completed successfully")
+		h.StateManager.SetOperationStatus("idle", nil)
+		return nil
+	})
+	if !started {
+		return
+	}
 
 	// Immediately return 202 Accepted
 	w.Header().Set("Content-Type", "application/json")
@@ -488,7 +1613,7 @@ func (h *Handlers) BuildHandler(w http.ResponseWriter, r *http.Request) {
 
 	response := map[string]string{
 		"status":  "accepted",
-		"message": "MPC image build initiated. Check daemon logs for build progress.",
+		"message": "MPC restart initiated. Check GET /api/operations/logs?name=restart for progress.",
 	}
 
 	if err := json.NewEncoder(w).Encode(response); err != nil {
@@ -496,55 +1621,130 @@ func (h *Handlers) BuildHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// DeployHandler handles POST /api/mpc/deploy requests.
-// It triggers the MPC deployment asynchronously and returns 202 Accepted immediately.
-// If a deployment is already in progress, it returns 409 Conflict.
-func (h *Handlers) DeployHandler(w http.ResponseWriter, r *http.Request) {
+// DeployMPC deploys MPC with deploy.DeployMPC, recording the deployment's step progress
+// in the state for GET /api/mpc/deploy/status and the images it replaced as the target
+// of POST /api/mpc/rollback (see RecordPreviousImages). Any OnStep and OnPreviousImages
+// callbacks in opts are replaced.
+func (h *Handlers) DeployMPC(ctx context.Context, cfg *config.Config, opts deploy.DeployOptions) error {
+	progress := state.DeployProgress{
+		Status:    "Running",
+		DryRun:    opts.DryRun,
+		StartTime: time.Now().Format(time.RFC3339),
+	}
+	h.StateManager.SetDeployProgress(&progress)
+
+	opts.OnPreviousImages = h.RecordPreviousImages
+	opts.OnStep = func(step deploy.DeployStep) {
+		progress.Step = step.Index
+		progress.TotalSteps = step.Total
+		progress.StepName = step.Name
+		current := progress
+		h.StateManager.SetDeployProgress(&current)
+	}
+
+	err := deploy.DeployMPC(ctx, cfg, opts)
+	progress.Status = "Succeeded"
+	if err != nil {
+		progress.Status = "Failed"
+		progress.Error = err.Error()
+	}
+	h.StateManager.SetDeployProgress(&progress)
+	return err
+}
+
+// DeployStatusHandler handles GET /api/mpc/deploy/status requests.
+// It reports the step progress of the current or most recent MPC deployment: its status,
+// the step it is at (1-based) out of the total, and that step's name. Before the first
+// deployment, status is "None" and both step counts are 0. It is safe to poll.
+func (h *Handlers) DeployStatusHandler(w http.ResponseWriter, r *http.Request) {
+	// Only accept GET requests
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	response := state.DeployProgress{Status: "None"}
+	if progress := h.StateManager.GetState().DeployProgress; progress != nil {
+		response = *progress
+	}
+
+	// Set Content-Type header
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// RecordPreviousImages records the images a deployment replaced in the state, as the
+// target of POST /api/mpc/rollback. It is meant as deploy.DeployOptions.OnPreviousImages.
+func (h *Handlers) RecordPreviousImages(previous deploy.Images) {
+	h.StateManager.SetPreviousMPCImages(&state.PreviousMPCImages{
+		ControllerImage: previous.Controller,
+		OTPImage:        previous.OTP,
+		ReplacedAt:      time.Now(),
+	})
+}
+
+// RollbackHandler handles POST /api/mpc/rollback requests.
+// It patches the MPC deployments back to the images the most recent deployment
+// replaced (previous_mpc_images in GET /api/status) and restarts them asynchronously.
+// It returns 409 Conflict when no previous images are recorded, when they use a mutable
+// tag such as :latest (see deploy.CheckRollbackTarget), or when another operation is in
+// progress, unless the rollback is queued with ?queue=true.
+func (h *Handlers) RollbackHandler(w http.ResponseWriter, r *http.Request) {
 	// Only accept POST requests
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
+	if !defaultEnvironmentOnly(w, r) {
+		return
+	}
 
-	// Try to acquire the lock. If we can't, a deployment is already in progress.
-	if !h.opMutex.TryLock() {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusConflict)
-		response := map[string]string{
-			"status": "conflict",
-			"error":  "A build, rebuild, or deployment operation is already in progress",
-		}
-		if err := json.NewEncoder(w).Encode(response); err != nil {
-			logger.Error(err, "failed to encode response")
-		}
+	recorded := h.StateManager.GetState().PreviousMPCImages
+	if recorded == nil {
+		writeOperationConflict(w, deploy.ErrNoPreviousImages)
+		return
+	}
+	if err := deploy.CheckRollbackTarget(deploy.Images{Controller: recorded.ControllerImage, OTP: recorded.OTPImage}); err != nil {
+		writeOperationConflict(w, err)
 		return
 	}
 
-	// Execute the deployment asynchronously in a goroutine
-	//nolint:contextcheck // Using Background context intentionally - request context would cancel when response is sent
-	go func() {
-		// Ensure we unlock the mutex when the goroutine completes
-		defer h.opMutex.Unlock()
+	cfg := h.Config()
 
-		// Set operation status to "deploying_mpc" at the start
-		h.StateManager.SetOperationStatus("deploying_mpc", nil)
+	// Execute the rollback asynchronously as a tracked operation
+	started := h.startOperation(w, r, "rollback", cfg.GetTimeouts().Deploy, func(ctx context.Context) error {
+		h.StateManager.SetOperationStatus("rolling_back_mpc", nil)
 
-		logger.Info("starting MPC deployment")
+		// Read again, as a queued rollback may start after the images changed
+		previous := h.StateManager.GetState().PreviousMPCImages
+		if previous == nil {
+			h.StateManager.SetOperationStatus("idle", deploy.ErrNoPreviousImages)
+			return deploy.ErrNoPreviousImages
+		}
 
-		// Create context with timeout (deployments can take several minutes)
-		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
-		defer cancel()
+		logger.Info("starting MPC rollback", "controllerImage", previous.ControllerImage, "otpImage", previous.OTPImage)
 
-		// Call the deploy function
-		if err := deploy.DeployMPC(ctx, h.Config); err != nil {
-			logger.Error(err, "MPC deployment failed")
+		deployManager := deploy.NewManager(cfg)
+		deployManager.SetOutput(h.operations.log("rollback"))
+		deployManager.SetPreviousImages(deploy.Images{Controller: previous.ControllerImage, OTP: previous.OTPImage})
+		if err := deployManager.Rollback(ctx); err != nil {
+			logger.Error(err, "MPC rollback failed")
 			h.StateManager.SetOperationStatus("idle", err)
-			return
+			return err
 		}
 
-		logger.Info("MPC deployment completed successfully")
+		logger.Info("MPC rollback completed successfully")
+		h.StateManager.SetPreviousMPCImages(nil)
 		h.StateManager.SetOperationStatus("idle", nil)
-	}()
+		return nil
+	})
+	if !started {
+		return
+	}
 
 	// Immediately return 202 Accepted
 	w.Header().Set("Content-Type", "application/json")
@@ -552,7 +1752,7 @@ func (h *Handlers) DeployHandler(w http.ResponseWriter, r *http.Request) {
 
 	response := map[string]string{
 		"status":  "accepted",
-		"message": "MPC deployment initiated. Check daemon logs for deployment progress.",
+		"message": "MPC rollback initiated. Check GET /api/operations/logs?name=rollback for progress.",
 	}
 
 	if err := json.NewEncoder(w).Encode(response); err != nil {
@@ -560,69 +1760,146 @@ func (h *Handlers) DeployHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// MPCLogsResponse represents the JSON response for GET /api/mpc/logs.
+type MPCLogsResponse struct {
+	Component string `json:"component"`
+	Logs      string `json:"logs"`
+}
+
+// MPCLogsHandler handles GET /api/mpc/logs requests.
+// It returns the last lines logged by the pods of an MPC component, selected with
+// ?component=controller (the default) or ?component=otp. ?tail=N sets how many lines
+// of each container are returned (default: deploy.DefaultLogTailLines). Every replica
+// is included, each preceded by a "==> pod/<name> <==" header. With ?profile=<name>
+// the logs are read from the profile's cluster.
+func (h *Handlers) MPCLogsHandler(w http.ResponseWriter, r *http.Request) {
+	// Only accept GET requests
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	cfg, ok := h.profileConfig(w, r)
+	if !ok {
+		return
+	}
+
+	component := r.URL.Query().Get("component")
+	if component == "" {
+		component = deploy.ComponentController
+	}
+	if component != deploy.ComponentController && component != deploy.ComponentOTP {
+		http.Error(w, "component must be controller or otp", http.StatusBadRequest)
+		return
+	}
+
+	tail := int64(deploy.DefaultLogTailLines)
+	if value := r.URL.Query().Get("tail"); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed < 1 {
+			http.Error(w, "tail must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		tail = parsed
+	}
+
+	// Create context with timeout
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	// Set Content-Type header
+	w.Header().Set("Content-Type", "application/json")
+
+	var logs string
+	reader, err := deploy.NewLogReader(cfg.KubeconfigPath, cfg.GetKubeContext())
+	if err == nil {
+		logs, err = reader.ComponentLogs(ctx, component, tail)
+	}
+	if err != nil {
+		logger.Error(err, "failed to get MPC logs", "component", component)
+		w.WriteHeader(http.StatusInternalServerError)
+		response := map[string]string{
+			"status": "error",
+			"error":  err.Error(),
+		}
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			logger.Error(err, "failed to encode response")
+		}
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(MPCLogsResponse{Component: component, Logs: logs}); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
 // RebuildAndRedeployHandler handles POST /api/mpc/rebuild-and-redeploy requests.
 // It orchestrates the full rebuild and redeploy workflow by calling build and deploy in sequence.
 // This is the primary endpoint for the live-debugging workflow.
-// If an operation is already in progress, it returns 409 Conflict.
+// If an operation is already in progress, it returns 409 Conflict, or queues the workflow with ?queue=true.
+// With ?force=true every image is rebuilt, even if its inputs are unchanged.
 func (h *Handlers) RebuildAndRedeployHandler(w http.ResponseWriter, r *http.Request) {
 	// Only accept POST requests
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
+	if !defaultEnvironmentOnly(w, r) {
+		return
+	}
 
-	// Try to acquire the lock. If we can't, an operation is already in progress.
-	if !h.opMutex.TryLock() {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusConflict)
-		response := map[string]string{
-			"status": "conflict",
-			"error":  "A build, rebuild, or deployment operation is already in progress",
-		}
-		if err := json.NewEncoder(w).Encode(response); err != nil {
-			logger.Error(err, "failed to encode response")
-		}
+	force, ok := boolQueryParam(w, r, "force")
+	if !ok {
 		return
 	}
 
-	// Execute the rebuild-and-redeploy workflow asynchronously in a goroutine
-	//nolint:contextcheck // Using Background context intentionally - request context would cancel when response is sent
-	go func() {
-		// Ensure we unlock the mutex when the goroutine completes
-		defer h.opMutex.Unlock()
+	cfg := h.Config()
 
+	// Execute the rebuild-and-redeploy workflow asynchronously as a tracked operation
+	// (both steps can take time, so it gets the build and deploy timeouts combined)
+	timeouts := cfg.GetTimeouts()
+	started := h.startOperation(w, r, "rebuild_and_redeploy", timeouts.Build+timeouts.Deploy, func(ctx context.Context) error {
 		// Set operation status to "rebuilding_and_redeploying" at the start
 		h.StateManager.SetOperationStatus("rebuilding_and_redeploying", nil)
 
 		logger.Info("starting rebuild-and-redeploy orchestration")
 
-		// Create context with timeout (both operations can take time)
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
-		defer cancel()
-
 		// Step 1: Build the MPC image
 		logger.Info("orchestration step 1/2: building MPC image")
-		if err := build.BuildMPCImage(ctx, h.Config); err != nil {
+		output := h.operations.log("rebuild_and_redeploy")
+		gitHash, err := h.BuildImages(ctx, cfg, force, output)
+		if err != nil {
 			logger.Error(err, "rebuild-and-redeploy failed during build")
 			h.StateManager.SetOperationStatus("idle", err)
-			return
+			return err
 		}
 		logger.Info("orchestration build completed successfully")
 
-		// Step 2: Deploy the MPC to the cluster
-		logger.Info("orchestration step 2/2: deploying MPC to cluster")
-		if err := deploy.DeployMPC(ctx, h.Config); err != nil {
+		// Step 2: Deploy the MPC to the cluster, pinned to the images just built
+		logger.Info("orchestration step 2/2: deploying MPC to cluster", "sourceGitHash", gitHash)
+		opts := deploy.DeployOptions{
+			SourceGitHash: gitHash,
+			IBMEnabled:    h.StateManager.GetState().Features.IBMEnabled,
+			Output:        output,
+		}
+		if err := h.DeployMPC(ctx, cfg, opts); err != nil {
 			logger.Error(err, "rebuild-and-redeploy failed during deploy")
 			h.StateManager.SetOperationStatus("idle", err)
-			return
+			return err
 		}
+		h.StateManager.SetMPCSourceGitHash(gitHash)
 		logger.Info("orchestration deploy completed successfully")
 
 		logger.Info("rebuild-and-redeploy orchestration completed successfully")
 
 		// Set operation status back to idle (no error)
 		h.StateManager.SetOperationStatus("idle", nil)
-	}()
+		return nil
+	})
+	if !started {
+		return
+	}
 
 	// Immediately return 202 Accepted
 	w.Header().Set("Content-Type", "application/json")
@@ -630,7 +1907,7 @@ func (h *Handlers) RebuildAndRedeployHandler(w http.ResponseWriter, r *http.Requ
 
 	response := map[string]string{
 		"status":  "accepted",
-		"message": "Rebuild-and-redeploy orchestration initiated. Check daemon logs for progress.",
+		"message": "Rebuild-and-redeploy orchestration initiated. Check GET /api/operations/logs?name=rebuild_and_redeploy for progress.",
 	}
 
 	if err := json.NewEncoder(w).Encode(response); err != nil {
@@ -640,34 +1917,58 @@ func (h *Handlers) RebuildAndRedeployHandler(w http.ResponseWriter, r *http.Requ
 
 // GitSyncHandler handles POST /api/git/sync requests.
 // It triggers Git synchronization for all configured repositories asynchronously
-// and returns 202 Accepted immediately.
+// and returns 202 Accepted immediately. The outcome of each repository's sync is
+// recorded in the state and served by GET /api/git/sync/status.
 func (h *Handlers) GitSyncHandler(w http.ResponseWriter, r *http.Request) {
 	// Only accept POST requests
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
+	if !defaultEnvironmentOnly(w, r) {
+		return
+	}
+
+	cfg := h.Config()
+
+	startTime := time.Now().Format(time.RFC3339)
+	h.StateManager.SetGitSyncInfo(&state.GitSyncInfo{Status: "Running", StartTime: startTime})
 
 	// Execute Git sync asynchronously in a goroutine
-	//nolint:contextcheck // Using Background context intentionally - request context would cancel when response is sent
-	go func() {
+	//nolint:contextcheck // Using the daemon operation context intentionally - request context would cancel when response is sent
+	if err := h.startBackground("git_sync", func() error {
 		logger.Info("starting git repository synchronization")
 
 		// Create context with timeout (sync operations can take time)
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		ctx, cancel := context.WithTimeout(h.operationCtx, 5*time.Minute)
 		defer cancel()
 
 		// Create a new Syncer instance
-		syncer := git.NewSyncer(h.Config)
+		syncer := git.NewSyncer(cfg.GetRepoPaths(), cfg.GetGitSyncStrategy(), cfg.GetGitSyncBackup())
 
 		// Synchronize all repositories
-		if err := syncer.SyncAllRepos(ctx); err != nil {
+		results, err := syncer.SyncAllRepos(ctx)
+		info := &state.GitSyncInfo{
+			Status:    "Succeeded",
+			StartTime: startTime,
+			EndTime:   time.Now().Format(time.RFC3339),
+			Results:   results,
+		}
+		if err != nil {
+			info.Status = "Failed"
+			h.StateManager.SetGitSyncInfo(info)
 			logger.Error(err, "git synchronization failed")
-			return
+			return err
 		}
+		h.StateManager.SetGitSyncInfo(info)
 
 		logger.Info("git repository synchronization completed successfully")
-	}()
+		return nil
+	}); err != nil {
+		h.StateManager.SetGitSyncInfo(&state.GitSyncInfo{Status: "Failed", StartTime: startTime, EndTime: time.Now().Format(time.RFC3339)})
+		writeOperationConflict(w, err)
+		return
+	}
 
 	// Immediately return 202 Accepted
 	w.Header().Set("Content-Type", "application/json")
@@ -675,9 +1976,175 @@ func (h *Handlers) GitSyncHandler(w http.ResponseWriter, r *http.Request) {
 
 	response := map[string]string{
 		"status":  "accepted",
-		"message": "Git synchronization initiated. Check daemon logs for sync progress.",
+		"message": "Git synchronization initiated. Use GET /api/git/sync/status for the results.",
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.Error(err, "failed to encode response")
+	}
+}
+
+// GitSyncStatusHandler handles GET /api/git/sync/status requests.
+//
+// It returns the most recent git sync started by POST /api/git/sync as a
+// state.GitSyncInfo: its status and, once finished, each repository's branch, target,
+// strategy, HEAD before and after the sync, and error. Returns 404 Not Found when no
+// sync has been started since the daemon started.
+func (h *Handlers) GitSyncStatusHandler(w http.ResponseWriter, r *http.Request) {
+	// Only accept GET requests
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	info := h.StateManager.GetState().GitSync
+	if info == nil {
+		w.WriteHeader(http.StatusNotFound)
+		response := map[string]string{
+			"status": "error",
+			"error":  "No git sync has been started",
+		}
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			logger.Error(err, "failed to encode response")
+		}
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(info); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// GitChangesResponse represents the JSON response for GET /api/git/changes.
+//
+// Changes holds one ChangeSet per configured repository, sorted by repository name.
+// Errors maps repository names to the reason their changes could not be computed
+// (e.g., upstream/main has not been fetched yet).
+type GitChangesResponse struct {
+	Changes []state.ChangeSet `json:"changes"`
+	Errors  map[string]string `json:"errors,omitempty"`
+}
+
+// GitChangesHandler handles GET /api/git/changes requests.
+// It reports the upstream commits and changed files each configured repository is
+// missing, based on the locally cached upstream refs (see POST /api/git/sync).
+func (h *Handlers) GitChangesHandler(w http.ResponseWriter, r *http.Request) {
+	// Only accept GET requests
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	cfg := h.Config()
+	repoPaths := cfg.GetRepoPaths()
+	names := make([]string, 0, len(repoPaths))
+	for name := range repoPaths {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	gitManager := daemongit.NewGitManager(cfg.GetUpstreamURLs(), cfg.GetTimeouts().GitCommand)
+	gitManager.SetMPCPathPrefixes(cfg.GetMPCPathPrefixes())
+	response := GitChangesResponse{Changes: []state.ChangeSet{}}
+	for _, name := range names {
+		changeSet, err := gitManager.ComputeChangeSet(repoPaths[name])
+		if err != nil {
+			if response.Errors == nil {
+				response.Errors = make(map[string]string)
+			}
+			response.Errors[name] = err.Error()
+			continue
+		}
+		response.Changes = append(response.Changes, *changeSet)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// GitCheckoutRequest represents the JSON request body for POST /api/git/checkout.
+type GitCheckoutRequest struct {
+	Branch string `json:"branch"`
+	Create bool   `json:"create"` // create the branch from the current HEAD (git checkout -b)
+}
+
+// GitCheckoutResponse represents the JSON response for POST /api/git/checkout.
+//
+// DirtyFiles is populated when the checkout is refused because the MPC repository
+// has uncommitted changes.
+type GitCheckoutResponse struct {
+	Status     string   `json:"status"`
+	Branch     string   `json:"branch"`
+	Error      string   `json:"error,omitempty"`
+	DirtyFiles []string `json:"dirty_files,omitempty"`
+}
+
+// GitCheckoutHandler handles POST /api/git/checkout requests.
+// It switches the MPC repository to the requested branch (optionally creating it) and
+// refreshes the state so GET /api/status reports the new branch. Returns 409 Conflict
+// with the list of dirty files if the repository has uncommitted changes.
+func (h *Handlers) GitCheckoutHandler(w http.ResponseWriter, r *http.Request) {
+	// Only accept POST requests
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !defaultEnvironmentOnly(w, r) {
+		return
+	}
+
+	// Parse request body
+	var req GitCheckoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Branch == "" {
+		http.Error(w, "branch is required", http.StatusBadRequest)
+		return
+	}
+
+	// Don't switch branches under a running build or deploy
+	end, err := h.operations.begin("git_checkout")
+	if err != nil {
+		writeOperationConflict(w, err)
+		return
+	}
+	defer end()
+
+	response := GitCheckoutResponse{Status: "success", Branch: req.Branch}
+	statusCode := http.StatusOK
+
+	cfg := h.Config()
+	gitManager := daemongit.NewGitManager(cfg.GetUpstreamURLs(), cfg.GetTimeouts().GitCommand)
+	err = gitManager.CheckoutBranch(cfg.GetMpcRepoPath(), req.Branch, req.Create)
+	var dirtyErr *daemongit.DirtyWorkingTreeError
+	switch {
+	case errors.As(err, &dirtyErr):
+		response.Status = "conflict"
+		response.Error = err.Error()
+		response.DirtyFiles = dirtyErr.Files
+		statusCode = http.StatusConflict
+	case err != nil:
+		logger.Error(err, "git checkout failed", "branch", req.Branch)
+		response.Status = "error"
+		response.Error = err.Error()
+		statusCode = http.StatusInternalServerError
+	default:
+		logger.Info("checked out MPC branch", "branch", req.Branch, "create", req.Create)
+		if err := h.StateManager.RefreshState(); err != nil {
+			logger.Error(err, "failed to refresh state after checkout")
+		}
 	}
 
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
 	if err := json.NewEncoder(w).Encode(response); err != nil {
 		logger.Error(err, "failed to encode response")
 	}
@@ -700,6 +2167,9 @@ func (h *Handlers) DeploySecretsHandler(w http.ResponseWriter, r *http.Request)
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
+	if !defaultEnvironmentOnly(w, r) {
+		return
+	}
 
 	// Parse request body
 	var req DeploySecretsRequest
@@ -720,48 +2190,43 @@ func (h *Handlers) DeploySecretsHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	cfg := h.Config()
+	creds := deploy.AWSCredentials{
+		AccessKeyID:     req.AWSAccessKeyID,
+		SecretAccessKey: req.AWSSecretAccessKey,
+		SessionToken:    req.AWSSessionToken,
+		SSHKeyPath:      req.SSHKeyPath,
+	}
+
 	// Execute secrets deployment asynchronously in a goroutine
-	//nolint:contextcheck // Using Background context intentionally - request context would cancel when response is sent
-	go func() {
+	//nolint:contextcheck // Using the daemon operation context intentionally - request context would cancel when response is sent
+	if err := h.startBackground("deploy_secrets", func() error {
 		// Set operation status to "deploying_secrets" at the start
 		h.StateManager.SetOperationStatus("deploying_secrets", nil)
 
 		logger.Info("starting AWS secrets deployment")
 
 		// Create context with timeout
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		ctx, cancel := context.WithTimeout(h.operationCtx, cfg.GetTimeouts().Secrets)
 		defer cancel()
 
-		// Set environment variables from request credentials
-		_ = os.Setenv("AWS_ACCESS_KEY_ID", req.AWSAccessKeyID)
-		_ = os.Setenv("AWS_SECRET_ACCESS_KEY", req.AWSSecretAccessKey)
-		_ = os.Setenv("AWS_SESSION_TOKEN", req.AWSSessionToken)
-		_ = os.Setenv("SSH_KEY_PATH", req.SSHKeyPath)
-
 		// Create deployment manager and apply secrets
-		deployManager := deploy.NewManager(h.Config)
-		if err := deployManager.ApplySecrets(ctx); err != nil {
+		deployManager := deploy.NewManager(cfg)
+		if err := deployManager.ApplySecrets(ctx, creds); err != nil {
 			logger.Error(err, "secrets deployment failed")
 			h.StateManager.SetOperationStatus("idle", err)
-			// Clear environment variables on failure
-			_ = os.Unsetenv("AWS_ACCESS_KEY_ID")
-			_ = os.Unsetenv("AWS_SECRET_ACCESS_KEY")
-			_ = os.Unsetenv("AWS_SESSION_TOKEN")
-			_ = os.Unsetenv("SSH_KEY_PATH")
-			return
+			return err
 		}
 
 		logger.Info("AWS secrets deployment completed successfully")
 
-		// Clear environment variables after successful deployment for security
-		_ = os.Unsetenv("AWS_ACCESS_KEY_ID")
-		_ = os.Unsetenv("AWS_SECRET_ACCESS_KEY")
-		_ = os.Unsetenv("AWS_SESSION_TOKEN")
-		_ = os.Unsetenv("SSH_KEY_PATH")
-
 		// Set operation status back to idle (no error)
 		h.StateManager.SetOperationStatus("idle", nil)
-	}()
+		return nil
+	}); err != nil {
+		writeOperationConflict(w, err)
+		return
+	}
 
 	// Immediately return 202 Accepted
 	w.Header().Set("Content-Type", "application/json")
@@ -779,39 +2244,56 @@ func (h *Handlers) DeploySecretsHandler(w http.ResponseWriter, r *http.Request)
 
 // DeployKonfluxHandler handles POST /api/deploy/konflux requests.
 // It triggers the deployment of Konflux to the Kind cluster asynchronously
-// and returns 202 Accepted immediately.
+// and returns 202 Accepted immediately. Once deployed, the Konflux UI URL and user
+// are reported as konflux in GET /api/status.
 func (h *Handlers) DeployKonfluxHandler(w http.ResponseWriter, r *http.Request) {
 	// Only accept POST requests
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
+	if !defaultEnvironmentOnly(w, r) {
+		return
+	}
+
+	cfg := h.Config()
 
 	// Execute Konflux deployment asynchronously in a goroutine
-	//nolint:contextcheck // Using Background context intentionally - request context would cancel when response is sent
-	go func() {
+	//nolint:contextcheck // Using the daemon operation context intentionally - request context would cancel when response is sent
+	if err := h.startBackground("deploy_konflux", func() error {
 		// Set operation status to "deploying_konflux" at the start
 		h.StateManager.SetOperationStatus("deploying_konflux", nil)
 
 		logger.Info("starting Konflux deployment")
 
 		// Create context with timeout (Konflux deployment can take 20+ minutes)
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+		ctx, cancel := context.WithTimeout(h.operationCtx, cfg.GetTimeouts().Konflux)
 		defer cancel()
 
-		// Create deployment manager and apply Konflux
-		deployManager := deploy.NewManager(h.Config)
+		// Create deployment manager and apply Konflux, keeping the scripts' output
+		output := h.operations.log("deploy_konflux")
+		output.reset()
+		deployManager := deploy.NewManager(cfg)
+		deployManager.SetOutput(output)
 		if err := deployManager.ApplyKonflux(ctx); err != nil {
 			logger.Error(err, "Konflux deployment failed")
 			h.StateManager.SetOperationStatus("idle", err)
-			return
+			return err
 		}
 
 		logger.Info("Konflux deployment completed successfully")
+		h.StateManager.SetKonfluxInfo(&state.KonfluxInfo{
+			URL:      cfg.GetKonfluxUIURL(),
+			Username: cfg.GetKonfluxUsername(),
+		})
 
 		// Set operation status back to idle (no error)
 		h.StateManager.SetOperationStatus("idle", nil)
-	}()
+		return nil
+	}); err != nil {
+		writeOperationConflict(w, err)
+		return
+	}
 
 	// Immediately return 202 Accepted
 	w.Header().Set("Content-Type", "application/json")
@@ -827,41 +2309,72 @@ func (h *Handlers) DeployKonfluxHandler(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// DeployMinimalStackRequest represents the optional JSON request body for
+// POST /api/deploy/minimal-stack.
+//
+// Components selects the components to deploy, from "tekton", "cert-manager",
+// "operator" and "otp" (see deploy.StackComponents); empty deploys all of them.
+type DeployMinimalStackRequest struct {
+	Components []string `json:"components,omitempty"`
+}
+
 // DeployMinimalStackHandler handles POST /api/deploy/minimal-stack requests.
 // It triggers the deployment of the minimal MPC stack (Tekton + MPC Operator + OTP)
 // to the Kind cluster asynchronously and returns 202 Accepted immediately.
+// The request body can limit the deployment to some of its components (see
+// DeployMinimalStackRequest); an unknown component is rejected with 400 Bad Request.
 func (h *Handlers) DeployMinimalStackHandler(w http.ResponseWriter, r *http.Request) {
 	// Only accept POST requests
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
+	if !defaultEnvironmentOnly(w, r) {
+		return
+	}
+
+	// The request body is optional
+	var req DeployMinimalStackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := deploy.ValidateStackComponents(req.Components); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	cfg := h.Config()
 
 	// Execute minimal stack deployment asynchronously in a goroutine
-	//nolint:contextcheck // Using Background context intentionally - request context would cancel when response is sent
-	go func() {
+	//nolint:contextcheck // Using the daemon operation context intentionally - request context would cancel when response is sent
+	if err := h.startBackground("deploy_minimal_stack", func() error {
 		// Set operation status to "deploying_minimal_stack" at the start
 		h.StateManager.SetOperationStatus("deploying_minimal_stack", nil)
 
 		logger.Info("starting minimal MPC stack deployment")
 
 		// Create context with timeout (minimal deployment should be fast, ~5 minutes)
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		ctx, cancel := context.WithTimeout(h.operationCtx, cfg.GetTimeouts().MinimalStack)
 		defer cancel()
 
 		// Create minimal deployer and deploy the stack
-		minimalDeployer := deploy.NewMinimalDeployer(h.Config)
-		if err := minimalDeployer.DeployMinimalStack(ctx); err != nil {
+		minimalDeployer := deploy.NewMinimalDeployer(cfg)
+		if err := minimalDeployer.DeployMinimalStack(ctx, req.Components); err != nil {
 			logger.Error(err, "minimal stack deployment failed")
 			h.StateManager.SetOperationStatus("idle", err)
-			return
+			return err
 		}
 
 		logger.Info("minimal stack deployment completed successfully")
 
 		// Set operation status back to idle (no error)
 		h.StateManager.SetOperationStatus("idle", nil)
-	}()
+		return nil
+	}); err != nil {
+		writeOperationConflict(w, err)
+		return
+	}
 
 	// Immediately return 202 Accepted
 	w.Header().Set("Content-Type", "application/json")
@@ -879,15 +2392,25 @@ func (h *Handlers) DeployMinimalStackHandler(w http.ResponseWriter, r *http.Requ
 
 // TaskRunRunRequest represents the JSON request body for POST /api/taskrun/run.
 //
-// The YAMLPath should point to a valid Tekton TaskRun YAML file on the filesystem.
-// This is typically a file in the taskruns/ directory.
+// Exactly one of YAMLPath or YAMLContent must be set. YAMLPath should point to a valid
+// Tekton TaskRun YAML file on the filesystem within the taskruns directory
+// (Config.GetTaskRunsDir); paths resolving elsewhere, e.g. via "..", are rejected.
+// YAMLContent holds the TaskRun YAML itself, so callers don't need to write a temp file.
+// Namespace optionally overrides the namespace the TaskRun is created in
+// (default: multi-platform-controller, or the profile's namespace). Params are merged into the TaskRun's
+// spec.params, overriding params of the same name, so image references or revisions
+// can change per run without editing the YAML; every value must be non-empty.
 type TaskRunRunRequest struct {
-	YAMLPath string `json:"yaml_path"`
+	YAMLPath    string            `json:"yaml_path,omitempty"`
+	YAMLContent string            `json:"yaml_content,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Params      map[string]string `json:"params,omitempty"`
 }
 
 // TaskRunRunHandler handles POST /api/taskrun/run requests.
 // It triggers the complete TaskRun workflow asynchronously and returns 202 Accepted immediately.
 // The workflow includes: applying TaskRun, monitoring status, streaming logs to file, and updating state.
+// With ?profile=<name>, the TaskRun runs in the named profile's cluster and namespace.
 func (h *Handlers) TaskRunRunHandler(w http.ResponseWriter, r *http.Request) {
 	// Only accept POST requests
 	if r.Method != http.MethodPost {
@@ -902,15 +2425,54 @@ func (h *Handlers) TaskRunRunHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validate YAML path
-	if req.YAMLPath == "" {
-		http.Error(w, "yaml_path is required", http.StatusBadRequest)
+	// Validate that exactly one TaskRun source is provided
+	if (req.YAMLPath == "") == (req.YAMLContent == "") {
+		http.Error(w, "exactly one of yaml_path or yaml_content is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := taskrun.ValidateParams(req.Params); err != nil {
+		http.Error(w, fmt.Sprintf("invalid params: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	cfg, ok := h.profileConfig(w, r)
+	if !ok {
 		return
 	}
 
-	// Start async operation
-	//nolint:contextcheck // Using Background context intentionally - request context would cancel when response is sent
-	go h.runTaskRunWorkflow(context.Background(), req.YAMLPath)
+	// A path is only read from the taskruns directory, so callers cannot make the daemon
+	// read or apply arbitrary files
+	if req.YAMLPath != "" {
+		resolved, err := resolveTaskRunPath(cfg.GetTaskRunsDir(), req.YAMLPath)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid yaml_path: %v", err), http.StatusBadRequest)
+			return
+		}
+		req.YAMLPath = resolved
+	}
+
+	// Inline YAML is parsed up front so malformed content is rejected synchronously
+	if req.YAMLContent != "" {
+		if _, err := taskrun.ParseTaskRunName([]byte(req.YAMLContent)); err != nil {
+			http.Error(w, fmt.Sprintf("invalid yaml_content: %v", err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Start async operation with a cancellable context so POST /api/taskrun/cancel can stop it
+	//nolint:contextcheck // Using the daemon operation context intentionally - request context would cancel when response is sent
+	ctx, cancel := context.WithCancel(h.operationCtx)
+	id := h.trackTaskRun(cancel)
+	if err := h.startBackground("taskrun", func() error {
+		defer h.untrackTaskRun(id)
+		return h.runTaskRunWorkflow(ctx, cfg, req)
+	}); err != nil {
+		h.untrackTaskRun(id)
+		cancel()
+		writeOperationConflict(w, err)
+		return
+	}
 
 	// Immediately return 202 Accepted
 	w.Header().Set("Content-Type", "application/json")
@@ -926,74 +2488,511 @@ func (h *Handlers) TaskRunRunHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// resolveTaskRunPath resolves yamlPath to an absolute path with symlinks followed and
+// fails unless the file exists and lies within dir (itself resolved the same way).
+// Relative paths are taken relative to the daemon's working directory.
+func resolveTaskRunPath(dir, yamlPath string) (string, error) {
+	root, err := filepath.Abs(dir)
+	if err == nil {
+		root, err = filepath.EvalSymlinks(root)
+	}
+	if err != nil {
+		return "", fmt.Errorf("taskruns directory %s is not accessible: %w", dir, err)
+	}
+
+	resolved, err := filepath.Abs(yamlPath)
+	if err == nil {
+		resolved, err = filepath.EvalSymlinks(resolved)
+	}
+	if err != nil {
+		return "", fmt.Errorf("cannot resolve %s: %w", yamlPath, err)
+	}
+
+	if rel, err := filepath.Rel(root, resolved); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) || rel == "." {
+		return "", fmt.Errorf("%s is not within the taskruns directory %s", yamlPath, dir)
+	}
+	return resolved, nil
+}
+
 // runTaskRunWorkflow runs the complete TaskRun workflow asynchronously.
 //
 // This method coordinates the entire TaskRun lifecycle:
 //  1. Updates state to "running_taskrun" and clears previous TaskRun info
-//  2. Generates log filename and ensures logs directory exists
+//  2. Generates log filename, publishes in-flight TaskRun info, and ensures logs directory exists
 //  3. Creates TaskRun manager and delegates to its RunTaskRunWorkflow method
-//  4. Updates state with final results (name, status, log location)
+//  4. Updates state with final results (name, status, log location, TaskRun results)
 //
 // All Kubernetes and Tekton operations are handled by the taskrun.Manager.
-// This handler only orchestrates the workflow and manages state updates.
-func (h *Handlers) runTaskRunWorkflow(ctx context.Context, yamlPath string) {
+// This handler only orchestrates the workflow and manages state updates. It returns
+// the reason the workflow failed, or context.Canceled if it was cancelled.
+func (h *Handlers) runTaskRunWorkflow(ctx context.Context, cfg *config.Config, req TaskRunRunRequest) error {
+	// Cancelled before it started - nothing to do
+	if ctx.Err() != nil {
+		return context.Canceled
+	}
+
 	// Update operation status to running_taskrun
 	h.StateManager.SetOperationStatus("running_taskrun", nil)
 	h.StateManager.ClearTaskRunInfo() // Clear previous TaskRun info
 
-	// Generate log filename based on TaskRun YAML filename
-	logFilename := generateLogFilename(yamlPath)
-	logPath := filepath.Join(h.Config.GetSessionLogDir(), logFilename)
+	// Generate log filename based on TaskRun YAML filename, or the TaskRun name for inline YAML
+	logFilename := generateLogFilename(req.YAMLPath)
+	if req.YAMLPath == "" {
+		logFilename = timestampedLogFilename(inlineTaskRunName(req.YAMLContent))
+	}
+	logPath := filepath.Join(cfg.GetSessionLogDir(), logFilename)
+	startTime := time.Now().Format(time.RFC3339)
+
+	// Publish in-flight info so GET /api/taskrun/status can report live progress
+	h.StateManager.SetTaskRunInfo(&state.TaskRunInfo{
+		Status:    "Running",
+		LogFile:   logPath,
+		StartTime: startTime,
+	})
 
 	// Ensure session log directory exists
-	if err := os.MkdirAll(h.Config.GetSessionLogDir(), 0750); err != nil {
+	if err := os.MkdirAll(cfg.GetSessionLogDir(), 0750); err != nil {
 		logger.Error(err, "failed to create session log directory")
 		h.StateManager.SetOperationStatus("idle", err)
 		h.StateManager.SetTaskRunInfo(&state.TaskRunInfo{
-			Status: "Error",
+			Status:    "Error",
+			LogFile:   logPath,
+			StartTime: startTime,
 		})
-		return
+		return err
 	}
 
 	// Create TaskRun manager
-	mgr, err := taskrun.NewManager()
+	namespace := req.Namespace
+	if namespace == "" {
+		namespace = cfg.TaskRunNamespace
+	}
+	mgr, err := taskrun.NewManagerForContext(cfg.KubeconfigPath, cfg.GetKubeContext(), namespace)
 	if err != nil {
 		errMsg := fmt.Errorf("failed to create TaskRun manager: %w", err)
 		logger.Error(errMsg, "failed to create TaskRun manager")
 		h.StateManager.SetOperationStatus("idle", errMsg)
-		return
+		h.StateManager.SetTaskRunInfo(&state.TaskRunInfo{
+			Status:    "Error",
+			LogFile:   logPath,
+			StartTime: startTime,
+		})
+		return errMsg
 	}
+	mgr.SetTimeout(cfg.GetTimeouts().TaskRun)
+	mgr.SetParams(req.Params)
 
 	// Run the workflow
-	logger.Info("starting TaskRun workflow", "yamlPath", yamlPath)
-	name, status, err := mgr.RunTaskRunWorkflow(ctx, yamlPath, logPath)
+	var name, status string
+	if req.YAMLPath != "" {
+		logger.Info("starting TaskRun workflow", "yamlPath", req.YAMLPath)
+		name, status, err = mgr.RunTaskRunWorkflow(ctx, req.YAMLPath, logPath)
+	} else {
+		logger.Info("starting TaskRun workflow from inline YAML")
+		name, status, err = mgr.RunTaskRunWorkflowFromYAML(ctx, []byte(req.YAMLContent), logPath)
+	}
+
+	// A cancelled workflow has already had its state reset by TaskRunCancelHandler
+	if errors.Is(err, taskrun.ErrTaskRunCancelled) || ctx.Err() != nil {
+		logger.Info("TaskRun workflow cancelled", "name", name)
+		return context.Canceled
+	}
 
 	// Update state with results
 	if err != nil {
 		errMsg := fmt.Errorf("TaskRun workflow failed: %w", err)
 		logger.Error(errMsg, "TaskRun workflow failed")
 		h.StateManager.SetOperationStatus("idle", errMsg)
-		return
+		h.StateManager.SetTaskRunInfo(&state.TaskRunInfo{
+			Name:      name,
+			Status:    "Error",
+			LogFile:   logPath,
+			StartTime: startTime,
+		})
+		return errMsg
 	}
 
-	// Success - store TaskRun info
+	// Success - store TaskRun info; a TaskRun that ran but failed still fails the operation
 	logger.Info("TaskRun workflow completed", "name", name, "status", status)
-	h.StateManager.SetOperationStatus("idle", nil)
+	var failure error
+	if status == "Failed" {
+		failure = fmt.Errorf("TaskRun '%s' failed - check logs at %s", name, logPath)
+	}
+	h.StateManager.SetOperationStatus("idle", failure)
 	h.StateManager.SetTaskRunInfo(&state.TaskRunInfo{
 		Name:      name,
 		Status:    status,
 		LogFile:   logPath,
-		StartTime: time.Now().Format(time.RFC3339),
+		StartTime: startTime,
+		Results:   mgr.Results(),
 	})
 
-	if status == "Failed" {
-		errMsg := fmt.Errorf("TaskRun '%s' failed - check logs at %s", name, logPath)
-		h.StateManager.SetOperationStatus("idle", errMsg)
-	}
-
 	// Log collection is triggered explicitly by the bash script via POST /api/collect-logs.
 	// This avoids a race condition where async collection could write artifacts into latest/
 	// after the bash script has already rotated the log directory for a new TaskRun.
+	return failure
+}
+
+// TaskRunCancelHandler handles POST /api/taskrun/cancel requests.
+//
+// It signals every running TaskRun workflow to stop. Each workflow deletes its TaskRun
+// from the cluster via taskrun.Manager.CancelTaskRun in the background, while the
+// operation status and TaskRun info are reset immediately.
+//
+// Returns 200 OK if a TaskRun was cancelled, or 404 Not Found if none is running.
+func (h *Handlers) TaskRunCancelHandler(w http.ResponseWriter, r *http.Request) {
+	// Only accept POST requests
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if cancelled := h.cancelTaskRuns(); cancelled == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		response := map[string]string{
+			"status": "error",
+			"error":  "No TaskRun is currently running",
+		}
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			logger.Error(err, "failed to encode response")
+		}
+		return
+	}
+
+	logger.Info("TaskRun workflow cancellation requested")
+	h.StateManager.SetOperationStatus("idle", nil)
+	h.StateManager.ClearTaskRunInfo()
+
+	response := map[string]string{
+		"status":  "cancelled",
+		"message": "TaskRun cancelled",
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.Error(err, "failed to encode response")
+	}
+}
+
+// trackTaskRun registers the cancel func of a new TaskRun workflow and returns its ID.
+func (h *Handlers) trackTaskRun(cancel context.CancelFunc) uint64 {
+	h.taskRunMutex.Lock()
+	defer h.taskRunMutex.Unlock()
+
+	if h.taskRunCancels == nil {
+		h.taskRunCancels = make(map[uint64]context.CancelFunc)
+	}
+	h.nextTaskRunID++
+	h.taskRunCancels[h.nextTaskRunID] = cancel
+	return h.nextTaskRunID
+}
+
+// untrackTaskRun releases the context of a finished TaskRun workflow.
+func (h *Handlers) untrackTaskRun(id uint64) {
+	h.taskRunMutex.Lock()
+	defer h.taskRunMutex.Unlock()
+
+	if cancel, ok := h.taskRunCancels[id]; ok {
+		cancel()
+		delete(h.taskRunCancels, id)
+	}
+}
+
+// cancelTaskRuns cancels all running TaskRun workflows and returns how many were cancelled.
+func (h *Handlers) cancelTaskRuns() int {
+	h.taskRunMutex.Lock()
+	defer h.taskRunMutex.Unlock()
+
+	cancelled := len(h.taskRunCancels)
+	for id, cancel := range h.taskRunCancels {
+		cancel()
+		delete(h.taskRunCancels, id)
+	}
+	return cancelled
+}
+
+// TaskRunStatusResponse represents the JSON response for GET /api/taskrun/status.
+//
+// Phase summarizes the TaskRun lifecycle for the bash front-end:
+//   - "none": No TaskRun has been started in this session
+//   - "running": A TaskRun workflow is in progress
+//   - "succeeded": The most recent TaskRun succeeded
+//   - "failed": The most recent TaskRun failed, timed out, or errored
+type TaskRunStatusResponse struct {
+	Phase     string            `json:"phase"`
+	Name      string            `json:"name,omitempty"`
+	Status    string            `json:"status,omitempty"`
+	LogFile   string            `json:"log_file,omitempty"`
+	StartTime string            `json:"start_time,omitempty"`
+	Results   map[string]string `json:"results,omitempty"`
+}
+
+// TaskRunStatusHandler handles GET /api/taskrun/status requests.
+// It reports the current or most recent TaskRun from the StateManager without
+// triggering any new work, so it is safe to poll.
+func (h *Handlers) TaskRunStatusHandler(w http.ResponseWriter, r *http.Request) {
+	// Only accept GET requests
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	currentState := h.StateManager.GetState()
+	info := currentState.TaskRunInfo
+
+	response := TaskRunStatusResponse{
+		Phase: taskRunPhase(currentState.OperationStatus, info),
+	}
+	if info != nil {
+		response.Name = info.Name
+		response.Status = info.Status
+		response.LogFile = info.LogFile
+		response.StartTime = info.StartTime
+		response.Results = info.Results
+	}
+
+	// Set Content-Type header
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// TaskRunListResponse represents the JSON response for GET /api/taskrun/list.
+type TaskRunListResponse struct {
+	TaskRuns []taskrun.TaskRunSummary `json:"taskruns"`
+}
+
+// TaskRunListHandler handles GET /api/taskrun/list requests.
+//
+// It lists the TaskRuns in the cluster with their status, start and completion times,
+// newest first. Query parameters:
+//   - limit: return at most this many TaskRuns (default: all)
+//   - namespace: list TaskRuns in this namespace instead of multi-platform-controller
+//   - profile: list TaskRuns in the named profile's cluster, and namespace unless given
+//
+// Returns 400 Bad Request for an invalid limit.
+func (h *Handlers) TaskRunListHandler(w http.ResponseWriter, r *http.Request) {
+	// Only accept GET requests
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	limit := 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			http.Error(w, "limit must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	cfg, ok := h.profileConfig(w, r)
+	if !ok {
+		return
+	}
+
+	// Create context with timeout
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	// Set Content-Type header
+	w.Header().Set("Content-Type", "application/json")
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		namespace = cfg.TaskRunNamespace
+	}
+
+	var taskRuns []taskrun.TaskRunSummary
+	mgr, err := taskrun.NewManagerForContext(cfg.KubeconfigPath, cfg.GetKubeContext(), namespace)
+	if err == nil {
+		taskRuns, err = mgr.ListTaskRuns(ctx, limit)
+	}
+	if err != nil {
+		logger.Error(err, "failed to list TaskRuns")
+		w.WriteHeader(http.StatusInternalServerError)
+		response := map[string]string{
+			"status": "error",
+			"error":  err.Error(),
+		}
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			logger.Error(err, "failed to encode response")
+		}
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(TaskRunListResponse{TaskRuns: taskRuns}); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// taskRunLogPollInterval is how often TaskRunLogsHandler checks the log file for new output.
+var taskRunLogPollInterval = 500 * time.Millisecond
+
+// TaskRunLogsHandler handles GET /api/taskrun/logs requests.
+//
+// It streams the log file of the current or most recent TaskRun as Server-Sent Events.
+// Each log line is sent as a "data:" event, following the file as the TaskRun manager
+// appends to it. Once the TaskRun reaches a terminal state and all output has been sent,
+// a final "done" event carrying the TaskRun status is emitted and the stream is closed.
+// Streaming stops early if the client disconnects.
+//
+// Returns 404 Not Found if no TaskRun has been started.
+func (h *Handlers) TaskRunLogsHandler(w http.ResponseWriter, r *http.Request) {
+	// Only accept GET requests
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	// runTaskRunWorkflow publishes the log path as soon as the workflow starts
+	info := h.StateManager.GetState().TaskRunInfo
+	if info == nil || info.LogFile == "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		response := map[string]string{
+			"status": "error",
+			"error":  "No TaskRun logs available",
+		}
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			logger.Error(err, "failed to encode response")
+		}
+		return
+	}
+	logPath := info.LogFile
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	var (
+		logFile *os.File
+		pending []byte
+	)
+	defer func() {
+		if logFile != nil {
+			_ = logFile.Close()
+		}
+	}()
+
+	for {
+		// Check for a terminal state before draining so output written right before
+		// the TaskRun finished is still sent
+		currentState := h.StateManager.GetState()
+		finished := taskRunPhase(currentState.OperationStatus, currentState.TaskRunInfo) != "running"
+
+		// The log file is only created once the TaskRun pod is running
+		if logFile == nil {
+			if f, err := os.Open(logPath); err == nil {
+				logFile = f
+			}
+		}
+
+		if logFile != nil {
+			var err error
+			pending, err = readAppended(logFile, pending)
+			if err != nil {
+				logger.Error(err, "failed to read TaskRun log file", "path", logPath)
+				return
+			}
+			pending = writeSSELines(w, pending)
+		}
+
+		if finished {
+			if len(pending) > 0 {
+				writeSSEData(w, pending)
+			}
+			status := ""
+			if currentState.TaskRunInfo != nil {
+				status = currentState.TaskRunInfo.Status
+			}
+			_, _ = fmt.Fprintf(w, "event: done\ndata: %s\n\n", status)
+			flusher.Flush()
+			return
+		}
+
+		flusher.Flush()
+
+		select {
+		case <-r.Context().Done():
+			// Client disconnected
+			return
+		case <-time.After(taskRunLogPollInterval):
+		}
+	}
+}
+
+// readAppended reads everything currently available from f and appends it to pending.
+func readAppended(f *os.File, pending []byte) ([]byte, error) {
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := f.Read(buf)
+		pending = append(pending, buf[:n]...)
+		if errors.Is(err, io.EOF) {
+			return pending, nil
+		}
+		if err != nil {
+			return pending, err
+		}
+	}
+}
+
+// writeSSELines writes each complete line in pending as an SSE data event and
+// returns the trailing partial line, if any.
+func writeSSELines(w io.Writer, pending []byte) []byte {
+	for {
+		i := bytes.IndexByte(pending, '\n')
+		if i < 0 {
+			return pending
+		}
+		writeSSEData(w, pending[:i])
+		pending = pending[i+1:]
+	}
+}
+
+// writeSSEData writes a single log line as an SSE data event.
+func writeSSEData(w io.Writer, line []byte) {
+	_, _ = fmt.Fprintf(w, "data: %s\n\n", bytes.TrimSuffix(line, []byte("\r")))
+}
+
+// taskRunPhase derives the TaskRunStatusResponse phase from the operation status
+// and the stored TaskRun info.
+func taskRunPhase(operationStatus string, info *state.TaskRunInfo) string {
+	if operationStatus == "running_taskrun" {
+		return "running"
+	}
+	if info == nil {
+		return "none"
+	}
+	if info.Status == "Succeeded" {
+		return "succeeded"
+	}
+	return "failed"
+}
+
+// inlineTaskRunName returns the TaskRun name from inline YAML for use in log filenames,
+// or "taskrun" if the YAML has no usable name.
+func inlineTaskRunName(yamlContent string) string {
+	name, err := taskrun.ParseTaskRunName([]byte(yamlContent))
+	if err != nil || name == "" {
+		return "taskrun"
+	}
+	return name
 }
 
 // generateLogFilename generates a timestamped log filename from the TaskRun YAML path.
@@ -1003,6 +3002,11 @@ func (h *Handlers) runTaskRunWorkflow(ctx context.Context, yamlPath string) {
 func generateLogFilename(yamlPath string) string {
 	base := filepath.Base(yamlPath)
 	base = strings.TrimSuffix(base, filepath.Ext(base))
+	return timestampedLogFilename(base)
+}
+
+// timestampedLogFilename appends the current timestamp and .log extension to base.
+func timestampedLogFilename(base string) string {
 	timestamp := time.Now().Format("20060102_150405")
 	return fmt.Sprintf("%s_%s.log", base, timestamp)
 }
@@ -1015,7 +3019,7 @@ func (h *Handlers) CollectLogsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	sessionDir := h.Config.GetSessionLogDir()
+	sessionDir := h.Config().GetSessionLogDir()
 
 	collector, err := logcollector.NewCollector(sessionDir)
 	if err != nil {
@@ -1,21 +1,32 @@
 package api_test
 
 import (
+	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
+	"mime"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/meyrevived/mpc-dev-env/internal/cluster"
 	"github.com/meyrevived/mpc-dev-env/internal/config"
 	"github.com/meyrevived/mpc-dev-env/internal/daemon/api"
 	"github.com/meyrevived/mpc-dev-env/internal/daemon/state"
+	"github.com/meyrevived/mpc-dev-env/internal/deploy"
+	"github.com/meyrevived/mpc-dev-env/internal/prereq"
+	"github.com/meyrevived/mpc-dev-env/internal/version"
 )
 
 func TestHandlers(t *testing.T) {
@@ -25,21 +36,45 @@ func TestHandlers(t *testing.T) {
 
 // Mock StateManager for testing
 type mockStateManager struct {
+	mu            sync.Mutex // Async workflows update the mock from background goroutines
 	stateToReturn state.DevEnvironment
 	lastStatus    string
 	lastError     error
+	refreshCount  int
+	refreshed     *state.DevEnvironment     // State RefreshState switches to, if set
+	refreshDelay  time.Duration             // How long RefreshState takes
+	refreshErr    error                     // Error RefreshState returns
+	updates       chan state.DevEnvironment // Channel of the latest Subscribe call
+	deploySteps   []string                  // Step names of each SetDeployProgress call
 }
 
 func (m *mockStateManager) GetState() state.DevEnvironment {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	return m.stateToReturn
 }
 
+func (m *mockStateManager) RefreshState() error {
+	time.Sleep(m.refreshDelay)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.refreshCount++
+	if m.refreshed != nil {
+		m.stateToReturn = *m.refreshed
+	}
+	return m.refreshErr
+}
+
 func (m *mockStateManager) SetOperationStatus(status string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.lastStatus = status
 	m.lastError = err
 }
 
 func (m *mockStateManager) TrySetOperationStatus(expectedCurrent, newStatus string, err error) (bool, string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if m.stateToReturn.OperationStatus != expectedCurrent {
 		return false, m.stateToReturn.OperationStatus
 	}
@@ -50,13 +85,176 @@ func (m *mockStateManager) TrySetOperationStatus(expectedCurrent, newStatus stri
 }
 
 func (m *mockStateManager) SetTaskRunInfo(info *state.TaskRunInfo) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.stateToReturn.TaskRunInfo = info
 }
 
 func (m *mockStateManager) ClearTaskRunInfo() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.stateToReturn.TaskRunInfo = nil
 }
 
+func (m *mockStateManager) SetTestResult(result *state.TestResult) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stateToReturn.SmokeTestResult = result
+}
+
+func (m *mockStateManager) SetIBMEnabled(enabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stateToReturn.Features.IBMEnabled = enabled
+}
+
+func (m *mockStateManager) ClearMPCDeployment() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stateToReturn.MPCDeployment = nil
+}
+
+func (m *mockStateManager) SetBuildInfo(info *state.BuildInfo) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stateToReturn.BuildInfo = info
+}
+
+func (m *mockStateManager) SetDeployProgress(progress *state.DeployProgress) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stateToReturn.DeployProgress = progress
+	m.deploySteps = append(m.deploySteps, progress.StepName)
+}
+
+// DeploySteps returns the step names of each SetDeployProgress call
+func (m *mockStateManager) DeploySteps() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.deploySteps)
+}
+
+func (m *mockStateManager) SetKonfluxInfo(info *state.KonfluxInfo) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stateToReturn.Konflux = info
+}
+
+func (m *mockStateManager) SetMPCSourceGitHash(gitHash string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.stateToReturn.MPCDeployment != nil {
+		m.stateToReturn.MPCDeployment.SourceGitHash = gitHash
+	}
+}
+
+func (m *mockStateManager) SetPreviousMPCImages(images *state.PreviousMPCImages) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stateToReturn.PreviousMPCImages = images
+}
+
+func (m *mockStateManager) SetMetricsConfig(metrics *state.MetricsConfig) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stateToReturn.Metrics = metrics
+}
+
+func (m *mockStateManager) SetGitSyncInfo(info *state.GitSyncInfo) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stateToReturn.GitSync = info
+}
+
+func (m *mockStateManager) Subscribe() (<-chan state.DevEnvironment, func()) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.updates = make(chan state.DevEnvironment, 1)
+	return m.updates, func() {}
+}
+
+// Publish sends env to the latest subscriber, once one has subscribed
+func (m *mockStateManager) Publish(env state.DevEnvironment) {
+	Eventually(func() chan state.DevEnvironment {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		return m.updates
+	}).ShouldNot(BeNil())
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.updates <- env
+}
+
+// LastStatus returns the most recent operation status, safe to call while async work runs
+func (m *mockStateManager) LastStatus() (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lastStatus, m.lastError
+}
+
+// Mock ClusterManager for testing
+type mockClusterManager struct {
+	mu          sync.Mutex
+	status      string        // Returned by Status
+	statusErr   error         // Returned by Status
+	creates     int           // Number of Create calls
+	createBlock chan struct{} // Create blocks until it is closed, if set
+}
+
+func (m *mockClusterManager) Status(ctx context.Context) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.status, m.statusErr
+}
+
+func (m *mockClusterManager) Create(ctx context.Context) error {
+	m.mu.Lock()
+	m.creates++
+	block := m.createBlock
+	m.mu.Unlock()
+
+	if block != nil {
+		<-block
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.status = cluster.StatusRunning
+	return nil
+}
+
+func (m *mockClusterManager) Destroy(ctx context.Context) error { return nil }
+
+func (m *mockClusterManager) Pause(ctx context.Context) error { return nil }
+
+func (m *mockClusterManager) Resume(ctx context.Context) error { return nil }
+
+func (m *mockClusterManager) GetKubeconfig(ctx context.Context) (string, error) { return "", nil }
+
+func (m *mockClusterManager) ContextName() string { return "kind-konflux" }
+
+// Creates returns the number of Create calls, safe to call while async work runs
+func (m *mockClusterManager) Creates() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.creates
+}
+
+// Mock ReadinessProbe for testing GET /api/ready
+type mockReadinessProbe struct {
+	prerequisites *prereq.CheckResult
+	unavailable   map[deploy.Deployment]error // Returned by DeploymentAvailable; nil for the others
+	checked       []deploy.Deployment
+}
+
+func (m *mockReadinessProbe) Prerequisites(ctx context.Context) (*prereq.CheckResult, error) {
+	return m.prerequisites, nil
+}
+
+func (m *mockReadinessProbe) DeploymentAvailable(ctx context.Context, deployment deploy.Deployment) error {
+	m.checked = append(m.checked, deployment)
+	return m.unavailable[deployment]
+}
+
 var _ = Describe("Handlers", func() {
 	var (
 		mockState *mockStateManager
@@ -73,7 +271,7 @@ var _ = Describe("Handlers", func() {
 				LastActive: time.Now(),
 				Cluster: state.ClusterState{
 					Name:            "konflux-mpc-debug",
-					Status:          "running",
+					Status:          cluster.StatusRunning,
 					KubeconfigPath:  "/home/test/.kube/config",
 					KonfluxDeployed: true,
 				},
@@ -98,8 +296,9 @@ var _ = Describe("Handlers", func() {
 			},
 		}
 
-		// Create a minimal mock config for testing
-		mockCfg = &config.Config{}
+		// Create a minimal mock config for testing; builds triggered by handlers write
+		// their log files into SessionLogDir, so keep them out of the package directory
+		mockCfg = &config.Config{SessionLogDir: GinkgoT().TempDir()}
 
 		// Create handlers with mock dependencies (no scriptRunner needed)
 		handlers = api.NewHandlers(mockState, mockCfg)
@@ -128,7 +327,7 @@ var _ = Describe("Handlers", func() {
 
 			Expect(response.SessionID).To(Equal("test-session-123"))
 			Expect(response.Cluster.Name).To(Equal("konflux-mpc-debug"))
-			Expect(response.Cluster.Status).To(Equal("running"))
+			Expect(response.Cluster.Status).To(Equal(cluster.StatusRunning))
 			Expect(response.Repositories).To(HaveKey("multi-platform-controller"))
 			Expect(response.MPCDeployment).NotTo(BeNil())
 			Expect(response.MPCDeployment.ControllerImage).To(Equal("localhost:5001/multi-platform-controller:debug"))
@@ -177,6 +376,293 @@ var _ = Describe("Handlers", func() {
 		})
 	})
 
+	Describe("StatusWatchHandler", func() {
+		var server *httptest.Server
+
+		BeforeEach(func() {
+			server = httptest.NewServer(http.HandlerFunc(handlers.StatusWatchHandler))
+			DeferCleanup(server.Close)
+		})
+
+		// nextState reads the next server-sent event and decodes its state
+		nextState := func(reader *bufio.Reader) state.DevEnvironment {
+			line, err := reader.ReadString('\n')
+			Expect(err).NotTo(HaveOccurred())
+			Expect(line).To(HavePrefix("data: "))
+			blank, err := reader.ReadString('\n')
+			Expect(err).NotTo(HaveOccurred())
+			Expect(blank).To(Equal("\n"))
+
+			var env state.DevEnvironment
+			Expect(json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &env)).To(Succeed())
+			return env
+		}
+
+		It("should send the current state and then each change", func() {
+			mockState.stateToReturn.OperationStatus = "idle"
+
+			resp, err := http.Get(server.URL)
+			Expect(err).NotTo(HaveOccurred())
+			defer func() { _ = resp.Body.Close() }()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			Expect(resp.Header.Get("Content-Type")).To(Equal("text/event-stream"))
+
+			reader := bufio.NewReader(resp.Body)
+			Expect(nextState(reader).OperationStatus).To(Equal("idle"))
+
+			mockState.Publish(state.DevEnvironment{SessionID: "test-session", OperationStatus: "deploying_mpc"})
+			Expect(nextState(reader).OperationStatus).To(Equal("deploying_mpc"))
+		})
+
+		It("should reject non-GET requests", func() {
+			resp, err := http.Post(server.URL, "application/json", nil)
+			Expect(err).NotTo(HaveOccurred())
+			defer func() { _ = resp.Body.Close() }()
+			Expect(resp.StatusCode).To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+
+	Describe("StatusRefreshHandler", func() {
+		It("should re-scan the environment and return the fresh state", func() {
+			mockState.stateToReturn.OperationStatus = "idle"
+			mockState.refreshed = &state.DevEnvironment{
+				SessionID:       "test-session",
+				OperationStatus: "idle",
+				Cluster:         state.ClusterState{Name: "konflux", Status: cluster.StatusRunning},
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/status/refresh", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.StatusRefreshHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(mockState.refreshCount).To(Equal(1))
+			var env state.DevEnvironment
+			Expect(json.Unmarshal(rr.Body.Bytes(), &env)).To(Succeed())
+			Expect(env.Cluster.Status).To(Equal(cluster.StatusRunning))
+		})
+
+		It("should return 500 when the re-scan fails", func() {
+			mockState.refreshErr = fmt.Errorf("kubeconfig unreadable")
+
+			req := httptest.NewRequest(http.MethodPost, "/api/status/refresh", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.StatusRefreshHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusInternalServerError))
+			Expect(rr.Body.String()).To(ContainSubstring("kubeconfig unreadable"))
+		})
+
+		It("should return 504 when the re-scan exceeds the timeout", func() {
+			DeferCleanup(api.SetStateRefreshTimeout(10 * time.Millisecond))
+			mockState.refreshDelay = 200 * time.Millisecond
+
+			req := httptest.NewRequest(http.MethodPost, "/api/status/refresh", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.StatusRefreshHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusGatewayTimeout))
+			Expect(rr.Body.String()).To(ContainSubstring("did not finish"))
+		})
+
+		It("should return 405 Method Not Allowed for non-POST requests", func() {
+			req := httptest.NewRequest(http.MethodGet, "/api/status/refresh", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.StatusRefreshHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+			Expect(mockState.refreshCount).To(BeZero())
+		})
+	})
+
+	Describe("HealthHandler", func() {
+		It("should return ok without touching the state", func() {
+			req := httptest.NewRequest(http.MethodGet, "/api/health", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.HealthHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Header().Get("Content-Type")).To(Equal("application/json"))
+			Expect(rr.Body.String()).To(MatchJSON(`{"status":"ok"}`))
+			Expect(mockState.refreshCount).To(BeZero())
+		})
+
+		It("should return 405 Method Not Allowed for non-GET requests", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/health", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.HealthHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+
+	Describe("VersionHandler", func() {
+		var originalVersion, originalCommit, originalBuildTime string
+
+		BeforeEach(func() {
+			originalVersion, originalCommit, originalBuildTime = version.Version, version.GitCommit, version.BuildTime
+		})
+
+		AfterEach(func() {
+			version.Version, version.GitCommit, version.BuildTime = originalVersion, originalCommit, originalBuildTime
+		})
+
+		It("should return the injected build information", func() {
+			version.Version = "v1.2.3"
+			version.GitCommit = "0123456789abcdef"
+			version.BuildTime = "2026-01-02T03:04:05Z"
+
+			req := httptest.NewRequest(http.MethodGet, "/api/version", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.VersionHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			var response api.VersionResponse
+			Expect(json.NewDecoder(rr.Body).Decode(&response)).To(Succeed())
+			Expect(response).To(Equal(api.VersionResponse{
+				Version:   "v1.2.3",
+				GitCommit: "0123456789abcdef",
+				BuildTime: "2026-01-02T03:04:05Z",
+			}))
+		})
+
+		It("should report the defaults for a plain go build", func() {
+			req := httptest.NewRequest(http.MethodGet, "/api/version", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.VersionHandler(rr, req)
+
+			var response api.VersionResponse
+			Expect(json.NewDecoder(rr.Body).Decode(&response)).To(Succeed())
+			Expect(response.Version).To(Equal("dev"))
+		})
+
+		It("should return 405 Method Not Allowed for non-GET requests", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/version", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.VersionHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+
+	Describe("Config and SetConfig", func() {
+		It("should swap the configuration and return the previous one", func() {
+			reloaded := &config.Config{SessionLogDir: GinkgoT().TempDir()}
+
+			Expect(handlers.SetConfig(reloaded)).To(BeIdenticalTo(mockCfg))
+			Expect(handlers.Config()).To(BeIdenticalTo(reloaded))
+		})
+
+		It("should never expose a half-updated configuration to concurrent readers", func() {
+			configs := make([]*config.Config, 10)
+			for i := range configs {
+				configs[i] = &config.Config{
+					ClusterName: fmt.Sprintf("cluster-%d", i),
+					MpcRepoPath: fmt.Sprintf("/repo-%d", i),
+				}
+			}
+			handlers.SetConfig(configs[0])
+
+			var wg sync.WaitGroup
+			mismatches := make(chan string, 100)
+			for range 4 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for range 1000 {
+						cfg := handlers.Config()
+						if strings.TrimPrefix(cfg.ClusterName, "cluster-") != strings.TrimPrefix(cfg.MpcRepoPath, "/repo-") {
+							select {
+							case mismatches <- cfg.ClusterName + " " + cfg.MpcRepoPath:
+							default:
+							}
+						}
+					}
+				}()
+			}
+			for i := range 1000 {
+				handlers.SetConfig(configs[i%len(configs)])
+			}
+			wg.Wait()
+			close(mismatches)
+
+			Expect(mismatches).To(BeEmpty())
+		})
+	})
+
+	Describe("HotReloadHandler", func() {
+		decode := func(rr *httptest.ResponseRecorder) api.HotReloadResponse {
+			var response api.HotReloadResponse
+			Expect(json.NewDecoder(rr.Body).Decode(&response)).To(Succeed())
+			return response
+		}
+
+		It("should report hot reload as enabled by default", func() {
+			req := httptest.NewRequest(http.MethodGet, "/api/hotreload", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.HotReloadHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Header().Get("Content-Type")).To(Equal("application/json"))
+			Expect(decode(rr).Enabled).To(BeTrue())
+			Expect(handlers.HotReloadEnabled()).To(BeTrue())
+		})
+
+		It("should disable and re-enable hot reload", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/hotreload", strings.NewReader(`{"enabled": false}`))
+			rr := httptest.NewRecorder()
+			handlers.HotReloadHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(decode(rr).Enabled).To(BeFalse())
+			Expect(handlers.HotReloadEnabled()).To(BeFalse())
+
+			req = httptest.NewRequest(http.MethodGet, "/api/hotreload", nil)
+			rr = httptest.NewRecorder()
+			handlers.HotReloadHandler(rr, req)
+			Expect(decode(rr).Enabled).To(BeFalse())
+
+			req = httptest.NewRequest(http.MethodPost, "/api/hotreload", strings.NewReader(`{"enabled": true}`))
+			rr = httptest.NewRecorder()
+			handlers.HotReloadHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(decode(rr).Enabled).To(BeTrue())
+			Expect(handlers.HotReloadEnabled()).To(BeTrue())
+		})
+
+		It("should return 400 Bad Request without an enabled field", func() {
+			for _, body := range []string{`{}`, `not json`} {
+				req := httptest.NewRequest(http.MethodPost, "/api/hotreload", strings.NewReader(body))
+				rr := httptest.NewRecorder()
+
+				handlers.HotReloadHandler(rr, req)
+
+				Expect(rr.Code).To(Equal(http.StatusBadRequest), "body %s", body)
+			}
+			Expect(handlers.HotReloadEnabled()).To(BeTrue())
+		})
+
+		It("should return 405 Method Not Allowed for DELETE requests", func() {
+			req := httptest.NewRequest(http.MethodDelete, "/api/hotreload", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.HotReloadHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+
 	Describe("RebuildHandler", func() {
 		It("should return 202 Accepted for POST requests", func() {
 			req := httptest.NewRequest(http.MethodPost, "/api/rebuild", nil)
@@ -259,6 +745,2142 @@ var _ = Describe("Handlers", func() {
 		// which is the primary responsibility of the API handler layer.
 	})
 
+	Describe("SmokeTestHandler", func() {
+		BeforeEach(func() {
+			// An empty mpc-dev-env checkout has no template, so the async smoke test
+			// fails fast instead of talking to a cluster
+			mockCfg.MpcDevEnvPath = GinkgoT().TempDir()
+			mockCfg.SessionLogDir = GinkgoT().TempDir()
+		})
+
+		It("should return 202 Accepted for POST requests and record the result", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/smoke-test", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.SmokeTestHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusAccepted))
+			Expect(rr.Header().Get("Content-Type")).To(Equal("application/json"))
+
+			var response map[string]string
+			Expect(json.NewDecoder(rr.Body).Decode(&response)).To(Succeed())
+			Expect(response["status"]).To(Equal("accepted"))
+			Expect(response["template_path"]).To(Equal(filepath.Join(mockCfg.MpcDevEnvPath, "taskruns", "localhost_test.yaml")))
+
+			Eventually(func() *state.TestResult {
+				return mockState.GetState().SmokeTestResult
+			}).ShouldNot(BeNil())
+			result := mockState.GetState().SmokeTestResult
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Error).NotTo(BeNil())
+			Expect(*result.Error).To(ContainSubstring("smoke test template not found"))
+			Expect(filepath.Base(result.LogFile)).To(HavePrefix("smoke_test_"))
+		})
+
+		It("should return 400 for a malformed request body", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/smoke-test", strings.NewReader("{"))
+			rr := httptest.NewRecorder()
+
+			handlers.SmokeTestHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should return 405 Method Not Allowed for GET requests", func() {
+			req := httptest.NewRequest(http.MethodGet, "/api/smoke-test", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.SmokeTestHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+
+	Describe("smokeTestTemplatePath", func() {
+		It("should default to the bundled localhost TaskRun", func() {
+			path, err := api.SmokeTestTemplatePath(&config.Config{MpcDevEnvPath: "/home/test/mpc-dev-env"}, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(path).To(Equal("/home/test/mpc-dev-env/taskruns/localhost_test.yaml"))
+		})
+
+		It("should prefer an explicitly requested template within the taskruns directory", func() {
+			taskRunsDir := GinkgoT().TempDir()
+			template := filepath.Join(taskRunsDir, "my_smoke.yaml")
+			Expect(os.WriteFile(template, []byte("kind: TaskRun\n"), 0644)).To(Succeed())
+
+			path, err := api.SmokeTestTemplatePath(&config.Config{TaskRunsDir: taskRunsDir}, template)
+			Expect(err).NotTo(HaveOccurred())
+			resolved, err := filepath.EvalSymlinks(template)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(path).To(Equal(resolved))
+		})
+
+		It("should reject a template outside the taskruns directory", func() {
+			outside := filepath.Join(GinkgoT().TempDir(), "my_smoke.yaml")
+			Expect(os.WriteFile(outside, []byte("kind: TaskRun\n"), 0644)).To(Succeed())
+
+			_, err := api.SmokeTestTemplatePath(&config.Config{TaskRunsDir: GinkgoT().TempDir()}, outside)
+			Expect(err).To(MatchError(ContainSubstring("not within the taskruns directory")))
+		})
+	})
+
+	Describe("ClusterEnsureHandler", func() {
+		var mockCluster *mockClusterManager
+
+		BeforeEach(func() {
+			mockCluster = &mockClusterManager{status: cluster.StatusNotRunning}
+			handlers.ClusterManager = mockCluster
+		})
+
+		// ensure calls the handler and decodes its response
+		ensure := func() (int, map[string]string) {
+			req := httptest.NewRequest(http.MethodPost, "/api/cluster/ensure", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.ClusterEnsureHandler(rr, req)
+
+			var response map[string]string
+			Expect(json.NewDecoder(rr.Body).Decode(&response)).To(Succeed())
+			return rr.Code, response
+		}
+
+		It("should return 200 without creating a running cluster", func() {
+			mockCluster.status = cluster.StatusRunning
+
+			code, response := ensure()
+
+			Expect(code).To(Equal(http.StatusOK))
+			Expect(response["action"]).To(Equal("already_running"))
+			Consistently(mockCluster.Creates, 100*time.Millisecond).Should(BeZero())
+		})
+
+		It("should create a cluster that does not exist", func() {
+			code, response := ensure()
+
+			Expect(code).To(Equal(http.StatusAccepted))
+			Expect(response["status"]).To(Equal("accepted"))
+			Expect(response["action"]).To(Equal("creating"))
+			Eventually(mockCluster.Creates).Should(Equal(1))
+
+			// Once created, the cluster is reported as running
+			Eventually(func() string {
+				_, response := ensure()
+				return response["action"]
+			}).Should(Equal("already_running"))
+			Expect(mockCluster.Creates()).To(Equal(1))
+		})
+
+		It("should not create the cluster again while its creation is in progress", func() {
+			mockCluster.createBlock = make(chan struct{})
+			defer close(mockCluster.createBlock)
+
+			_, response := ensure()
+			Expect(response["action"]).To(Equal("creating"))
+
+			code, response := ensure()
+			Expect(code).To(Equal(http.StatusAccepted))
+			Expect(response["action"]).To(Equal("in_progress"))
+
+			// POST /api/cluster/start shares the guard
+			rr := httptest.NewRecorder()
+			handlers.ClusterStartHandler(rr, httptest.NewRequest(http.MethodPost, "/api/cluster/start", nil))
+			Expect(rr.Code).To(Equal(http.StatusAccepted))
+			Expect(rr.Body.String()).To(ContainSubstring("already in progress"))
+
+			Eventually(mockCluster.Creates).Should(Equal(1))
+			Consistently(mockCluster.Creates, 100*time.Millisecond).Should(Equal(1))
+		})
+
+		It("should create the cluster only once for concurrent callers", func() {
+			mockCluster.createBlock = make(chan struct{})
+			defer close(mockCluster.createBlock)
+
+			const callers = 5
+			actions := make(chan string, callers)
+			var wg sync.WaitGroup
+			for range callers {
+				wg.Add(1)
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+					_, response := ensure()
+					actions <- response["action"]
+				}()
+			}
+			wg.Wait()
+			close(actions)
+
+			var creating int
+			for action := range actions {
+				if action == "creating" {
+					creating++
+				} else {
+					Expect(action).To(Equal("in_progress"))
+				}
+			}
+			Expect(creating).To(Equal(1))
+			Eventually(mockCluster.Creates).Should(Equal(1))
+		})
+
+		It("should report a cluster that is still initializing as in progress", func() {
+			mockCluster.status = cluster.StatusInitializing
+
+			code, response := ensure()
+
+			Expect(code).To(Equal(http.StatusAccepted))
+			Expect(response["action"]).To(Equal("in_progress"))
+			Consistently(mockCluster.Creates, 100*time.Millisecond).Should(BeZero())
+		})
+
+		It("should return 409 Conflict for a paused cluster", func() {
+			mockCluster.status = cluster.StatusPaused
+
+			code, response := ensure()
+
+			Expect(code).To(Equal(http.StatusConflict))
+			Expect(response["action"]).To(Equal("paused"))
+			Expect(response["message"]).To(ContainSubstring("/api/cluster/resume"))
+		})
+
+		It("should return 500 when the cluster status cannot be determined", func() {
+			mockCluster.status = cluster.StatusError
+			mockCluster.statusErr = fmt.Errorf("neither docker nor podman found in PATH")
+
+			req := httptest.NewRequest(http.MethodPost, "/api/cluster/ensure", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.ClusterEnsureHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusInternalServerError))
+			Expect(rr.Body.String()).To(ContainSubstring("neither docker nor podman"))
+			Expect(mockCluster.Creates()).To(BeZero())
+		})
+
+		It("should return 405 Method Not Allowed for GET requests", func() {
+			req := httptest.NewRequest(http.MethodGet, "/api/cluster/ensure", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.ClusterEnsureHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+
+	Describe("profile selection", func() {
+		var (
+			defaultCluster  *mockClusterManager
+			profileClusters map[string]*mockClusterManager // By cluster name
+		)
+
+		BeforeEach(func() {
+			mockCfg.Profiles = map[string]config.Profile{
+				"stable":       {ClusterName: "konflux-stable"},
+				"experimental": {},
+			}
+			defaultCluster = &mockClusterManager{status: cluster.StatusRunning}
+			handlers.ClusterManager = defaultCluster
+
+			profileClusters = map[string]*mockClusterManager{
+				"konflux-stable": {status: cluster.StatusPaused},
+				"experimental":   {status: cluster.StatusNotRunning},
+			}
+			handlers.SetClusterManagerFactory(func(cfg *config.Config) api.ClusterManager {
+				profileCluster, ok := profileClusters[cfg.GetClusterName()]
+				Expect(ok).To(BeTrue(), "unexpected cluster %q", cfg.GetClusterName())
+				return profileCluster
+			})
+		})
+
+		// clusterStatus calls GET /api/cluster/status with the given query
+		clusterStatus := func(query string) (int, map[string]string) {
+			rr := httptest.NewRecorder()
+			handlers.ClusterStatusHandler(rr, httptest.NewRequest(http.MethodGet, "/api/cluster/status"+query, nil))
+
+			var response map[string]string
+			Expect(json.NewDecoder(rr.Body).Decode(&response)).To(Succeed())
+			return rr.Code, response
+		}
+
+		It("should route each profile to its own cluster", func() {
+			_, response := clusterStatus("")
+			Expect(response["status"]).To(Equal(cluster.StatusRunning))
+
+			_, response = clusterStatus("?profile=stable")
+			Expect(response["status"]).To(Equal(cluster.StatusPaused))
+
+			_, response = clusterStatus("?profile=experimental")
+			Expect(response["status"]).To(Equal(cluster.StatusNotRunning))
+		})
+
+		It("should create only the selected profile's cluster", func() {
+			rr := httptest.NewRecorder()
+			handlers.ClusterEnsureHandler(rr, httptest.NewRequest(http.MethodPost, "/api/cluster/ensure?profile=experimental", nil))
+			Expect(rr.Code).To(Equal(http.StatusAccepted))
+
+			Eventually(profileClusters["experimental"].Creates).Should(Equal(1))
+			Expect(profileClusters["konflux-stable"].Creates()).To(BeZero())
+			Expect(defaultCluster.Creates()).To(BeZero())
+		})
+
+		It("should track cluster creations per profile", func() {
+			defaultCluster.status = cluster.StatusNotRunning
+			defaultCluster.createBlock = make(chan struct{})
+			defer close(defaultCluster.createBlock)
+
+			rr := httptest.NewRecorder()
+			handlers.ClusterStartHandler(rr, httptest.NewRequest(http.MethodPost, "/api/cluster/start", nil))
+			Expect(rr.Body.String()).To(ContainSubstring("initiated"))
+
+			// The default cluster's creation does not hold up the profile's
+			rr = httptest.NewRecorder()
+			handlers.ClusterStartHandler(rr, httptest.NewRequest(http.MethodPost, "/api/cluster/start?profile=experimental", nil))
+			Expect(rr.Body.String()).To(ContainSubstring("initiated"))
+			Eventually(profileClusters["experimental"].Creates).Should(Equal(1))
+		})
+
+		It("should report the profile's cluster and repositories in the status", func() {
+			mockState.stateToReturn.Profiles = map[string]state.ProfileState{
+				"stable": {
+					Cluster:      state.ClusterState{Name: "konflux-stable", Status: cluster.StatusPaused},
+					Repositories: map[string]state.RepositoryState{"multi-platform-controller": {Path: "/src/stable"}},
+				},
+			}
+
+			rr := httptest.NewRecorder()
+			handlers.StatusHandler(rr, httptest.NewRequest(http.MethodGet, "/api/status?profile=stable", nil))
+			Expect(rr.Code).To(Equal(http.StatusOK))
+
+			var response state.DevEnvironment
+			Expect(json.NewDecoder(rr.Body).Decode(&response)).To(Succeed())
+			Expect(response.SessionID).To(Equal("test-session-123"))
+			Expect(response.Cluster.Name).To(Equal("konflux-stable"))
+			Expect(response.Repositories["multi-platform-controller"].Path).To(Equal("/src/stable"))
+		})
+
+		It("should return 400 Bad Request for an unknown profile", func() {
+			code, response := clusterStatus("?profile=missing")
+
+			Expect(code).To(Equal(http.StatusBadRequest))
+			Expect(response["status"]).To(Equal("error"))
+			Expect(response["error"]).To(ContainSubstring(`unknown profile "missing"`))
+		})
+
+		It("should reject ?profile= on the endpoints that only act on the default environment", func() {
+			endpoints := map[string]http.HandlerFunc{
+				"/api/mpc/build":      handlers.BuildHandler,
+				"/api/mpc/deploy":     handlers.DeployHandler,
+				"/api/git/sync":       handlers.GitSyncHandler,
+				"/api/deploy/secrets": handlers.DeploySecretsHandler,
+			}
+			for path, handler := range endpoints {
+				rr := httptest.NewRecorder()
+				handler(rr, httptest.NewRequest(http.MethodPost, path+"?profile=stable", nil))
+
+				Expect(rr.Code).To(Equal(http.StatusBadRequest), path)
+				var response map[string]string
+				Expect(json.Unmarshal(rr.Body.Bytes(), &response)).To(Succeed())
+				Expect(response["error"]).To(ContainSubstring("only acts on the default environment"), path)
+			}
+		})
+	})
+
+	Describe("ClusterPauseHandler and ClusterResumeHandler", func() {
+		var (
+			tempDir      string
+			originalPath string
+		)
+
+		BeforeEach(func() {
+			tempDir = GinkgoT().TempDir()
+			callsLog := filepath.Join(tempDir, "calls.log")
+
+			// Mock kind that lists a single control-plane node, and a mock podman
+			mockKind := fmt.Sprintf("#!/bin/sh\necho \"kind $@\" >> %s\n[ \"$2\" = \"nodes\" ] && echo \"$4-control-plane\"\nexit 0\n", callsLog)
+			Expect(os.WriteFile(filepath.Join(tempDir, "kind"), []byte(mockKind), 0755)).To(Succeed())
+			mockPodman := fmt.Sprintf("#!/bin/sh\necho \"podman $@\" >> %s\nexit 0\n", callsLog)
+			Expect(os.WriteFile(filepath.Join(tempDir, "podman"), []byte(mockPodman), 0755)).To(Succeed())
+			originalPath = os.Getenv("PATH")
+			_ = os.Setenv("PATH", tempDir+":"+originalPath)
+		})
+
+		AfterEach(func() {
+			_ = os.Setenv("PATH", originalPath)
+		})
+
+		// calls returns what the mocks have recorded so far
+		calls := func() string {
+			data, _ := os.ReadFile(filepath.Join(tempDir, "calls.log"))
+			return string(data)
+		}
+
+		It("should stop the node containers on pause", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/cluster/pause", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.ClusterPauseHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusAccepted))
+			Eventually(calls).Should(ContainSubstring("podman stop konflux-control-plane"))
+		})
+
+		It("should start the node containers on resume", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/cluster/resume", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.ClusterResumeHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusAccepted))
+			Eventually(calls).Should(ContainSubstring("podman start konflux-control-plane"))
+		})
+
+		It("should return 405 Method Not Allowed for GET requests", func() {
+			for _, handler := range []http.HandlerFunc{handlers.ClusterPauseHandler, handlers.ClusterResumeHandler} {
+				req := httptest.NewRequest(http.MethodGet, "/api/cluster/pause", nil)
+				rr := httptest.NewRecorder()
+
+				handler(rr, req)
+
+				Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+			}
+		})
+	})
+
+	Describe("BuildImages", func() {
+		var (
+			tempDir      string
+			originalPath string
+		)
+
+		BeforeEach(func() {
+			var err error
+			tempDir, err = os.MkdirTemp("", "build-images-test-*")
+			Expect(err).NotTo(HaveOccurred())
+
+			// No Dockerfile in the repository, so the build fails right after runtime detection
+			mockCfg.MpcRepoPath = filepath.Join(tempDir, "multi-platform-controller")
+			Expect(os.MkdirAll(mockCfg.MpcRepoPath, 0755)).To(Succeed())
+			mockCfg.SessionLogDir = filepath.Join(tempDir, "logs")
+
+			Expect(os.WriteFile(filepath.Join(tempDir, "fake-runtime"), []byte("#!/bin/sh\nexit 0\n"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(tempDir, "git"), []byte("#!/bin/sh\nexit 128\n"), 0755)).To(Succeed())
+			_ = os.Setenv("DOCKER_CLI", filepath.Join(tempDir, "fake-runtime"))
+			originalPath = os.Getenv("PATH")
+			_ = os.Setenv("PATH", tempDir+":"+originalPath)
+		})
+
+		AfterEach(func() {
+			_ = os.Setenv("PATH", originalPath)
+			_ = os.Unsetenv("DOCKER_CLI")
+			_ = os.RemoveAll(tempDir)
+		})
+
+		It("should record the build log file and outcome in the state", func() {
+			_, err := handlers.BuildImages(context.Background(), mockCfg, false, nil)
+			Expect(err).To(MatchError(ContainSubstring("dockerfile not found")))
+
+			info := mockState.GetState().BuildInfo
+			Expect(info).NotTo(BeNil())
+			Expect(info.Status).To(Equal("Failed"))
+			Expect(filepath.Dir(info.LogFile)).To(Equal(mockCfg.SessionLogDir))
+			Expect(filepath.Base(info.LogFile)).To(MatchRegexp(`^build_\d{8}_\d{6}\.log$`))
+			Expect(info.LogFile).To(BeAnExistingFile())
+		})
+
+		It("should record the image inputs and skip unchanged images on the next build", func() {
+			for _, dockerfile := range []string{"Dockerfile", "Dockerfile.otp"} {
+				Expect(os.WriteFile(filepath.Join(mockCfg.MpcRepoPath, dockerfile), []byte("FROM scratch\n"), 0644)).To(Succeed())
+			}
+			mockRuntime := fmt.Sprintf("#!/bin/sh\necho \"$@\" >> %s\nexit 0\n", filepath.Join(tempDir, "runtime_calls.log"))
+			Expect(os.WriteFile(filepath.Join(tempDir, "fake-runtime"), []byte(mockRuntime), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(tempDir, "kind"), []byte("#!/bin/sh\ncat > /dev/null\n"), 0755)).To(Succeed())
+
+			_, err := handlers.BuildImages(context.Background(), mockCfg, false, nil)
+			Expect(err).NotTo(HaveOccurred())
+			inputs := mockState.GetState().BuildInfo.ImageInputs
+			Expect(inputs).To(HaveKey(mockCfg.GetControllerImage()))
+			Expect(inputs).To(HaveKey(mockCfg.GetOTPImage()))
+
+			Expect(os.Remove(filepath.Join(tempDir, "runtime_calls.log"))).To(Succeed())
+			_, err = handlers.BuildImages(context.Background(), mockCfg, false, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockState.GetState().BuildInfo.ImageInputs).To(Equal(inputs))
+
+			calls, err := os.ReadFile(filepath.Join(tempDir, "runtime_calls.log"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(calls)).NotTo(ContainSubstring("build "))
+
+			_, err = handlers.BuildImages(context.Background(), mockCfg, true, nil)
+			Expect(err).NotTo(HaveOccurred())
+			calls, err = os.ReadFile(filepath.Join(tempDir, "runtime_calls.log"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(calls)).To(ContainSubstring("build "))
+		})
+
+		It("should publish the built image sizes in the state", func() {
+			for _, dockerfile := range []string{"Dockerfile", "Dockerfile.otp"} {
+				Expect(os.WriteFile(filepath.Join(mockCfg.MpcRepoPath, dockerfile), []byte("FROM scratch\n"), 0644)).To(Succeed())
+			}
+			mockRuntime := "#!/bin/sh\ncase \"$*\" in \"image inspect --format\"*) echo '1048576 4';; esac\n"
+			Expect(os.WriteFile(filepath.Join(tempDir, "fake-runtime"), []byte(mockRuntime), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(tempDir, "kind"), []byte("#!/bin/sh\ncat > /dev/null\n"), 0755)).To(Succeed())
+
+			_, err := handlers.BuildImages(context.Background(), mockCfg, false, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(mockState.GetState().BuildInfo.Images).To(Equal([]state.ImageInfo{
+				{Image: mockCfg.GetControllerImage(), SizeBytes: 1048576, Layers: 4},
+				{Image: mockCfg.GetOTPImage(), SizeBytes: 1048576, Layers: 4},
+			}))
+		})
+
+		It("should return 400 Bad Request for an invalid force value", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/mpc/build?force=maybe", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.BuildHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusBadRequest))
+			Expect(rr.Body.String()).To(ContainSubstring("force must be true or false"))
+		})
+	})
+
+	Describe("DeployHandler", func() {
+		var (
+			tempDir      string
+			originalPath string
+		)
+
+		BeforeEach(func() {
+			var err error
+			tempDir, err = os.MkdirTemp("", "deploy-handler-test-*")
+			Expect(err).NotTo(HaveOccurred())
+
+			mpcRepoPath := filepath.Join(tempDir, "multi-platform-controller")
+			Expect(os.MkdirAll(filepath.Join(mpcRepoPath, "deploy", "operator"), 0755)).To(Succeed())
+			mockCfg.MpcRepoPath = mpcRepoPath
+			mockCfg.MpcDevEnvPath = tempDir
+			mockCfg.TempDir = filepath.Join(tempDir, "temp")
+
+			mockKubectl := fmt.Sprintf("#!/bin/sh\necho \"$@\" | tr '\\n' ' ' >> %[1]s\necho >> %[1]s\nexit 0\n", filepath.Join(tempDir, "kubectl_calls.log"))
+			Expect(os.WriteFile(filepath.Join(tempDir, "kubectl"), []byte(mockKubectl), 0755)).To(Succeed())
+			originalPath = os.Getenv("PATH")
+			_ = os.Setenv("PATH", tempDir+":"+originalPath)
+		})
+
+		AfterEach(func() {
+			_ = os.Setenv("PATH", originalPath)
+			_ = os.RemoveAll(tempDir)
+		})
+
+		It("should run a server-side dry run when dry_run=true", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/mpc/deploy?dry_run=true", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.DeployHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusAccepted))
+			var response api.DeployResponse
+			Expect(json.NewDecoder(rr.Body).Decode(&response)).To(Succeed())
+			Expect(response.DryRun).To(BeTrue())
+			Expect(response.Message).To(ContainSubstring("dry run"))
+
+			Eventually(func() string {
+				status, _ := mockState.LastStatus()
+				return status
+			}).Should(Equal("idle"))
+			_, err := mockState.LastStatus()
+			Expect(err).NotTo(HaveOccurred())
+
+			calls, err := os.ReadFile(filepath.Join(tempDir, "kubectl_calls.log"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(calls)).To(ContainSubstring("apply -k " + filepath.Join(mockCfg.MpcRepoPath, "deploy", "operator") + " --dry-run=server"))
+			Expect(string(calls)).NotTo(ContainSubstring("rollout restart"))
+		})
+
+		It("should report the progress of each step through GET /api/mpc/deploy/status", func() {
+			// deployStatus calls GET /api/mpc/deploy/status
+			deployStatus := func() state.DeployProgress {
+				rr := httptest.NewRecorder()
+				handlers.DeployStatusHandler(rr, httptest.NewRequest(http.MethodGet, "/api/mpc/deploy/status", nil))
+				Expect(rr.Code).To(Equal(http.StatusOK))
+
+				var progress state.DeployProgress
+				Expect(json.NewDecoder(rr.Body).Decode(&progress)).To(Succeed())
+				return progress
+			}
+
+			Expect(deployStatus()).To(Equal(state.DeployProgress{Status: "None"}))
+
+			rr := httptest.NewRecorder()
+			handlers.DeployHandler(rr, httptest.NewRequest(http.MethodPost, "/api/mpc/deploy?dry_run=true", nil))
+			Expect(rr.Code).To(Equal(http.StatusAccepted))
+
+			Eventually(func() string { return deployStatus().Status }).Should(Equal("Succeeded"))
+			progress := deployStatus()
+			Expect(progress.Step).To(Equal(4))
+			Expect(progress.TotalSteps).To(Equal(4))
+			Expect(progress.StepName).To(Equal(deploy.StepPatchOTP))
+			Expect(progress.DryRun).To(BeTrue())
+
+			// Running before the first step, then each step, then the final status
+			Expect(mockState.DeploySteps()).To(Equal([]string{"",
+				deploy.StepHostConfig, deploy.StepApplyManifests, deploy.StepPatchController, deploy.StepPatchOTP,
+				deploy.StepPatchOTP,
+			}))
+		})
+
+		It("should report the step a deployment failed at", func() {
+			mockKubectl := "#!/bin/sh\n[ \"$1\" = \"apply\" ] && [ \"$2\" = \"-k\" ] && exit 1\nexit 0\n"
+			Expect(os.WriteFile(filepath.Join(tempDir, "kubectl"), []byte(mockKubectl), 0755)).To(Succeed())
+
+			rr := httptest.NewRecorder()
+			handlers.DeployHandler(rr, httptest.NewRequest(http.MethodPost, "/api/mpc/deploy", nil))
+			Expect(rr.Code).To(Equal(http.StatusAccepted))
+
+			Eventually(func() string {
+				status, _ := mockState.LastStatus()
+				return status
+			}).Should(Equal("idle"))
+
+			progress := mockState.GetState().DeployProgress
+			Expect(progress).NotTo(BeNil())
+			Expect(progress.Status).To(Equal("Failed"))
+			Expect(progress.Step).To(Equal(2))
+			Expect(progress.TotalSteps).To(Equal(8))
+			Expect(progress.StepName).To(Equal(deploy.StepApplyManifests))
+			Expect(progress.Error).To(ContainSubstring("failed to apply MPC manifests"))
+		})
+
+		It("should return 400 Bad Request for an invalid dry_run value", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/mpc/deploy?dry_run=maybe", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.DeployHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Describe("GitSyncHandler", func() {
+		var tempDir string
+
+		BeforeEach(func() {
+			var err error
+			tempDir, err = os.MkdirTemp("", "git-sync-handler-test-*")
+			Expect(err).NotTo(HaveOccurred())
+			mockCfg.MpcRepoPath = filepath.Join(tempDir, "multi-platform-controller")
+		})
+
+		AfterEach(func() {
+			_ = os.RemoveAll(tempDir)
+		})
+
+		// lastSync waits for the sync started by the handler to finish and returns its info
+		lastSync := func() *state.GitSyncInfo {
+			Eventually(func() string {
+				if info := mockState.GetState().GitSync; info != nil {
+					return info.Status
+				}
+				return ""
+			}).ShouldNot(Or(BeEmpty(), Equal("Running")))
+			return mockState.GetState().GitSync
+		}
+
+		It("should record each repository's old and new commit", func() {
+			// The local repository is one commit behind upstream/main
+			upstreamPath := filepath.Join(tempDir, "upstream.git")
+			Expect(os.MkdirAll(mockCfg.MpcRepoPath, 0755)).To(Succeed())
+			git := func(args ...string) string {
+				out, err := exec.Command("git", args...).CombinedOutput()
+				Expect(err).NotTo(HaveOccurred(), "git %v: %s", args, out)
+				return strings.TrimSpace(string(out))
+			}
+			git("init", "--bare", upstreamPath)
+			git("-C", mockCfg.MpcRepoPath, "init")
+			git("-C", mockCfg.MpcRepoPath, "-c", "user.email=test@example.com", "-c", "user.name=Test User", "commit", "--allow-empty", "-m", "Initial commit")
+			oldHash := git("-C", mockCfg.MpcRepoPath, "rev-parse", "HEAD")
+			git("-C", mockCfg.MpcRepoPath, "-c", "user.email=test@example.com", "-c", "user.name=Test User", "commit", "--allow-empty", "-m", "Upstream commit")
+			newHash := git("-C", mockCfg.MpcRepoPath, "rev-parse", "HEAD")
+			git("-C", mockCfg.MpcRepoPath, "remote", "add", "upstream", upstreamPath)
+			git("-C", mockCfg.MpcRepoPath, "push", "upstream", "HEAD:main")
+			git("-C", mockCfg.MpcRepoPath, "reset", "--hard", oldHash)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/git/sync", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.GitSyncHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusAccepted))
+			info := lastSync()
+			Expect(info.Status).To(Equal("Succeeded"))
+			Expect(info.EndTime).NotTo(BeEmpty())
+			Expect(info.Results).To(HaveLen(1))
+			Expect(info.Results[0].Name).To(Equal("multi-platform-controller"))
+			Expect(info.Results[0].Target).To(Equal("upstream/main"))
+			Expect(info.Results[0].Strategy).To(Equal(config.GitSyncStrategyRebase))
+			Expect(info.Results[0].OldHash).To(Equal(oldHash))
+			Expect(info.Results[0].NewHash).To(Equal(newHash))
+			Expect(info.Results[0].Error).To(BeEmpty())
+		})
+
+		It("should record the repositories that failed to sync", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/git/sync", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.GitSyncHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusAccepted))
+			info := lastSync()
+			Expect(info.Status).To(Equal("Failed"))
+			Expect(info.Results).To(HaveLen(1))
+			Expect(info.Results[0].Error).To(ContainSubstring("failed to get current branch"))
+		})
+
+		It("should return 405 Method Not Allowed for non-POST requests", func() {
+			req := httptest.NewRequest(http.MethodGet, "/api/git/sync", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.GitSyncHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+			Expect(mockState.GetState().GitSync).To(BeNil())
+		})
+	})
+
+	Describe("GitSyncStatusHandler", func() {
+		It("should return the last sync's results", func() {
+			mockState.SetGitSyncInfo(&state.GitSyncInfo{
+				Status: "Failed",
+				Results: []state.RepoSyncResult{
+					{Name: "multi-platform-controller", Strategy: "rebase", OldHash: "abc", NewHash: "abc", Error: "failed to fetch from upstream"},
+				},
+			})
+			req := httptest.NewRequest(http.MethodGet, "/api/git/sync/status", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.GitSyncStatusHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Header().Get("Content-Type")).To(Equal("application/json"))
+			var response state.GitSyncInfo
+			Expect(json.NewDecoder(rr.Body).Decode(&response)).To(Succeed())
+			Expect(response.Status).To(Equal("Failed"))
+			Expect(response.Results).To(HaveLen(1))
+			Expect(response.Results[0].Error).To(Equal("failed to fetch from upstream"))
+		})
+
+		It("should return 404 Not Found when no sync has been started", func() {
+			req := httptest.NewRequest(http.MethodGet, "/api/git/sync/status", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.GitSyncStatusHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusNotFound))
+			Expect(rr.Body.String()).To(ContainSubstring("No git sync has been started"))
+		})
+
+		It("should return 405 Method Not Allowed for non-GET requests", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/git/sync/status", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.GitSyncStatusHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+
+	Describe("GitChangesHandler", func() {
+		var tempDir string
+
+		BeforeEach(func() {
+			var err error
+			tempDir, err = os.MkdirTemp("", "git-changes-handler-test-*")
+			Expect(err).NotTo(HaveOccurred())
+			mockCfg.MpcRepoPath = filepath.Join(tempDir, "multi-platform-controller")
+		})
+
+		AfterEach(func() {
+			_ = os.RemoveAll(tempDir)
+		})
+
+		It("should return the change set of each configured repository", func() {
+			upstreamPath := filepath.Join(tempDir, "upstream.git")
+			Expect(os.MkdirAll(mockCfg.MpcRepoPath, 0755)).To(Succeed())
+			for _, args := range [][]string{
+				{"init", "--bare", upstreamPath},
+				{"-C", mockCfg.MpcRepoPath, "init"},
+				{"-C", mockCfg.MpcRepoPath, "-c", "user.email=test@example.com", "-c", "user.name=Test User", "commit", "--allow-empty", "-m", "Initial commit"},
+				{"-C", mockCfg.MpcRepoPath, "branch", "-M", "main"},
+				{"-C", mockCfg.MpcRepoPath, "remote", "add", "upstream", upstreamPath},
+				{"-C", mockCfg.MpcRepoPath, "push", "upstream", "main"},
+				{"-C", mockCfg.MpcRepoPath, "fetch", "upstream"},
+			} {
+				out, err := exec.Command("git", args...).CombinedOutput()
+				Expect(err).NotTo(HaveOccurred(), "git %v: %s", args, out)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/git/changes", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.GitChangesHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Header().Get("Content-Type")).To(Equal("application/json"))
+			var response api.GitChangesResponse
+			Expect(json.NewDecoder(rr.Body).Decode(&response)).To(Succeed())
+			Expect(response.Errors).To(BeEmpty())
+			Expect(response.Changes).To(HaveLen(1))
+			Expect(response.Changes[0].RepoName).To(Equal("multi-platform-controller"))
+			Expect(response.Changes[0].HasUpdates).To(BeFalse())
+		})
+
+		It("should report repositories whose changes cannot be computed", func() {
+			req := httptest.NewRequest(http.MethodGet, "/api/git/changes", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.GitChangesHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			var response api.GitChangesResponse
+			Expect(json.NewDecoder(rr.Body).Decode(&response)).To(Succeed())
+			Expect(response.Changes).To(BeEmpty())
+			Expect(response.Errors).To(HaveKeyWithValue("multi-platform-controller", ContainSubstring("not a git repository")))
+		})
+
+		It("should return 405 Method Not Allowed for non-GET requests", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/git/changes", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.GitChangesHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+
+	Describe("GitCheckoutHandler", func() {
+		var tempDir string
+
+		// git runs a git command in the MPC repository and fails the test if it fails
+		git := func(args ...string) string {
+			out, err := exec.Command("git", append([]string{"-C", mockCfg.MpcRepoPath}, args...)...).CombinedOutput()
+			Expect(err).NotTo(HaveOccurred(), "git %v: %s", args, out)
+			return strings.TrimSpace(string(out))
+		}
+
+		// checkout posts body to the handler and returns the recorded response
+		checkout := func(body string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodPost, "/api/git/checkout", strings.NewReader(body))
+			rr := httptest.NewRecorder()
+			handlers.GitCheckoutHandler(rr, req)
+			return rr
+		}
+
+		BeforeEach(func() {
+			var err error
+			tempDir, err = os.MkdirTemp("", "git-checkout-handler-test-*")
+			Expect(err).NotTo(HaveOccurred())
+			mockCfg.MpcRepoPath = filepath.Join(tempDir, "multi-platform-controller")
+			Expect(os.MkdirAll(mockCfg.MpcRepoPath, 0755)).To(Succeed())
+
+			git("init")
+			git("-c", "user.email=test@example.com", "-c", "user.name=Test User", "commit", "--allow-empty", "-m", "Initial commit")
+			git("branch", "-M", "main")
+			git("branch", "pr-123")
+		})
+
+		AfterEach(func() {
+			_ = os.RemoveAll(tempDir)
+		})
+
+		It("should check out an existing branch and refresh the state", func() {
+			rr := checkout(`{"branch": "pr-123"}`)
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			var response api.GitCheckoutResponse
+			Expect(json.NewDecoder(rr.Body).Decode(&response)).To(Succeed())
+			Expect(response.Status).To(Equal("success"))
+			Expect(response.Branch).To(Equal("pr-123"))
+			Expect(git("rev-parse", "--abbrev-ref", "HEAD")).To(Equal("pr-123"))
+			Expect(mockState.refreshCount).To(Equal(1))
+		})
+
+		It("should create a new branch when create is true", func() {
+			rr := checkout(`{"branch": "feature-x", "create": true}`)
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(git("rev-parse", "--abbrev-ref", "HEAD")).To(Equal("feature-x"))
+		})
+
+		It("should return 409 Conflict with the dirty files when the repository has uncommitted changes", func() {
+			Expect(os.WriteFile(filepath.Join(mockCfg.MpcRepoPath, "wip.go"), []byte("package main\n"), 0644)).To(Succeed())
+
+			rr := checkout(`{"branch": "pr-123"}`)
+
+			Expect(rr.Code).To(Equal(http.StatusConflict))
+			var response api.GitCheckoutResponse
+			Expect(json.NewDecoder(rr.Body).Decode(&response)).To(Succeed())
+			Expect(response.Status).To(Equal("conflict"))
+			Expect(response.DirtyFiles).To(Equal([]string{"wip.go"}))
+			Expect(git("rev-parse", "--abbrev-ref", "HEAD")).To(Equal("main"))
+			Expect(mockState.refreshCount).To(Equal(0))
+		})
+
+		It("should return 400 Bad Request when the branch is missing", func() {
+			rr := checkout(`{"create": true}`)
+
+			Expect(rr.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should return 405 Method Not Allowed for non-POST requests", func() {
+			req := httptest.NewRequest(http.MethodGet, "/api/git/checkout", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.GitCheckoutHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+
+	Describe("UndeployHandler", func() {
+		var (
+			tempDir      string
+			originalPath string
+		)
+
+		BeforeEach(func() {
+			var err error
+			tempDir, err = os.MkdirTemp("", "undeploy-test-*")
+			Expect(err).NotTo(HaveOccurred())
+
+			mpcRepoPath := filepath.Join(tempDir, "multi-platform-controller")
+			Expect(os.MkdirAll(filepath.Join(mpcRepoPath, "deploy", "operator"), 0755)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(mpcRepoPath, "deploy", "otp"), 0755)).To(Succeed())
+			mockCfg.MpcRepoPath = mpcRepoPath
+
+			mockKubectl := fmt.Sprintf("#!/bin/sh\necho \"$@\" >> %s\nexit 0\n", filepath.Join(tempDir, "kubectl_calls.log"))
+			Expect(os.WriteFile(filepath.Join(tempDir, "kubectl"), []byte(mockKubectl), 0755)).To(Succeed())
+			originalPath = os.Getenv("PATH")
+			_ = os.Setenv("PATH", tempDir+":"+originalPath)
+		})
+
+		AfterEach(func() {
+			_ = os.Setenv("PATH", originalPath)
+			_ = os.RemoveAll(tempDir)
+		})
+
+		It("should return 202 Accepted and clear the MPC deployment on success", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/mpc/undeploy", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.UndeployHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusAccepted))
+			Eventually(func() *state.MPCDeployment {
+				return mockState.GetState().MPCDeployment
+			}).Should(BeNil())
+			Eventually(func() string {
+				status, _ := mockState.LastStatus()
+				return status
+			}).Should(Equal("idle"))
+
+			calls, err := os.ReadFile(filepath.Join(tempDir, "kubectl_calls.log"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(calls)).To(ContainSubstring("delete configmap host-config"))
+		})
+
+		It("should keep the MPC deployment in state when undeploy fails", func() {
+			Expect(os.RemoveAll(filepath.Join(mockCfg.MpcRepoPath, "deploy"))).To(Succeed())
+
+			req := httptest.NewRequest(http.MethodPost, "/api/mpc/undeploy", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.UndeployHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusAccepted))
+			Eventually(func() error {
+				_, err := mockState.LastStatus()
+				return err
+			}).Should(HaveOccurred())
+			Expect(mockState.GetState().MPCDeployment).NotTo(BeNil())
+		})
+
+		It("should return 405 Method Not Allowed for GET requests", func() {
+			req := httptest.NewRequest(http.MethodGet, "/api/mpc/undeploy", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.UndeployHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+
+	Describe("RestartHandler", func() {
+		var (
+			tempDir      string
+			originalPath string
+		)
+
+		BeforeEach(func() {
+			var err error
+			tempDir, err = os.MkdirTemp("", "restart-test-*")
+			Expect(err).NotTo(HaveOccurred())
+
+			mockKubectl := fmt.Sprintf("#!/bin/sh\necho \"$@\" >> %s\nexit 0\n", filepath.Join(tempDir, "kubectl_calls.log"))
+			Expect(os.WriteFile(filepath.Join(tempDir, "kubectl"), []byte(mockKubectl), 0755)).To(Succeed())
+			originalPath = os.Getenv("PATH")
+			_ = os.Setenv("PATH", tempDir+":"+originalPath)
+		})
+
+		AfterEach(func() {
+			_ = os.Setenv("PATH", originalPath)
+			_ = os.RemoveAll(tempDir)
+		})
+
+		It("should return 202 Accepted and restart only the selected component", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/mpc/restart?component=controller", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.RestartHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusAccepted))
+			Eventually(func() string {
+				status, _ := mockState.LastStatus()
+				return status
+			}).Should(Equal("idle"))
+
+			calls, err := os.ReadFile(filepath.Join(tempDir, "kubectl_calls.log"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(calls)).To(ContainSubstring("rollout restart deployment/multi-platform-controller"))
+			Expect(string(calls)).To(ContainSubstring("rollout status deployment/multi-platform-controller"))
+			Expect(string(calls)).NotTo(ContainSubstring("multi-platform-otp-server"))
+		})
+
+		It("should return 400 Bad Request for an unknown component", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/mpc/restart?component=operator", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.RestartHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusBadRequest))
+			Expect(filepath.Join(tempDir, "kubectl_calls.log")).NotTo(BeAnExistingFile())
+		})
+
+		It("should return 405 Method Not Allowed for GET requests", func() {
+			req := httptest.NewRequest(http.MethodGet, "/api/mpc/restart", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.RestartHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+
+	Describe("RollbackHandler", func() {
+		var (
+			tempDir      string
+			originalPath string
+		)
+
+		BeforeEach(func() {
+			var err error
+			tempDir, err = os.MkdirTemp("", "rollback-test-*")
+			Expect(err).NotTo(HaveOccurred())
+
+			// The deployments report the rolled-back images, so verification passes
+			mockKubectl := fmt.Sprintf("#!/bin/sh\necho \"$@\" | tr '\\n' ' ' >> %[1]s\necho >> %[1]s\n"+
+				"[ \"$1 $3\" = 'get multi-platform-controller' ] && printf 'localhost/multi-platform-controller:aaaaaaaaaaaa'\n"+
+				"[ \"$1 $3\" = 'get multi-platform-otp-server' ] && printf 'localhost/multi-platform-otp:aaaaaaaaaaaa'\nexit 0\n", filepath.Join(tempDir, "kubectl_calls.log"))
+			Expect(os.WriteFile(filepath.Join(tempDir, "kubectl"), []byte(mockKubectl), 0755)).To(Succeed())
+			originalPath = os.Getenv("PATH")
+			_ = os.Setenv("PATH", tempDir+":"+originalPath)
+		})
+
+		AfterEach(func() {
+			_ = os.Setenv("PATH", originalPath)
+			_ = os.RemoveAll(tempDir)
+		})
+
+		It("should patch the deployments back to the previous images and clear them", func() {
+			handlers.RecordPreviousImages(deploy.Images{
+				Controller: "localhost/multi-platform-controller:aaaaaaaaaaaa",
+				OTP:        "localhost/multi-platform-otp:aaaaaaaaaaaa",
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/api/mpc/rollback", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.RollbackHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusAccepted))
+			Eventually(func() *state.PreviousMPCImages {
+				return mockState.GetState().PreviousMPCImages
+			}).Should(BeNil())
+			Eventually(func() string {
+				status, _ := mockState.LastStatus()
+				return status
+			}).Should(Equal("idle"))
+
+			calls, err := os.ReadFile(filepath.Join(tempDir, "kubectl_calls.log"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(calls)).To(MatchRegexp(`patch deployment multi-platform-controller .*"value": "localhost/multi-platform-controller:aaaaaaaaaaaa"`))
+			Expect(string(calls)).To(MatchRegexp(`patch deployment multi-platform-otp-server .*"value": "localhost/multi-platform-otp:aaaaaaaaaaaa"`))
+		})
+
+		It("should return 409 Conflict when the previous images use a mutable tag", func() {
+			handlers.RecordPreviousImages(deploy.Images{
+				Controller: "localhost/multi-platform-controller:latest",
+				OTP:        "localhost/multi-platform-otp:latest",
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/api/mpc/rollback", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.RollbackHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusConflict))
+			Expect(rr.Body.String()).To(ContainSubstring("mutable tag"))
+			Expect(filepath.Join(tempDir, "kubectl_calls.log")).NotTo(BeAnExistingFile())
+		})
+
+		It("should return 409 Conflict when no previous images are recorded", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/mpc/rollback", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.RollbackHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusConflict))
+			Expect(rr.Body.String()).To(ContainSubstring("no previous MPC images"))
+			Expect(filepath.Join(tempDir, "kubectl_calls.log")).NotTo(BeAnExistingFile())
+		})
+	})
+
+	Describe("DeployKonfluxHandler", func() {
+		var originalPath string
+
+		BeforeEach(func() {
+			// The konflux-ci checkout is a sibling of mpc_dev_env; its scripts succeed right away
+			tempDir := GinkgoT().TempDir()
+			mockCfg.MpcDevEnvPath = filepath.Join(tempDir, "mpc_dev_env")
+			konfluxCIDir := filepath.Join(tempDir, "konflux-ci")
+			Expect(os.MkdirAll(konfluxCIDir, 0755)).To(Succeed())
+			for _, script := range []string{"deploy-deps.sh", "deploy-konflux.sh", "deploy-test-resources.sh"} {
+				Expect(os.WriteFile(filepath.Join(konfluxCIDir, script), []byte("exit 0\n"), 0755)).To(Succeed())
+			}
+
+			// kubectl provides the kubeconfig the scripts run with
+			Expect(os.WriteFile(filepath.Join(tempDir, "kubectl"), []byte("#!/bin/sh\nexit 0\n"), 0755)).To(Succeed())
+			originalPath = os.Getenv("PATH")
+			_ = os.Setenv("PATH", tempDir+":"+originalPath)
+		})
+
+		AfterEach(func() {
+			_ = os.Setenv("PATH", originalPath)
+		})
+
+		It("should record the configured Konflux UI in the state once deployed", func() {
+			mockCfg.KonfluxUIURL = "https://konflux.example.com:8443"
+			mockCfg.KonfluxUsername = "dev@konflux.dev"
+
+			rr := httptest.NewRecorder()
+			handlers.DeployKonfluxHandler(rr, httptest.NewRequest(http.MethodPost, "/api/deploy/konflux", nil))
+
+			Expect(rr.Code).To(Equal(http.StatusAccepted))
+			Eventually(func() *state.KonfluxInfo {
+				return mockState.GetState().Konflux
+			}).Should(Equal(&state.KonfluxInfo{URL: "https://konflux.example.com:8443", Username: "dev@konflux.dev"}))
+		})
+
+		It("should not record the Konflux UI when the deployment fails", func() {
+			Expect(os.WriteFile(filepath.Join(filepath.Dir(mockCfg.MpcDevEnvPath), "konflux-ci", "deploy-konflux.sh"), []byte("echo applying konflux manifests\nexit 1\n"), 0755)).To(Succeed())
+
+			rr := httptest.NewRecorder()
+			handlers.DeployKonfluxHandler(rr, httptest.NewRequest(http.MethodPost, "/api/deploy/konflux", nil))
+
+			Eventually(func() error {
+				_, err := mockState.LastStatus()
+				return err
+			}).Should(MatchError(ContainSubstring("failed to deploy Konflux components")))
+			Expect(mockState.GetState().Konflux).To(BeNil())
+
+			// The scripts' output is kept as the deploy_konflux operation log
+			rr = httptest.NewRecorder()
+			handlers.OperationLogsHandler(rr, httptest.NewRequest(http.MethodGet, "/api/operations/logs?name=deploy_konflux", nil))
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Body.String()).To(ContainSubstring("applying konflux manifests"))
+		})
+	})
+
+	Describe("DeployMinimalStackHandler", func() {
+		It("should reject an unknown component without starting a deployment", func() {
+			body := strings.NewReader(`{"components":["operator","konflux"]}`)
+
+			rr := httptest.NewRecorder()
+			handlers.DeployMinimalStackHandler(rr, httptest.NewRequest(http.MethodPost, "/api/deploy/minimal-stack", body))
+
+			Expect(rr.Code).To(Equal(http.StatusBadRequest))
+			Expect(rr.Body.String()).To(ContainSubstring(`"konflux"`))
+			status, _ := mockState.LastStatus()
+			Expect(status).To(BeEmpty())
+		})
+	})
+
+	Describe("DeployMetricsHandler", func() {
+		var (
+			tempDir      string
+			originalPath string
+		)
+
+		BeforeEach(func() {
+			tempDir = GinkgoT().TempDir()
+
+			mockKubectl := fmt.Sprintf("#!/bin/sh\necho \"$@\" >> %s\nexit 0\n", filepath.Join(tempDir, "kubectl_calls.log"))
+			Expect(os.WriteFile(filepath.Join(tempDir, "kubectl"), []byte(mockKubectl), 0755)).To(Succeed())
+			originalPath = os.Getenv("PATH")
+			_ = os.Setenv("PATH", tempDir+":"+originalPath)
+		})
+
+		AfterEach(func() {
+			_ = os.Setenv("PATH", originalPath)
+		})
+
+		It("should return 202 Accepted and record the metrics stack once it is ready", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/metrics/deploy", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.DeployMetricsHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusAccepted))
+			Eventually(func() *state.MetricsConfig {
+				return mockState.GetState().Metrics
+			}).ShouldNot(BeNil())
+			Eventually(func() string {
+				status, _ := mockState.LastStatus()
+				return status
+			}).Should(Equal("idle"))
+
+			metrics := mockState.GetState().Metrics
+			Expect(metrics.PrometheusEnabled).To(BeTrue())
+			Expect(metrics.GrafanaEnabled).To(BeTrue())
+			Expect(metrics.PrometheusURL).To(Equal("http://prometheus.mpc-metrics.svc:9090"))
+			Expect(metrics.GrafanaURL).To(Equal("http://grafana.mpc-metrics.svc:3000"))
+			Expect(metrics.RetentionDays).To(Equal(7))
+
+			calls, err := os.ReadFile(filepath.Join(tempDir, "kubectl_calls.log"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(calls)).To(ContainSubstring("apply -f -"))
+			Expect(string(calls)).To(ContainSubstring("rollout status deployment/prometheus -n mpc-metrics"))
+			Expect(string(calls)).To(ContainSubstring("rollout status deployment/grafana -n mpc-metrics"))
+		})
+
+		It("should report the error and leave metrics unset when kubectl fails", func() {
+			mockKubectl := "#!/bin/sh\necho 'connection refused' >&2\nexit 1\n"
+			Expect(os.WriteFile(filepath.Join(tempDir, "kubectl"), []byte(mockKubectl), 0755)).To(Succeed())
+
+			req := httptest.NewRequest(http.MethodPost, "/api/metrics/deploy", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.DeployMetricsHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusAccepted))
+			Eventually(func() error {
+				_, err := mockState.LastStatus()
+				return err
+			}).Should(MatchError(ContainSubstring("failed to apply metrics manifests")))
+			Expect(mockState.GetState().Metrics).To(BeNil())
+		})
+
+		It("should return 405 Method Not Allowed for GET requests", func() {
+			req := httptest.NewRequest(http.MethodGet, "/api/metrics/deploy", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.DeployMetricsHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+
+	Describe("EnableFeatureHandler", func() {
+		var (
+			tempDir      string
+			originalPath string
+		)
+
+		BeforeEach(func() {
+			var err error
+			tempDir, err = os.MkdirTemp("", "enable-feature-test-*")
+			Expect(err).NotTo(HaveOccurred())
+
+			// Mock kubectl that records its arguments and reports every resource as present
+			mockKubectl := fmt.Sprintf("#!/bin/sh\necho \"$@\" >> %s\nexit 0\n", filepath.Join(tempDir, "kubectl_calls.log"))
+			Expect(os.WriteFile(filepath.Join(tempDir, "kubectl"), []byte(mockKubectl), 0755)).To(Succeed())
+			originalPath = os.Getenv("PATH")
+			_ = os.Setenv("PATH", tempDir+":"+originalPath)
+		})
+
+		AfterEach(func() {
+			_ = os.Setenv("PATH", originalPath)
+			_ = os.RemoveAll(tempDir)
+		})
+
+		It("should deploy IBM secrets and flip IBMEnabled for ibm-secrets", func() {
+			mockState.SetIBMEnabled(false)
+			s390xKey := filepath.Join(tempDir, "s390x_id_rsa")
+			ppc64leKey := filepath.Join(tempDir, "ppc64le_id_rsa")
+			Expect(os.WriteFile(s390xKey, []byte("fake-key"), 0600)).To(Succeed())
+			Expect(os.WriteFile(ppc64leKey, []byte("fake-key"), 0600)).To(Succeed())
+
+			body, err := json.Marshal(api.EnableFeatureRequest{
+				FeatureName: "ibm-secrets",
+				Credentials: map[string]string{
+					"IBM_S390X_SSH_KEY_PATH":   s390xKey,
+					"IBM_PPC64LE_SSH_KEY_PATH": ppc64leKey,
+					"IBMCLOUD_API_KEY":         "test-api-key",
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			req := httptest.NewRequest(http.MethodPost, "/api/features/enable", strings.NewReader(string(body)))
+			rr := httptest.NewRecorder()
+
+			handlers.EnableFeatureHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusAccepted))
+			Eventually(func() bool {
+				return mockState.GetState().Features.IBMEnabled
+			}).Should(BeTrue())
+
+			calls, err := os.ReadFile(filepath.Join(tempDir, "kubectl_calls.log"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(calls)).To(ContainSubstring("create secret generic ibmcloud-api-key"))
+		})
+
+		It("should pass aws-secrets credentials without setting them in the environment", func() {
+			// kubectl inherits the daemon's environment, so it records any credential set in it
+			envLog := filepath.Join(tempDir, "kubectl_env.log")
+			mockKubectl := fmt.Sprintf("#!/bin/sh\necho \"$@\" >> %s\necho \"key=$AWS_ACCESS_KEY_ID secret=$AWS_SECRET_ACCESS_KEY\" >> %s\nexit 0\n",
+				filepath.Join(tempDir, "kubectl_calls.log"), envLog)
+			Expect(os.WriteFile(filepath.Join(tempDir, "kubectl"), []byte(mockKubectl), 0755)).To(Succeed())
+			sshKey := filepath.Join(tempDir, "id_rsa")
+			Expect(os.WriteFile(sshKey, []byte("fake-key"), 0600)).To(Succeed())
+			for _, key := range []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_SESSION_TOKEN", "SSH_KEY_PATH"} {
+				GinkgoT().Setenv(key, "")
+				Expect(os.Unsetenv(key)).To(Succeed())
+			}
+
+			body, err := json.Marshal(api.EnableFeatureRequest{
+				FeatureName: "aws-secrets",
+				Credentials: map[string]string{
+					"AWS_ACCESS_KEY_ID":     "test-key-id",
+					"AWS_SECRET_ACCESS_KEY": "test-secret-key",
+					"SSH_KEY_PATH":          sshKey,
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			req := httptest.NewRequest(http.MethodPost, "/api/features/enable", strings.NewReader(string(body)))
+			rr := httptest.NewRecorder()
+
+			handlers.EnableFeatureHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusAccepted))
+			Eventually(func() string {
+				calls, _ := os.ReadFile(filepath.Join(tempDir, "kubectl_calls.log"))
+				return string(calls)
+			}).Should(ContainSubstring("create secret generic aws-ssh-key"))
+
+			calls, err := os.ReadFile(filepath.Join(tempDir, "kubectl_calls.log"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(calls)).To(ContainSubstring("create secret generic aws-account --from-file=access-key-id="))
+
+			env, err := os.ReadFile(envLog)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(env)).NotTo(ContainSubstring("test-key-id"))
+			Expect(string(env)).NotTo(ContainSubstring("test-secret-key"))
+			for _, key := range []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "SSH_KEY_PATH"} {
+				_, set := os.LookupEnv(key)
+				Expect(set).To(BeFalse(), "%s is set", key)
+			}
+		})
+
+		It("should bound the secrets deployment by the configured secrets timeout", func() {
+			mockCfg.Timeouts.Secrets = 200 * time.Millisecond
+			Expect(os.WriteFile(filepath.Join(tempDir, "kubectl"), []byte("#!/bin/sh\nexec sleep 10\n"), 0755)).To(Succeed())
+			mockState.SetIBMEnabled(false)
+			s390xKey := filepath.Join(tempDir, "s390x_id_rsa")
+			ppc64leKey := filepath.Join(tempDir, "ppc64le_id_rsa")
+			Expect(os.WriteFile(s390xKey, []byte("fake-key"), 0600)).To(Succeed())
+			Expect(os.WriteFile(ppc64leKey, []byte("fake-key"), 0600)).To(Succeed())
+
+			body, err := json.Marshal(api.EnableFeatureRequest{
+				FeatureName: "ibm-secrets",
+				Credentials: map[string]string{
+					"IBM_S390X_SSH_KEY_PATH":   s390xKey,
+					"IBM_PPC64LE_SSH_KEY_PATH": ppc64leKey,
+					"IBMCLOUD_API_KEY":         "test-api-key",
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			started := time.Now()
+			rr := httptest.NewRecorder()
+			handlers.EnableFeatureHandler(rr, httptest.NewRequest(http.MethodPost, "/api/features/enable", strings.NewReader(string(body))))
+			Expect(rr.Code).To(Equal(http.StatusAccepted))
+
+			// Shutdown waits out its grace period for background work still running
+			Expect(handlers.Shutdown(context.Background(), 8*time.Second)).To(Succeed())
+			Expect(time.Since(started)).To(BeNumerically("<", 5*time.Second))
+			Expect(mockState.GetState().Features.IBMEnabled).To(BeFalse())
+		})
+
+		It("should return 400 for unsupported features", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/features/enable", strings.NewReader(`{"feature_name": "gcp-secrets"}`))
+			rr := httptest.NewRecorder()
+
+			handlers.EnableFeatureHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusBadRequest))
+			Expect(rr.Body.String()).To(ContainSubstring("'aws-secrets' and 'ibm-secrets'"))
+		})
+	})
+
+	Describe("ClusterKubeconfigHandler", func() {
+		var (
+			tempDir      string
+			originalPath string
+		)
+
+		BeforeEach(func() {
+			var err error
+			tempDir, err = os.MkdirTemp("", "kubeconfig-test-*")
+			Expect(err).NotTo(HaveOccurred())
+
+			// Mock kind that only knows about a cluster named "running-cluster"
+			mockKind := `#!/bin/sh
+if [ "$4" = "running-cluster" ]; then
+  echo "apiVersion: v1"
+  echo "current-context: kind-running-cluster"
+  exit 0
+fi
+echo "ERROR: could not locate any control plane nodes for cluster named '$4'" >&2
+exit 1
+`
+			Expect(os.WriteFile(filepath.Join(tempDir, "kind"), []byte(mockKind), 0755)).To(Succeed())
+			// The cluster runs on the detected container runtime, so one has to be found
+			Expect(os.WriteFile(filepath.Join(tempDir, "podman"), []byte("#!/bin/sh\nexit 0\n"), 0755)).To(Succeed())
+			originalPath = os.Getenv("PATH")
+			_ = os.Setenv("PATH", tempDir+":"+originalPath)
+		})
+
+		AfterEach(func() {
+			_ = os.Setenv("PATH", originalPath)
+			_ = os.RemoveAll(tempDir)
+		})
+
+		It("should return the kubeconfig and context name when the cluster exists", func() {
+			handlers = api.NewHandlers(mockState, &config.Config{ClusterName: "running-cluster"})
+			req := httptest.NewRequest(http.MethodGet, "/api/cluster/kubeconfig", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.ClusterKubeconfigHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Header().Get("Content-Type")).To(Equal("application/json"))
+
+			var response map[string]string
+			Expect(json.NewDecoder(rr.Body).Decode(&response)).To(Succeed())
+			Expect(response["context"]).To(Equal("kind-running-cluster"))
+			Expect(response["kubeconfig"]).To(ContainSubstring("current-context: kind-running-cluster"))
+		})
+
+		It("should return 404 when the cluster is not running", func() {
+			req := httptest.NewRequest(http.MethodGet, "/api/cluster/kubeconfig", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.ClusterKubeconfigHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusNotFound))
+
+			var response map[string]string
+			Expect(json.NewDecoder(rr.Body).Decode(&response)).To(Succeed())
+			Expect(response["error"]).To(ContainSubstring("not running"))
+		})
+
+		It("should return 405 Method Not Allowed for POST requests", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/cluster/kubeconfig", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.ClusterKubeconfigHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+
+	Describe("ReadyHandler", func() {
+		var (
+			mockCluster *mockClusterManager
+			probe       *mockReadinessProbe
+		)
+
+		BeforeEach(func() {
+			mockCluster = &mockClusterManager{status: cluster.StatusRunning}
+			handlers.ClusterManager = mockCluster
+			probe = &mockReadinessProbe{prerequisites: &prereq.CheckResult{AllMet: true}}
+			handlers.SetReadinessProbeFactory(func(cfg *config.Config, kubeContext string) api.ReadinessProbe {
+				return probe
+			})
+		})
+
+		ready := func() (int, api.ReadyResponse) {
+			req := httptest.NewRequest(http.MethodGet, "/api/ready", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.ReadyHandler(rr, req)
+
+			var response api.ReadyResponse
+			Expect(json.Unmarshal(rr.Body.Bytes(), &response)).To(Succeed())
+			return rr.Code, response
+		}
+
+		It("should return 200 and ready when every check passes", func() {
+			code, response := ready()
+
+			Expect(code).To(Equal(http.StatusOK))
+			Expect(response.Ready).To(BeTrue())
+			Expect(response.Failed).To(BeEmpty())
+			Expect(response.Checks).To(HaveLen(5))
+			for _, check := range response.Checks {
+				Expect(check.Ready).To(BeTrue(), check.Name)
+			}
+			Expect(probe.checked).To(ConsistOf(deploy.TektonWebhookDeployment, deploy.ControllerDeployment, deploy.OTPDeployment))
+		})
+
+		It("should return 503 with the failed checks when only some pass", func() {
+			probe.prerequisites = &prereq.CheckResult{AllMet: false, Errors: []string{"kind is not installed"}}
+			probe.unavailable = map[deploy.Deployment]error{
+				deploy.OTPDeployment: fmt.Errorf("%w: %s", deploy.ErrDeploymentNotAvailable, deploy.OTPDeployment),
+			}
+
+			code, response := ready()
+
+			Expect(code).To(Equal(http.StatusServiceUnavailable))
+			Expect(response.Ready).To(BeFalse())
+			Expect(response.Failed).To(Equal([]string{api.ReadyCheckPrerequisites, api.ReadyCheckOTP}))
+			Expect(response.Checks).To(ContainElement(api.ReadyCheck{
+				Name: api.ReadyCheckPrerequisites, Ready: false, Message: "kind is not installed",
+			}))
+			Expect(response.Checks).To(ContainElement(api.ReadyCheck{Name: api.ReadyCheckController, Ready: true}))
+		})
+
+		It("should fail the deployment checks without probing them while the cluster is not running", func() {
+			mockCluster.status = cluster.StatusPaused
+
+			code, response := ready()
+
+			Expect(code).To(Equal(http.StatusServiceUnavailable))
+			Expect(response.Failed).To(Equal([]string{
+				api.ReadyCheckCluster, api.ReadyCheckTektonWebhook, api.ReadyCheckController, api.ReadyCheckOTP,
+			}))
+			Expect(response.Checks).To(ContainElement(api.ReadyCheck{
+				Name: api.ReadyCheckCluster, Ready: false, Message: "cluster is Paused",
+			}))
+			Expect(probe.checked).To(BeEmpty())
+		})
+
+		It("should return 405 for non-GET requests", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/ready", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.ReadyHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+
+	Describe("ResolveTaskRunPath", func() {
+		var dir, outsideDir string
+
+		BeforeEach(func() {
+			dir = GinkgoT().TempDir()
+			outsideDir = GinkgoT().TempDir()
+			Expect(os.MkdirAll(filepath.Join(dir, "e2e"), 0750)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "e2e", "arm64.yaml"), nil, 0600)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(outsideDir, "secret.yaml"), nil, 0600)).To(Succeed())
+		})
+
+		It("should accept a file within the directory, including subdirectories", func() {
+			resolved, err := api.ResolveTaskRunPath(dir, filepath.Join(dir, "e2e", "..", "e2e", "arm64.yaml"))
+			Expect(err).NotTo(HaveOccurred())
+
+			expected, err := filepath.EvalSymlinks(filepath.Join(dir, "e2e", "arm64.yaml"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resolved).To(Equal(expected))
+		})
+
+		It("should reject a path traversing out of the directory", func() {
+			traversal := filepath.Join(dir, "..", filepath.Base(outsideDir), "secret.yaml")
+			_, err := api.ResolveTaskRunPath(dir, traversal)
+			Expect(err).To(MatchError(ContainSubstring("is not within the taskruns directory")))
+		})
+
+		It("should reject a symlink in the directory pointing outside it", func() {
+			link := filepath.Join(dir, "link.yaml")
+			Expect(os.Symlink(filepath.Join(outsideDir, "secret.yaml"), link)).To(Succeed())
+
+			_, err := api.ResolveTaskRunPath(dir, link)
+			Expect(err).To(MatchError(ContainSubstring("is not within the taskruns directory")))
+		})
+
+		It("should reject a missing file and the directory itself", func() {
+			_, err := api.ResolveTaskRunPath(dir, filepath.Join(dir, "missing.yaml"))
+			Expect(err).To(MatchError(ContainSubstring("cannot resolve")))
+
+			_, err = api.ResolveTaskRunPath(dir, dir)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("TaskRunRunHandler", func() {
+		const inlineYAML = `apiVersion: tekton.dev/v1
+kind: TaskRun
+metadata:
+  name: inline-taskrun
+spec:
+  taskRef:
+    name: echo
+`
+
+		var taskRunPath string
+
+		BeforeEach(func() {
+			// Point the session log dir at a regular file so the async workflow stops
+			// right after publishing its log location, before touching any cluster
+			blocker := filepath.Join(GinkgoT().TempDir(), "not-a-dir")
+			Expect(os.WriteFile(blocker, nil, 0600)).To(Succeed())
+			mockCfg.SessionLogDir = blocker
+
+			mockCfg.TaskRunsDir = GinkgoT().TempDir()
+			taskRunPath = filepath.Join(mockCfg.TaskRunsDir, "my_taskrun.yaml")
+			Expect(os.WriteFile(taskRunPath, []byte(inlineYAML), 0600)).To(Succeed())
+		})
+
+		post := func(body string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodPost, "/api/taskrun/run", strings.NewReader(body))
+			rr := httptest.NewRecorder()
+			handlers.TaskRunRunHandler(rr, req)
+			return rr
+		}
+
+		finishedLogFile := func() string {
+			var logFile string
+			Eventually(func() string {
+				info := mockState.GetState().TaskRunInfo
+				if info == nil {
+					return ""
+				}
+				logFile = info.LogFile
+				return info.Status
+			}).Should(Equal("Error"))
+			return filepath.Base(logFile)
+		}
+
+		It("should accept a yaml_path and name the log after the file", func() {
+			rr := post(fmt.Sprintf(`{"yaml_path": %q}`, taskRunPath))
+
+			Expect(rr.Code).To(Equal(http.StatusAccepted))
+			Expect(finishedLogFile()).To(HavePrefix("my_taskrun_"))
+		})
+
+		It("should return 400 when yaml_path is outside the taskruns directory", func() {
+			outside := filepath.Join(GinkgoT().TempDir(), "outside.yaml")
+			Expect(os.WriteFile(outside, []byte(inlineYAML), 0600)).To(Succeed())
+
+			for _, yamlPath := range []string{
+				outside,
+				filepath.Join(mockCfg.TaskRunsDir, "..", filepath.Base(filepath.Dir(outside)), "outside.yaml"),
+				"/etc/passwd",
+			} {
+				rr := post(fmt.Sprintf(`{"yaml_path": %q}`, yamlPath))
+
+				Expect(rr.Code).To(Equal(http.StatusBadRequest), yamlPath)
+				Expect(rr.Body.String()).To(ContainSubstring("invalid yaml_path"))
+			}
+			Expect(mockState.GetState().TaskRunInfo).To(BeNil())
+		})
+
+		It("should accept inline yaml_content and name the log after the TaskRun", func() {
+			body, err := json.Marshal(api.TaskRunRunRequest{YAMLContent: inlineYAML})
+			Expect(err).NotTo(HaveOccurred())
+
+			rr := post(string(body))
+
+			Expect(rr.Code).To(Equal(http.StatusAccepted))
+			Expect(finishedLogFile()).To(HavePrefix("inline-taskrun_"))
+		})
+
+		It("should return 400 when both yaml_path and yaml_content are provided", func() {
+			body, err := json.Marshal(api.TaskRunRunRequest{
+				YAMLPath:    "/path/to/my_taskrun.yaml",
+				YAMLContent: inlineYAML,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			rr := post(string(body))
+
+			Expect(rr.Code).To(Equal(http.StatusBadRequest))
+			Expect(rr.Body.String()).To(ContainSubstring("exactly one of yaml_path or yaml_content"))
+		})
+
+		It("should return 400 when neither yaml_path nor yaml_content is provided", func() {
+			rr := post(`{}`)
+
+			Expect(rr.Code).To(Equal(http.StatusBadRequest))
+			Expect(rr.Body.String()).To(ContainSubstring("exactly one of yaml_path or yaml_content"))
+		})
+
+		It("should return 400 when a param has an empty value", func() {
+			rr := post(fmt.Sprintf(`{"yaml_path": %q, "params": {"IMAGE": ""}}`, taskRunPath))
+
+			Expect(rr.Code).To(Equal(http.StatusBadRequest))
+			Expect(rr.Body.String()).To(ContainSubstring(`invalid params: param "IMAGE" must have a non-empty value`))
+		})
+
+		It("should return 400 when yaml_content is not a TaskRun", func() {
+			rr := post(`{"yaml_content": "apiVersion: v1\nkind: Pod\nmetadata:\n  name: my-pod\n"}`)
+
+			Expect(rr.Code).To(Equal(http.StatusBadRequest))
+			Expect(rr.Body.String()).To(ContainSubstring("invalid yaml_content"))
+		})
+
+		It("should return 405 Method Not Allowed for GET requests", func() {
+			req := httptest.NewRequest(http.MethodGet, "/api/taskrun/run", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.TaskRunRunHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+
+	Describe("TaskRunCancelHandler", func() {
+		It("should return 404 when no TaskRun is running", func() {
+			mockState.stateToReturn.OperationStatus = "idle"
+
+			req := httptest.NewRequest(http.MethodPost, "/api/taskrun/cancel", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.TaskRunCancelHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusNotFound))
+			Expect(rr.Header().Get("Content-Type")).To(Equal("application/json"))
+
+			var response map[string]string
+			Expect(json.NewDecoder(rr.Body).Decode(&response)).To(Succeed())
+			Expect(response["status"]).To(Equal("error"))
+		})
+
+		It("should cancel the running workflow and clear the TaskRun state", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			handlers.TrackTaskRun(cancel)
+
+			mockState.stateToReturn.OperationStatus = "running_taskrun"
+			mockState.stateToReturn.TaskRunInfo = &state.TaskRunInfo{
+				Status:  "Running",
+				LogFile: "/tmp/logs/my_taskrun.log",
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/taskrun/cancel", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.TaskRunCancelHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+
+			var response map[string]string
+			Expect(json.NewDecoder(rr.Body).Decode(&response)).To(Succeed())
+			Expect(response["status"]).To(Equal("cancelled"))
+
+			// The workflow context is signalled and the state is reset
+			Expect(ctx.Err()).To(MatchError(context.Canceled))
+			Expect(mockState.lastStatus).To(Equal("idle"))
+			Expect(mockState.lastError).NotTo(HaveOccurred())
+			Expect(mockState.stateToReturn.TaskRunInfo).To(BeNil())
+		})
+
+		It("should return 404 on a second cancel after the workflow was cancelled", func() {
+			_, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			handlers.TrackTaskRun(cancel)
+
+			first := httptest.NewRecorder()
+			handlers.TaskRunCancelHandler(first, httptest.NewRequest(http.MethodPost, "/api/taskrun/cancel", nil))
+			Expect(first.Code).To(Equal(http.StatusOK))
+
+			second := httptest.NewRecorder()
+			handlers.TaskRunCancelHandler(second, httptest.NewRequest(http.MethodPost, "/api/taskrun/cancel", nil))
+			Expect(second.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should return 405 Method Not Allowed for GET requests", func() {
+			req := httptest.NewRequest(http.MethodGet, "/api/taskrun/cancel", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.TaskRunCancelHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+
+	Describe("TaskRunLogsHandler", func() {
+		var logPath string
+
+		BeforeEach(func() {
+			DeferCleanup(api.SetTaskRunLogPollInterval(10 * time.Millisecond))
+			logPath = filepath.Join(GinkgoT().TempDir(), "my_taskrun.log")
+		})
+
+		It("should return 404 when no TaskRun has been started", func() {
+			mockState.stateToReturn.TaskRunInfo = nil
+
+			req := httptest.NewRequest(http.MethodGet, "/api/taskrun/logs", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.TaskRunLogsHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should stream appended log lines as server-sent events until the TaskRun finishes", func() {
+			Expect(os.WriteFile(logPath, []byte("step one\n"), 0600)).To(Succeed())
+			mockState.stateToReturn.OperationStatus = "running_taskrun"
+			mockState.stateToReturn.TaskRunInfo = &state.TaskRunInfo{Status: "Running", LogFile: logPath}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/taskrun/logs", nil)
+			rr := httptest.NewRecorder()
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				handlers.TaskRunLogsHandler(rr, req)
+			}()
+
+			// Append while the handler is following the file, then finish the TaskRun
+			time.Sleep(50 * time.Millisecond)
+			f, err := os.OpenFile(logPath, os.O_APPEND|os.O_WRONLY, 0600)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = f.WriteString("step two\npartial")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(f.Close()).To(Succeed())
+
+			time.Sleep(50 * time.Millisecond)
+			mockState.SetTaskRunInfo(&state.TaskRunInfo{Name: "my-taskrun", Status: "Succeeded", LogFile: logPath})
+			ok, _ := mockState.TrySetOperationStatus("running_taskrun", "idle", nil)
+			Expect(ok).To(BeTrue())
+
+			Eventually(done).Should(BeClosed())
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Header().Get("Content-Type")).To(Equal("text/event-stream"))
+			Expect(rr.Body.String()).To(Equal(
+				"data: step one\n\n" +
+					"data: step two\n\n" +
+					"data: partial\n\n" +
+					"event: done\ndata: Succeeded\n\n"))
+		})
+
+		It("should stop streaming when the client disconnects", func() {
+			mockState.stateToReturn.OperationStatus = "running_taskrun"
+			mockState.stateToReturn.TaskRunInfo = &state.TaskRunInfo{Status: "Running", LogFile: logPath}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			req := httptest.NewRequest(http.MethodGet, "/api/taskrun/logs", nil).WithContext(ctx)
+			rr := httptest.NewRecorder()
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				handlers.TaskRunLogsHandler(rr, req)
+			}()
+
+			cancel()
+			Eventually(done).Should(BeClosed())
+			Expect(rr.Body.String()).NotTo(ContainSubstring("event: done"))
+		})
+
+		It("should return 405 Method Not Allowed for POST requests", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/taskrun/logs", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.TaskRunLogsHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+
+	Describe("TaskRunStatusHandler", func() {
+		decode := func(rr *httptest.ResponseRecorder) api.TaskRunStatusResponse {
+			var response api.TaskRunStatusResponse
+			Expect(json.NewDecoder(rr.Body).Decode(&response)).To(Succeed())
+			return response
+		}
+
+		It("should report phase none when no TaskRun has been started", func() {
+			mockState.stateToReturn.OperationStatus = "idle"
+			mockState.stateToReturn.TaskRunInfo = nil
+
+			req := httptest.NewRequest(http.MethodGet, "/api/taskrun/status", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.TaskRunStatusHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Header().Get("Content-Type")).To(Equal("application/json"))
+			response := decode(rr)
+			Expect(response.Phase).To(Equal("none"))
+			Expect(response.Name).To(BeEmpty())
+		})
+
+		It("should report phase running while a TaskRun workflow is in progress", func() {
+			mockState.stateToReturn.OperationStatus = "running_taskrun"
+			mockState.stateToReturn.TaskRunInfo = &state.TaskRunInfo{
+				Status:    "Running",
+				LogFile:   "/tmp/logs/my_taskrun.log",
+				StartTime: "2024-01-01T12:00:00Z",
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/taskrun/status", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.TaskRunStatusHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			response := decode(rr)
+			Expect(response.Phase).To(Equal("running"))
+			Expect(response.LogFile).To(Equal("/tmp/logs/my_taskrun.log"))
+			Expect(response.StartTime).To(Equal("2024-01-01T12:00:00Z"))
+		})
+
+		It("should report phase succeeded for a finished successful TaskRun", func() {
+			mockState.stateToReturn.OperationStatus = "idle"
+			mockState.stateToReturn.TaskRunInfo = &state.TaskRunInfo{
+				Name:      "my-taskrun-abc12",
+				Status:    "Succeeded",
+				LogFile:   "/tmp/logs/my_taskrun.log",
+				StartTime: "2024-01-01T12:00:00Z",
+				Results:   map[string]string{"IMAGE_DIGEST": "sha256:0123abcd"},
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/taskrun/status", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.TaskRunStatusHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			response := decode(rr)
+			Expect(response.Phase).To(Equal("succeeded"))
+			Expect(response.Name).To(Equal("my-taskrun-abc12"))
+			Expect(response.Status).To(Equal("Succeeded"))
+			Expect(response.Results).To(Equal(map[string]string{"IMAGE_DIGEST": "sha256:0123abcd"}))
+		})
+
+		It("should report phase failed for a finished failed TaskRun", func() {
+			mockState.stateToReturn.OperationStatus = "idle"
+			mockState.stateToReturn.TaskRunInfo = &state.TaskRunInfo{
+				Name:   "my-taskrun-abc12",
+				Status: "Failed",
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/taskrun/status", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.TaskRunStatusHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			response := decode(rr)
+			Expect(response.Phase).To(Equal("failed"))
+			Expect(response.Status).To(Equal("Failed"))
+		})
+
+		It("should return 405 Method Not Allowed for POST requests", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/taskrun/status", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.TaskRunStatusHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+
+	Describe("TaskRunListHandler", func() {
+		It("should return 400 Bad Request for an invalid limit", func() {
+			for _, limit := range []string{"abc", "0", "-3"} {
+				req := httptest.NewRequest(http.MethodGet, "/api/taskrun/list?limit="+limit, nil)
+				rr := httptest.NewRecorder()
+
+				handlers.TaskRunListHandler(rr, req)
+
+				Expect(rr.Code).To(Equal(http.StatusBadRequest), "limit=%s", limit)
+				Expect(rr.Body.String()).To(ContainSubstring("limit must be a positive integer"))
+			}
+		})
+
+		It("should return 405 Method Not Allowed for POST requests", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/taskrun/list", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.TaskRunListHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+
+	Describe("MPCLogsHandler", func() {
+		It("should return 400 Bad Request for an unknown component", func() {
+			req := httptest.NewRequest(http.MethodGet, "/api/mpc/logs?component=operator", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.MPCLogsHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusBadRequest))
+			Expect(rr.Body.String()).To(ContainSubstring("component must be controller or otp"))
+		})
+
+		It("should return 400 Bad Request for an invalid tail", func() {
+			for _, tail := range []string{"abc", "0", "-3"} {
+				req := httptest.NewRequest(http.MethodGet, "/api/mpc/logs?component=otp&tail="+tail, nil)
+				rr := httptest.NewRecorder()
+
+				handlers.MPCLogsHandler(rr, req)
+
+				Expect(rr.Code).To(Equal(http.StatusBadRequest), "tail=%s", tail)
+				Expect(rr.Body.String()).To(ContainSubstring("tail must be a positive integer"))
+			}
+		})
+
+		It("should return 405 Method Not Allowed for POST requests", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/mpc/logs", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.MPCLogsHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+
+	Describe("LogFilesHandler", func() {
+		var logDir string
+
+		BeforeEach(func() {
+			logDir = mockCfg.SessionLogDir
+			Expect(os.WriteFile(filepath.Join(logDir, "build_20251130_143052.log"), []byte("build output\n"), 0600)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(logDir, "artifacts"), 0750)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(logDir, "artifacts", "pods.log"), []byte("pod output\n"), 0600)).To(Succeed())
+			older := time.Now().Add(-time.Hour)
+			Expect(os.Chtimes(filepath.Join(logDir, "build_20251130_143052.log"), older, older)).To(Succeed())
+		})
+
+		It("should list the log files with their size, newest first", func() {
+			req := httptest.NewRequest(http.MethodGet, "/api/logs", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.LogFilesHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			var response api.LogFilesResponse
+			Expect(json.Unmarshal(rr.Body.Bytes(), &response)).To(Succeed())
+			Expect(response.Files).To(HaveLen(2))
+			Expect(response.Files[0].Name).To(Equal("artifacts/pods.log"))
+			Expect(response.Files[1].Name).To(Equal("build_20251130_143052.log"))
+			Expect(response.Files[1].Size).To(Equal(int64(len("build output\n"))))
+		})
+
+		It("should return an empty list when nothing has been logged yet", func() {
+			mockCfg.SessionLogDir = filepath.Join(GinkgoT().TempDir(), "missing")
+
+			req := httptest.NewRequest(http.MethodGet, "/api/logs", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.LogFilesHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Body.String()).To(MatchJSON(`{"files": []}`))
+		})
+
+		It("should download a log file by name", func() {
+			req := httptest.NewRequest(http.MethodGet, "/api/logs/artifacts/pods.log", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.LogFilesHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Body.String()).To(Equal("pod output\n"))
+			Expect(rr.Header().Get("Content-Disposition")).To(Equal("attachment; filename=pods.log"))
+			Expect(rr.Header().Get("Content-Type")).To(HavePrefix("text/"))
+		})
+
+		It("should escape the file name and type the file by its extension", func() {
+			Expect(os.WriteFile(filepath.Join(logDir, "artifacts", `pod "a" status.json`), []byte(`{"phase": "Running"}`), 0600)).To(Succeed())
+
+			req := httptest.NewRequest(http.MethodGet, "/api/logs/artifacts/pod%20%22a%22%20status.json", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.LogFilesHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Header().Get("Content-Type")).To(Equal("application/json"))
+			_, params, err := mime.ParseMediaType(rr.Header().Get("Content-Disposition"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(params["filename"]).To(Equal(`pod "a" status.json`))
+		})
+
+		It("should return 404 for a missing log file", func() {
+			req := httptest.NewRequest(http.MethodGet, "/api/logs/missing.log", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.LogFilesHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should reject names leading out of the log directory", func() {
+			secret := filepath.Join(filepath.Dir(logDir), "secret.txt")
+			Expect(os.WriteFile(secret, []byte("secret"), 0600)).To(Succeed())
+
+			for _, name := range []string{"../secret.txt", "artifacts/../../secret.txt", "/" + secret} {
+				req := httptest.NewRequest(http.MethodGet, "/api/logs/placeholder", nil)
+				req.URL.Path = "/api/logs/" + name
+				rr := httptest.NewRecorder()
+
+				handlers.LogFilesHandler(rr, req)
+
+				Expect(rr.Code).To(Equal(http.StatusBadRequest), name)
+				Expect(rr.Body.String()).NotTo(Equal("secret"), name)
+			}
+		})
+
+		It("should not follow symlinks out of the log directory", func() {
+			secret := filepath.Join(GinkgoT().TempDir(), "secret.txt")
+			Expect(os.WriteFile(secret, []byte("secret"), 0600)).To(Succeed())
+			Expect(os.Symlink(secret, filepath.Join(logDir, "link.log"))).To(Succeed())
+
+			req := httptest.NewRequest(http.MethodGet, "/api/logs/link.log", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.LogFilesHandler(rr, req)
+
+			Expect(rr.Code).NotTo(Equal(http.StatusOK))
+			Expect(rr.Body.String()).NotTo(Equal("secret"))
+		})
+
+		It("should return 405 for non-GET requests", func() {
+			req := httptest.NewRequest(http.MethodDelete, "/api/logs/build_20251130_143052.log", nil)
+			rr := httptest.NewRecorder()
+
+			handlers.LogFilesHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+
 	Describe("generateLogFilename", func() {
 		// This function is not exported, so we copy its logic here for testing.
 		generateLogFilename := func(yamlPath string) string {
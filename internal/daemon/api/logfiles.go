@@ -0,0 +1,161 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/meyrevived/mpc-dev-env/internal/logger"
+)
+
+// logFilesPath is the route of the log file endpoints; a file is downloaded from
+// logFilesPath + "/" + its name.
+const logFilesPath = "/api/logs"
+
+// LogFile describes a log file stored in the session log directory.
+type LogFile struct {
+	// Name is the file's path relative to the session log directory, with forward slashes
+	Name    string    `json:"name"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+}
+
+// LogFilesResponse represents the JSON response for GET /api/logs.
+type LogFilesResponse struct {
+	Files []LogFile `json:"files"`
+}
+
+// LogFilesHandler handles GET /api/logs and GET /api/logs/{name} requests.
+//
+// GET /api/logs lists the files in the session log directory (TaskRun, build and
+// smoke test logs, and collected artifacts), newest first. GET /api/logs/{name}
+// downloads one of them by the name the listing returned. Names that are absolute
+// or contain ".." are rejected with 400 Bad Request, and files are opened through
+// an os.Root of the log directory, so symlinks cannot lead out of it either.
+func (h *Handlers) LogFilesHandler(w http.ResponseWriter, r *http.Request) {
+	// Only accept GET requests
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	logDir := h.Config().GetSessionLogDir()
+
+	name := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, logFilesPath), "/")
+	if name == "" {
+		h.listLogFiles(w, logDir)
+		return
+	}
+	h.downloadLogFile(w, r, logDir, name)
+}
+
+// listLogFiles writes the files in logDir as a LogFilesResponse.
+func (h *Handlers) listLogFiles(w http.ResponseWriter, logDir string) {
+	files := []LogFile{}
+	err := filepath.WalkDir(logDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(logDir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, LogFile{Name: filepath.ToSlash(rel), Size: info.Size(), ModTime: info.ModTime()})
+		return nil
+	})
+	// Nothing has been logged yet
+	if errors.Is(err, fs.ErrNotExist) {
+		err = nil
+	}
+	if err != nil {
+		logger.Error(err, "failed to list log files", "dir", logDir)
+		writeLogFileError(w, http.StatusInternalServerError, "failed to list log files")
+		return
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].ModTime.After(files[j].ModTime)
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(LogFilesResponse{Files: files}); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// downloadLogFile serves the log file name from logDir.
+func (h *Handlers) downloadLogFile(w http.ResponseWriter, r *http.Request, logDir, name string) {
+	if !filepath.IsLocal(filepath.FromSlash(name)) {
+		writeLogFileError(w, http.StatusBadRequest, "invalid log file name: "+name)
+		return
+	}
+
+	file, info, err := openLogFile(logDir, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		writeLogFileError(w, http.StatusNotFound, "log file not found: "+name)
+		return
+	}
+	if err != nil {
+		logger.Error(err, "failed to open log file", "name", name)
+		writeLogFileError(w, http.StatusInternalServerError, "failed to open log file: "+name)
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	// ServeContent sets the Content-Type from the extension, or from the content for
+	// an extension without a registered type
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": info.Name()}))
+	http.ServeContent(w, r, info.Name(), info.ModTime(), file)
+}
+
+// openLogFile opens the regular file name within logDir. Names leading out of logDir,
+// including through symlinks, fail to open.
+func openLogFile(logDir, name string) (*os.File, os.FileInfo, error) {
+	root, err := os.OpenRoot(logDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = root.Close() }()
+
+	file, err := root.Open(filepath.FromSlash(name))
+	if err != nil {
+		return nil, nil, err
+	}
+	info, err := file.Stat()
+	if err == nil && !info.Mode().IsRegular() {
+		err = fs.ErrNotExist
+	}
+	if err != nil {
+		_ = file.Close()
+		return nil, nil, err
+	}
+	return file, info, nil
+}
+
+// writeLogFileError writes a JSON error response with the given status code.
+func writeLogFileError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	response := map[string]string{
+		"status": "error",
+		"error":  message,
+	}
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.Error(err, "failed to encode response")
+	}
+}
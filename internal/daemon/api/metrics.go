@@ -0,0 +1,57 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// operationMetrics are the daemon's own Prometheus metrics, served by GET /metrics.
+// They describe the daemon's operations (builds, deploys, ...), not the cluster; the
+// Prometheus that POST /api/metrics/deploy installs into the cluster is unrelated.
+type operationMetrics struct {
+	operations *prometheus.CounterVec   // Finished operations by name and outcome
+	durations  *prometheus.HistogramVec // Duration of finished operations by name
+}
+
+// newOperationMetrics creates the daemon's operation collectors. They are registered
+// with a registry by NewRouter.
+func newOperationMetrics() *operationMetrics {
+	return &operationMetrics{
+		operations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "mpc_daemon",
+			Name:      "operations_total",
+			Help:      "Number of finished daemon operations by operation and outcome (succeeded or failed).",
+		}, []string{"operation", "outcome"}),
+		durations: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "mpc_daemon",
+			Name:      "operation_duration_seconds",
+			Help:      "Duration of finished daemon operations in seconds.",
+			// Operations range from seconds (cluster pause) to half an hour (Konflux)
+			Buckets: []float64{1, 5, 15, 30, 60, 120, 300, 600, 900, 1800, 3600},
+		}, []string{"operation"}),
+	}
+}
+
+// collectors returns the collectors to register.
+func (m *operationMetrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{m.operations, m.durations}
+}
+
+// observe records a finished operation: it counts it as failed if err is non-nil and
+// as succeeded otherwise, and records its duration since started. An operation that
+// was cancelled (err is context.Canceled, such as a cancelled TaskRun) is not
+// recorded, as its duration says nothing about the operation.
+func (m *operationMetrics) observe(operation string, started time.Time, err error) {
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	outcome := "succeeded"
+	if err != nil {
+		outcome = "failed"
+	}
+	m.operations.WithLabelValues(operation, outcome).Inc()
+	m.durations.WithLabelValues(operation).Observe(time.Since(started).Seconds())
+}
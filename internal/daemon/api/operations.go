@@ -0,0 +1,486 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/meyrevived/mpc-dev-env/internal/daemon/state"
+	"github.com/meyrevived/mpc-dev-env/internal/logger"
+)
+
+// maxQueuedOperations bounds how many operations may wait behind the running one.
+const maxQueuedOperations = 5
+
+var (
+	// errOperationInProgress is returned when an operation is running and the caller did not ask to queue.
+	errOperationInProgress = errors.New("another operation is already in progress")
+
+	// errOperationQueueFull is returned when maxQueuedOperations operations are already waiting.
+	errOperationQueueFull = errors.New("operation queue is full")
+
+	// errShuttingDown is returned for operations submitted after Handlers.Shutdown.
+	errShuttingDown = errors.New("daemon is shutting down")
+
+	// ErrOperationSkipped is returned by the fn of StartOperation when it decided not to
+	// do its work, e.g. a hot reload while the daemon is busy. It is not recorded in the
+	// operation metrics.
+	ErrOperationSkipped = errors.New("operation skipped")
+)
+
+// OperationInfo describes a running or queued operation.
+//
+// StartTime is empty while the operation is still queued.
+type OperationInfo struct {
+	ID        uint64 `json:"id"`
+	Name      string `json:"name"`
+	QueuedAt  string `json:"queued_at"`
+	StartTime string `json:"start_time,omitempty"`
+}
+
+// operation is a unit of work tracked by operationManager.
+type operation struct {
+	info    OperationInfo
+	timeout time.Duration
+	run     func(ctx context.Context)
+	cancel  context.CancelFunc // set once the operation is running
+	started time.Time          // set once the operation is running
+}
+
+// operationManager serializes the daemon's write operations (builds, deploys, ...).
+//
+// At most one operation runs at a time. Further operations are either rejected or
+// appended to a bounded FIFO queue and started, in order, as the running one finishes.
+// Each operation runs with its own context, derived from the handlers' operation
+// context so it outlives the HTTP request, which POST /api/operations/cancel can cancel.
+type operationManager struct {
+	parent context.Context // Parent of every operation's context
+
+	mu       sync.Mutex
+	current  *operation
+	queue    []*operation
+	nextID   uint64
+	stopped  bool
+	finished chan struct{} // Closed and replaced each time an operation finishes
+
+	logs map[string]*operationLog // Output of the latest operation of each name
+}
+
+// newOperationManager creates an operationManager whose operations derive from parent.
+func newOperationManager(parent context.Context) *operationManager {
+	return &operationManager{
+		parent:   parent,
+		finished: make(chan struct{}),
+		logs:     make(map[string]*operationLog),
+	}
+}
+
+// submit runs fn as the named operation with the given timeout. If another operation
+// is running, fn is queued when enqueue is true and errOperationInProgress is returned
+// otherwise. queued reports whether the operation is waiting rather than running.
+func (m *operationManager) submit(name string, timeout time.Duration, enqueue bool, fn func(ctx context.Context)) (info OperationInfo, queued bool, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.stopped {
+		return OperationInfo{}, false, errShuttingDown
+	}
+	if m.current != nil {
+		if !enqueue {
+			return OperationInfo{}, false, errOperationInProgress
+		}
+		if len(m.queue) >= maxQueuedOperations {
+			return OperationInfo{}, false, errOperationQueueFull
+		}
+	}
+
+	m.nextID++
+	op := &operation{
+		info: OperationInfo{
+			ID:       m.nextID,
+			Name:     name,
+			QueuedAt: time.Now().Format(time.RFC3339),
+		},
+		timeout: timeout,
+		run:     fn,
+	}
+
+	if m.current != nil {
+		m.queue = append(m.queue, op)
+		logger.Info("operation queued", "operation", name, "position", len(m.queue))
+		return op.info, true, nil
+	}
+
+	m.startLocked(op)
+	return op.info, false, nil
+}
+
+// begin marks the named synchronous operation as running and returns a func that
+// must be called when it is done. It fails with errOperationInProgress if another
+// operation is running.
+func (m *operationManager) begin(name string) (end func(), err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.stopped {
+		return nil, errShuttingDown
+	}
+	if m.current != nil {
+		return nil, errOperationInProgress
+	}
+
+	m.nextID++
+	started := time.Now()
+	now := started.Format(time.RFC3339)
+	op := &operation{
+		info:    OperationInfo{ID: m.nextID, Name: name, QueuedAt: now, StartTime: now},
+		cancel:  func() {},
+		started: started,
+	}
+	m.current = op
+	m.logLocked(name).reset()
+	logger.Info("operation started", "operation", name, "id", op.info.ID)
+	return func() { m.finish(op) }, nil
+}
+
+// startLocked makes op the current operation and runs it in a goroutine. m.mu must be held.
+func (m *operationManager) startLocked(op *operation) {
+	ctx, cancel := context.WithTimeout(m.parent, op.timeout)
+	op.cancel = cancel
+	op.started = time.Now()
+	op.info.StartTime = op.started.Format(time.RFC3339)
+	m.current = op
+	m.logLocked(op.info.Name).reset()
+
+	logger.Info("operation started", "operation", op.info.Name, "id", op.info.ID)
+	go func() {
+		defer m.finish(op)
+		defer cancel()
+		op.run(ctx)
+	}()
+}
+
+// finish clears the finished operation and starts the next queued one, if any.
+func (m *operationManager) finish(op *operation) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.current != op {
+		return
+	}
+	logger.Info("operation finished", "operation", op.info.Name, "id", op.info.ID,
+		"duration", time.Since(op.started).Round(time.Millisecond).String())
+	m.current = nil
+	close(m.finished)
+	m.finished = make(chan struct{})
+
+	if len(m.queue) > 0 && !m.stopped {
+		next := m.queue[0]
+		m.queue = m.queue[1:]
+		m.startLocked(next)
+	}
+}
+
+// log returns the output buffer of the named operation. The buffer is cleared each
+// time an operation of that name starts, so it holds the output of the latest run.
+func (m *operationManager) log(name string) *operationLog {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.logLocked(name)
+}
+
+// logLocked returns the output buffer of the named operation, creating it if needed.
+// m.mu must be held.
+func (m *operationManager) logLocked(name string) *operationLog {
+	l, ok := m.logs[name]
+	if !ok {
+		l = newOperationLog(maxOperationLogLines)
+		m.logs[name] = l
+	}
+	return l
+}
+
+// hasLog reports whether an operation of the given name has produced a buffer yet.
+func (m *operationManager) hasLog(name string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.logs[name]
+	return ok
+}
+
+// shutdown drops the queued operations and rejects new ones. The running operation,
+// if any, is left to its context.
+func (m *operationManager) shutdown() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.stopped = true
+	for _, op := range m.queue {
+		logger.Info("dropping queued operation", "operation", op.info.Name, "id", op.info.ID)
+	}
+	m.queue = nil
+}
+
+// wait blocks until no operation is running or ctx is done.
+func (m *operationManager) wait(ctx context.Context) error {
+	for {
+		m.mu.Lock()
+		if m.current == nil {
+			m.mu.Unlock()
+			return nil
+		}
+		finished := m.finished
+		m.mu.Unlock()
+
+		select {
+		case <-finished:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// cancelCurrent cancels the context of the running operation. It returns the cancelled
+// operation, or false if none is running. Queued operations are left in place.
+func (m *operationManager) cancelCurrent() (OperationInfo, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.current == nil {
+		return OperationInfo{}, false
+	}
+	m.current.cancel()
+	return m.current.info, true
+}
+
+// list returns the running operation (nil if none) and the queued ones in start order.
+func (m *operationManager) list() (*OperationInfo, []OperationInfo) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var current *OperationInfo
+	if m.current != nil {
+		info := m.current.info
+		current = &info
+	}
+
+	queued := make([]OperationInfo, 0, len(m.queue))
+	for _, op := range m.queue {
+		queued = append(queued, op.info)
+	}
+	return current, queued
+}
+
+// startOperation submits fn as the named operation for an async handler.
+//
+// By default a busy daemon answers 409 Conflict; with ?queue=true the operation is
+// queued instead and 202 Accepted with status "queued" is returned. started is true
+// only when fn was started right away, in which case the handler writes its own
+// response; otherwise the response has already been written. The error fn returns
+// is recorded in the operation metrics under name.
+func (h *Handlers) startOperation(w http.ResponseWriter, r *http.Request, name string, timeout time.Duration, fn func(ctx context.Context) error) (started bool) {
+	enqueue, ok := boolQueryParam(w, r, "queue")
+	if !ok {
+		return false
+	}
+
+	info, queued, err := h.operations.submit(name, timeout, enqueue, func(ctx context.Context) {
+		start := time.Now()
+		h.metrics.observe(name, start, fn(ctx))
+	})
+	if err != nil {
+		writeOperationConflict(w, err)
+		return false
+	}
+	if !queued {
+		return true
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	response := map[string]any{
+		"status":    "queued",
+		"message":   "Operation queued behind the running one. Check GET /api/operations for progress.",
+		"operation": info,
+	}
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.Error(err, "failed to encode response")
+	}
+	return false
+}
+
+// StartOperation runs fn as the named tracked operation for callers outside the HTTP
+// handlers, such as the daemon's file watcher. It never queues: if another operation
+// is running, or the daemon is shutting down, it returns an error instead. fn receives
+// the operation's context and the buffer served by GET /api/operations/logs?name=<name>.
+// The error fn returns is recorded in the operation metrics under name, like that of
+// startOperation, unless it is ErrOperationSkipped.
+func (h *Handlers) StartOperation(name string, timeout time.Duration, fn func(ctx context.Context, output io.Writer) error) error {
+	output := h.operations.log(name)
+	_, _, err := h.operations.submit(name, timeout, false, func(ctx context.Context) {
+		start := time.Now()
+		if err := fn(ctx, output); !errors.Is(err, ErrOperationSkipped) {
+			h.metrics.observe(name, start, err)
+		}
+	})
+	return err
+}
+
+// writeOperationConflict writes the response for an operation that could not be
+// started or queued: 409 Conflict if one is running, 503 if the queue is full or
+// the daemon is shutting down.
+func writeOperationConflict(w http.ResponseWriter, err error) {
+	statusCode := http.StatusConflict
+	if errors.Is(err, errOperationQueueFull) || errors.Is(err, errShuttingDown) {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	response := map[string]string{
+		"status": "conflict",
+		"error":  err.Error(),
+	}
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.Error(err, "failed to encode response")
+	}
+}
+
+// OperationsResponse represents the JSON response for GET /api/operations.
+type OperationsResponse struct {
+	Current *OperationInfo  `json:"current"`
+	Queued  []OperationInfo `json:"queued"`
+}
+
+// OperationsHandler handles GET /api/operations requests.
+// It lists the running operation (null when idle) and the queued ones in start order.
+func (h *Handlers) OperationsHandler(w http.ResponseWriter, r *http.Request) {
+	// Only accept GET requests
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	current, queued := h.operations.list()
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(OperationsResponse{Current: current, Queued: queued}); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// OperationHistoryResponse represents the JSON response for GET /api/operations/history.
+type OperationHistoryResponse struct {
+	History []state.OperationRecord `json:"history"`
+}
+
+// OperationHistoryHandler handles GET /api/operations/history requests.
+// It lists the operation statuses finished in this session, oldest first, with their
+// outcome, error and start and end times (the most recent 100).
+func (h *Handlers) OperationHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	// Only accept GET requests
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	history := h.StateManager.GetState().OperationHistory
+	if history == nil {
+		history = []state.OperationRecord{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(OperationHistoryResponse{History: history}); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// OperationsCancelHandler handles POST /api/operations/cancel requests.
+// It cancels the context of the running operation, which then fails with a context
+// error and records it as the last operation error. Queued operations still run.
+//
+// Returns 200 OK with the cancelled operation, or 404 Not Found if none is running.
+func (h *Handlers) OperationsCancelHandler(w http.ResponseWriter, r *http.Request) {
+	// Only accept POST requests
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	info, ok := h.operations.cancelCurrent()
+
+	w.Header().Set("Content-Type", "application/json")
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		response := map[string]string{
+			"status": "not_found",
+			"error":  "No operation is in progress",
+		}
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			logger.Error(err, "failed to encode response")
+		}
+		return
+	}
+
+	logger.Info("operation cancellation requested", "operation", info.Name, "id", info.ID)
+	response := map[string]any{
+		"status":    "cancelling",
+		"operation": info,
+	}
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.Error(err, "failed to encode response")
+	}
+}
+
+// OperationLogsResponse represents the JSON response for GET /api/operations/logs.
+type OperationLogsResponse struct {
+	Operation string   `json:"operation"`
+	Lines     []string `json:"lines"`
+	Dropped   int      `json:"dropped"` // Older lines overwritten because the buffer was full
+}
+
+// OperationLogsHandler handles GET /api/operations/logs requests.
+// It returns the buffered output (up to maxOperationLogLines lines, oldest first) of
+// the operation named by the "name" query parameter, e.g. "build" or "deploy". Without
+// it, the running operation's output is returned. The buffer of an operation type is
+// cleared when the next operation of that type starts.
+//
+// Returns 400 Bad Request if no name is given and nothing is running, and 404 Not Found
+// if no operation of that name has run since the daemon started.
+func (h *Handlers) OperationLogsHandler(w http.ResponseWriter, r *http.Request) {
+	// Only accept GET requests
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		current, _ := h.operations.list()
+		if current == nil {
+			http.Error(w, "name is required when no operation is running", http.StatusBadRequest)
+			return
+		}
+		name = current.Name
+	}
+
+	if !h.operations.hasLog(name) {
+		http.Error(w, fmt.Sprintf("no logs for operation %q", name), http.StatusNotFound)
+		return
+	}
+	lines, dropped := h.operations.log(name).snapshot()
+
+	w.Header().Set("Content-Type", "application/json")
+	response := OperationLogsResponse{Operation: name, Lines: lines, Dropped: dropped}
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
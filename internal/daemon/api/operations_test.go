@@ -0,0 +1,355 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/meyrevived/mpc-dev-env/internal/config"
+	"github.com/meyrevived/mpc-dev-env/internal/daemon/api"
+	"github.com/meyrevived/mpc-dev-env/internal/daemon/state"
+)
+
+var _ = Describe("Operations", func() {
+	var (
+		handlers *api.Handlers
+		release  chan struct{}
+	)
+
+	// blockUntilReleased returns an operation that runs until this spec's release channel
+	// is closed or the operation is cancelled
+	blockUntilReleased := func() func(ctx context.Context) {
+		ch := release
+		return func(ctx context.Context) {
+			select {
+			case <-ch:
+			case <-ctx.Done():
+			}
+		}
+	}
+
+	// listOperations returns the decoded GET /api/operations response
+	listOperations := func() api.OperationsResponse {
+		req := httptest.NewRequest(http.MethodGet, "/api/operations", nil)
+		rr := httptest.NewRecorder()
+		handlers.OperationsHandler(rr, req)
+		Expect(rr.Code).To(Equal(http.StatusOK))
+
+		var response api.OperationsResponse
+		Expect(json.NewDecoder(rr.Body).Decode(&response)).To(Succeed())
+		return response
+	}
+
+	BeforeEach(func() {
+		handlers = api.NewHandlers(&mockStateManager{}, &config.Config{SessionLogDir: GinkgoT().TempDir()})
+		release = make(chan struct{})
+	})
+
+	AfterEach(func() {
+		select {
+		case <-release:
+		default:
+			close(release)
+		}
+	})
+
+	It("should report no operations when idle", func() {
+		response := listOperations()
+		Expect(response.Current).To(BeNil())
+		Expect(response.Queued).To(BeEmpty())
+	})
+
+	It("should reject a second operation unless it is enqueued", func() {
+		Expect(handlers.SubmitOperation("first", false, blockUntilReleased())).To(Succeed())
+
+		err := handlers.SubmitOperation("second", false, func(context.Context) {})
+		Expect(err).To(MatchError(ContainSubstring("already in progress")))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/mpc/build", nil)
+		rr := httptest.NewRecorder()
+		handlers.BuildHandler(rr, req)
+		Expect(rr.Code).To(Equal(http.StatusConflict))
+	})
+
+	It("should run queued operations in FIFO order", func() {
+		var (
+			mu    sync.Mutex
+			order []string
+		)
+		record := func(name string) func(context.Context) {
+			return func(context.Context) {
+				mu.Lock()
+				defer mu.Unlock()
+				order = append(order, name)
+			}
+		}
+
+		block := blockUntilReleased()
+		Expect(handlers.SubmitOperation("first", false, func(ctx context.Context) {
+			block(ctx)
+			record("first")(ctx)
+		})).To(Succeed())
+		Expect(handlers.SubmitOperation("second", true, record("second"))).To(Succeed())
+		Expect(handlers.SubmitOperation("third", true, record("third"))).To(Succeed())
+
+		response := listOperations()
+		Expect(response.Current).NotTo(BeNil())
+		Expect(response.Current.Name).To(Equal("first"))
+		Expect(response.Current.StartTime).NotTo(BeEmpty())
+		Expect(response.Queued).To(HaveLen(2))
+		Expect(response.Queued[0].Name).To(Equal("second"))
+		Expect(response.Queued[1].Name).To(Equal("third"))
+		Expect(response.Queued[0].StartTime).To(BeEmpty())
+
+		close(release)
+
+		Eventually(func() []string {
+			mu.Lock()
+			defer mu.Unlock()
+			return append([]string(nil), order...)
+		}).Should(Equal([]string{"first", "second", "third"}))
+		Eventually(func() *api.OperationInfo { return listOperations().Current }).Should(BeNil())
+	})
+
+	It("should queue a handler's operation with ?queue=true", func() {
+		Expect(handlers.SubmitOperation("first", false, blockUntilReleased())).To(Succeed())
+
+		req := httptest.NewRequest(http.MethodPost, "/api/mpc/build?queue=true", nil)
+		rr := httptest.NewRecorder()
+		handlers.BuildHandler(rr, req)
+
+		Expect(rr.Code).To(Equal(http.StatusAccepted))
+		var response struct {
+			Status    string            `json:"status"`
+			Operation api.OperationInfo `json:"operation"`
+		}
+		Expect(json.NewDecoder(rr.Body).Decode(&response)).To(Succeed())
+		Expect(response.Status).To(Equal("queued"))
+		Expect(response.Operation.Name).To(Equal("build"))
+		Expect(listOperations().Queued).To(HaveLen(1))
+	})
+
+	It("should reject an invalid queue value", func() {
+		req := httptest.NewRequest(http.MethodPost, "/api/mpc/build?queue=later", nil)
+		rr := httptest.NewRecorder()
+		handlers.BuildHandler(rr, req)
+
+		Expect(rr.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should return 503 when the queue is full", func() {
+		Expect(handlers.SubmitOperation("first", false, blockUntilReleased())).To(Succeed())
+		for {
+			if err := handlers.SubmitOperation("queued", true, func(context.Context) {}); err != nil {
+				Expect(err).To(MatchError(ContainSubstring("queue is full")))
+				break
+			}
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/api/mpc/build?queue=true", nil)
+		rr := httptest.NewRecorder()
+		handlers.BuildHandler(rr, req)
+		Expect(rr.Code).To(Equal(http.StatusServiceUnavailable))
+	})
+
+	Describe("OperationsCancelHandler", func() {
+		It("should cancel the running operation's context", func() {
+			cancelled := make(chan error, 1)
+			Expect(handlers.SubmitOperation("long", false, func(ctx context.Context) {
+				<-ctx.Done()
+				cancelled <- ctx.Err()
+			})).To(Succeed())
+
+			req := httptest.NewRequest(http.MethodPost, "/api/operations/cancel", nil)
+			rr := httptest.NewRecorder()
+			handlers.OperationsCancelHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			var response struct {
+				Status    string            `json:"status"`
+				Operation api.OperationInfo `json:"operation"`
+			}
+			Expect(json.NewDecoder(rr.Body).Decode(&response)).To(Succeed())
+			Expect(response.Status).To(Equal("cancelling"))
+			Expect(response.Operation.Name).To(Equal("long"))
+
+			Eventually(cancelled).WithTimeout(time.Second).Should(Receive(MatchError(context.Canceled)))
+			Eventually(func() *api.OperationInfo { return listOperations().Current }).Should(BeNil())
+		})
+
+		It("should start the next queued operation after cancelling", func() {
+			next := make(chan struct{})
+			Expect(handlers.SubmitOperation("long", false, blockUntilReleased())).To(Succeed())
+			Expect(handlers.SubmitOperation("next", true, func(context.Context) { close(next) })).To(Succeed())
+
+			req := httptest.NewRequest(http.MethodPost, "/api/operations/cancel", nil)
+			handlers.OperationsCancelHandler(httptest.NewRecorder(), req)
+
+			Eventually(next).WithTimeout(time.Second).Should(BeClosed())
+		})
+
+		It("should return 404 Not Found when no operation is running", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/operations/cancel", nil)
+			rr := httptest.NewRecorder()
+			handlers.OperationsCancelHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should return 405 Method Not Allowed for GET requests", func() {
+			req := httptest.NewRequest(http.MethodGet, "/api/operations/cancel", nil)
+			rr := httptest.NewRecorder()
+			handlers.OperationsCancelHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+
+	Describe("OperationHistoryHandler", func() {
+		It("should return an empty history before any operation finished", func() {
+			req := httptest.NewRequest(http.MethodGet, "/api/operations/history", nil)
+			rr := httptest.NewRecorder()
+			handlers.OperationHistoryHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Body.String()).To(MatchJSON(`{"history": []}`))
+		})
+
+		It("should return the recorded history", func() {
+			started := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+			mockState := &mockStateManager{stateToReturn: state.DevEnvironment{
+				OperationHistory: []state.OperationRecord{
+					{Operation: "deploying_mpc", Status: "succeeded", StartedAt: started, EndedAt: started.Add(time.Minute)},
+					{Operation: "running_taskrun", Status: "failed", Error: "TaskRun failed", StartedAt: started.Add(2 * time.Minute), EndedAt: started.Add(5 * time.Minute)},
+				},
+			}}
+			handlers = api.NewHandlers(mockState, &config.Config{SessionLogDir: GinkgoT().TempDir()})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/operations/history", nil)
+			rr := httptest.NewRecorder()
+			handlers.OperationHistoryHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			var response api.OperationHistoryResponse
+			Expect(json.NewDecoder(rr.Body).Decode(&response)).To(Succeed())
+			Expect(response.History).To(HaveLen(2))
+			Expect(response.History[1].Operation).To(Equal("running_taskrun"))
+			Expect(response.History[1].Error).To(Equal("TaskRun failed"))
+			Expect(response.History[1].EndedAt).To(Equal(started.Add(5 * time.Minute)))
+		})
+
+		It("should return 405 Method Not Allowed for POST requests", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/operations/history", nil)
+			rr := httptest.NewRecorder()
+			handlers.OperationHistoryHandler(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+
+	Describe("Shutdown", func() {
+		It("should cancel the running operation and wait for it to return", func() {
+			cancelled := make(chan error, 1)
+			Expect(handlers.SubmitOperation("long", false, func(ctx context.Context) {
+				<-ctx.Done()
+				cancelled <- ctx.Err()
+			})).To(Succeed())
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			Expect(handlers.Shutdown(ctx, 0)).To(Succeed())
+
+			Expect(cancelled).To(Receive(MatchError(context.Canceled)))
+			Expect(handlers.OperationContext().Err()).To(MatchError(context.Canceled))
+			Expect(listOperations().Current).To(BeNil())
+		})
+
+		It("should drop queued operations and reject new ones", func() {
+			ran := make(chan struct{}, 1)
+			Expect(handlers.SubmitOperation("long", false, blockUntilReleased())).To(Succeed())
+			Expect(handlers.SubmitOperation("queued", true, func(context.Context) { ran <- struct{}{} })).To(Succeed())
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			Expect(handlers.Shutdown(ctx, 0)).To(Succeed())
+
+			Consistently(ran).WithTimeout(100 * time.Millisecond).ShouldNot(Receive())
+			Expect(handlers.SubmitOperation("late", true, func(context.Context) {})).
+				To(MatchError(ContainSubstring("shutting down")))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/mpc/build", nil)
+			rr := httptest.NewRecorder()
+			handlers.BuildHandler(rr, req)
+			Expect(rr.Code).To(Equal(http.StatusServiceUnavailable))
+		})
+
+		It("should return the context error if the operation does not stop in time", func() {
+			ch := release
+			Expect(handlers.SubmitOperation("stubborn", false, func(context.Context) {
+				<-ch
+			})).To(Succeed())
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			Expect(handlers.Shutdown(ctx, 0)).To(MatchError(context.DeadlineExceeded))
+		})
+
+		It("should let the running operation and background work finish within the grace period", func() {
+			var mu sync.Mutex
+			var operationErr error
+			finished := make(chan struct{}, 2)
+			Expect(handlers.SubmitOperation("deploy", false, func(ctx context.Context) {
+				time.Sleep(100 * time.Millisecond)
+				mu.Lock()
+				operationErr = ctx.Err()
+				mu.Unlock()
+				finished <- struct{}{}
+			})).To(Succeed())
+			Expect(handlers.StartBackground(func() {
+				time.Sleep(150 * time.Millisecond)
+				finished <- struct{}{}
+			})).To(Succeed())
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			Expect(handlers.Shutdown(ctx, 2*time.Second)).To(Succeed())
+
+			// Both had finished, uncancelled, by the time Shutdown returned
+			Expect(finished).To(HaveLen(2))
+			mu.Lock()
+			defer mu.Unlock()
+			Expect(operationErr).NotTo(HaveOccurred())
+		})
+
+		It("should cancel work still running after the grace period", func() {
+			cancelled := make(chan error, 1)
+			Expect(handlers.StartBackground(func() {
+				<-handlers.OperationContext().Done()
+				cancelled <- handlers.OperationContext().Err()
+			})).To(Succeed())
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			started := time.Now()
+			Expect(handlers.Shutdown(ctx, 100*time.Millisecond)).To(Succeed())
+
+			Expect(time.Since(started)).To(BeNumerically(">=", 100*time.Millisecond))
+			Expect(cancelled).To(Receive(MatchError(context.Canceled)))
+		})
+
+		It("should reject new background work", func() {
+			Expect(handlers.Shutdown(context.Background(), 0)).To(Succeed())
+			Expect(handlers.StartBackground(func() {})).To(MatchError(ContainSubstring("shutting down")))
+
+			rr := httptest.NewRecorder()
+			handlers.DeployKonfluxHandler(rr, httptest.NewRequest(http.MethodPost, "/api/deploy/konflux", nil))
+			Expect(rr.Code).To(Equal(http.StatusServiceUnavailable))
+		})
+	})
+})
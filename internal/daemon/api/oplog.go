@@ -0,0 +1,88 @@
+package api
+
+import (
+	"strings"
+	"sync"
+)
+
+// maxOperationLogLines is the number of output lines kept per operation type.
+const maxOperationLogLines = 1000
+
+// operationLog is a bounded, in-memory ring buffer of an operation's output lines.
+//
+// It is an io.Writer, so it can be handed to the build and deploy packages (and to
+// exec.Cmd) as an extra output sink. Writes are split into lines; a trailing partial
+// line is held back until its newline arrives. Once the buffer is full, the oldest
+// lines are overwritten.
+type operationLog struct {
+	mu      sync.Mutex
+	lines   []string // Ring storage, len(lines) == capacity once full
+	next    int      // Index the next line is written to once the ring is full
+	dropped int      // Number of lines overwritten since the last reset
+	partial strings.Builder
+}
+
+// newOperationLog creates an empty operationLog holding at most capacity lines.
+func newOperationLog(capacity int) *operationLog {
+	return &operationLog{lines: make([]string, 0, capacity)}
+}
+
+// Write appends p to the log, one entry per newline-terminated line.
+func (l *operationLog) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	data := string(p)
+	for {
+		i := strings.IndexByte(data, '\n')
+		if i < 0 {
+			l.partial.WriteString(data)
+			break
+		}
+		l.partial.WriteString(data[:i])
+		l.appendLocked(strings.TrimSuffix(l.partial.String(), "\r"))
+		l.partial.Reset()
+		data = data[i+1:]
+	}
+
+	return len(p), nil
+}
+
+// appendLocked adds a complete line, overwriting the oldest one if the ring is full.
+// l.mu must be held.
+func (l *operationLog) appendLocked(line string) {
+	if len(l.lines) < cap(l.lines) {
+		l.lines = append(l.lines, line)
+		return
+	}
+
+	l.lines[l.next] = line
+	l.next = (l.next + 1) % len(l.lines)
+	l.dropped++
+}
+
+// snapshot returns the buffered lines, oldest first, including a pending partial
+// line, and the number of older lines that have been overwritten.
+func (l *operationLog) snapshot() (lines []string, dropped int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	lines = make([]string, 0, len(l.lines)+1)
+	lines = append(lines, l.lines[l.next:]...)
+	lines = append(lines, l.lines[:l.next]...)
+	if l.partial.Len() > 0 {
+		lines = append(lines, l.partial.String())
+	}
+	return lines, l.dropped
+}
+
+// reset discards all buffered output.
+func (l *operationLog) reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.lines = l.lines[:0]
+	l.next = 0
+	l.dropped = 0
+	l.partial.Reset()
+}
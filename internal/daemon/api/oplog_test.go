@@ -0,0 +1,148 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/meyrevived/mpc-dev-env/internal/config"
+	"github.com/meyrevived/mpc-dev-env/internal/daemon/api"
+)
+
+var _ = Describe("Operation logs", func() {
+	Describe("operationLog", func() {
+		It("should split writes into lines and hold back a partial line", func() {
+			l := api.NewOperationLog(10)
+			_, _ = io.WriteString(l, "STEP 1/2\nSTEP 2")
+			_, _ = io.WriteString(l, "/2\r\nCOMMIT")
+
+			lines, dropped := l.Snapshot()
+			Expect(lines).To(Equal([]string{"STEP 1/2", "STEP 2/2", "COMMIT"}))
+			Expect(dropped).To(BeZero())
+		})
+
+		It("should keep only the newest lines once it wraps around", func() {
+			l := api.NewOperationLog(3)
+			for i := 1; i <= 7; i++ {
+				_, _ = fmt.Fprintf(l, "line %d\n", i)
+			}
+
+			lines, dropped := l.Snapshot()
+			Expect(lines).To(Equal([]string{"line 5", "line 6", "line 7"}))
+			Expect(dropped).To(Equal(4))
+		})
+
+		It("should be empty after a reset", func() {
+			l := api.NewOperationLog(2)
+			_, _ = io.WriteString(l, "a\nb\nc\npartial")
+			l.Reset()
+
+			lines, dropped := l.Snapshot()
+			Expect(lines).To(BeEmpty())
+			Expect(dropped).To(BeZero())
+
+			_, _ = io.WriteString(l, "d\n")
+			lines, _ = l.Snapshot()
+			Expect(lines).To(Equal([]string{"d"}))
+		})
+	})
+
+	Describe("OperationLogsHandler", func() {
+		var handlers *api.Handlers
+
+		// getLogs performs GET /api/operations/logs with the given query string
+		getLogs := func(query string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodGet, "/api/operations/logs"+query, nil)
+			rr := httptest.NewRecorder()
+			handlers.OperationLogsHandler(rr, req)
+			return rr
+		}
+
+		// runToCompletion runs fn as the named operation and waits for it to finish
+		runToCompletion := func(name string, fn func(ctx context.Context)) {
+			done := make(chan struct{})
+			Expect(handlers.SubmitOperation(name, false, func(ctx context.Context) {
+				defer close(done)
+				fn(ctx)
+			})).To(Succeed())
+			Eventually(done).Should(BeClosed())
+			Eventually(func() *api.OperationInfo {
+				rr := httptest.NewRecorder()
+				handlers.OperationsHandler(rr, httptest.NewRequest(http.MethodGet, "/api/operations", nil))
+				var response api.OperationsResponse
+				_ = json.NewDecoder(rr.Body).Decode(&response)
+				return response.Current
+			}).Should(BeNil())
+		}
+
+		BeforeEach(func() {
+			handlers = api.NewHandlers(&mockStateManager{}, &config.Config{SessionLogDir: GinkgoT().TempDir()})
+		})
+
+		It("should return the output of the named operation", func() {
+			runToCompletion("build", func(context.Context) {
+				_, _ = io.WriteString(handlers.OperationLog("build"), "STEP 1/2: FROM scratch\nCOMMIT done\n")
+			})
+
+			rr := getLogs("?name=build")
+			Expect(rr.Code).To(Equal(http.StatusOK))
+
+			var response api.OperationLogsResponse
+			Expect(json.NewDecoder(rr.Body).Decode(&response)).To(Succeed())
+			Expect(response.Operation).To(Equal("build"))
+			Expect(response.Lines).To(Equal([]string{"STEP 1/2: FROM scratch", "COMMIT done"}))
+		})
+
+		It("should clear the buffer when the next operation of the same type starts", func() {
+			runToCompletion("build", func(context.Context) {
+				_, _ = io.WriteString(handlers.OperationLog("build"), "first build\n")
+			})
+			runToCompletion("build", func(context.Context) {
+				_, _ = io.WriteString(handlers.OperationLog("build"), "second build\n")
+			})
+
+			var response api.OperationLogsResponse
+			Expect(json.NewDecoder(getLogs("?name=build").Body).Decode(&response)).To(Succeed())
+			Expect(response.Lines).To(Equal([]string{"second build"}))
+		})
+
+		It("should default to the running operation", func() {
+			release := make(chan struct{})
+			defer close(release)
+			Expect(handlers.SubmitOperation("deploy", false, func(ctx context.Context) {
+				_, _ = io.WriteString(handlers.OperationLog("deploy"), "configmap/host-config created\n")
+				select {
+				case <-release:
+				case <-ctx.Done():
+				}
+			})).To(Succeed())
+
+			Eventually(func() []string {
+				var response api.OperationLogsResponse
+				_ = json.NewDecoder(getLogs("").Body).Decode(&response)
+				return response.Lines
+			}).Should(Equal([]string{"configmap/host-config created"}))
+		})
+
+		It("should return 400 Bad Request without a name when idle", func() {
+			Expect(getLogs("").Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should return 404 Not Found for an operation that never ran", func() {
+			Expect(getLogs("?name=konflux").Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should return 405 Method Not Allowed for POST requests", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/operations/logs", nil)
+			rr := httptest.NewRecorder()
+			handlers.OperationLogsHandler(rr, req)
+			Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+})
@@ -0,0 +1,88 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/meyrevived/mpc-dev-env/internal/config"
+	"github.com/meyrevived/mpc-dev-env/internal/daemon/api"
+)
+
+var _ = Describe("RequireLocalOrigin", func() {
+	var (
+		handler http.Handler
+		reached bool
+	)
+
+	// serve sends a request for host through the middleware, optionally with an Origin header
+	serve := func(method, host, origin string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/api/cluster/stop", nil)
+		req.Host = host
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	BeforeEach(func() {
+		reached = false
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			reached = true
+			w.WriteHeader(http.StatusAccepted)
+		})
+		handler = api.RequireLocalOrigin(config.DefaultAllowedHosts, next)
+	})
+
+	It("should pass a same-origin POST through", func() {
+		rr := serve(http.MethodPost, "localhost:8765", "http://localhost:8765")
+
+		Expect(rr.Code).To(Equal(http.StatusAccepted))
+		Expect(reached).To(BeTrue())
+	})
+
+	It("should pass a POST without an Origin header through", func() {
+		Expect(serve(http.MethodPost, "127.0.0.1:8765", "").Code).To(Equal(http.StatusAccepted))
+		Expect(serve(http.MethodPost, "[::1]:8765", "").Code).To(Equal(http.StatusAccepted))
+	})
+
+	It("should block a POST with a foreign Origin", func() {
+		rr := serve(http.MethodPost, "localhost:8765", "https://evil.example.com")
+
+		Expect(rr.Code).To(Equal(http.StatusForbidden))
+		Expect(reached).To(BeFalse())
+	})
+
+	It("should block a POST with an opaque Origin", func() {
+		rr := serve(http.MethodPost, "localhost:8765", "null")
+
+		Expect(rr.Code).To(Equal(http.StatusForbidden))
+		Expect(reached).To(BeFalse())
+	})
+
+	It("should block a POST with a foreign Host (DNS rebinding)", func() {
+		rr := serve(http.MethodPost, "evil.example.com:8765", "")
+
+		Expect(rr.Code).To(Equal(http.StatusForbidden))
+		Expect(reached).To(BeFalse())
+	})
+
+	It("should let GET requests through regardless of Origin", func() {
+		rr := serve(http.MethodGet, "evil.example.com:8765", "https://evil.example.com")
+
+		Expect(rr.Code).To(Equal(http.StatusAccepted))
+	})
+
+	It("should honor a configured host list", func() {
+		handler = api.RequireLocalOrigin([]string{"devbox.local"}, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+		}))
+
+		Expect(serve(http.MethodPost, "devbox.local:8765", "http://DevBox.local:8765").Code).To(Equal(http.StatusAccepted))
+		Expect(serve(http.MethodPost, "localhost:8765", "").Code).To(Equal(http.StatusForbidden))
+	})
+})
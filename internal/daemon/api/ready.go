@@ -0,0 +1,163 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/meyrevived/mpc-dev-env/internal/cluster"
+	"github.com/meyrevived/mpc-dev-env/internal/config"
+	"github.com/meyrevived/mpc-dev-env/internal/deploy"
+	"github.com/meyrevived/mpc-dev-env/internal/logger"
+	"github.com/meyrevived/mpc-dev-env/internal/prereq"
+)
+
+// Names of the checks GET /api/ready reports.
+const (
+	ReadyCheckPrerequisites = "prerequisites"
+	ReadyCheckCluster       = "cluster"
+	ReadyCheckTektonWebhook = "tekton_webhook"
+	ReadyCheckController    = "mpc_controller"
+	ReadyCheckOTP           = "mpc_otp"
+)
+
+// readyDeployments are the deployments GET /api/ready checks once the cluster is running.
+var readyDeployments = []struct {
+	check      string
+	deployment deploy.Deployment
+}{
+	{ReadyCheckTektonWebhook, deploy.TektonWebhookDeployment},
+	{ReadyCheckController, deploy.ControllerDeployment},
+	{ReadyCheckOTP, deploy.OTPDeployment},
+}
+
+// ReadinessProbe checks the parts of an environment GET /api/ready reports on besides
+// its cluster, which the environment's ClusterManager checks.
+type ReadinessProbe interface {
+	// Prerequisites runs the checks of GET /api/prerequisites.
+	Prerequisites(ctx context.Context) (*prereq.CheckResult, error)
+
+	// DeploymentAvailable returns nil when the deployment's rollout has finished.
+	DeploymentAvailable(ctx context.Context, deployment deploy.Deployment) error
+}
+
+// newReadinessProbe returns the ReadinessProbe of the environment cfg belongs to, whose
+// cluster kubectl reaches through kubeContext.
+func newReadinessProbe(cfg *config.Config, kubeContext string) ReadinessProbe {
+	return &readinessProbe{config: cfg, kubeContext: kubeContext}
+}
+
+// readinessProbe is the ReadinessProbe running the prerequisite checker and reading
+// deployments through the Kubernetes API.
+type readinessProbe struct {
+	config      *config.Config
+	kubeContext string
+	checker     *deploy.ReadinessChecker // Created on first use
+}
+
+func (p *readinessProbe) Prerequisites(ctx context.Context) (*prereq.CheckResult, error) {
+	// The daemon's own port is necessarily in use
+	checker := prereq.NewChecker(p.config)
+	checker.SkipPort(config.DaemonPort)
+	return checker.CheckAll(ctx)
+}
+
+func (p *readinessProbe) DeploymentAvailable(ctx context.Context, deployment deploy.Deployment) error {
+	if p.checker == nil {
+		checker, err := deploy.NewReadinessChecker(p.config.KubeconfigPath, p.kubeContext)
+		if err != nil {
+			return err
+		}
+		p.checker = checker
+	}
+	return p.checker.DeploymentAvailable(ctx, deployment)
+}
+
+// ReadyCheck is the result of one of the checks of GET /api/ready.
+type ReadyCheck struct {
+	Name    string `json:"name"`
+	Ready   bool   `json:"ready"`
+	Message string `json:"message,omitempty"` // Why the check failed
+}
+
+// ReadyResponse represents the JSON response for GET /api/ready.
+type ReadyResponse struct {
+	Ready  bool         `json:"ready"`
+	Checks []ReadyCheck `json:"checks"`
+	Failed []string     `json:"failed"` // Names of the checks that failed
+}
+
+// ReadyHandler handles GET /api/ready requests.
+//
+// It reports whether the environment can run a TaskRun: the prerequisites are all met,
+// the cluster is running, and the Tekton webhook and the MPC controller and OTP server
+// deployments are available. The response holds the overall result, each check's
+// result, and the names of the checks that failed; it is 200 OK when the environment is
+// ready and 503 Service Unavailable otherwise, so scripts can use `curl -f`. While the
+// cluster is not running, the deployment checks fail without contacting it.
+// It supports ?profile=<name>.
+func (h *Handlers) ReadyHandler(w http.ResponseWriter, r *http.Request) {
+	// Only accept GET requests
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	cfg, ok := h.profileConfig(w, r)
+	if !ok {
+		return
+	}
+
+	// Create context with timeout
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	response := ReadyResponse{Ready: true, Checks: []ReadyCheck{}, Failed: []string{}}
+	record := func(name string, err error) {
+		check := ReadyCheck{Name: name, Ready: err == nil}
+		if err != nil {
+			check.Message = err.Error()
+			response.Ready = false
+			response.Failed = append(response.Failed, name)
+		}
+		response.Checks = append(response.Checks, check)
+	}
+
+	probe := h.newReadinessProbe(cfg, cfg.GetKubeContext())
+
+	result, err := probe.Prerequisites(ctx)
+	if err == nil && !result.AllMet {
+		err = errors.New("prerequisites not met")
+		if len(result.Errors) > 0 {
+			err = errors.New(strings.Join(result.Errors, "; "))
+		}
+	}
+	record(ReadyCheckPrerequisites, err)
+
+	status, err := h.clusterManagerFor(cfg).Status(ctx)
+	if err == nil && status != cluster.StatusRunning {
+		err = fmt.Errorf("cluster is %s", status)
+	}
+	record(ReadyCheckCluster, err)
+	clusterRunning := err == nil
+
+	for _, ready := range readyDeployments {
+		err := errors.New("cluster is not running")
+		if clusterRunning {
+			err = probe.DeploymentAvailable(ctx, ready.deployment)
+		}
+		record(ready.check, err)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !response.Ready {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.Error(err, "failed to encode response")
+	}
+}
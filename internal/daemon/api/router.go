@@ -2,6 +2,10 @@ package api
 
 import (
 	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 // NewRouter creates and configures a new HTTP router with all API endpoints.
@@ -26,9 +30,24 @@ func NewRouter(handlers *Handlers) *http.ServeMux {
 	// Register GET /api/status - Returns current environment state
 	mux.HandleFunc("/api/status", handlers.StatusHandler)
 
+	// Register GET /api/status/watch - Streams the environment state as Server-Sent Events on each change
+	mux.HandleFunc("/api/status/watch", handlers.StatusWatchHandler)
+
+	// Register POST /api/status/refresh - Re-scans the live environment and returns the updated state
+	mux.HandleFunc("/api/status/refresh", handlers.StatusRefreshHandler)
+
+	// Register GET /api/health - Liveness check without side effects
+	mux.HandleFunc("/api/health", handlers.HealthHandler)
+
+	// Register GET /api/version - Returns the daemon's version, git commit, and build time
+	mux.HandleFunc("/api/version", handlers.VersionHandler)
+
 	// Register POST /api/rebuild - Triggers rebuild asynchronously
 	mux.HandleFunc("/api/rebuild", handlers.RebuildHandler)
 
+	// Register GET/POST /api/hotreload - Reads or toggles rebuilds triggered by file changes
+	mux.HandleFunc("/api/hotreload", handlers.HotReloadHandler)
+
 	// Register POST /api/smoke-test - Triggers smoke test asynchronously
 	mux.HandleFunc("/api/smoke-test", handlers.SmokeTestHandler)
 
@@ -38,30 +57,81 @@ func NewRouter(handlers *Handlers) *http.ServeMux {
 	// Register POST /api/features/enable - Enables a feature with credentials
 	mux.HandleFunc("/api/features/enable", handlers.EnableFeatureHandler)
 
+	// Register GET /api/ready - Reports whether the environment can run a TaskRun, check by check
+	mux.HandleFunc("/api/ready", handlers.ReadyHandler)
+
 	// Register GET /api/prerequisites - Returns prerequisite check results
 	mux.HandleFunc("/api/prerequisites", handlers.PrerequisitesHandler)
 
 	// Register GET /api/cluster/status - Returns cluster status
 	mux.HandleFunc("/api/cluster/status", handlers.ClusterStatusHandler)
 
+	// Register GET /api/cluster/kubeconfig - Returns the cluster kubeconfig and context name
+	mux.HandleFunc("/api/cluster/kubeconfig", handlers.ClusterKubeconfigHandler)
+
 	// Register POST /api/cluster/start - Starts the cluster asynchronously
 	mux.HandleFunc("/api/cluster/start", handlers.ClusterStartHandler)
 
+	// Register POST /api/cluster/ensure - Creates the cluster unless it is running or being created
+	mux.HandleFunc("/api/cluster/ensure", handlers.ClusterEnsureHandler)
+
 	// Register POST /api/cluster/stop - Stops the cluster asynchronously
 	mux.HandleFunc("/api/cluster/stop", handlers.ClusterStopHandler)
 
+	// Register POST /api/cluster/pause - Stops the cluster's node containers asynchronously
+	mux.HandleFunc("/api/cluster/pause", handlers.ClusterPauseHandler)
+
+	// Register POST /api/cluster/resume - Restarts a paused cluster's node containers asynchronously
+	mux.HandleFunc("/api/cluster/resume", handlers.ClusterResumeHandler)
+
 	// Register POST /api/mpc/build - Builds MPC container image asynchronously
 	mux.HandleFunc("/api/mpc/build", handlers.BuildHandler)
 
 	// Register POST /api/mpc/deploy - Deploys MPC to the cluster asynchronously
 	mux.HandleFunc("/api/mpc/deploy", handlers.DeployHandler)
 
+	// Register GET /api/mpc/deploy/status - Returns the step progress of the current or most recent deployment
+	mux.HandleFunc("/api/mpc/deploy/status", handlers.DeployStatusHandler)
+
+	// Register POST /api/mpc/undeploy - Removes MPC and the OTP server from the cluster asynchronously
+	mux.HandleFunc("/api/mpc/undeploy", handlers.UndeployHandler)
+
+	// Register POST /api/mpc/rollback - Restores the images the last deployment replaced asynchronously
+	mux.HandleFunc("/api/mpc/rollback", handlers.RollbackHandler)
+
+	// Register POST /api/mpc/restart - Restarts the controller and/or OTP server without a rebuild asynchronously
+	mux.HandleFunc("/api/mpc/restart", handlers.RestartHandler)
+
+	// Register GET /api/mpc/logs - Returns recent logs of the controller or OTP server pods
+	mux.HandleFunc("/api/mpc/logs", handlers.MPCLogsHandler)
+
 	// Register POST /api/mpc/rebuild-and-redeploy - Orchestrates build and deploy workflow asynchronously
 	mux.HandleFunc("/api/mpc/rebuild-and-redeploy", handlers.RebuildAndRedeployHandler)
 
+	// Register GET /api/operations - Lists the running and queued operations
+	mux.HandleFunc("/api/operations", handlers.OperationsHandler)
+
+	// Register POST /api/operations/cancel - Cancels the running operation
+	mux.HandleFunc("/api/operations/cancel", handlers.OperationsCancelHandler)
+
+	// Register GET /api/operations/logs - Returns the buffered output of an operation
+	mux.HandleFunc("/api/operations/logs", handlers.OperationLogsHandler)
+
+	// Register GET /api/operations/history - Lists the operations finished in this session
+	mux.HandleFunc("/api/operations/history", handlers.OperationHistoryHandler)
+
 	// Register POST /api/git/sync - Synchronizes all Git repositories asynchronously
 	mux.HandleFunc("/api/git/sync", handlers.GitSyncHandler)
 
+	// Register GET /api/git/sync/status - Returns the per-repository results of the last git sync
+	mux.HandleFunc("/api/git/sync/status", handlers.GitSyncStatusHandler)
+
+	// Register GET /api/git/changes - Reports upstream changes not yet in the local repositories
+	mux.HandleFunc("/api/git/changes", handlers.GitChangesHandler)
+
+	// Register POST /api/git/checkout - Switches the MPC repository to a branch
+	mux.HandleFunc("/api/git/checkout", handlers.GitCheckoutHandler)
+
 	// Register POST /api/deploy/secrets - Deploys AWS secrets to the cluster asynchronously
 	mux.HandleFunc("/api/deploy/secrets", handlers.DeploySecretsHandler)
 
@@ -74,8 +144,32 @@ func NewRouter(handlers *Handlers) *http.ServeMux {
 	// Register POST /api/taskrun/run - Runs a TaskRun workflow asynchronously
 	mux.HandleFunc("/api/taskrun/run", handlers.TaskRunRunHandler)
 
+	// Register POST /api/taskrun/cancel - Cancels the in-progress TaskRun
+	mux.HandleFunc("/api/taskrun/cancel", handlers.TaskRunCancelHandler)
+
+	// Register GET /api/taskrun/status - Returns the current or most recent TaskRun status
+	mux.HandleFunc("/api/taskrun/status", handlers.TaskRunStatusHandler)
+
+	// Register GET /api/taskrun/list - Lists TaskRuns in the cluster, newest first
+	mux.HandleFunc("/api/taskrun/list", handlers.TaskRunListHandler)
+
+	// Register GET /api/taskrun/logs - Streams the current TaskRun log as Server-Sent Events
+	mux.HandleFunc("/api/taskrun/logs", handlers.TaskRunLogsHandler)
+
 	// Register POST /api/collect-logs - Triggers Kubernetes log collection into session directory
 	mux.HandleFunc("/api/collect-logs", handlers.CollectLogsHandler)
 
+	// Register GET /api/logs - Lists the files in the session log directory
+	mux.HandleFunc(logFilesPath, handlers.LogFilesHandler)
+
+	// Register GET /api/logs/{name} - Downloads a file from the session log directory
+	mux.HandleFunc(logFilesPath+"/", handlers.LogFilesHandler)
+
+	// Register GET /metrics - Serves the daemon's own metrics in the Prometheus text format
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(handlers.metrics.collectors()...)
+	registry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
 	return mux
 }
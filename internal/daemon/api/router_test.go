@@ -3,6 +3,8 @@ package api_test
 import (
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -31,8 +33,9 @@ var _ = Describe("Router", func() {
 			},
 		}
 
-		// Create minimal mock config
-		mockCfg = &config.Config{}
+		// Create minimal mock config; builds started via /api/rebuild write their log
+		// files into SessionLogDir, so keep them out of the package directory
+		mockCfg = &config.Config{SessionLogDir: GinkgoT().TempDir()}
 
 		// Create handlers and router (no scriptRunner needed)
 		handlers = api.NewHandlers(mockState, mockCfg)
@@ -63,6 +66,15 @@ var _ = Describe("Router", func() {
 			// Should not return 404
 			Expect(rr.Code).NotTo(Equal(http.StatusNotFound))
 		})
+
+		It("should register /api/hotreload route", func() {
+			req := httptest.NewRequest(http.MethodGet, "/api/hotreload", nil)
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+		})
 	})
 
 	Describe("Route Mapping", func() {
@@ -76,6 +88,17 @@ var _ = Describe("Router", func() {
 			Expect(rr.Header().Get("Content-Type")).To(Equal("application/json"))
 		})
 
+		It("should map GET /api/health and /api/version", func() {
+			for _, path := range []string{"/api/health", "/api/version"} {
+				req := httptest.NewRequest(http.MethodGet, path, nil)
+				rr := httptest.NewRecorder()
+
+				router.ServeHTTP(rr, req)
+
+				Expect(rr.Code).To(Equal(http.StatusOK), path)
+			}
+		})
+
 		It("should map POST /api/rebuild to RebuildHandler", func() {
 			req := httptest.NewRequest(http.MethodPost, "/api/rebuild", nil)
 			rr := httptest.NewRecorder()
@@ -86,6 +109,88 @@ var _ = Describe("Router", func() {
 			Expect(rr.Header().Get("Content-Type")).To(Equal("application/json"))
 		})
 
+		It("should register /api/cluster/kubeconfig route", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/cluster/kubeconfig", nil)
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, req)
+
+			// POST is rejected by the handler, proving the route exists
+			Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+
+		It("should register the cluster pause and resume routes", func() {
+			for _, path := range []string{"/api/cluster/pause", "/api/cluster/resume"} {
+				req := httptest.NewRequest(http.MethodGet, path, nil)
+				rr := httptest.NewRecorder()
+
+				router.ServeHTTP(rr, req)
+
+				// GET is rejected by the handler, proving the route exists
+				Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+			}
+		})
+
+		It("should register /api/taskrun/cancel route", func() {
+			req := httptest.NewRequest(http.MethodGet, "/api/taskrun/cancel", nil)
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, req)
+
+			// GET is rejected by the handler, proving the route exists
+			Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+
+		It("should register /api/taskrun/logs route", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/taskrun/logs", nil)
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, req)
+
+			// POST is rejected by the handler, proving the route exists
+			Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+
+		It("should register /api/mpc/undeploy route", func() {
+			req := httptest.NewRequest(http.MethodGet, "/api/mpc/undeploy", nil)
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, req)
+
+			// GET is rejected by the handler, proving the route exists
+			Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+
+		It("should register /api/taskrun/status route", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/taskrun/status", nil)
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, req)
+
+			// POST is rejected by the handler, proving the route exists
+			Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+
+		It("should register /api/taskrun/list route", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/taskrun/list", nil)
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, req)
+
+			// POST is rejected by the handler, proving the route exists
+			Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+
+		It("should register /api/operations/logs route", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/operations/logs", nil)
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, req)
+
+			// POST is rejected by the handler, proving the route exists
+			Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+
 		It("should return 404 for unknown routes", func() {
 			req := httptest.NewRequest(http.MethodGet, "/api/unknown", nil)
 			rr := httptest.NewRecorder()
@@ -163,6 +268,22 @@ var _ = Describe("Router", func() {
 			Expect(rr.Body.String()).To(ContainSubstring("test-session"))
 		})
 
+		It("should route both the log file listing and downloads to LogFilesHandler", func() {
+			Expect(os.WriteFile(filepath.Join(mockCfg.SessionLogDir, "build.log"), []byte("build output\n"), 0600)).To(Succeed())
+
+			req := httptest.NewRequest(http.MethodGet, "/api/logs", nil)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Body.String()).To(ContainSubstring(`"name":"build.log"`))
+
+			req = httptest.NewRequest(http.MethodGet, "/api/logs/build.log", nil)
+			rr = httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Body.String()).To(Equal("build output\n"))
+		})
+
 		It("should correctly call RebuildHandler through router", func() {
 			req := httptest.NewRequest(http.MethodPost, "/api/rebuild", nil)
 			rr := httptest.NewRecorder()
@@ -176,4 +297,31 @@ var _ = Describe("Router", func() {
 			// now uses native Go build.BuildMPCImage() instead of shell scripts
 		})
 	})
+
+	Describe("Metrics", func() {
+		scrape := func() string {
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			return rr.Body.String()
+		}
+
+		It("should serve the daemon's metrics in the Prometheus text format", func() {
+			Expect(scrape()).To(ContainSubstring("go_goroutines"))
+		})
+
+		It("should count a finished operation by name and outcome", func() {
+			Expect(scrape()).NotTo(ContainSubstring(`operation="rebuild"`))
+
+			// Without a repository or container runtime the rebuild fails quickly
+			req := httptest.NewRequest(http.MethodPost, "/api/rebuild", nil)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+			Expect(rr.Code).To(Equal(http.StatusAccepted))
+
+			Eventually(scrape, 30*time.Second).Should(ContainSubstring(`mpc_daemon_operations_total{operation="rebuild",outcome="failed"} 1`))
+			Expect(scrape()).To(ContainSubstring(`mpc_daemon_operation_duration_seconds_count{operation="rebuild"} 1`))
+		})
+	})
 })
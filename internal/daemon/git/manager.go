@@ -5,7 +5,8 @@
 //   - 'upstream' remote pointing to the original repository
 //
 // It provides functionality to check repository state (commits behind upstream, local changes)
-// and synchronize with upstream. All operations use exec.Command to run Git natively.
+// and synchronize with upstream. All operations run Git natively with exec.CommandContext,
+// each bounded by a per-command timeout so an unreachable remote cannot hang the daemon.
 //
 // This is distinct from internal/git which handles repository synchronization for
 // keeping local repos up-to-date. This package focuses on state tracking for the daemon.
@@ -13,30 +14,103 @@ package git
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os/exec"
 	"strings"
+	"time"
 
+	"github.com/meyrevived/mpc-dev-env/internal/config"
 	"github.com/meyrevived/mpc-dev-env/internal/daemon/state"
+	syncgit "github.com/meyrevived/mpc-dev-env/internal/git"
+	"github.com/meyrevived/mpc-dev-env/internal/logger"
 )
 
+// DefaultUpstreamURLs maps known repository names (the repository directory's
+// basename) to the canonical repository they are forked from. Sync uses it to add
+// a missing 'upstream' remote.
+var DefaultUpstreamURLs = map[string]string{
+	"multi-platform-controller": "https://github.com/konflux-ci/multi-platform-controller.git",
+	"konflux-ci":                "https://github.com/konflux-ci/konflux-ci.git",
+	"infra-deployments":         "https://github.com/redhat-appstudio/infra-deployments.git",
+}
+
+// DirtyWorkingTreeError is returned by CheckoutBranch when the repository has uncommitted
+// changes. Files lists the affected paths as reported by "git status --porcelain".
+type DirtyWorkingTreeError struct {
+	Files []string
+}
+
+func (e *DirtyWorkingTreeError) Error() string {
+	return fmt.Sprintf("repository has uncommitted changes: %s", strings.Join(e.Files, ", "))
+}
+
+// DetachedHeadBranch is reported as RepositoryState.CurrentBranch when HEAD is detached,
+// e.g. after checking out a commit or tag rather than a branch.
+const DetachedHeadBranch = "(detached)"
+
+// commandWaitDelay bounds the wait for a killed Git command's output; a fetch's ssh
+// transport can outlive git itself.
+const commandWaitDelay = 5 * time.Second
+
 // GitManager provides Git operations for repository management with fork-aware logic.
 // It is designed to work with forked repositories where:
 // - 'origin' is the user's fork
 // - 'upstream' is the original repository
-// All operations are implemented natively in Go using exec.Command for Git.
+// All operations are implemented natively in Go using exec.CommandContext for Git.
 type GitManager struct {
-	// Empty struct - all methods operate on repositories via their paths
+	// upstreamURLs maps repository names to the URL used when the 'upstream'
+	// remote has to be added. All other methods operate on repositories via their paths.
+	upstreamURLs map[string]string
+
+	// mpcPathPrefixes lists the path prefixes ComputeChangeSet treats as MPC-relevant;
+	// config.DefaultMPCPathPrefixes unless changed with SetMPCPathPrefixes.
+	mpcPathPrefixes []string
+
+	// commandTimeout bounds each Git command; commands still running after it are killed.
+	commandTimeout time.Duration
 }
 
 // NewGitManager creates a new GitManager instance.
 //
+// Args:
+//
+//	upstreamOverrides: Repository name to upstream URL entries that replace or extend
+//	                   DefaultUpstreamURLs (e.g., config.GetUpstreamURLs()); may be nil
+//	commandTimeout:    How long each Git command may run (e.g., config.GetTimeouts().GitCommand);
+//	                   zero means config.DefaultGitCommandTimeout
+//
 // Returns:
 //
 //	A new GitManager instance
-func NewGitManager() *GitManager {
-	return &GitManager{}
+func NewGitManager(upstreamOverrides map[string]string, commandTimeout time.Duration) *GitManager {
+	upstreamURLs := make(map[string]string, len(DefaultUpstreamURLs)+len(upstreamOverrides))
+	for name, url := range DefaultUpstreamURLs {
+		upstreamURLs[name] = url
+	}
+	for name, url := range upstreamOverrides {
+		upstreamURLs[name] = url
+	}
+
+	if commandTimeout <= 0 {
+		commandTimeout = config.DefaultGitCommandTimeout
+	}
+
+	return &GitManager{
+		upstreamURLs:    upstreamURLs,
+		mpcPathPrefixes: config.DefaultMPCPathPrefixes,
+		commandTimeout:  commandTimeout,
+	}
+}
+
+// SetMPCPathPrefixes sets the path prefixes ComputeChangeSet treats as MPC-relevant
+// (e.g., config.GetMPCPathPrefixes()). An empty list keeps the current prefixes.
+func (m *GitManager) SetMPCPathPrefixes(prefixes []string) {
+	if len(prefixes) > 0 {
+		m.mpcPathPrefixes = prefixes
+	}
 }
 
 // CheckRepoState checks the Git state of a repository using ONLY local data.
@@ -44,7 +118,7 @@ func NewGitManager() *GitManager {
 // It compares the local branch against the locally cached 'upstream/main' ref.
 //
 // IMPORTANT: This method assumes Sync() has been called to fetch upstream changes.
-// If Sync() has never been called, CommitsBehindUpstream may be inaccurate.
+// If Sync() has never been called, CommitsBehindUpstream and CommitsAheadUpstream may be inaccurate.
 //
 // Args:
 //
@@ -57,7 +131,7 @@ func NewGitManager() *GitManager {
 //
 // Example:
 //
-//	manager := NewGitManager()
+//	manager := NewGitManager(nil, 0)
 //	repoState, err := manager.CheckRepoState("/home/user/multi-platform-controller")
 //	if err != nil {
 //	    log.Printf("Failed to check repo state: %v", err)
@@ -69,7 +143,7 @@ func (m *GitManager) CheckRepoState(repoPath string) (*state.RepositoryState, er
 	}
 
 	// Get the current branch
-	currentBranch, err := m.getCurrentBranch(repoPath)
+	currentBranch, detached, err := m.getCurrentBranch(repoPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get current branch: %w", err)
 	}
@@ -80,22 +154,33 @@ func (m *GitManager) CheckRepoState(repoPath string) (*state.RepositoryState, er
 		return nil, fmt.Errorf("failed to check for local changes: %w", err)
 	}
 
-	// Compare against upstream/main to get commits ahead/behind (using locally cached refs)
-	commitsBehindUpstream, err := m.getCommitsBehindUpstream(repoPath, currentBranch)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get upstream commits: %w", err)
-	}
-
 	// Extract repository name from path
 	repoName := m.extractRepoName(repoPath)
 
 	// Build the RepositoryState struct
 	repoState := &state.RepositoryState{
-		Name:                  repoName,
-		Path:                  repoPath,
-		CurrentBranch:         currentBranch,
-		CommitsBehindUpstream: commitsBehindUpstream,
-		HasLocalChanges:       hasLocalChanges,
+		Name:            repoName,
+		Path:            repoPath,
+		CurrentBranch:   currentBranch,
+		DetachedHead:    detached,
+		HasLocalChanges: hasLocalChanges,
+	}
+
+	// A detached HEAD is not a branch that tracks upstream, so there is nothing to compare
+	if detached {
+		repoState.CurrentBranch = DetachedHeadBranch
+		return repoState, nil
+	}
+
+	// Compare against upstream/main to get commits ahead/behind (using locally cached refs)
+	repoState.CommitsBehindUpstream, err = m.getCommitsBehindUpstream(repoPath, currentBranch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get upstream commits: %w", err)
+	}
+
+	repoState.CommitsAheadUpstream, err = m.getCommitsAheadUpstream(repoPath, currentBranch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get local commits: %w", err)
 	}
 
 	return repoState, nil
@@ -103,11 +188,13 @@ func (m *GitManager) CheckRepoState(repoPath string) (*state.RepositoryState, er
 
 // Sync performs network operations to synchronize the repository with upstream.
 // This method:
-// - Ensures the 'upstream' remote exists
+// - Ensures the 'upstream' remote exists, adding it for known repository names
 // - Fetches the latest changes from the 'upstream' remote
 //
 // This method should be called periodically in the background to keep the local
 // cache of upstream refs up to date. CheckRepoState relies on these cached refs.
+// It holds the repository's lock (see git.LockRepo in internal/git) so it never runs
+// alongside a POST /api/git/sync or a branch checkout.
 //
 // Args:
 //
@@ -119,11 +206,14 @@ func (m *GitManager) CheckRepoState(repoPath string) (*state.RepositoryState, er
 //
 // Example:
 //
-//	manager := NewGitManager()
+//	manager := NewGitManager(nil, 0)
 //	if err := manager.Sync("/home/user/multi-platform-controller"); err != nil {
 //	    log.Printf("Failed to sync repository: %v", err)
 //	}
 func (m *GitManager) Sync(repoPath string) error {
+	unlock := syncgit.LockRepo(repoPath)
+	defer unlock()
+
 	// Verify this is a Git repository
 	if err := m.verifyGitRepo(repoPath); err != nil {
 		return err
@@ -142,47 +232,247 @@ func (m *GitManager) Sync(repoPath string) error {
 	return nil
 }
 
+// CheckoutBranch switches the repository to the given branch using "git checkout <branch>",
+// or "git checkout -b <branch>" when create is true. It refuses to switch when the working
+// directory has uncommitted or untracked changes, returning a *DirtyWorkingTreeError that
+// lists them, so local work is never carried over to or clobbered by another branch.
+// Like Sync, it holds the repository's lock so it cannot interleave with a sync.
+//
+// Args:
+//
+//	repoPath: The absolute path to the Git repository
+//	branch:   The branch to check out (or create)
+//	create:   Whether to create the branch from the current HEAD
+//
+// Returns:
+//
+//	An error if the repository is dirty or the checkout fails
+//
+// Example:
+//
+//	manager := NewGitManager(nil, 0)
+//	if err := manager.CheckoutBranch("/home/user/multi-platform-controller", "pr-123", false); err != nil {
+//	    log.Printf("Failed to check out branch: %v", err)
+//	}
+func (m *GitManager) CheckoutBranch(repoPath, branch string, create bool) error {
+	// Reject names Git would parse as options
+	if branch == "" || strings.HasPrefix(branch, "-") {
+		return fmt.Errorf("invalid branch name: %q", branch)
+	}
+
+	unlock := syncgit.LockRepo(repoPath)
+	defer unlock()
+
+	// Verify this is a Git repository
+	if err := m.verifyGitRepo(repoPath); err != nil {
+		return err
+	}
+
+	dirtyFiles, err := m.localChanges(repoPath)
+	if err != nil {
+		return fmt.Errorf("failed to check for local changes: %w", err)
+	}
+	if len(dirtyFiles) > 0 {
+		return &DirtyWorkingTreeError{Files: dirtyFiles}
+	}
+
+	args := []string{"checkout"}
+	if create {
+		args = append(args, "-b")
+	}
+	// "--" keeps a branch name from being interpreted as a path
+	args = append(args, branch, "--")
+
+	var stderr bytes.Buffer
+	if err := m.runGit(repoPath, nil, &stderr, args...); err != nil {
+		return fmt.Errorf("failed to check out branch %s: %w, stderr: %s", branch, err, stderr.String())
+	}
+
+	return nil
+}
+
+// ComputeChangeSet summarizes the upstream changes that the local branch does not have yet.
+// Like CheckRepoState, it uses ONLY local data: it compares the current branch against the
+// locally cached 'upstream/main' ref, so Sync() should be called first to fetch new commits.
+//
+// Commits are listed with "git log --oneline HEAD..upstream/main" and changed files with
+// "git diff --name-only HEAD...upstream/main" (changes on upstream since the merge base).
+// PotentiallyAffectsMPC is set when any changed file falls under the MPC-relevant path
+// prefixes (see SetMPCPathPrefixes).
+//
+// Args:
+//
+//	repoPath: The absolute path to the Git repository
+//
+// Returns:
+//
+//	A ChangeSet describing the upstream changes (HasUpdates is false when there are none)
+//	An error if the repository is invalid or upstream/main has not been fetched
+//
+// Example:
+//
+//	manager := NewGitManager(nil, 0)
+//	changeSet, err := manager.ComputeChangeSet("/home/user/multi-platform-controller")
+//	if err == nil && changeSet.PotentiallyAffectsMPC {
+//	    log.Printf("Upstream changes may require a rebuild: %s", changeSet.ImpactSummary)
+//	}
+func (m *GitManager) ComputeChangeSet(repoPath string) (*state.ChangeSet, error) {
+	// Verify this is a Git repository
+	if err := m.verifyGitRepo(repoPath); err != nil {
+		return nil, err
+	}
+
+	// Without a fetched upstream/main there is nothing to compare against
+	if err := m.runGit(repoPath, nil, nil, "rev-parse", "--verify", "--quiet", "upstream/main"); err != nil {
+		return nil, errors.New("upstream/main not found (sync the repository first)")
+	}
+
+	commits, err := m.gitOutputLines(repoPath, "log", "--oneline", "HEAD..upstream/main")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list upstream commits: %w", err)
+	}
+
+	filesChanged, err := m.gitOutputLines(repoPath, "diff", "--name-only", "HEAD...upstream/main")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list changed files: %w", err)
+	}
+
+	var mpcFiles int
+	for _, file := range filesChanged {
+		if m.affectsMPC(file) {
+			mpcFiles++
+		}
+	}
+
+	changeSet := &state.ChangeSet{
+		RepoName:              m.extractRepoName(repoPath),
+		Commits:               commits,
+		FilesChanged:          filesChanged,
+		PotentiallyAffectsMPC: mpcFiles > 0,
+		HasUpdates:            len(commits) > 0,
+	}
+
+	switch {
+	case !changeSet.HasUpdates:
+		changeSet.ImpactSummary = "up to date with upstream/main"
+	case changeSet.PotentiallyAffectsMPC:
+		changeSet.ImpactSummary = fmt.Sprintf("%d new upstream commit(s) changing %d file(s), %d under %s; MPC rebuild recommended",
+			len(commits), len(filesChanged), mpcFiles, strings.Join(m.mpcPathPrefixes, ", "))
+	default:
+		changeSet.ImpactSummary = fmt.Sprintf("%d new upstream commit(s) changing %d file(s), none affecting MPC",
+			len(commits), len(filesChanged))
+	}
+
+	return changeSet, nil
+}
+
+// affectsMPC reports whether a changed file path falls under one of the MPC-relevant prefixes.
+func (m *GitManager) affectsMPC(file string) bool {
+	for _, prefix := range m.mpcPathPrefixes {
+		if strings.HasPrefix(file, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// gitOutputLines runs a Git command in the repository and returns its non-empty output lines.
+// It returns an empty (non-nil) slice when the command prints nothing, so the result
+// serializes as an empty JSON array.
+func (m *GitManager) gitOutputLines(repoPath string, args ...string) ([]string, error) {
+	var stdout, stderr bytes.Buffer
+	if err := m.runGit(repoPath, &stdout, &stderr, args...); err != nil {
+		return nil, fmt.Errorf("git %s failed: %w, stderr: %s", strings.Join(args, " "), err, stderr.String())
+	}
+
+	lines := []string{}
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
+
+// runGit runs "git -C <repoPath> <args>", writing its output to stdout and stderr when
+// they are not nil. The command is killed once it outlives the command timeout, and the
+// returned error then wraps context.DeadlineExceeded.
+func (m *GitManager) runGit(repoPath string, stdout, stderr io.Writer, args ...string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), m.commandTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", repoPath}, args...)...)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.WaitDelay = commandWaitDelay
+
+	err := cmd.Run()
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("git %s timed out after %s: %w", args[0], m.commandTimeout, context.DeadlineExceeded)
+	}
+	return err
+}
+
 // verifyGitRepo checks if the given path is a valid Git repository.
 // It uses "git rev-parse --git-dir" which succeeds only if the path contains a .git directory.
 // Returns an error if the path is not a Git repository.
 func (m *GitManager) verifyGitRepo(repoPath string) error {
-	cmd := exec.Command("git", "-C", repoPath, "rev-parse", "--git-dir")
-	if err := cmd.Run(); err != nil {
+	if err := m.runGit(repoPath, nil, nil, "rev-parse", "--git-dir"); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return err
+		}
 		return fmt.Errorf("not a git repository: %s", repoPath)
 	}
 	return nil
 }
 
-// getCurrentBranch returns the name of the current branch using "git rev-parse --abbrev-ref HEAD".
-// Returns an error if in detached HEAD state or if the command fails.
-func (m *GitManager) getCurrentBranch(repoPath string) (string, error) {
-	cmd := exec.Command("git", "-C", repoPath, "rev-parse", "--abbrev-ref", "HEAD")
+// getCurrentBranch returns the name of the current branch using "git symbolic-ref -q --short HEAD",
+// or "" and true when HEAD is detached, which makes the command exit with status 1.
+// Returns an error if the command fails otherwise.
+func (m *GitManager) getCurrentBranch(repoPath string) (string, bool, error) {
 	var stdout bytes.Buffer
-	cmd.Stdout = &stdout
-
-	if err := cmd.Run(); err != nil {
-		return "", fmt.Errorf("failed to get current branch: %w", err)
+	if err := m.runGit(repoPath, &stdout, nil, "symbolic-ref", "-q", "--short", "HEAD"); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return "", true, nil
+		}
+		return "", false, fmt.Errorf("failed to get current branch: %w", err)
 	}
 
 	branch := strings.TrimSpace(stdout.String())
 	if branch == "" {
-		return "", errors.New("empty branch name")
+		return "", false, errors.New("empty branch name")
 	}
 
-	return branch, nil
+	return branch, false, nil
 }
 
 // ensureUpstreamRemote verifies that the 'upstream' remote is configured.
-// It uses "git remote get-url upstream" to check if the remote exists.
-// Returns an error if the upstream remote is not configured, with instructions
-// on how to add it manually.
-//
-// Future enhancement: Could automatically add upstream remote based on repository name.
+// It uses "git remote get-url upstream" to check if the remote exists. When it is
+// missing and the repository name (its directory basename) has a known upstream URL,
+// the remote is added with "git remote add upstream <url>". Otherwise it returns an
+// error with instructions on how to add it manually.
 func (m *GitManager) ensureUpstreamRemote(repoPath string) error {
-	cmd := exec.Command("git", "-C", repoPath, "remote", "get-url", "upstream")
-	if err := cmd.Run(); err != nil {
+	err := m.runGit(repoPath, nil, nil, "remote", "get-url", "upstream")
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+
+	repoName := m.extractRepoName(repoPath)
+	upstreamURL, ok := m.upstreamURLs[repoName]
+	if !ok {
 		return errors.New("upstream remote not configured (use: git remote add upstream <url>)")
 	}
+
+	logger.Info("adding missing upstream remote", "repo", repoName, "url", upstreamURL)
+	var stderr bytes.Buffer
+	if err := m.runGit(repoPath, nil, &stderr, "remote", "add", "upstream", upstreamURL); err != nil {
+		return fmt.Errorf("failed to add upstream remote %s: %w, stderr: %s", upstreamURL, err, stderr.String())
+	}
+
 	return nil
 }
 
@@ -190,11 +480,8 @@ func (m *GitManager) ensureUpstreamRemote(repoPath string) error {
 // It runs "git fetch upstream" to download new commits and update the locally
 // cached upstream refs (e.g., upstream/main). This does not modify the working directory.
 func (m *GitManager) fetchUpstream(repoPath string) error {
-	cmd := exec.Command("git", "-C", repoPath, "fetch", "upstream")
 	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
+	if err := m.runGit(repoPath, nil, &stderr, "fetch", "upstream"); err != nil {
 		return fmt.Errorf("failed to fetch upstream: %w, stderr: %s", err, stderr.String())
 	}
 
@@ -205,17 +492,30 @@ func (m *GitManager) fetchUpstream(repoPath string) error {
 // It uses "git status --porcelain" which provides machine-readable output.
 // Returns true if there are any modified, added, deleted, or untracked files.
 func (m *GitManager) hasLocalChanges(repoPath string) (bool, error) {
-	cmd := exec.Command("git", "-C", repoPath, "status", "--porcelain")
-	var stdout bytes.Buffer
-	cmd.Stdout = &stdout
-
-	if err := cmd.Run(); err != nil {
-		return false, fmt.Errorf("failed to check status: %w", err)
+	files, err := m.localChanges(repoPath)
+	if err != nil {
+		return false, err
 	}
 
 	// If there's any output, there are changes
-	output := strings.TrimSpace(stdout.String())
-	return output != "", nil
+	return len(files) > 0, nil
+}
+
+// localChanges returns the paths of modified, added, deleted, or untracked files.
+// It parses "git status --porcelain", whose lines have the form "XY <path>".
+func (m *GitManager) localChanges(repoPath string) ([]string, error) {
+	var stdout bytes.Buffer
+	if err := m.runGit(repoPath, &stdout, nil, "status", "--porcelain"); err != nil {
+		return nil, fmt.Errorf("failed to check status: %w", err)
+	}
+
+	var files []string
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		if len(line) > 3 {
+			files = append(files, line[3:])
+		}
+	}
+	return files, nil
 }
 
 // getCommitsBehindUpstream returns the number of commits that the local branch is behind upstream/main.
@@ -229,12 +529,27 @@ func (m *GitManager) getCommitsBehindUpstream(repoPath, currentBranch string) (i
 	// Format: git rev-list --count upstream/main..HEAD (commits ahead)
 	// Format: git rev-list --count HEAD..upstream/main (commits behind - what we want)
 
-	cmd := exec.Command("git", "-C", repoPath, "rev-list", "--count", currentBranch+"..upstream/main")
-	var stdout bytes.Buffer
-	cmd.Stdout = &stdout
+	return m.countCommits(repoPath, currentBranch+"..upstream/main")
+}
+
+// getCommitsAheadUpstream returns the number of local commits on the current branch that are
+// not on upstream/main. It uses "git rev-list --count upstream/main..HEAD", which shows how much
+// local work would need to be rebased onto upstream.
+//
+// Returns 0 if upstream/main doesn't exist (e.g., on first run before Sync() is called).
+func (m *GitManager) getCommitsAheadUpstream(repoPath, currentBranch string) (int, error) {
+	return m.countCommits(repoPath, "upstream/main.."+currentBranch)
+}
 
-	if err := cmd.Run(); err != nil {
-		// If upstream/main doesn't exist, return 0 (no commits ahead)
+// countCommits runs "git rev-list --count <revRange>" and parses the result.
+// Returns 0 if the range cannot be resolved (e.g., upstream/main doesn't exist yet).
+func (m *GitManager) countCommits(repoPath, revRange string) (int, error) {
+	var stdout bytes.Buffer
+	if err := m.runGit(repoPath, &stdout, nil, "rev-list", "--count", revRange); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return 0, err
+		}
+		// If upstream/main doesn't exist, there is nothing to compare against
 		return 0, nil
 	}
 
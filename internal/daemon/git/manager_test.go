@@ -2,15 +2,19 @@ package git_test
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"github.com/meyrevived/mpc-dev-env/internal/daemon/git"
+	syncgit "github.com/meyrevived/mpc-dev-env/internal/git"
 )
 
 func TestGit(t *testing.T) {
@@ -26,7 +30,7 @@ var _ = Describe("GitManager", func() {
 	)
 
 	BeforeEach(func() {
-		manager = git.NewGitManager()
+		manager = git.NewGitManager(nil, 0)
 
 		// Create a temporary directory for test repositories
 		var err error
@@ -45,7 +49,7 @@ var _ = Describe("GitManager", func() {
 
 	Describe("NewGitManager", func() {
 		It("should create a new GitManager instance", func() {
-			m := git.NewGitManager()
+			m := git.NewGitManager(nil, 0)
 			Expect(m).NotTo(BeNil())
 		})
 	})
@@ -121,6 +125,32 @@ var _ = Describe("GitManager", func() {
 					repoState, err := manager.CheckRepoState(repoPath)
 					Expect(err).ToNot(HaveOccurred())
 					Expect(repoState.CommitsBehindUpstream).To(Equal(0))
+					Expect(repoState.CommitsAheadUpstream).To(Equal(0))
+				})
+			})
+
+			Context("when HEAD is detached", func() {
+				It("should flag it and skip the upstream comparison", func() {
+					// A second commit, then check out the first one directly
+					Expect(exec.Command("git", "-C", repoPath, "commit", "--allow-empty", "-m", "Second commit").Run()).To(Succeed())
+					Expect(exec.Command("git", "-C", repoPath, "checkout", "--detach", "HEAD~1").Run()).To(Succeed())
+
+					repoState, err := manager.CheckRepoState(repoPath)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(repoState.DetachedHead).To(BeTrue())
+					Expect(repoState.CurrentBranch).To(Equal(git.DetachedHeadBranch))
+					Expect(repoState.CommitsBehindUpstream).To(Equal(0))
+					Expect(repoState.CommitsAheadUpstream).To(Equal(0))
+				})
+
+				It("should report the branch again once one is checked out", func() {
+					Expect(exec.Command("git", "-C", repoPath, "checkout", "--detach").Run()).To(Succeed())
+					Expect(exec.Command("git", "-C", repoPath, "checkout", "main").Run()).To(Succeed())
+
+					repoState, err := manager.CheckRepoState(repoPath)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(repoState.DetachedHead).To(BeFalse())
+					Expect(repoState.CurrentBranch).To(Equal("main"))
 				})
 			})
 
@@ -154,6 +184,7 @@ var _ = Describe("GitManager", func() {
 					Expect(repoState.CurrentBranch).To(Equal("main"))
 					Expect(repoState.HasLocalChanges).To(BeFalse())
 					Expect(repoState.CommitsBehindUpstream).To(Equal(0))
+					Expect(repoState.CommitsAheadUpstream).To(Equal(0))
 				})
 
 				It("should detect local changes when files are modified", func() {
@@ -240,6 +271,46 @@ var _ = Describe("GitManager", func() {
 					repoState, err := manager.CheckRepoState(repoPath)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(repoState.CommitsBehindUpstream).To(Equal(1))
+					Expect(repoState.CommitsAheadUpstream).To(Equal(0))
+				})
+
+				It("should detect when the local branch has commits not on upstream", func() {
+					// Push the local commit to a fork so it only exists outside upstream
+					originPath := filepath.Join(tempDir, "origin-repo.git")
+					cmd := exec.Command("git", "init", "--bare", originPath)
+					err := cmd.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					cmd = exec.Command("git", "remote", "add", "origin", originPath)
+					cmd.Dir = repoPath
+					err = cmd.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					// Make a local commit
+					newFile := filepath.Join(repoPath, "local-change.txt")
+					err = os.WriteFile(newFile, []byte("local change\n"), 0644)
+					Expect(err).NotTo(HaveOccurred())
+
+					cmd = exec.Command("git", "add", "local-change.txt")
+					cmd.Dir = repoPath
+					err = cmd.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					cmd = exec.Command("git", "commit", "-m", "Local commit")
+					cmd.Dir = repoPath
+					err = cmd.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					cmd = exec.Command("git", "push", "origin", "main")
+					cmd.Dir = repoPath
+					err = cmd.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					repoState, err := manager.CheckRepoState(repoPath)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(repoState.CommitsAheadUpstream).To(Equal(1))
+					Expect(repoState.CommitsBehindUpstream).To(Equal(0))
+					Expect(repoState.HasLocalChanges).To(BeFalse())
 				})
 
 				It("should work on a feature branch", func() {
@@ -410,4 +481,273 @@ var _ = Describe("GitManager", func() {
 			})
 		})
 	})
+
+	Describe("Sync", func() {
+		// initRepo creates a git repository with one commit at path
+		initRepo := func(path string) {
+			Expect(os.MkdirAll(path, 0755)).To(Succeed())
+			for _, args := range [][]string{
+				{"init"},
+				{"config", "user.email", "test@example.com"},
+				{"config", "user.name", "Test User"},
+				{"commit", "--allow-empty", "-m", "Initial commit"},
+			} {
+				cmd := exec.Command("git", args...)
+				cmd.Dir = path
+				Expect(cmd.Run()).To(Succeed())
+			}
+		}
+
+		It("should add the upstream remote for a known repository name", func() {
+			upstreamPath := filepath.Join(tempDir, "upstream-repo.git")
+			Expect(exec.Command("git", "init", "--bare", upstreamPath).Run()).To(Succeed())
+
+			mpcPath := filepath.Join(tempDir, "multi-platform-controller")
+			initRepo(mpcPath)
+
+			// Override the known URL with a local bare repository so no network is needed
+			m := git.NewGitManager(map[string]string{"multi-platform-controller": upstreamPath}, 0)
+			Expect(m.Sync(mpcPath)).To(Succeed())
+
+			url, err := exec.Command("git", "-C", mpcPath, "remote", "get-url", "upstream").Output()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(bytes.TrimSpace(url))).To(Equal(upstreamPath))
+		})
+
+		It("should keep an existing upstream remote", func() {
+			initRepo(repoPath)
+			customPath := filepath.Join(tempDir, "custom.git")
+			Expect(exec.Command("git", "-C", repoPath, "remote", "add", "upstream", customPath).Run()).To(Succeed())
+
+			m := git.NewGitManager(map[string]string{"test-repo": filepath.Join(tempDir, "unused.git")}, 0)
+			// Fetching the missing repository fails, but the remote must not be replaced
+			_ = m.Sync(repoPath)
+
+			url, err := exec.Command("git", "-C", repoPath, "remote", "get-url", "upstream").Output()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(bytes.TrimSpace(url))).To(Equal(customPath))
+		})
+
+		It("should return an error for an unknown repository name", func() {
+			initRepo(repoPath)
+
+			err := manager.Sync(repoPath)
+			Expect(err).To(MatchError(ContainSubstring("upstream remote not configured")))
+
+			Expect(exec.Command("git", "-C", repoPath, "remote", "get-url", "upstream").Run()).NotTo(Succeed())
+		})
+
+		It("should wait for other git-mutating operations on the repository", func() {
+			upstreamPath := filepath.Join(tempDir, "upstream-repo.git")
+			Expect(exec.Command("git", "init", "--bare", upstreamPath).Run()).To(Succeed())
+			initRepo(repoPath)
+			Expect(exec.Command("git", "-C", repoPath, "remote", "add", "upstream", upstreamPath).Run()).To(Succeed())
+
+			// As held by a POST /api/git/sync in progress
+			unlock := syncgit.LockRepo(repoPath)
+			done := make(chan error, 1)
+			go func() {
+				done <- manager.Sync(repoPath)
+			}()
+
+			Consistently(done, 200*time.Millisecond).ShouldNot(Receive())
+			unlock()
+			Eventually(done).Should(Receive(BeNil()))
+		})
+
+		It("should time out a git fetch that hangs", func() {
+			upstreamPath := filepath.Join(tempDir, "upstream-repo.git")
+			Expect(exec.Command("git", "init", "--bare", upstreamPath).Run()).To(Succeed())
+			initRepo(repoPath)
+			Expect(exec.Command("git", "-C", repoPath, "remote", "add", "upstream", upstreamPath).Run()).To(Succeed())
+
+			// A git wrapper on PATH whose fetch hangs, as against an unreachable remote
+			realGit, err := exec.LookPath("git")
+			Expect(err).NotTo(HaveOccurred())
+			stubDir := filepath.Join(tempDir, "bin")
+			Expect(os.MkdirAll(stubDir, 0755)).To(Succeed())
+			stub := "#!/bin/sh\nif [ \"$3\" = fetch ]; then exec sleep 30; fi\nexec " + realGit + " \"$@\"\n"
+			Expect(os.WriteFile(filepath.Join(stubDir, "git"), []byte(stub), 0755)).To(Succeed())
+			originalPath := os.Getenv("PATH")
+			Expect(os.Setenv("PATH", stubDir+string(os.PathListSeparator)+originalPath)).To(Succeed())
+			DeferCleanup(os.Setenv, "PATH", originalPath)
+
+			m := git.NewGitManager(nil, 200*time.Millisecond)
+			start := time.Now()
+			err = m.Sync(repoPath)
+
+			Expect(err).To(MatchError(ContainSubstring("git fetch timed out after 200ms")))
+			Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+			Expect(time.Since(start)).To(BeNumerically("<", 10*time.Second))
+		})
+
+		It("should know the canonical multi-platform-controller upstream", func() {
+			Expect(git.DefaultUpstreamURLs).To(HaveKeyWithValue("multi-platform-controller", "https://github.com/konflux-ci/multi-platform-controller.git"))
+		})
+	})
+	Describe("ComputeChangeSet", func() {
+		var upstreamPath, contributorPath string
+
+		// run executes a git command in dir and fails the test if it fails
+		run := func(dir string, args ...string) {
+			cmd := exec.Command("git", args...)
+			cmd.Dir = dir
+			out, err := cmd.CombinedOutput()
+			Expect(err).NotTo(HaveOccurred(), "git %v: %s", args, out)
+		}
+
+		// commitUpstream commits files (path -> content) in the contributor clone and pushes them upstream
+		commitUpstream := func(message string, files map[string]string) {
+			for path, content := range files {
+				fullPath := filepath.Join(contributorPath, path)
+				Expect(os.MkdirAll(filepath.Dir(fullPath), 0755)).To(Succeed())
+				Expect(os.WriteFile(fullPath, []byte(content), 0644)).To(Succeed())
+			}
+			run(contributorPath, "add", "-A")
+			run(contributorPath, "commit", "-m", message)
+			run(contributorPath, "push", "origin", "main")
+			run(repoPath, "fetch", "upstream")
+		}
+
+		BeforeEach(func() {
+			Expect(os.MkdirAll(repoPath, 0755)).To(Succeed())
+			run(repoPath, "init")
+			run(repoPath, "config", "user.email", "test@example.com")
+			run(repoPath, "config", "user.name", "Test User")
+			run(repoPath, "commit", "--allow-empty", "-m", "Initial commit")
+			run(repoPath, "branch", "-M", "main")
+
+			upstreamPath = filepath.Join(tempDir, "upstream-repo.git")
+			run(tempDir, "init", "--bare", upstreamPath)
+			run(repoPath, "remote", "add", "upstream", upstreamPath)
+			run(repoPath, "push", "upstream", "main")
+
+			contributorPath = filepath.Join(tempDir, "contributor")
+			run(tempDir, "clone", "--branch", "main", upstreamPath, contributorPath)
+			run(contributorPath, "config", "user.email", "test@example.com")
+			run(contributorPath, "config", "user.name", "Test User")
+		})
+
+		It("should report no updates when up to date with upstream", func() {
+			run(repoPath, "fetch", "upstream")
+
+			changeSet, err := manager.ComputeChangeSet(repoPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changeSet.RepoName).To(Equal("test-repo"))
+			Expect(changeSet.HasUpdates).To(BeFalse())
+			Expect(changeSet.PotentiallyAffectsMPC).To(BeFalse())
+			Expect(changeSet.Commits).To(BeEmpty())
+			Expect(changeSet.FilesChanged).To(BeEmpty())
+		})
+
+		It("should list upstream commits and flag changes under pkg/ or cmd/", func() {
+			commitUpstream("Update docs", map[string]string{"docs/notes.md": "notes\n"})
+			commitUpstream("Add allocator", map[string]string{"pkg/allocator.go": "package pkg\n"})
+
+			changeSet, err := manager.ComputeChangeSet(repoPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changeSet.HasUpdates).To(BeTrue())
+			Expect(changeSet.Commits).To(HaveLen(2))
+			Expect(changeSet.Commits[0]).To(HaveSuffix(" Add allocator"))
+			Expect(changeSet.Commits[1]).To(HaveSuffix(" Update docs"))
+			Expect(changeSet.FilesChanged).To(ConsistOf("docs/notes.md", "pkg/allocator.go"))
+			Expect(changeSet.PotentiallyAffectsMPC).To(BeTrue())
+			Expect(changeSet.ImpactSummary).To(ContainSubstring("2 new upstream commit(s)"))
+		})
+
+		It("should not flag changes outside the MPC-relevant paths", func() {
+			commitUpstream("Update docs", map[string]string{"docs/notes.md": "notes\n"})
+
+			changeSet, err := manager.ComputeChangeSet(repoPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changeSet.HasUpdates).To(BeTrue())
+			Expect(changeSet.FilesChanged).To(Equal([]string{"docs/notes.md"}))
+			Expect(changeSet.PotentiallyAffectsMPC).To(BeFalse())
+		})
+
+		It("should flag changes under configured MPC-relevant paths", func() {
+			commitUpstream("Update docs", map[string]string{"docs/notes.md": "notes\n"})
+			manager.SetMPCPathPrefixes([]string{"docs/"})
+
+			changeSet, err := manager.ComputeChangeSet(repoPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changeSet.PotentiallyAffectsMPC).To(BeTrue())
+			Expect(changeSet.ImpactSummary).To(ContainSubstring("docs/"))
+		})
+
+		It("should not list local commits as upstream changes", func() {
+			Expect(os.WriteFile(filepath.Join(repoPath, "local.go"), []byte("package main\n"), 0644)).To(Succeed())
+			run(repoPath, "add", "local.go")
+			run(repoPath, "commit", "-m", "Local commit")
+			commitUpstream("Add command", map[string]string{"cmd/main.go": "package main\n"})
+
+			changeSet, err := manager.ComputeChangeSet(repoPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changeSet.Commits).To(HaveLen(1))
+			Expect(changeSet.FilesChanged).To(Equal([]string{"cmd/main.go"}))
+			Expect(changeSet.PotentiallyAffectsMPC).To(BeTrue())
+		})
+
+		It("should return an error when upstream/main has not been fetched", func() {
+			run(repoPath, "remote", "remove", "upstream")
+
+			_, err := manager.ComputeChangeSet(repoPath)
+			Expect(err).To(MatchError(ContainSubstring("upstream/main not found")))
+		})
+	})
+	Describe("CheckoutBranch", func() {
+		// currentBranch returns the branch checked out in repoPath
+		currentBranch := func() string {
+			out, err := exec.Command("git", "-C", repoPath, "rev-parse", "--abbrev-ref", "HEAD").Output()
+			Expect(err).NotTo(HaveOccurred())
+			return string(bytes.TrimSpace(out))
+		}
+
+		BeforeEach(func() {
+			Expect(os.MkdirAll(repoPath, 0755)).To(Succeed())
+			for _, args := range [][]string{
+				{"init"},
+				{"config", "user.email", "test@example.com"},
+				{"config", "user.name", "Test User"},
+				{"commit", "--allow-empty", "-m", "Initial commit"},
+				{"branch", "-M", "main"},
+				{"branch", "pr-123"},
+			} {
+				cmd := exec.Command("git", args...)
+				cmd.Dir = repoPath
+				Expect(cmd.Run()).To(Succeed())
+			}
+		})
+
+		It("should check out an existing branch", func() {
+			Expect(manager.CheckoutBranch(repoPath, "pr-123", false)).To(Succeed())
+			Expect(currentBranch()).To(Equal("pr-123"))
+		})
+
+		It("should create and check out a new branch", func() {
+			Expect(manager.CheckoutBranch(repoPath, "feature-x", true)).To(Succeed())
+			Expect(currentBranch()).To(Equal("feature-x"))
+		})
+
+		It("should fail for a branch that does not exist without create", func() {
+			err := manager.CheckoutBranch(repoPath, "no-such-branch", false)
+			Expect(err).To(MatchError(ContainSubstring("failed to check out branch no-such-branch")))
+			Expect(currentBranch()).To(Equal("main"))
+		})
+
+		It("should refuse to switch branches with uncommitted changes", func() {
+			Expect(os.WriteFile(filepath.Join(repoPath, "wip.go"), []byte("package main\n"), 0644)).To(Succeed())
+
+			err := manager.CheckoutBranch(repoPath, "pr-123", false)
+			var dirtyErr *git.DirtyWorkingTreeError
+			Expect(errors.As(err, &dirtyErr)).To(BeTrue())
+			Expect(dirtyErr.Files).To(Equal([]string{"wip.go"}))
+			Expect(currentBranch()).To(Equal("main"))
+		})
+
+		It("should reject branch names that look like options", func() {
+			err := manager.CheckoutBranch(repoPath, "--orphan", false)
+			Expect(err).To(MatchError(ContainSubstring("invalid branch name")))
+		})
+	})
 })
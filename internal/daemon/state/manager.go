@@ -1,13 +1,30 @@
 package state
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/meyrevived/mpc-dev-env/internal/cluster"
+	"github.com/meyrevived/mpc-dev-env/internal/config"
+	"github.com/meyrevived/mpc-dev-env/internal/deploy"
+)
+
+const (
+	// MPC deployment coordinates, matching those used by internal/deploy
+	mpcNamespace      = "multi-platform-controller"
+	mpcDeploymentName = "multi-platform-controller"
+	otpDeploymentName = "multi-platform-otp-server"
+
+	// konfluxNamespace is created by konflux-ci's deploy-konflux.sh (POST /api/deploy/konflux)
+	// and holds the Konflux UI, so its presence means Konflux is deployed.
+	konfluxNamespace = "konflux-ui"
 )
 
 // GitManager abstracts Git operations for repository state checking.
@@ -24,6 +41,7 @@ type GitManager interface {
 // direct coupling to the cluster package implementation.
 type ClusterManager interface {
 	Status(ctx context.Context) (string, error)
+	Info(ctx context.Context) (cluster.Info, error)
 }
 
 // StateManager manages the in-memory development environment state.
@@ -35,7 +53,7 @@ type ClusterManager interface {
 // The manager maintains a single DevEnvironment struct that is updated through
 // RefreshState() calls and operation status updates (SetOperationStatus, SetTaskRunInfo).
 // State is exposed to HTTP handlers via GetState() which returns a copy to prevent
-// external modifications.
+// external modifications, and pushed to Subscribe() callers after each change.
 type StateManager struct {
 	mu sync.RWMutex
 
@@ -47,6 +65,25 @@ type StateManager struct {
 	clusterManager ClusterManager
 	repoPaths      map[string]string // map[repoName]repoPath
 	kubeconfigPath string
+	kubeContext    string // Context kubectl uses, the current one when empty
+	clusterName    string
+	profiles       map[string]profileManager // Named environments tracked in state.Profiles
+
+	// sourceGitHash is the commit the last deployed images were built from.
+	// It is only reported while the running controller image carries its tag.
+	sourceGitHash string
+
+	// webhookURL receives an OperationEvent each time an operation finishes; empty disables it.
+	webhookURL string
+
+	// operationStarted is when the operation status last left "idle".
+	operationStarted time.Time
+
+	// statusStarted is when the operation status last changed.
+	statusStarted time.Time
+
+	// subscribers receive the state after each change (see Subscribe).
+	subscribers subscribers
 }
 
 // StateManagerConfig holds configuration for creating a StateManager.
@@ -58,6 +95,13 @@ type StateManagerConfig struct {
 	ClusterManager ClusterManager
 	RepoPaths      map[string]string // map[repoName]repoPath (e.g., "multi-platform-controller" -> "/home/user/mpc/...")
 	KubeconfigPath string
+	KubeContext    string // Kubeconfig context kubectl uses, the current one when empty (see config.Config.GetKubeContext)
+	ClusterName    string // Kind cluster name reported in ClusterState (defaults to "konflux")
+	WebhookURL     string // Optional URL notified when an operation finishes (see OperationEvent)
+
+	// Profiles are the named environments tracked in DevEnvironment.Profiles, keyed by
+	// name. Each needs a ClusterManager.
+	Profiles map[string]ProfileConfig
 }
 
 // NewStateManager creates a new StateManager instance and performs an initial
@@ -91,11 +135,36 @@ func NewStateManager(config *StateManagerConfig) (*StateManager, error) {
 		return nil, errors.New("ClusterManager is required")
 	}
 
+	clusterName := config.ClusterName
+	if clusterName == "" {
+		clusterName = "konflux"
+	}
+
+	var profiles map[string]profileManager
+	for name, profile := range config.Profiles {
+		if profile.ClusterManager == nil {
+			return nil, fmt.Errorf("ClusterManager is required for profile %q", name)
+		}
+		if profiles == nil {
+			profiles = make(map[string]profileManager, len(config.Profiles))
+		}
+		profiles[name] = profileManager{
+			clusterManager: profile.ClusterManager,
+			clusterName:    profile.ClusterName,
+			kubeContext:    profile.KubeContext,
+			repoPaths:      profile.RepoPaths,
+		}
+	}
+
 	manager := &StateManager{
 		gitManager:     config.GitManager,
 		clusterManager: config.ClusterManager,
 		repoPaths:      config.RepoPaths,
 		kubeconfigPath: config.KubeconfigPath,
+		kubeContext:    config.KubeContext,
+		clusterName:    clusterName,
+		profiles:       profiles,
+		webhookURL:     config.WebhookURL,
 	}
 
 	// Perform initial state scan
@@ -115,6 +184,7 @@ func NewStateManager(config *StateManagerConfig) (*StateManager, error) {
 //  3. Scans all configured Git repositories
 //  4. Checks MPC deployment status
 //  5. Initializes feature states to disabled
+//  6. Checks the cluster and repositories of each profile
 //
 // Unlike RefreshState, initialScan sets up the entire state structure from scratch.
 func (m *StateManager) initialScan() error {
@@ -140,7 +210,7 @@ func (m *StateManager) initialScan() error {
 		// If cluster check fails, set a default empty state
 		newState.Cluster = ClusterState{
 			Name:   "",
-			Status: "unknown",
+			Status: cluster.StatusError,
 		}
 	} else {
 		newState.Cluster = clusterState
@@ -173,6 +243,7 @@ func (m *StateManager) initialScan() error {
 	}
 
 	m.state = newState
+	m.refreshProfilesLocked(now)
 	return nil
 }
 
@@ -217,8 +288,7 @@ func (m *StateManager) RefreshState() error {
 	clusterState, err := m.checkClusterState()
 	if err != nil {
 		// Log the error but don't fail the entire refresh
-		// Set cluster status to unknown
-		m.state.Cluster.Status = "unknown"
+		m.state.Cluster.Status = cluster.StatusError
 	} else {
 		m.state.Cluster = clusterState
 	}
@@ -241,16 +311,23 @@ func (m *StateManager) RefreshState() error {
 		// If MPC deployment check fails, set to nil (not deployed)
 		m.state.MPCDeployment = nil
 	} else {
+		m.applySourceGitHash(mpcDeployment)
 		m.state.MPCDeployment = mpcDeployment
 	}
 
+	// Check the profiles' clusters and repositories
+	m.refreshProfilesLocked(now)
+
+	m.publishLocked()
 	return nil
 }
 
 // checkClusterState queries the cluster status using the native Go cluster manager.
 //
 // This is a private helper method called by RefreshState and initialScan. It uses
-// the ClusterManager interface to get the current Kind cluster status with a 10-second timeout.
+// the ClusterManager interface to get the current Kind cluster status, creation time,
+// and node count, and kubectl to check whether Konflux is deployed, with a 10-second
+// timeout. Without a cluster, CreatedAt is left zero, NodeCount 0, and KonfluxDeployed false.
 func (m *StateManager) checkClusterState() (ClusterState, error) {
 	// Use the native Go cluster manager to get status
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -262,14 +339,18 @@ func (m *StateManager) checkClusterState() (ClusterState, error) {
 		return ClusterState{}, err
 	}
 
-	// Parse status to determine cluster state
-	// Status can be: "running", "not_running", or an error message
+	// Status is one of the cluster.Status* constants and is reported unchanged
 	clusterState := ClusterState{
-		Name:            "konflux",  // Kind cluster name
-		CreatedAt:       time.Now(), // TODO: Get actual creation time from cluster
+		Name:            m.clusterName, // Kind cluster name
 		Status:          status,
 		KubeconfigPath:  m.kubeconfigPath,
-		KonfluxDeployed: false, // TODO: Check if Konflux is deployed
+		KonfluxDeployed: konfluxDeployed(ctx, m.kubeconfigPath, m.kubeContext),
+	}
+
+	// Creation time and node count are only known while the cluster exists
+	if info, err := m.clusterManager.Info(ctx); err == nil {
+		clusterState.CreatedAt = info.CreatedAt
+		clusterState.NodeCount = info.NodeCount
 	}
 
 	return clusterState, nil
@@ -277,25 +358,81 @@ func (m *StateManager) checkClusterState() (ClusterState, error) {
 
 // checkMPCDeployment queries the MPC deployment status.
 //
-// This is a private helper method called by RefreshState and initialScan.
+// This is a private helper method called by RefreshState and initialScan. It uses
+// kubectl to read the multi-platform-controller and OTP server deployments and
+// extracts their container images and the controller's creation timestamp.
 //
-// TODO: Implement native Go deployment status checking using kubectl commands.
-// Future implementation should:
-//  1. Use kubectl to check if multi-platform-controller deployment exists
-//  2. Get deployment image tags
-//  3. Get deployment timestamps
+// Returns nil (with no error) only when the controller deployment does not exist.
+// A missing OTP deployment leaves OTPImage empty. Any other kubectl failure, such
+// as an unreachable cluster, is returned as an error.
 func (m *StateManager) checkMPCDeployment() (*MPCDeployment, error) {
-	// TODO: Implement native Go deployment status checking
-	// For now, return nil to indicate no deployment information available
-	// Future implementation should:
-	// 1. Use kubectl to check if multi-platform-controller deployment exists
-	// 2. Get deployment image tags
-	// 3. Get deployment timestamps
-	return nil, nil
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	controllerImage, deployedAt, found, err := getDeploymentInfo(ctx, m.kubeconfigPath, m.kubeContext, mpcDeploymentName)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+
+	otpImage, _, _, err := getDeploymentInfo(ctx, m.kubeconfigPath, m.kubeContext, otpDeploymentName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MPCDeployment{
+		ControllerImage: controllerImage,
+		OTPImage:        otpImage,
+		DeployedAt:      deployedAt,
+	}, nil
+}
+
+// getDeploymentInfo reads the first container image and creation timestamp of a
+// deployment in the MPC namespace using kubectl, with the kubeconfig at kubeconfigPath
+// and its kubeContext context.
+//
+// found is false (with no error) when kubectl reports the deployment as NotFound.
+func getDeploymentInfo(ctx context.Context, kubeconfigPath, kubeContext, name string) (image string, createdAt time.Time, found bool, err error) {
+	cmd := deploy.KubectlCommand(ctx, kubeconfigPath, kubeContext, "get", "deployment", name,
+		"-n", mpcNamespace,
+		"-o", `jsonpath={.spec.template.spec.containers[0].image}{"\n"}{.metadata.creationTimestamp}`)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if strings.Contains(stderr.String(), "NotFound") {
+			return "", time.Time{}, false, nil
+		}
+		return "", time.Time{}, false, fmt.Errorf("failed to get deployment %s: %w (stderr: %s)", name, err, stderr.String())
+	}
+
+	lines := strings.SplitN(strings.TrimSpace(stdout.String()), "\n", 2)
+	image = strings.TrimSpace(lines[0])
+	if len(lines) > 1 {
+		if ts, parseErr := time.Parse(time.RFC3339, strings.TrimSpace(lines[1])); parseErr == nil {
+			createdAt = ts
+		}
+	}
+
+	return image, createdAt, true, nil
+}
+
+// konfluxDeployed reports whether the Konflux namespace exists in the cluster.
+// Any kubectl failure, including an unreachable cluster, counts as not deployed.
+func konfluxDeployed(ctx context.Context, kubeconfigPath, kubeContext string) bool {
+	cmd := deploy.KubectlCommand(ctx, kubeconfigPath, kubeContext, "get", "namespace", konfluxNamespace, "-o", "name")
+	cmd.Stdout = &bytes.Buffer{}
+	cmd.Stderr = &bytes.Buffer{}
+	return cmd.Run() == nil
 }
 
 // SetOperationStatus updates the operation status and error message in the state.
-// This method is thread-safe and uses a write lock.
+// This method is thread-safe and uses a write lock. Leaving a status other than "idle"
+// appends it to the operation history, and when the status returns to "idle", the
+// finished operation is reported to the webhook, if one is configured.
 //
 // Args:
 //
@@ -308,7 +445,27 @@ func (m *StateManager) checkMPCDeployment() (*MPCDeployment, error) {
 //	manager.SetOperationStatus("idle", fmt.Errorf("rebuild failed"))
 func (m *StateManager) SetOperationStatus(status string, err error) {
 	m.mu.Lock()
-	defer m.mu.Unlock()
+	event := m.setOperationStatusLocked(status, err)
+	m.mu.Unlock()
+
+	m.notifyWebhook(event)
+}
+
+// setOperationStatusLocked records status and err, tracks when operations start, and
+// returns the OperationEvent to deliver when status finishes an operation. m.mu must be held.
+func (m *StateManager) setOperationStatusLocked(status string, err error) *OperationEvent {
+	now := time.Now()
+	previous := m.state.OperationStatus
+	if status != "idle" && (previous == "" || previous == "idle") {
+		m.operationStarted = now
+	}
+	event := m.completionEvent(previous, status, err, now)
+	if status != previous {
+		if previous != "" && previous != "idle" {
+			m.recordOperationLocked(previous, err, now)
+		}
+		m.statusStarted = now
+	}
 
 	m.state.OperationStatus = status
 	if err != nil {
@@ -316,7 +473,35 @@ func (m *StateManager) SetOperationStatus(status string, err error) {
 	} else {
 		m.state.LastOperationError = ""
 	}
-	m.state.LastActive = time.Now()
+	m.state.LastActive = now
+	m.publishLocked()
+	return event
+}
+
+// maxOperationHistory bounds DevEnvironment.OperationHistory; the oldest entries are
+// dropped beyond it.
+const maxOperationHistory = 100
+
+// recordOperationLocked appends the finished operation status to the history, dropping
+// the oldest entry once it holds maxOperationHistory. m.mu must be held.
+func (m *StateManager) recordOperationLocked(operation string, err error, now time.Time) {
+	record := OperationRecord{
+		Operation: operation,
+		Status:    "succeeded",
+		StartedAt: m.statusStarted,
+		EndedAt:   now,
+	}
+	if err != nil {
+		record.Status = "failed"
+		record.Error = err.Error()
+	}
+
+	// Entries are never modified in place, so copies returned by GetState stay valid
+	history := m.state.OperationHistory
+	if len(history) >= maxOperationHistory {
+		history = history[len(history)-maxOperationHistory+1:]
+	}
+	m.state.OperationHistory = append(history, record)
 }
 
 // TrySetOperationStatus atomically transitions the operation status from expectedCurrent
@@ -333,19 +518,14 @@ func (m *StateManager) SetOperationStatus(status string, err error) {
 //	}
 func (m *StateManager) TrySetOperationStatus(expectedCurrent, newStatus string, err error) (ok bool, actualCurrent string) {
 	m.mu.Lock()
-	defer m.mu.Unlock()
-
-	if m.state.OperationStatus != expectedCurrent {
-		return false, m.state.OperationStatus
+	if current := m.state.OperationStatus; current != expectedCurrent {
+		m.mu.Unlock()
+		return false, current
 	}
+	event := m.setOperationStatusLocked(newStatus, err)
+	m.mu.Unlock()
 
-	m.state.OperationStatus = newStatus
-	if err != nil {
-		m.state.LastOperationError = err.Error()
-	} else {
-		m.state.LastOperationError = ""
-	}
-	m.state.LastActive = time.Now()
+	m.notifyWebhook(event)
 	return true, newStatus
 }
 
@@ -370,6 +550,139 @@ func (m *StateManager) SetTaskRunInfo(info *TaskRunInfo) {
 
 	m.state.TaskRunInfo = info
 	m.state.LastActive = time.Now()
+	m.publishLocked()
+}
+
+// SetBuildInfo records the most recent image build in the state.
+// This method is thread-safe and uses a write lock.
+func (m *StateManager) SetBuildInfo(info *BuildInfo) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state.BuildInfo = info
+	m.state.LastActive = time.Now()
+	m.publishLocked()
+}
+
+// SetDeployProgress records the progress of the current or most recent MPC deployment
+// in the state. This method is thread-safe and uses a write lock.
+func (m *StateManager) SetDeployProgress(progress *DeployProgress) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state.DeployProgress = progress
+	m.state.LastActive = time.Now()
+	m.publishLocked()
+}
+
+// SetPreviousMPCImages records the images a deployment replaced, which POST
+// /api/mpc/rollback restores. A nil images clears them, e.g. after a rollback.
+// This method is thread-safe and uses a write lock.
+func (m *StateManager) SetPreviousMPCImages(images *PreviousMPCImages) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state.PreviousMPCImages = images
+	m.state.LastActive = time.Now()
+	m.publishLocked()
+}
+
+// SetGitSyncInfo records the most recent git sync in the state.
+// This method is thread-safe and uses a write lock.
+func (m *StateManager) SetGitSyncInfo(info *GitSyncInfo) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state.GitSync = info
+	m.state.LastActive = time.Now()
+	m.publishLocked()
+}
+
+// SetKonfluxInfo records the deployed Konflux UI in the state.
+// This method is thread-safe and uses a write lock.
+func (m *StateManager) SetKonfluxInfo(info *KonfluxInfo) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state.Konflux = info
+	m.state.LastActive = time.Now()
+	m.publishLocked()
+}
+
+// SetMetricsConfig records the deployed metrics stack in the state.
+// This method is thread-safe and uses a write lock.
+func (m *StateManager) SetMetricsConfig(metrics *MetricsConfig) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state.Metrics = metrics
+	m.state.LastActive = time.Now()
+	m.publishLocked()
+}
+
+// SetTestResult records the result of the most recent smoke test in the state.
+// This method is thread-safe and uses a write lock.
+func (m *StateManager) SetTestResult(result *TestResult) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state.SmokeTestResult = result
+	m.state.LastActive = time.Now()
+	m.publishLocked()
+}
+
+// ClearMPCDeployment clears the MPC deployment information from the state.
+//
+// This is called after MPC has been undeployed so the state no longer reports
+// stale images until the next RefreshState. This method is thread-safe and uses
+// a write lock.
+func (m *StateManager) ClearMPCDeployment() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state.MPCDeployment = nil
+	m.state.LastActive = time.Now()
+	m.publishLocked()
+}
+
+// SetMPCSourceGitHash records the git hash the deployed MPC images were built from.
+//
+// The hash is reported as MPCDeployment.SourceGitHash for as long as the running
+// controller image is tagged with it, so a later deploy of differently tagged
+// images (or an external change to the cluster) does not keep a stale hash around.
+// This method is thread-safe and uses a write lock.
+func (m *StateManager) SetMPCSourceGitHash(gitHash string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sourceGitHash = gitHash
+	m.applySourceGitHash(m.state.MPCDeployment)
+	m.state.LastActive = time.Now()
+	m.publishLocked()
+}
+
+// applySourceGitHash sets deployment.SourceGitHash from the recorded hash when the
+// controller image is tagged with it. Callers must hold the write lock.
+func (m *StateManager) applySourceGitHash(deployment *MPCDeployment) {
+	if deployment == nil {
+		return
+	}
+
+	deployment.SourceGitHash = ""
+	if m.sourceGitHash != "" && strings.HasSuffix(deployment.ControllerImage, ":"+config.ShortGitHash(m.sourceGitHash)) {
+		deployment.SourceGitHash = m.sourceGitHash
+	}
+}
+
+// SetIBMEnabled records whether the IBM Cloud secrets have been deployed.
+// This method is thread-safe and uses a write lock.
+func (m *StateManager) SetIBMEnabled(enabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state.Features.IBMEnabled = enabled
+	m.state.LastActive = time.Now()
+	m.publishLocked()
 }
 
 // ClearTaskRunInfo clears the TaskRun information from the state.
@@ -383,4 +696,5 @@ func (m *StateManager) ClearTaskRunInfo() {
 
 	m.state.TaskRunInfo = nil
 	m.state.LastActive = time.Now()
+	m.publishLocked()
 }
@@ -2,13 +2,20 @@ package state_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/meyrevived/mpc-dev-env/internal/cluster"
 	"github.com/meyrevived/mpc-dev-env/internal/daemon/state"
 )
 
@@ -33,13 +40,21 @@ func (m *MockGitManager) CheckRepoState(repoPath string) (*state.RepositoryState
 // MockClusterManager is a mock implementation of the cluster.Manager for testing
 type MockClusterManager struct {
 	StatusFunc func(ctx context.Context) (string, error)
+	InfoFunc   func(ctx context.Context) (cluster.Info, error)
 }
 
 func (m *MockClusterManager) Status(ctx context.Context) (string, error) {
 	if m.StatusFunc != nil {
 		return m.StatusFunc(ctx)
 	}
-	return "running", nil
+	return cluster.StatusRunning, nil
+}
+
+func (m *MockClusterManager) Info(ctx context.Context) (cluster.Info, error) {
+	if m.InfoFunc != nil {
+		return m.InfoFunc(ctx)
+	}
+	return cluster.Info{}, cluster.ErrClusterNotFound
 }
 
 func (m *MockClusterManager) Create(ctx context.Context) error {
@@ -50,14 +65,35 @@ func (m *MockClusterManager) Destroy(ctx context.Context) error {
 	return nil
 }
 
+// mockKubectlNotFound is a kubectl stub that reports every deployment as missing
+const mockKubectlNotFound = `#!/bin/sh
+echo "Error from server (NotFound): deployments.apps \"$3\" not found" >&2
+exit 1
+`
+
+// writeMockKubectl writes a kubectl stub with the given script into dir
+func writeMockKubectl(dir, script string) {
+	Expect(os.WriteFile(filepath.Join(dir, "kubectl"), []byte(script), 0755)).To(Succeed())
+}
+
 var _ = Describe("StateManager", func() {
 	var (
 		mockGitManager     *MockGitManager
 		mockClusterManager *MockClusterManager
 		config             *state.StateManagerConfig
+		mockBinDir         string
+		originalPath       string
 	)
 
 	BeforeEach(func() {
+		// Put a kubectl stub on PATH so MPC deployment checks never reach a real cluster
+		var err error
+		mockBinDir, err = os.MkdirTemp("", "state-test-*")
+		Expect(err).NotTo(HaveOccurred())
+		writeMockKubectl(mockBinDir, mockKubectlNotFound)
+		originalPath = os.Getenv("PATH")
+		_ = os.Setenv("PATH", mockBinDir+":"+originalPath)
+
 		mockGitManager = &MockGitManager{}
 		mockClusterManager = &MockClusterManager{}
 
@@ -71,6 +107,11 @@ var _ = Describe("StateManager", func() {
 		}
 	})
 
+	AfterEach(func() {
+		_ = os.Setenv("PATH", originalPath)
+		_ = os.RemoveAll(mockBinDir)
+	})
+
 	Describe("NewStateManager", func() {
 		It("should create a new StateManager successfully", func() {
 			manager, err := state.NewStateManager(config)
@@ -115,7 +156,7 @@ var _ = Describe("StateManager", func() {
 			clusterStatusCalled := false
 			mockClusterManager.StatusFunc = func(ctx context.Context) (string, error) {
 				clusterStatusCalled = true
-				return "running", nil
+				return cluster.StatusRunning, nil
 			}
 
 			manager, err := state.NewStateManager(config)
@@ -126,6 +167,22 @@ var _ = Describe("StateManager", func() {
 			Expect(clusterStatusCalled).To(BeTrue())
 		})
 
+		It("should report the configured cluster name", func() {
+			config.ClusterName = "mpc-dev-2"
+
+			manager, err := state.NewStateManager(config)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(manager.GetState().Cluster.Name).To(Equal("mpc-dev-2"))
+		})
+
+		It("should default the cluster name to konflux", func() {
+			manager, err := state.NewStateManager(config)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(manager.GetState().Cluster.Name).To(Equal("konflux"))
+		})
+
 		It("should initialize state with session ID and timestamps", func() {
 			manager, err := state.NewStateManager(config)
 
@@ -159,12 +216,12 @@ var _ = Describe("StateManager", func() {
 
 			manager, err := state.NewStateManager(config)
 
-			// Should not fail, but cluster status should be unknown (error treated as cluster state unknown)
+			// Should not fail, but the cluster status should be Error
 			Expect(err).ToNot(HaveOccurred())
 			Expect(manager).ToNot(BeNil())
 
 			currentState := manager.GetState()
-			Expect(currentState.Cluster.Status).To(Equal("unknown"))
+			Expect(currentState.Cluster.Status).To(Equal(cluster.StatusError))
 		})
 	})
 
@@ -211,6 +268,367 @@ var _ = Describe("StateManager", func() {
 		})
 	})
 
+	Describe("SetTestResult", func() {
+		It("should store the most recent smoke test result", func() {
+			manager, err := state.NewStateManager(config)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(manager.GetState().SmokeTestResult).To(BeNil())
+
+			manager.SetTestResult(&state.TestResult{
+				Passed:          true,
+				DurationSeconds: 42,
+				LogFile:         "/tmp/logs/smoke_test.log",
+			})
+
+			result := manager.GetState().SmokeTestResult
+			Expect(result).ToNot(BeNil())
+			Expect(result.Passed).To(BeTrue())
+			Expect(result.LogFile).To(Equal("/tmp/logs/smoke_test.log"))
+		})
+	})
+
+	Describe("SetBuildInfo", func() {
+		It("should store the most recent build", func() {
+			manager, err := state.NewStateManager(config)
+			Expect(err).ToNot(HaveOccurred())
+
+			manager.SetBuildInfo(&state.BuildInfo{Status: "Running", LogFile: "/logs/build_20251127_143052.log"})
+
+			info := manager.GetState().BuildInfo
+			Expect(info).ToNot(BeNil())
+			Expect(info.Status).To(Equal("Running"))
+			Expect(info.LogFile).To(Equal("/logs/build_20251127_143052.log"))
+		})
+	})
+
+	Describe("SetGitSyncInfo", func() {
+		It("should store the most recent git sync", func() {
+			manager, err := state.NewStateManager(config)
+			Expect(err).ToNot(HaveOccurred())
+
+			manager.SetGitSyncInfo(&state.GitSyncInfo{
+				Status: "Failed",
+				Results: []state.RepoSyncResult{
+					{Name: "multi-platform-controller", Strategy: "rebase", OldHash: "abc", NewHash: "def"},
+					{Name: "konflux-ci", Strategy: "rebase", OldHash: "123", NewHash: "123", Error: "failed to fetch from upstream"},
+				},
+			})
+
+			info := manager.GetState().GitSync
+			Expect(info).ToNot(BeNil())
+			Expect(info.Status).To(Equal("Failed"))
+			Expect(info.Results).To(HaveLen(2))
+			Expect(info.Results[0].NewHash).To(Equal("def"))
+			Expect(info.Results[1].Error).To(ContainSubstring("failed to fetch"))
+		})
+	})
+
+	Describe("operation webhook", func() {
+		var (
+			server *httptest.Server
+			events chan state.OperationEvent
+		)
+
+		BeforeEach(func() {
+			events = make(chan state.OperationEvent, 10)
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+				Expect(r.Method).To(Equal(http.MethodPost))
+				Expect(r.Header.Get("Content-Type")).To(Equal("application/json"))
+				var event state.OperationEvent
+				Expect(json.NewDecoder(r.Body).Decode(&event)).To(Succeed())
+				events <- event
+			}))
+			config.WebhookURL = server.URL
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should POST a succeeded event when an operation returns to idle", func() {
+			manager, err := state.NewStateManager(config)
+			Expect(err).ToNot(HaveOccurred())
+
+			manager.SetOperationStatus("deploying_mpc", nil)
+			manager.SetOperationStatus("idle", nil)
+
+			var event state.OperationEvent
+			Eventually(events).Should(Receive(&event))
+			Expect(event.Operation).To(Equal("deploying_mpc"))
+			Expect(event.Status).To(Equal("succeeded"))
+			Expect(event.Error).To(BeEmpty())
+			Expect(event.Duration).To(Equal("0s"))
+		})
+
+		It("should POST a failed event with the operation's error", func() {
+			manager, err := state.NewStateManager(config)
+			Expect(err).ToNot(HaveOccurred())
+
+			ok, _ := manager.TrySetOperationStatus("idle", "running_taskrun", nil)
+			Expect(ok).To(BeTrue())
+			manager.SetOperationStatus("idle", errors.New("taskrun failed"))
+
+			var event state.OperationEvent
+			Eventually(events).Should(Receive(&event))
+			Expect(event.Operation).To(Equal("running_taskrun"))
+			Expect(event.Status).To(Equal("failed"))
+			Expect(event.Error).To(Equal("taskrun failed"))
+		})
+
+		It("should not POST for transitions that do not finish an operation", func() {
+			manager, err := state.NewStateManager(config)
+			Expect(err).ToNot(HaveOccurred())
+
+			manager.SetOperationStatus("idle", nil)
+			manager.SetOperationStatus("deploying_mpc", nil)
+			manager.SetOperationStatus("rebuilding_component", nil)
+
+			Consistently(events, 200*time.Millisecond).ShouldNot(Receive())
+		})
+
+		It("should not affect the operation when the webhook is unreachable", func() {
+			server.Close()
+			manager, err := state.NewStateManager(config)
+			Expect(err).ToNot(HaveOccurred())
+
+			manager.SetOperationStatus("deploying_mpc", nil)
+			manager.SetOperationStatus("idle", nil)
+
+			Expect(manager.GetState().OperationStatus).To(Equal("idle"))
+		})
+	})
+
+	Describe("operation history", func() {
+		It("should append an entry for each finished operation status", func() {
+			manager, err := state.NewStateManager(config)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(manager.GetState().OperationHistory).To(BeEmpty())
+
+			manager.SetOperationStatus("deploying_mpc", nil)
+			manager.SetOperationStatus("idle", nil)
+			manager.SetOperationStatus("running_taskrun", nil)
+			manager.SetOperationStatus("idle", errors.New("taskrun failed"))
+
+			history := manager.GetState().OperationHistory
+			Expect(history).To(HaveLen(2))
+
+			Expect(history[0].Operation).To(Equal("deploying_mpc"))
+			Expect(history[0].Status).To(Equal("succeeded"))
+			Expect(history[0].Error).To(BeEmpty())
+
+			Expect(history[1].Operation).To(Equal("running_taskrun"))
+			Expect(history[1].Status).To(Equal("failed"))
+			Expect(history[1].Error).To(Equal("taskrun failed"))
+
+			// Entries are in order and each ends no earlier than it started
+			for i, record := range history {
+				Expect(record.StartedAt).ToNot(BeZero())
+				Expect(record.EndedAt).ToNot(BeTemporally("<", record.StartedAt))
+				if i > 0 {
+					Expect(record.StartedAt).ToNot(BeTemporally("<", history[i-1].EndedAt))
+				}
+			}
+		})
+
+		It("should not record idle or repeated statuses", func() {
+			manager, err := state.NewStateManager(config)
+			Expect(err).ToNot(HaveOccurred())
+
+			manager.SetOperationStatus("idle", nil)
+			manager.SetOperationStatus("rebuilding", nil)
+			manager.SetOperationStatus("rebuilding", nil)
+			Expect(manager.GetState().OperationHistory).To(BeEmpty())
+
+			manager.SetOperationStatus("idle", nil)
+			manager.SetOperationStatus("idle", errors.New("late error"))
+			Expect(manager.GetState().OperationHistory).To(HaveLen(1))
+		})
+
+		It("should keep only the most recent entries", func() {
+			manager, err := state.NewStateManager(config)
+			Expect(err).ToNot(HaveOccurred())
+
+			for i := range 150 {
+				manager.SetOperationStatus(fmt.Sprintf("step_%d", i), nil)
+				manager.SetOperationStatus("idle", nil)
+			}
+
+			history := manager.GetState().OperationHistory
+			Expect(history).To(HaveLen(100))
+			Expect(history[0].Operation).To(Equal("step_50"))
+			Expect(history[99].Operation).To(Equal("step_149"))
+		})
+	})
+
+	Describe("Subscribe", func() {
+		It("should deliver the state after SetOperationStatus", func() {
+			manager, err := state.NewStateManager(config)
+			Expect(err).ToNot(HaveOccurred())
+
+			updates, unsubscribe := manager.Subscribe()
+			defer unsubscribe()
+
+			manager.SetOperationStatus("deploying_mpc", nil)
+
+			var env state.DevEnvironment
+			Eventually(updates).Should(Receive(&env))
+			Expect(env.OperationStatus).To(Equal("deploying_mpc"))
+		})
+
+		It("should drop the oldest updates for a slow subscriber without blocking", func() {
+			manager, err := state.NewStateManager(config)
+			Expect(err).ToNot(HaveOccurred())
+
+			updates, unsubscribe := manager.Subscribe()
+			defer unsubscribe()
+
+			// Far more updates than the subscriber buffers, none of them read
+			for i := range 100 {
+				manager.SetOperationStatus(fmt.Sprintf("step_%d", i), nil)
+			}
+			manager.SetOperationStatus("idle", nil)
+
+			var latest state.DevEnvironment
+			for len(updates) > 0 {
+				latest = <-updates
+			}
+			Expect(latest.OperationStatus).To(Equal("idle"))
+		})
+
+		It("should close the channel on unsubscribe and stop delivering", func() {
+			manager, err := state.NewStateManager(config)
+			Expect(err).ToNot(HaveOccurred())
+
+			updates, unsubscribe := manager.Subscribe()
+			unsubscribe()
+			unsubscribe() // Safe to call twice
+
+			manager.SetOperationStatus("deploying_mpc", nil)
+			Eventually(updates).Should(BeClosed())
+		})
+	})
+
+	Describe("SetMetricsConfig", func() {
+		It("should store the deployed metrics stack", func() {
+			manager, err := state.NewStateManager(config)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(manager.GetState().Metrics).To(BeNil())
+
+			manager.SetMetricsConfig(&state.MetricsConfig{PrometheusEnabled: true, PrometheusURL: "http://prometheus.mpc-metrics.svc:9090"})
+
+			metrics := manager.GetState().Metrics
+			Expect(metrics).ToNot(BeNil())
+			Expect(metrics.PrometheusEnabled).To(BeTrue())
+			Expect(metrics.PrometheusURL).To(Equal("http://prometheus.mpc-metrics.svc:9090"))
+		})
+	})
+
+	Describe("SetIBMEnabled", func() {
+		It("should flip the IBM feature flag", func() {
+			manager, err := state.NewStateManager(config)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(manager.GetState().Features.IBMEnabled).To(BeFalse())
+
+			manager.SetIBMEnabled(true)
+
+			Expect(manager.GetState().Features.IBMEnabled).To(BeTrue())
+			Expect(manager.GetState().Features.AWSEnabled).To(BeFalse())
+		})
+	})
+
+	Describe("ClearMPCDeployment", func() {
+		It("should remove the MPC deployment information", func() {
+			manager, err := state.NewStateManager(config)
+			Expect(err).ToNot(HaveOccurred())
+
+			manager.ClearMPCDeployment()
+
+			Expect(manager.GetState().MPCDeployment).To(BeNil())
+		})
+	})
+
+	Describe("KonfluxDeployed", func() {
+		// mockKubectlKonflux reports the konflux-ui namespace as present and every deployment as missing
+		const mockKubectlKonflux = `#!/bin/sh
+if [ "$2" = "namespace" ] && [ "$3" = "konflux-ui" ]; then
+  echo "namespace/konflux-ui"
+  exit 0
+fi
+echo "Error from server (NotFound): deployments.apps \"$3\" not found" >&2
+exit 1
+`
+
+		It("should follow the presence of the Konflux namespace", func() {
+			manager, err := state.NewStateManager(config)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(manager.GetState().Cluster.KonfluxDeployed).To(BeFalse())
+
+			writeMockKubectl(mockBinDir, mockKubectlKonflux)
+			Expect(manager.RefreshState()).To(Succeed())
+			Expect(manager.GetState().Cluster.KonfluxDeployed).To(BeTrue())
+
+			writeMockKubectl(mockBinDir, mockKubectlNotFound)
+			Expect(manager.RefreshState()).To(Succeed())
+			Expect(manager.GetState().Cluster.KonfluxDeployed).To(BeFalse())
+		})
+
+		It("should be false when kubectl cannot reach the cluster", func() {
+			writeMockKubectl(mockBinDir, `#!/bin/sh
+echo "The connection to the server localhost:8080 was refused" >&2
+exit 1
+`)
+
+			manager, err := state.NewStateManager(config)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(manager.GetState().Cluster.KonfluxDeployed).To(BeFalse())
+		})
+	})
+
+	Describe("SetMPCSourceGitHash", func() {
+		const gitHash = "0123456789abcdef0123456789abcdef01234567"
+
+		writeDeployedController := func(image string) {
+			writeMockKubectl(mockBinDir, fmt.Sprintf(`#!/bin/sh
+case "$3" in
+  multi-platform-controller)
+    printf '%s\n2025-11-27T14:30:52Z'
+    ;;
+  *)
+    echo "Error from server (NotFound): deployments.apps \"$3\" not found" >&2
+    exit 1
+    ;;
+esac
+exit 0
+`, image))
+		}
+
+		It("should report the hash while the controller runs the revision-tagged image", func() {
+			writeDeployedController("localhost/multi-platform-controller:0123456789ab")
+			manager, err := state.NewStateManager(config)
+			Expect(err).ToNot(HaveOccurred())
+
+			manager.SetMPCSourceGitHash(gitHash)
+			Expect(manager.GetState().MPCDeployment.SourceGitHash).To(Equal(gitHash))
+
+			// The hash survives a refresh as long as the image is unchanged
+			Expect(manager.RefreshState()).To(Succeed())
+			Expect(manager.GetState().MPCDeployment.SourceGitHash).To(Equal(gitHash))
+		})
+
+		It("should not report the hash once a differently tagged image is running", func() {
+			writeDeployedController("localhost/multi-platform-controller:0123456789ab")
+			manager, err := state.NewStateManager(config)
+			Expect(err).ToNot(HaveOccurred())
+			manager.SetMPCSourceGitHash(gitHash)
+
+			writeDeployedController("localhost/multi-platform-controller:latest")
+			Expect(manager.RefreshState()).To(Succeed())
+			Expect(manager.GetState().MPCDeployment.SourceGitHash).To(BeEmpty())
+		})
+	})
+
 	Describe("RefreshState", func() {
 		It("should update the state by querying dependencies", func() {
 			manager, err := state.NewStateManager(config)
@@ -264,7 +682,7 @@ var _ = Describe("StateManager", func() {
 			clusterCheckCalled := false
 			mockClusterManager.StatusFunc = func(ctx context.Context) (string, error) {
 				clusterCheckCalled = true
-				return "running", nil
+				return cluster.StatusRunning, nil
 			}
 
 			manager, err := state.NewStateManager(config)
@@ -279,6 +697,34 @@ var _ = Describe("StateManager", func() {
 			Expect(clusterCheckCalled).To(BeTrue())
 		})
 
+		It("should report the cluster's creation time and node count", func() {
+			createdAt := time.Date(2025, 11, 27, 14, 30, 52, 0, time.UTC)
+			mockClusterManager.InfoFunc = func(ctx context.Context) (cluster.Info, error) {
+				return cluster.Info{CreatedAt: createdAt, NodeCount: 3}, nil
+			}
+
+			manager, err := state.NewStateManager(config)
+			Expect(err).ToNot(HaveOccurred())
+
+			clusterState := manager.GetState().Cluster
+			Expect(clusterState.CreatedAt).To(Equal(createdAt))
+			Expect(clusterState.NodeCount).To(Equal(3))
+		})
+
+		It("should leave the creation time and node count empty without a cluster", func() {
+			mockClusterManager.StatusFunc = func(ctx context.Context) (string, error) {
+				return cluster.StatusNotRunning, nil
+			}
+
+			manager, err := state.NewStateManager(config)
+			Expect(err).ToNot(HaveOccurred())
+
+			clusterState := manager.GetState().Cluster
+			Expect(clusterState.Status).To(Equal(cluster.StatusNotRunning))
+			Expect(clusterState.CreatedAt).To(BeZero())
+			Expect(clusterState.NodeCount).To(BeZero())
+		})
+
 		It("should check MPC deployment status", func() {
 			manager, err := state.NewStateManager(config)
 			Expect(err).ToNot(HaveOccurred())
@@ -286,12 +732,65 @@ var _ = Describe("StateManager", func() {
 			err = manager.RefreshState()
 			Expect(err).ToNot(HaveOccurred())
 
-			// MPC deployment check is currently a no-op (returns nil)
-			// This test verifies it doesn't cause errors
+			// The kubectl stub reports the deployments as NotFound
 			currentState := manager.GetState()
 			Expect(currentState.MPCDeployment).To(BeNil())
 		})
 
+		It("should populate MPC deployment info when the deployments exist", func() {
+			writeMockKubectl(mockBinDir, `#!/bin/sh
+case "$3" in
+  multi-platform-controller)
+    printf 'localhost/multi-platform-controller:latest\n2025-11-27T14:30:52Z'
+    ;;
+  multi-platform-otp-server)
+    printf 'localhost/multi-platform-otp:latest\n2025-11-27T14:31:10Z'
+    ;;
+esac
+exit 0
+`)
+
+			manager, err := state.NewStateManager(config)
+			Expect(err).ToNot(HaveOccurred())
+
+			deployment := manager.GetState().MPCDeployment
+			Expect(deployment).ToNot(BeNil())
+			Expect(deployment.ControllerImage).To(Equal("localhost/multi-platform-controller:latest"))
+			Expect(deployment.OTPImage).To(Equal("localhost/multi-platform-otp:latest"))
+			Expect(deployment.DeployedAt).To(Equal(time.Date(2025, 11, 27, 14, 30, 52, 0, time.UTC)))
+		})
+
+		It("should leave the OTP image empty when only the controller is deployed", func() {
+			writeMockKubectl(mockBinDir, `#!/bin/sh
+if [ "$3" = "multi-platform-controller" ]; then
+  printf 'localhost/multi-platform-controller:latest\n2025-11-27T14:30:52Z'
+  exit 0
+fi
+echo "Error from server (NotFound): deployments.apps \"$3\" not found" >&2
+exit 1
+`)
+
+			manager, err := state.NewStateManager(config)
+			Expect(err).ToNot(HaveOccurred())
+
+			deployment := manager.GetState().MPCDeployment
+			Expect(deployment).ToNot(BeNil())
+			Expect(deployment.ControllerImage).To(Equal("localhost/multi-platform-controller:latest"))
+			Expect(deployment.OTPImage).To(BeEmpty())
+		})
+
+		It("should clear MPC deployment info when kubectl cannot reach the cluster", func() {
+			writeMockKubectl(mockBinDir, `#!/bin/sh
+echo "The connection to the server localhost:8080 was refused" >&2
+exit 1
+`)
+
+			manager, err := state.NewStateManager(config)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(manager.GetState().MPCDeployment).To(BeNil())
+		})
+
 		It("should update repository state when changes are detected", func() {
 			// Initial state: no local changes
 			mockGitManager.CheckRepoStateFunc = func(repoPath string) (*state.RepositoryState, error) {
@@ -360,8 +859,8 @@ var _ = Describe("StateManager", func() {
 			Expect(err).ToNot(HaveOccurred())
 
 			updatedState := manager.GetState()
-			// Error is treated as cluster not available, so status becomes "unknown" on refresh
-			Expect(updatedState.Cluster.Status).To(Equal("unknown"))
+			// A failed check is reported as the Error status on refresh
+			Expect(updatedState.Cluster.Status).To(Equal(cluster.StatusError))
 		})
 
 		It("should be thread-safe (concurrent reads and writes)", func() {
@@ -460,33 +959,52 @@ var _ = Describe("StateManager", func() {
 			Expect(updatedState.Repositories).To(HaveKey("konflux-ci"))
 		})
 
-		It("should set cluster status to running when cluster manager returns running", func() {
+		It("should set cluster status to Running when cluster manager returns Running", func() {
 			mockClusterManager.StatusFunc = func(ctx context.Context) (string, error) {
-				return "running", nil
+				return cluster.StatusRunning, nil
 			}
 
 			manager, err := state.NewStateManager(config)
 			Expect(err).ToNot(HaveOccurred())
 
 			currentState := manager.GetState()
-			Expect(currentState.Cluster.Status).To(Equal("running"))
+			Expect(currentState.Cluster.Status).To(Equal(cluster.StatusRunning))
 			Expect(currentState.Cluster.KonfluxDeployed).To(BeFalse())
 		})
 
-		It("should set cluster status to unknown when cluster manager returns error", func() {
+		DescribeTable("should report exactly the status the cluster manager returned",
+			func(status string) {
+				mockClusterManager.StatusFunc = func(ctx context.Context) (string, error) {
+					return status, nil
+				}
+
+				manager, err := state.NewStateManager(config)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(manager.GetState().Cluster.Status).To(Equal(status))
+
+				Expect(manager.RefreshState()).To(Succeed())
+				Expect(manager.GetState().Cluster.Status).To(Equal(status))
+			},
+			Entry("running", cluster.StatusRunning),
+			Entry("initializing", cluster.StatusInitializing),
+			Entry("paused", cluster.StatusPaused),
+			Entry("not running", cluster.StatusNotRunning),
+		)
+
+		It("should set cluster status to Error when cluster manager returns error", func() {
 			mockClusterManager.StatusFunc = func(ctx context.Context) (string, error) {
-				return "", errors.New("cluster not found")
+				return cluster.StatusError, errors.New("cluster not found")
 			}
 
 			manager, err := state.NewStateManager(config)
 			Expect(err).ToNot(HaveOccurred())
 
 			currentState := manager.GetState()
-			Expect(currentState.Cluster.Status).To(Equal("unknown"))
+			Expect(currentState.Cluster.Status).To(Equal(cluster.StatusError))
 		})
 
 		It("should handle MPC deployment state", func() {
-			// MPC deployment check is currently a no-op (returns nil)
+			// The kubectl stub reports the deployments as NotFound
 			manager, err := state.NewStateManager(config)
 			Expect(err).ToNot(HaveOccurred())
 
@@ -497,8 +1015,62 @@ var _ = Describe("StateManager", func() {
 			Expect(err).ToNot(HaveOccurred())
 
 			updatedState := manager.GetState()
-			// MPC deployment check returns nil (not yet implemented)
 			Expect(updatedState.MPCDeployment).To(BeNil())
 		})
 	})
+
+	Describe("profiles", func() {
+		var stableCluster *MockClusterManager
+
+		BeforeEach(func() {
+			stableCluster = &MockClusterManager{
+				StatusFunc: func(ctx context.Context) (string, error) {
+					return cluster.StatusPaused, nil
+				},
+			}
+			config.Profiles = map[string]state.ProfileConfig{
+				"stable": {
+					ClusterManager: stableCluster,
+					ClusterName:    "konflux-stable",
+					RepoPaths:      map[string]string{"multi-platform-controller": "/home/user/mpc-stable"},
+				},
+			}
+		})
+
+		It("should track each profile's cluster and repositories apart from the default environment", func() {
+			manager, err := state.NewStateManager(config)
+			Expect(err).ToNot(HaveOccurred())
+
+			currentState := manager.GetState()
+			Expect(currentState.Cluster.Status).To(Equal(cluster.StatusRunning))
+			Expect(currentState.Repositories["multi-platform-controller"].Path).To(Equal("/home/user/mpc"))
+
+			Expect(currentState.Profiles).To(HaveKey("stable"))
+			stable := currentState.Profiles["stable"]
+			Expect(stable.Cluster.Name).To(Equal("konflux-stable"))
+			Expect(stable.Cluster.Status).To(Equal(cluster.StatusPaused))
+			Expect(stable.Repositories["multi-platform-controller"].Path).To(Equal("/home/user/mpc-stable"))
+		})
+
+		It("should refresh the profiles' clusters", func() {
+			manager, err := state.NewStateManager(config)
+			Expect(err).ToNot(HaveOccurred())
+
+			stableCluster.StatusFunc = func(ctx context.Context) (string, error) {
+				return "", errors.New("docker is not running")
+			}
+			Expect(manager.RefreshState()).To(Succeed())
+
+			stable := manager.GetState().Profiles["stable"]
+			Expect(stable.Cluster.Name).To(Equal("konflux-stable"))
+			Expect(stable.Cluster.Status).To(Equal(cluster.StatusError))
+		})
+
+		It("should return an error if a profile has no ClusterManager", func() {
+			config.Profiles["broken"] = state.ProfileConfig{ClusterName: "broken"}
+
+			_, err := state.NewStateManager(config)
+			Expect(err).To(MatchError(ContainSubstring(`ClusterManager is required for profile "broken"`)))
+		})
+	})
 })
@@ -10,12 +10,14 @@ import "time"
 
 // ClusterState represents the state of the Kind cluster.
 //
-// Status can be "running", "paused", or "stopped". The daemon updates this state
-// when cluster operations (create, destroy) complete.
+// Status is one of the cluster.Status* constants ("Running", "Initializing", "Paused",
+// "Not Running" or "Error"), as returned by cluster.Manager.Status; it is "Error" when
+// the status could not be determined. The daemon updates this state on each refresh.
 type ClusterState struct {
 	Name            string    `json:"name"`
-	CreatedAt       time.Time `json:"created_at"`
-	Status          string    `json:"status"` // "running" | "paused" | "stopped"
+	CreatedAt       time.Time `json:"created_at"` // Zero when the cluster does not exist
+	NodeCount       int       `json:"node_count"`
+	Status          string    `json:"status"` // One of the cluster.Status* constants
 	KubeconfigPath  string    `json:"kubeconfig_path"`
 	KonfluxDeployed bool      `json:"konflux_deployed"`
 }
@@ -23,13 +25,17 @@ type ClusterState struct {
 // RepositoryState represents the state of a Git repository.
 //
 // This tracks the current branch, sync status, and whether there are uncommitted changes.
-// The daemon's Git manager updates these fields during sync operations.
+// The daemon's Git manager updates these fields during sync operations. When HEAD is
+// detached, DetachedHead is set, CurrentBranch is "(detached)" and the commit counts
+// are left at zero since there is no branch to compare with upstream.
 type RepositoryState struct {
 	Name                  string    `json:"name"`
 	Path                  string    `json:"path"`
 	CurrentBranch         string    `json:"current_branch"`
+	DetachedHead          bool      `json:"detached_head"`
 	LastSynced            time.Time `json:"last_synced"`
 	CommitsBehindUpstream int       `json:"commits_behind_upstream"`
+	CommitsAheadUpstream  int       `json:"commits_ahead_upstream"`
 	HasLocalChanges       bool      `json:"has_local_changes"`
 }
 
@@ -44,6 +50,14 @@ type MPCDeployment struct {
 	SourceGitHash   string    `json:"source_git_hash"`
 }
 
+// PreviousMPCImages represents the images the MPC deployments ran before the most
+// recent deployment replaced them: the target of POST /api/mpc/rollback.
+type PreviousMPCImages struct {
+	ControllerImage string    `json:"controller_image"`
+	OTPImage        string    `json:"otp_image"`
+	ReplacedAt      time.Time `json:"replaced_at"`
+}
+
 // FeatureState represents the enabled/disabled state of cloud provider features.
 //
 // Currently tracks AWS and IBM cloud provider secrets. Features are enabled when
@@ -58,10 +72,88 @@ type FeatureState struct {
 // This stores the results of the most recent TaskRun workflow. The bash scripts
 // read this information via /api/status to display results and make cleanup decisions.
 type TaskRunInfo struct {
-	Name      string `json:"name,omitempty"`
-	Status    string `json:"status,omitempty"`
-	LogFile   string `json:"log_file,omitempty"`
-	StartTime string `json:"start_time,omitempty"`
+	Name      string            `json:"name,omitempty"`
+	Status    string            `json:"status,omitempty"`
+	LogFile   string            `json:"log_file,omitempty"`
+	StartTime string            `json:"start_time,omitempty"`
+	Results   map[string]string `json:"results,omitempty"` // Tekton results (e.g. IMAGE_DIGEST) of a succeeded TaskRun
+}
+
+// BuildInfo represents information about an MPC image build.
+//
+// This stores the most recent build started by the daemon, including the log file
+// that captures the full build output so it can be shared after the build finishes.
+type BuildInfo struct {
+	Status        string `json:"status,omitempty"` // "Running", "Succeeded", or "Failed"
+	LogFile       string `json:"log_file,omitempty"`
+	StartTime     string `json:"start_time,omitempty"`
+	SourceGitHash string `json:"source_git_hash,omitempty"`
+
+	// ImageInputs maps each image reference to the hash of the inputs it was last
+	// built from (see build.InputsHash), so unchanged images can skip the next build.
+	ImageInputs map[string]string `json:"image_inputs,omitempty"`
+
+	// Images holds the size of each image produced by a succeeded build.
+	Images []ImageInfo `json:"images,omitempty"`
+}
+
+// DeployProgress represents the progress of the current or most recent MPC deployment,
+// reported by GET /api/mpc/deploy/status.
+//
+// Step is the 1-based index of the step the deployment has started, out of TotalSteps,
+// and StepName its name (one of the deploy.Step* constants). Once the deployment has
+// finished, they keep the last step it started, i.e. the failing one if it failed.
+type DeployProgress struct {
+	Status     string `json:"status"` // "Running", "Succeeded", or "Failed"
+	Step       int    `json:"step"`   // 0 until the first step starts
+	TotalSteps int    `json:"total_steps"`
+	StepName   string `json:"step_name,omitempty"`
+	DryRun     bool   `json:"dry_run,omitempty"`
+	StartTime  string `json:"start_time,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// KonfluxInfo represents where to reach the Konflux UI and who to log in as.
+//
+// Set once POST /api/deploy/konflux has deployed Konflux, from MPC_KONFLUX_UI_URL and
+// MPC_KONFLUX_USERNAME.
+type KonfluxInfo struct {
+	URL      string `json:"url"`
+	Username string `json:"username"`
+}
+
+// GitSyncInfo represents the most recent git sync started by POST /api/git/sync.
+//
+// Results holds one entry per synced repository, sorted by repository name, once the
+// sync has finished.
+type GitSyncInfo struct {
+	Status    string           `json:"status"` // "Running", "Succeeded", or "Failed"
+	StartTime string           `json:"start_time,omitempty"`
+	EndTime   string           `json:"end_time,omitempty"`
+	Results   []RepoSyncResult `json:"results,omitempty"`
+}
+
+// RepoSyncResult represents the outcome of syncing one repository.
+//
+// OldHash and NewHash are the HEAD commits before and after the sync; they are equal
+// when the repository was already up to date or the sync failed. Target is the ref the
+// branch was brought up to date with, e.g. "upstream/main".
+type RepoSyncResult struct {
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Branch   string `json:"branch,omitempty"`
+	Target   string `json:"target,omitempty"`
+	Strategy string `json:"strategy"`
+	OldHash  string `json:"old_hash,omitempty"`
+	NewHash  string `json:"new_hash,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// ImageInfo represents the size of a built image, reported to spot accidental image bloat.
+type ImageInfo struct {
+	Image     string `json:"image"`
+	SizeBytes int64  `json:"size_bytes"`
+	Layers    int    `json:"layers"`
 }
 
 // DevEnvironment represents the top-level development environment state.
@@ -70,11 +162,18 @@ type TaskRunInfo struct {
 // snapshot of the current development environment including:
 //   - Cluster status and configuration
 //   - Repository sync states
-//   - MPC deployment information
+//   - MPC deployment information, and the images a rollback would restore
 //   - Enabled features (cloud providers)
 //   - Current operation status (idle, rebuilding, running_taskrun, etc.)
 //   - Any errors from the last operation
 //   - Most recent TaskRun results
+//   - Most recent smoke test result
+//   - Most recent image build
+//   - Step progress of the current or most recent MPC deployment
+//   - Deployed metrics stack, if any
+//   - Most recent git sync
+//   - Konflux UI location, once Konflux has been deployed
+//   - Cluster and repository states of the named profiles, if any are configured
 //
 // The bash scripts poll this endpoint to track operation progress and make workflow decisions.
 type DevEnvironment struct {
@@ -84,10 +183,40 @@ type DevEnvironment struct {
 	Cluster            ClusterState               `json:"cluster"`
 	Repositories       map[string]RepositoryState `json:"repositories"`
 	MPCDeployment      *MPCDeployment             `json:"mpc_deployment"`
+	PreviousMPCImages  *PreviousMPCImages         `json:"previous_mpc_images,omitempty"` // images POST /api/mpc/rollback restores
 	Features           FeatureState               `json:"features"`
-	OperationStatus    string                     `json:"operation_status"`       // e.g., "idle", "rebuilding", "configuring_aws", "running_taskrun"
-	LastOperationError string                     `json:"last_operation_error"`   // stores error messages from background operations
-	TaskRunInfo        *TaskRunInfo               `json:"taskrun_info,omitempty"` // information about the most recent TaskRun
+	OperationStatus    string                     `json:"operation_status"`            // e.g., "idle", "rebuilding", "configuring_aws", "running_taskrun"
+	LastOperationError string                     `json:"last_operation_error"`        // stores error messages from background operations
+	TaskRunInfo        *TaskRunInfo               `json:"taskrun_info,omitempty"`      // information about the most recent TaskRun
+	SmokeTestResult    *TestResult                `json:"smoke_test_result,omitempty"` // result of the most recent smoke test
+	BuildInfo          *BuildInfo                 `json:"build_info,omitempty"`        // information about the most recent image build
+	DeployProgress     *DeployProgress            `json:"deploy_progress,omitempty"`   // step progress of the most recent MPC deployment
+	Metrics            *MetricsConfig             `json:"metrics,omitempty"`           // Prometheus/Grafana deployed by POST /api/metrics/deploy
+	GitSync            *GitSyncInfo               `json:"git_sync,omitempty"`          // results of the most recent POST /api/git/sync
+	Konflux            *KonfluxInfo               `json:"konflux,omitempty"`           // Konflux UI deployed by POST /api/deploy/konflux
+	OperationHistory   []OperationRecord          `json:"operation_history,omitempty"` // finished operation statuses, oldest first
+	Profiles           map[string]ProfileState    `json:"profiles,omitempty"`          // state of each named profile (MPC_PROFILES)
+}
+
+// ProfileState represents the state of a named environment (profile) managed next to
+// the default one. GET /api/status?profile=<name> reports it in place of the default
+// environment's Cluster and Repositories.
+type ProfileState struct {
+	Cluster      ClusterState               `json:"cluster"`
+	Repositories map[string]RepositoryState `json:"repositories"`
+}
+
+// OperationRecord represents one finished operation status in DevEnvironment.OperationHistory.
+//
+// An entry is appended each time the operation status moves away from a status other
+// than "idle", e.g. from "deploying_mpc" back to "idle". Status is "failed" when the
+// transition carried an error, which is then stored in Error, and "succeeded" otherwise.
+type OperationRecord struct {
+	Operation string    `json:"operation"` // e.g., "deploying_mpc", "running_taskrun"
+	Status    string    `json:"status"`    // "succeeded" or "failed"
+	Error     string    `json:"error,omitempty"`
+	StartedAt time.Time `json:"started_at"`
+	EndedAt   time.Time `json:"ended_at"`
 }
 
 // ChangeSet represents detected changes in a repository.
@@ -105,12 +234,14 @@ type ChangeSet struct {
 
 // TestResult represents the result of running tests.
 //
-// Currently not actively used but reserved for future test automation features.
+// Populated by the smoke test (POST /api/smoke-test), where LogFile points at the
+// smoke test TaskRun's log.
 type TestResult struct {
 	Passed          bool    `json:"passed"`
 	DurationSeconds int     `json:"duration_seconds"`
 	Output          string  `json:"output"`
 	Error           *string `json:"error"`
+	LogFile         string  `json:"log_file,omitempty"`
 }
 
 // TaskRunResult represents the result of a Tekton TaskRun.
@@ -127,8 +258,8 @@ type TaskRunResult struct {
 
 // MetricsConfig represents the configuration for Prometheus/Grafana.
 //
-// Reserved for future metrics integration. The daemon currently doesn't deploy
-// or configure metrics systems.
+// Set once POST /api/metrics/deploy has deployed the metrics stack. The URLs are
+// in-cluster service addresses.
 type MetricsConfig struct {
 	PrometheusEnabled bool   `json:"prometheus_enabled"`
 	GrafanaEnabled    bool   `json:"grafana_enabled"`
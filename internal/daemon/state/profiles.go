@@ -0,0 +1,82 @@
+package state
+
+import (
+	"context"
+	"time"
+
+	"github.com/meyrevived/mpc-dev-env/internal/cluster"
+)
+
+// ProfileConfig describes a named environment (see config.Profile) whose cluster and
+// repositories the StateManager tracks next to the default environment.
+type ProfileConfig struct {
+	ClusterManager ClusterManager
+	ClusterName    string            // Kind cluster name reported in the profile's ClusterState
+	KubeContext    string            // Kubeconfig context of the profile's cluster
+	RepoPaths      map[string]string // map[repoName]repoPath
+}
+
+// profileManager is the tracked configuration of one profile.
+type profileManager struct {
+	clusterManager ClusterManager
+	clusterName    string
+	kubeContext    string
+	repoPaths      map[string]string
+}
+
+// refreshProfilesLocked re-checks the cluster and repositories of every profile. It is
+// called by initialScan and RefreshState, so m.mu must be held. A repository whose
+// check fails is left out, like in the default environment.
+func (m *StateManager) refreshProfilesLocked(now time.Time) {
+	if len(m.profiles) == 0 {
+		m.state.Profiles = nil
+		return
+	}
+
+	profiles := make(map[string]ProfileState, len(m.profiles))
+	for name, profile := range m.profiles {
+		profileState := ProfileState{Repositories: make(map[string]RepositoryState)}
+
+		clusterState, err := m.checkProfileClusterState(profile)
+		if err != nil {
+			clusterState = ClusterState{Name: profile.clusterName, Status: cluster.StatusError}
+		}
+		profileState.Cluster = clusterState
+
+		for repoName, repoPath := range profile.repoPaths {
+			repoState, err := m.gitManager.CheckRepoState(repoPath)
+			if err != nil {
+				continue
+			}
+			repoState.LastSynced = now
+			profileState.Repositories[repoName] = *repoState
+		}
+
+		profiles[name] = profileState
+	}
+	m.state.Profiles = profiles
+}
+
+// checkProfileClusterState queries the status of a profile's cluster with a 10-second
+// timeout, like checkClusterState does for the default environment.
+func (m *StateManager) checkProfileClusterState(profile profileManager) (ClusterState, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	status, err := profile.clusterManager.Status(ctx)
+	if err != nil {
+		return ClusterState{}, err
+	}
+
+	clusterState := ClusterState{
+		Name:            profile.clusterName,
+		Status:          status,
+		KubeconfigPath:  m.kubeconfigPath,
+		KonfluxDeployed: konfluxDeployed(ctx, m.kubeconfigPath, profile.kubeContext),
+	}
+	if info, err := profile.clusterManager.Info(ctx); err == nil {
+		clusterState.CreatedAt = info.CreatedAt
+		clusterState.NodeCount = info.NodeCount
+	}
+	return clusterState, nil
+}
@@ -0,0 +1,71 @@
+package state
+
+import "sync"
+
+// subscriberBuffer is how many state updates a subscriber may fall behind by. Once
+// its buffer is full, the oldest pending update is dropped to make room for the
+// newest, so a slow consumer never blocks the StateManager and always ends up with
+// the current state.
+const subscriberBuffer = 16
+
+// subscribers is the set of channels that receive a copy of the state after each change.
+type subscribers struct {
+	mu    sync.Mutex
+	chans map[chan DevEnvironment]struct{}
+}
+
+// Subscribe registers for state changes. The returned channel receives a copy of the
+// state each time it changes, e.g. through SetOperationStatus, SetTaskRunInfo or
+// RefreshState; a subscriber that falls more than subscriberBuffer updates behind
+// loses the oldest ones. Call the returned func to unsubscribe, which closes the channel.
+//
+// Example:
+//
+//	updates, unsubscribe := manager.Subscribe()
+//	defer unsubscribe()
+//	for env := range updates {
+//	    log.Printf("operation status: %s", env.OperationStatus)
+//	}
+func (m *StateManager) Subscribe() (<-chan DevEnvironment, func()) {
+	ch := make(chan DevEnvironment, subscriberBuffer)
+
+	m.subscribers.mu.Lock()
+	if m.subscribers.chans == nil {
+		m.subscribers.chans = make(map[chan DevEnvironment]struct{})
+	}
+	m.subscribers.chans[ch] = struct{}{}
+	m.subscribers.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			m.subscribers.mu.Lock()
+			defer m.subscribers.mu.Unlock()
+			delete(m.subscribers.chans, ch)
+			close(ch)
+		})
+	}
+}
+
+// publishLocked sends a copy of the current state to every subscriber without
+// blocking. m.mu must be held, so subscribers receive updates in the order they
+// were made.
+func (m *StateManager) publishLocked() {
+	m.subscribers.mu.Lock()
+	defer m.subscribers.mu.Unlock()
+
+	for ch := range m.subscribers.chans {
+		for sent := false; !sent; {
+			select {
+			case ch <- m.state:
+				sent = true
+			default:
+				// Full: drop the oldest update, unless the subscriber just took it
+				select {
+				case <-ch:
+				default:
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package state
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/meyrevived/mpc-dev-env/internal/logger"
+)
+
+// webhookTimeout bounds each webhook delivery, so a slow or unreachable endpoint
+// never piles up goroutines.
+const webhookTimeout = 10 * time.Second
+
+// OperationEvent is the JSON payload POSTed to the webhook when an operation finishes,
+// i.e. when the operation status returns to "idle".
+//
+// Operation is the status the operation ran under (e.g. "deploying_mpc" or
+// "running_taskrun"), Status is "succeeded" or "failed", Error is the operation's error
+// message, and Duration is how long the operation ran as a Go duration string.
+type OperationEvent struct {
+	Operation string `json:"operation"`
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+	Duration  string `json:"duration"`
+}
+
+// completionEvent returns the OperationEvent for a transition from previous to status,
+// or nil if the transition does not finish an operation. m.mu must be held.
+func (m *StateManager) completionEvent(previous, status string, err error, now time.Time) *OperationEvent {
+	if status != "idle" || previous == "" || previous == "idle" {
+		return nil
+	}
+
+	event := &OperationEvent{
+		Operation: previous,
+		Status:    "succeeded",
+		Duration:  now.Sub(m.operationStarted).Round(time.Second).String(),
+	}
+	if err != nil {
+		event.Status = "failed"
+		event.Error = err.Error()
+	}
+	return event
+}
+
+// notifyWebhook delivers event to the configured webhook in the background. Delivery
+// failures are logged and otherwise ignored, so they never affect the operation.
+func (m *StateManager) notifyWebhook(event *OperationEvent) {
+	if event == nil || m.webhookURL == "" {
+		return
+	}
+
+	go func() {
+		if err := postWebhook(m.webhookURL, event); err != nil {
+			logger.Error(err, "failed to deliver operation webhook", "operation", event.Operation)
+		}
+	}()
+}
+
+// postWebhook POSTs event as JSON to url and fails on a non-2xx response.
+func postWebhook(url string, event *OperationEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
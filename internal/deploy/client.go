@@ -0,0 +1,284 @@
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/meyrevived/mpc-dev-env/internal/config"
+)
+
+// ErrRolloutNotReady is returned by the client backend when a deployment's rollout
+// does not finish in time.
+var ErrRolloutNotReady = errors.New("deployment rollout did not finish")
+
+// restartedAtAnnotation is the pod template annotation `kubectl rollout restart` sets;
+// the client backend sets the same one, so both restart deployments the same way.
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// rolloutTimeout bounds the wait for a restarted deployment's rollout.
+const rolloutTimeout = 5 * time.Minute
+
+// deploymentsResource is the resource of the MPC deployments.
+var deploymentsResource = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+
+// deploymentClient reads and changes the MPC deployments for Deploy, Rollback and
+// Restart, through kubectl (kubectlDeployments) or the Kubernetes API
+// (apiDeployments), as selected by MPC_DEPLOY_BACKEND. Every deployment is in the
+// MPC namespace. Applying the manifests with kustomize, the host-config ConfigMap,
+// the secrets and Undeploy are outside its scope and run kubectl with either backend.
+type deploymentClient interface {
+	// exists reports an error unless the deployment exists.
+	exists(ctx context.Context, deployment string) error
+
+	// image returns the image of the deployment's first container.
+	image(ctx context.Context, deployment string) (string, error)
+
+	// patchImage sets the image and imagePullPolicy of the deployment's first container.
+	patchImage(ctx context.Context, deployment, image, pullPolicy string) error
+
+	// restart triggers a rollout of the deployment's pods.
+	restart(ctx context.Context, deployment string) error
+
+	// waitRollout waits until the deployment's latest rollout has finished.
+	waitRollout(ctx context.Context, deployment string, timeout time.Duration) error
+}
+
+// deploymentClient returns the client of the configured backend, creating it on first use.
+func (m *Manager) deploymentClient() (deploymentClient, error) {
+	if m.deployments != nil {
+		return m.deployments, nil
+	}
+
+	if m.config.GetDeployBackend() != config.DeployBackendClient {
+		m.deployments = &kubectlDeployments{manager: m}
+		return m.deployments, nil
+	}
+
+	restConfig, err := RESTConfig(m.config.KubeconfigPath, m.config.GetKubeContext())
+	if err != nil {
+		return nil, err
+	}
+	client, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+	m.deployments = &apiDeployments{client: client, dryRun: m.dryRun, pollInterval: 2 * time.Second}
+	return m.deployments, nil
+}
+
+// imagePatch returns the JSON patch setting the image and imagePullPolicy of a
+// deployment's first container.
+func imagePatch(image, pullPolicy string) ([]byte, error) {
+	type operation struct {
+		Op    string `json:"op"`
+		Path  string `json:"path"`
+		Value string `json:"value"`
+	}
+	return json.MarshalIndent([]operation{
+		{Op: "replace", Path: "/spec/template/spec/containers/0/image", Value: image},
+		{Op: "replace", Path: "/spec/template/spec/containers/0/imagePullPolicy", Value: pullPolicy},
+	}, "", "  ")
+}
+
+// kubectlDeployments is the default deploymentClient, running kubectl for each call.
+// Mutating commands honour the manager's dry-run mode and copy their output to it.
+// The short commands are retried on transient errors (see Manager.runKubectl), but
+// not exists, which is polled anyway, nor waitRollout's `rollout status`.
+type kubectlDeployments struct {
+	manager *Manager
+}
+
+func (k *kubectlDeployments) exists(ctx context.Context, deployment string) error {
+	return k.manager.kubectl(ctx, "get", "deployment", deployment,
+		"-n", mpcNamespace).Run()
+}
+
+func (k *kubectlDeployments) image(ctx context.Context, deployment string) (string, error) {
+	var output bytes.Buffer
+	err := k.manager.runKubectl(ctx, func() *exec.Cmd {
+		output.Reset()
+		cmd := k.manager.kubectl(ctx, "get", "deployment", deployment,
+			"-n", mpcNamespace,
+			"-o", "jsonpath={.spec.template.spec.containers[0].image}")
+		cmd.Stdout = &output
+		return cmd
+	})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(output.String()), nil
+}
+
+func (k *kubectlDeployments) patchImage(ctx context.Context, deployment, image, pullPolicy string) error {
+	patch, err := imagePatch(image, pullPolicy)
+	if err != nil {
+		return err
+	}
+
+	return k.manager.runKubectl(ctx, func() *exec.Cmd {
+		cmd := k.manager.kubectl(ctx, k.manager.kubectlArgs("patch", "deployment", deployment,
+			"-n", mpcNamespace,
+			"--type=json",
+			"--patch", string(patch))...)
+		cmd.Stdout = k.manager.stdout()
+		cmd.Stderr = k.manager.stderr()
+		return cmd
+	})
+}
+
+func (k *kubectlDeployments) restart(ctx context.Context, deployment string) error {
+	return k.manager.runKubectl(ctx, func() *exec.Cmd {
+		cmd := k.manager.kubectl(ctx, "rollout", "restart",
+			"deployment/"+deployment,
+			"-n", mpcNamespace)
+		cmd.Stdout = k.manager.stdout()
+		cmd.Stderr = k.manager.stderr()
+		return cmd
+	})
+}
+
+func (k *kubectlDeployments) waitRollout(ctx context.Context, deployment string, timeout time.Duration) error {
+	cmd := k.manager.kubectl(ctx, "rollout", "status",
+		"deployment/"+deployment,
+		"-n", mpcNamespace,
+		"--timeout="+timeout.String())
+	cmd.Stdout = k.manager.stdout()
+	cmd.Stderr = k.manager.stderr()
+	return cmd.Run()
+}
+
+// apiDeployments is the deploymentClient of the client backend, talking to the
+// Kubernetes API through a dynamic client instead of running kubectl. Its errors
+// are the API's own, e.g. apierrors.IsNotFound holds for a missing deployment.
+type apiDeployments struct {
+	client dynamic.Interface
+
+	// dryRun sends every change with dryRun=All, like kubectl --dry-run=server.
+	dryRun bool
+
+	// pollInterval is how often waitRollout checks the deployment's status.
+	pollInterval time.Duration
+}
+
+// deployments returns the client of the deployments in the MPC namespace.
+func (a *apiDeployments) deployments() dynamic.ResourceInterface {
+	return a.client.Resource(deploymentsResource).Namespace(mpcNamespace)
+}
+
+// patchOptions returns the options of a change, a dry run in dry-run mode.
+func (a *apiDeployments) patchOptions() metav1.PatchOptions {
+	if a.dryRun {
+		return metav1.PatchOptions{DryRun: []string{metav1.DryRunAll}}
+	}
+	return metav1.PatchOptions{}
+}
+
+func (a *apiDeployments) exists(ctx context.Context, deployment string) error {
+	_, err := a.deployments().Get(ctx, deployment, metav1.GetOptions{})
+	return err
+}
+
+func (a *apiDeployments) image(ctx context.Context, deployment string) (string, error) {
+	obj, err := a.deployments().Get(ctx, deployment, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	containers, _, err := unstructured.NestedSlice(obj.Object, "spec", "template", "spec", "containers")
+	if err != nil {
+		return "", fmt.Errorf("invalid containers of deployment %s: %w", deployment, err)
+	}
+	if len(containers) == 0 {
+		return "", nil
+	}
+	container, ok := containers[0].(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("invalid container of deployment %s", deployment)
+	}
+	image, _, err := unstructured.NestedString(container, "image")
+	return image, err
+}
+
+func (a *apiDeployments) patchImage(ctx context.Context, deployment, image, pullPolicy string) error {
+	patch, err := imagePatch(image, pullPolicy)
+	if err != nil {
+		return err
+	}
+	_, err = a.deployments().Patch(ctx, deployment, types.JSONPatchType, patch, a.patchOptions())
+	return err
+}
+
+func (a *apiDeployments) restart(ctx context.Context, deployment string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]string{
+						restartedAtAnnotation: time.Now().Format(time.RFC3339),
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = a.deployments().Patch(ctx, deployment, types.MergePatchType, patch, a.patchOptions())
+	return err
+}
+
+func (a *apiDeployments) waitRollout(ctx context.Context, deployment string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(a.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		obj, err := a.deployments().Get(ctx, deployment, metav1.GetOptions{})
+		if err == nil && rolloutComplete(obj) {
+			return nil
+		}
+		if err != nil && ctx.Err() == nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w within %s: %w", ErrRolloutNotReady, timeout, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// rolloutComplete reports whether a deployment's latest rollout has finished, by the
+// same rules as `kubectl rollout status`: the controller has observed the latest spec,
+// and every replica is updated and available with no old replica left.
+func rolloutComplete(deployment *unstructured.Unstructured) bool {
+	field := func(fields ...string) int64 {
+		value, _, _ := unstructured.NestedInt64(deployment.Object, fields...)
+		return value
+	}
+
+	replicas := int64(1)
+	if value, found, _ := unstructured.NestedInt64(deployment.Object, "spec", "replicas"); found {
+		replicas = value
+	}
+
+	return field("status", "observedGeneration") >= deployment.GetGeneration() &&
+		field("status", "updatedReplicas") >= replicas &&
+		field("status", "replicas") <= field("status", "updatedReplicas") &&
+		field("status", "availableReplicas") >= field("status", "updatedReplicas")
+}
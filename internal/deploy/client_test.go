@@ -0,0 +1,126 @@
+package deploy
+
+import (
+	"context"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"github.com/meyrevived/mpc-dev-env/internal/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("client backend", func() {
+	var (
+		ctx    context.Context
+		client *dynamicfake.FakeDynamicClient
+		api    *apiDeployments
+	)
+
+	// deployment returns an MPC namespace deployment running image, whose rollout of
+	// generation 2 has finished when ready is true
+	deployment := func(name, image string, ready bool) *unstructured.Unstructured {
+		updated := int64(1)
+		if !ready {
+			updated = 0
+		}
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]interface{}{
+				"name":       name,
+				"namespace":  mpcNamespace,
+				"generation": int64(2),
+			},
+			"spec": map[string]interface{}{
+				"replicas": int64(1),
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							map[string]interface{}{"name": "manager", "image": image, "imagePullPolicy": "IfNotPresent"},
+						},
+					},
+				},
+			},
+			"status": map[string]interface{}{
+				"observedGeneration": int64(2),
+				"replicas":           int64(1),
+				"updatedReplicas":    updated,
+				"availableReplicas":  updated,
+			},
+		}}
+	}
+
+	// get returns a deployment from the fake cluster
+	get := func(name string) *unstructured.Unstructured {
+		obj, err := client.Resource(deploymentsResource).Namespace(mpcNamespace).Get(ctx, name, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		return obj
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		client = dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{deploymentsResource: "DeploymentList"},
+			deployment(mpcDeploymentName, "quay.io/konflux-ci/multi-platform-controller:v1", true),
+			deployment(otpDeploymentName, "quay.io/konflux-ci/multi-platform-otp:v1", false),
+		)
+		api = &apiDeployments{client: client, pollInterval: 10 * time.Millisecond}
+	})
+
+	It("should patch a deployment's image and pull policy and read the image back", func() {
+		Expect(api.patchImage(ctx, mpcDeploymentName, "localhost/multi-platform-controller:dev", "Never")).To(Succeed())
+
+		Expect(api.image(ctx, mpcDeploymentName)).To(Equal("localhost/multi-platform-controller:dev"))
+		containers, _, _ := unstructured.NestedSlice(get(mpcDeploymentName).Object, "spec", "template", "spec", "containers")
+		Expect(containers[0]).To(HaveKeyWithValue("imagePullPolicy", "Never"))
+	})
+
+	It("should return the API's not found error for a missing deployment", func() {
+		_, err := api.image(ctx, "missing")
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		Expect(apierrors.IsNotFound(api.exists(ctx, "missing"))).To(BeTrue())
+		Expect(api.exists(ctx, mpcDeploymentName)).To(Succeed())
+	})
+
+	It("should restart a deployment by annotating its pod template", func() {
+		Expect(api.restart(ctx, otpDeploymentName)).To(Succeed())
+
+		annotations, _, _ := unstructured.NestedStringMap(get(otpDeploymentName).Object, "spec", "template", "metadata", "annotations")
+		Expect(annotations).To(HaveKey(restartedAtAnnotation))
+	})
+
+	It("should wait for a finished rollout and time out on an unfinished one", func() {
+		Expect(api.waitRollout(ctx, mpcDeploymentName, time.Second)).To(Succeed())
+
+		err := api.waitRollout(ctx, otpDeploymentName, 50*time.Millisecond)
+		Expect(err).To(MatchError(ErrRolloutNotReady))
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+	})
+
+	It("should patch and verify the deployments through the manager", func() {
+		manager := NewManager(&config.Config{
+			ControllerImage: "localhost/multi-platform-controller:dev",
+			OTPImage:        "localhost/multi-platform-otp:dev",
+		})
+		manager.deployments = api
+
+		Expect(manager.verifyDeploymentImages(ctx)).To(MatchError(ContainSubstring(
+			"controller using wrong image: quay.io/konflux-ci/multi-platform-controller:v1 (expected: localhost/multi-platform-controller:dev)")))
+
+		Expect(manager.patchMPCDeployment(ctx)).To(Succeed())
+		Expect(manager.patchOTPDeployment(ctx)).To(Succeed())
+		Expect(manager.verifyDeploymentImages(ctx)).To(Succeed())
+	})
+
+	It("should select the kubectl backend by default", func() {
+		manager := NewManager(&config.Config{})
+		Expect(manager.deploymentClient()).To(BeAssignableToTypeOf(&kubectlDeployments{}))
+	})
+})
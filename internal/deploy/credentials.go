@@ -0,0 +1,39 @@
+package deploy
+
+// AWSCredentials are the credentials ApplySecrets stores in the cluster for the
+// controller to provision and reach AWS hosts.
+type AWSCredentials struct {
+	AccessKeyID     string // AWS_ACCESS_KEY_ID
+	SecretAccessKey string // AWS_SECRET_ACCESS_KEY
+	SessionToken    string // AWS_SESSION_TOKEN, only set for temporary (SSO) credentials
+	SSHKeyPath      string // SSH_KEY_PATH, private key file for the AWS hosts
+}
+
+// IBMCredentials are the credentials ApplyIBMSecrets stores in the cluster for the
+// static s390x and ppc64le hosts and for provisioning dynamic IBM Cloud hosts.
+type IBMCredentials struct {
+	S390XSSHKeyPath   string // IBM_S390X_SSH_KEY_PATH
+	PPC64LESSHKeyPath string // IBM_PPC64LE_SSH_KEY_PATH
+	APIKey            string // IBMCLOUD_API_KEY
+}
+
+// AWSCredentialsFrom reads AWSCredentials through lookup, which maps the environment
+// variable names in the AWSCredentials field comments to values, e.g. os.Getenv.
+func AWSCredentialsFrom(lookup func(string) string) AWSCredentials {
+	return AWSCredentials{
+		AccessKeyID:     lookup("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: lookup("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    lookup("AWS_SESSION_TOKEN"),
+		SSHKeyPath:      lookup("SSH_KEY_PATH"),
+	}
+}
+
+// IBMCredentialsFrom reads IBMCredentials through lookup, which maps the environment
+// variable names in the IBMCredentials field comments to values, e.g. os.Getenv.
+func IBMCredentialsFrom(lookup func(string) string) IBMCredentials {
+	return IBMCredentials{
+		S390XSSHKeyPath:   lookup("IBM_S390X_SSH_KEY_PATH"),
+		PPC64LESSHKeyPath: lookup("IBM_PPC64LE_SSH_KEY_PATH"),
+		APIKey:            lookup("IBMCLOUD_API_KEY"),
+	}
+}
@@ -0,0 +1,132 @@
+package deploy
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Keys every dynamic.<platform>.* group needs, and the additional ones an "aws"
+// platform needs to allocate instances.
+var (
+	requiredDynamicKeys    = []string{"type", "ssh-secret", "max-instances"}
+	requiredAWSDynamicKeys = []string{"region", "ami", "instance-type", "key-name", "aws-secret"}
+)
+
+// requiredHostKeys are the keys every host.<name>.* group of a static host needs.
+var requiredHostKeys = []string{"address", "platform", "user", "secret"}
+
+// validateHostConfig checks a host-config.yaml before it is applied, so mistakes are
+// reported up front instead of as controller errors once MPC reads the ConfigMap.
+//
+// The file must be a ConfigMap with data. Every platform listed in dynamic-platforms
+// must have a dynamic.<platform>.* group (with "/" replaced by "-"), every dynamic
+// group must have requiredDynamicKeys (plus requiredAWSDynamicKeys for type "aws")
+// and a positive max-instances, and every host.<name>.* group must have
+// requiredHostKeys. All problems found are returned, joined into one error.
+func validateHostConfig(content []byte) error {
+	var cm struct {
+		Kind string            `yaml:"kind"`
+		Data map[string]string `yaml:"data"`
+	}
+	if err := yaml.Unmarshal(content, &cm); err != nil {
+		return fmt.Errorf("not valid YAML: %w", err)
+	}
+	if cm.Kind != "ConfigMap" {
+		return fmt.Errorf("kind is %q, expected ConfigMap", cm.Kind)
+	}
+	if len(cm.Data) == 0 {
+		return errors.New("ConfigMap has no data")
+	}
+
+	dynamic := map[string]map[string]string{}
+	hosts := map[string]map[string]string{}
+	for key, value := range cm.Data {
+		var groups map[string]map[string]string
+		var rest string
+		switch {
+		case strings.HasPrefix(key, "dynamic."):
+			groups, rest = dynamic, strings.TrimPrefix(key, "dynamic.")
+		case strings.HasPrefix(key, "host."):
+			groups, rest = hosts, strings.TrimPrefix(key, "host.")
+		default:
+			continue
+		}
+
+		name, setting, ok := strings.Cut(rest, ".")
+		if !ok {
+			continue
+		}
+		if groups[name] == nil {
+			groups[name] = map[string]string{}
+		}
+		groups[name][setting] = value
+	}
+
+	var problems []error
+	for _, platform := range splitPlatforms(cm.Data["dynamic-platforms"]) {
+		name := strings.ReplaceAll(platform, "/", "-")
+		if dynamic[name] == nil {
+			problems = append(problems, fmt.Errorf("dynamic platform %s has no dynamic.%s.* settings", platform, name))
+		}
+	}
+
+	for _, name := range sortedKeys(dynamic) {
+		settings := dynamic[name]
+		required := requiredDynamicKeys
+		if settings["type"] == "aws" {
+			required = append(append([]string{}, required...), requiredAWSDynamicKeys...)
+		}
+		problems = append(problems, missingKeys("dynamic."+name, settings, required)...)
+
+		if value, ok := settings["max-instances"]; ok {
+			if maxInstances, err := strconv.Atoi(value); err != nil || maxInstances <= 0 {
+				problems = append(problems, fmt.Errorf("dynamic.%s.max-instances is %q, expected a whole number of at least 1", name, value))
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(hosts) {
+		problems = append(problems, missingKeys("host."+name, hosts[name], requiredHostKeys)...)
+	}
+
+	return errors.Join(problems...)
+}
+
+// missingKeys reports each of required that is missing or empty in the settings of
+// the group named prefix.
+func missingKeys(prefix string, settings map[string]string, required []string) []error {
+	var problems []error
+	for _, key := range required {
+		if strings.TrimSpace(settings[key]) == "" {
+			problems = append(problems, fmt.Errorf("%s.%s is missing", prefix, key))
+		}
+	}
+	return problems
+}
+
+// splitPlatforms splits a comma-separated platform list such as dynamic-platforms,
+// whose YAML line continuations leave spaces around the entries.
+func splitPlatforms(value string) []string {
+	var platforms []string
+	for _, platform := range strings.Split(value, ",") {
+		if platform = strings.TrimSpace(platform); platform != "" {
+			platforms = append(platforms, platform)
+		}
+	}
+	return platforms
+}
+
+// sortedKeys returns the keys of groups in order, so problems are reported stably.
+func sortedKeys(groups map[string]map[string]string) []string {
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
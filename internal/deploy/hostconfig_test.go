@@ -0,0 +1,95 @@
+package deploy
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/meyrevived/mpc-dev-env/internal/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// Note: Test suite entry point is in manager_test.go
+
+var _ = Describe("validateHostConfig", func() {
+	const validConfig = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: host-config
+data:
+  dynamic-platforms: "\
+    linux/arm64,\
+    linux-mlarge/arm64\
+    "
+  dynamic.linux-arm64.type: "aws"
+  dynamic.linux-arm64.region: "eu-west-1"
+  dynamic.linux-arm64.ami: "ami-arm"
+  dynamic.linux-arm64.instance-type: "m6g.large"
+  dynamic.linux-arm64.key-name: "dev"
+  dynamic.linux-arm64.aws-secret: "aws-account"
+  dynamic.linux-arm64.ssh-secret: "aws-ssh-key"
+  dynamic.linux-arm64.max-instances: "10"
+  dynamic.linux-mlarge-arm64.type: "ibmz"
+  dynamic.linux-mlarge-arm64.ssh-secret: "ibm-ssh-key"
+  dynamic.linux-mlarge-arm64.max-instances: "2"
+  host.s390x-dev.address: "127.0.0.1"
+  host.s390x-dev.platform: "linux/s390x"
+  host.s390x-dev.user: "root"
+  host.s390x-dev.secret: "ibm-s390x-ssh-key"
+`
+
+	It("should accept a complete config", func() {
+		Expect(validateHostConfig([]byte(validConfig))).To(Succeed())
+	})
+
+	It("should accept the generated minimal config and the example in the project root", func() {
+		outputPath := filepath.Join(GinkgoT().TempDir(), "host-config.yaml")
+		Expect(NewManager(&config.Config{}).generateMinimalHostConfig(outputPath)).To(Succeed())
+		generated, err := os.ReadFile(outputPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(validateHostConfig(generated)).To(Succeed())
+
+		example, err := os.ReadFile(filepath.Join("..", "..", "host-config.yaml"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(validateHostConfig(example)).To(Succeed())
+	})
+
+	It("should accept the generated config with the IBM platforms", func() {
+		outputPath := filepath.Join(GinkgoT().TempDir(), "host-config.yaml")
+		manager := NewManager(&config.Config{})
+		manager.ibmEnabled = true
+		Expect(manager.generateMinimalHostConfig(outputPath)).To(Succeed())
+		generated, err := os.ReadFile(outputPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(validateHostConfig(generated)).To(Succeed())
+	})
+
+	It("should report every missing key", func() {
+		broken := strings.NewReplacer(
+			"  dynamic.linux-arm64.ami: \"ami-arm\"\n", "",
+			"  host.s390x-dev.user: \"root\"\n", "",
+		).Replace(validConfig)
+
+		err := validateHostConfig([]byte(broken))
+		Expect(err).To(MatchError(ContainSubstring("dynamic.linux-arm64.ami is missing")))
+		Expect(err).To(MatchError(ContainSubstring("host.s390x-dev.user is missing")))
+	})
+
+	It("should report listed platforms without settings and invalid max-instances", func() {
+		broken := strings.NewReplacer(
+			"linux-mlarge/arm64\\", "linux-mlarge/arm64,\\\n    linux/amd64\\",
+			`dynamic.linux-arm64.max-instances: "10"`, `dynamic.linux-arm64.max-instances: "ten"`,
+		).Replace(validConfig)
+
+		err := validateHostConfig([]byte(broken))
+		Expect(err).To(MatchError(ContainSubstring("dynamic platform linux/amd64 has no dynamic.linux-amd64.* settings")))
+		Expect(err).To(MatchError(ContainSubstring(`dynamic.linux-arm64.max-instances is "ten"`)))
+	})
+
+	It("should reject files that are not a ConfigMap with data", func() {
+		Expect(validateHostConfig([]byte("kind: Secret\n"))).To(MatchError(ContainSubstring("expected ConfigMap")))
+		Expect(validateHostConfig([]byte("kind: ConfigMap\n"))).To(MatchError(ContainSubstring("no data")))
+		Expect(validateHostConfig([]byte("data: [\n"))).To(MatchError(ContainSubstring("not valid YAML")))
+	})
+})
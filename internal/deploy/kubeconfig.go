@@ -0,0 +1,56 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/homedir"
+)
+
+// RESTConfig loads the client configuration of the given kubeconfig context (e.g.
+// "kind-konflux") from the kubeconfig file at kubeconfigPath, or ~/.kube/config when it
+// is empty. An empty kubeContext uses the current context, the cluster kubectl talks to.
+func RESTConfig(kubeconfigPath, kubeContext string) (*rest.Config, error) {
+	if kubeconfigPath == "" {
+		kubeconfigPath = filepath.Join(homedir.HomeDir(), ".kube", "config")
+	}
+
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfigPath},
+		&clientcmd.ConfigOverrides{CurrentContext: kubeContext},
+	).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build kubeconfig: %w", err)
+	}
+	return config, nil
+}
+
+// KubectlCommand returns a "kubectl <args>" command using the kubeconfig at
+// kubeconfigPath, or kubectl's default when it is empty, and its kubeContext context,
+// or the current context when it is empty. It is shared by every package running
+// kubectl, so all of them select the cluster the same way.
+func KubectlCommand(ctx context.Context, kubeconfigPath, kubeContext string, args ...string) *exec.Cmd {
+	if kubeconfigPath != "" {
+		args = append(args, "--kubeconfig", kubeconfigPath)
+	}
+	if kubeContext != "" {
+		args = append(args, "--context", kubeContext)
+	}
+	return exec.CommandContext(ctx, "kubectl", args...)
+}
+
+// kubectl returns a "kubectl <args>" command using the configured kubeconfig and the
+// context of the configured cluster.
+func (m *Manager) kubectl(ctx context.Context, args ...string) *exec.Cmd {
+	return KubectlCommand(ctx, m.config.KubeconfigPath, m.config.GetKubeContext(), args...)
+}
+
+// kubectl returns a "kubectl <args>" command using the configured kubeconfig and the
+// context of the configured cluster.
+func (m *MinimalDeployer) kubectl(ctx context.Context, args ...string) *exec.Cmd {
+	return KubectlCommand(ctx, m.config.KubeconfigPath, m.config.GetKubeContext(), args...)
+}
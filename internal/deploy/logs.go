@@ -0,0 +1,132 @@
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// MPC components whose logs ComponentLogs returns.
+const (
+	ComponentController = "controller"
+	ComponentOTP        = "otp"
+)
+
+// DefaultLogTailLines is how many lines per container ComponentLogs returns when
+// no tail is given.
+const DefaultLogTailLines = 100
+
+// ErrUnknownComponent is returned by ComponentLogs for a component other than
+// ComponentController and ComponentOTP.
+var ErrUnknownComponent = errors.New("unknown MPC component, expected controller or otp")
+
+// componentDeployments maps each component to its deployment in the MPC namespace.
+var componentDeployments = map[string]string{
+	ComponentController: mpcDeploymentName,
+	ComponentOTP:        otpDeploymentName,
+}
+
+// LogReader reads the logs of the MPC components' pods through the Kubernetes API.
+type LogReader struct {
+	client kubernetes.Interface
+}
+
+// NewLogReader creates a LogReader for the cluster of the kubeContext context in the
+// kubeconfig at kubeconfigPath, or ~/.kube/config when it is empty.
+func NewLogReader(kubeconfigPath, kubeContext string) (*LogReader, error) {
+	restConfig, err := RESTConfig(kubeconfigPath, kubeContext)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	return &LogReader{client: client}, nil
+}
+
+// ComponentLogs returns the last tail lines of each container of the component's
+// pods, ComponentController or ComponentOTP. The pods are those matched by the
+// deployment's selector, so every replica is included, in name order. Each pod's
+// (or, for pods with several containers, each container's) logs are preceded by a
+// "==> pod/<name> <==" header. A non-positive tail means DefaultLogTailLines.
+func (r *LogReader) ComponentLogs(ctx context.Context, component string, tail int64) (string, error) {
+	deploymentName, ok := componentDeployments[component]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownComponent, component)
+	}
+	if tail <= 0 {
+		tail = DefaultLogTailLines
+	}
+
+	deployment, err := r.client.AppsV1().Deployments(mpcNamespace).Get(ctx, deploymentName, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get deployment %s: %w", deploymentName, err)
+	}
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return "", fmt.Errorf("invalid selector of deployment %s: %w", deploymentName, err)
+	}
+
+	pods, err := r.client.CoreV1().Pods(mpcNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list pods of deployment %s: %w", deploymentName, err)
+	}
+	if len(pods.Items) == 0 {
+		return "", fmt.Errorf("deployment %s has no pods", deploymentName)
+	}
+	sort.Slice(pods.Items, func(i, j int) bool { return pods.Items[i].Name < pods.Items[j].Name })
+
+	var logs strings.Builder
+	for _, pod := range pods.Items {
+		for _, container := range pod.Spec.Containers {
+			header := "pod/" + pod.Name
+			if len(pod.Spec.Containers) > 1 {
+				header += "/" + container.Name
+			}
+			_, _ = fmt.Fprintf(&logs, "==> %s <==\n", header)
+
+			output, err := r.containerLogs(ctx, pod.Name, container.Name, tail)
+			if err != nil {
+				// A pod that never started has no logs; report it and keep going
+				_, _ = fmt.Fprintf(&logs, "(no logs: %v)\n", err)
+				continue
+			}
+			logs.WriteString(output)
+			if output != "" && !strings.HasSuffix(output, "\n") {
+				logs.WriteString("\n")
+			}
+		}
+	}
+	return logs.String(), nil
+}
+
+// containerLogs returns the last tail lines logged by a container of a pod in the
+// MPC namespace.
+func (r *LogReader) containerLogs(ctx context.Context, pod, container string, tail int64) (string, error) {
+	stream, err := r.client.CoreV1().Pods(mpcNamespace).GetLogs(pod, &corev1.PodLogOptions{
+		Container: container,
+		TailLines: &tail,
+	}).Stream(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = stream.Close() }()
+
+	output, err := io.ReadAll(stream)
+	if err != nil {
+		return "", err
+	}
+	return string(output), nil
+}
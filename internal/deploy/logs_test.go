@@ -0,0 +1,115 @@
+package deploy
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetesFake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// Note: Test suite entry point is in manager_test.go
+
+var _ = Describe("LogReader", func() {
+	var (
+		ctx       context.Context
+		fakeK8sCS *kubernetesFake.Clientset
+		reader    *LogReader
+	)
+
+	deployment := func(name, app string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: mpcNamespace},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": app}},
+			},
+		}
+	}
+
+	pod := func(name, app string, containers ...string) *corev1.Pod {
+		p := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: mpcNamespace,
+			Labels:    map[string]string{"app": app},
+		}}
+		for _, container := range containers {
+			p.Spec.Containers = append(p.Spec.Containers, corev1.Container{Name: container})
+		}
+		return p
+	}
+
+	// logRequests returns the container and tail of each log request, in order; the
+	// fake clientset does not record the pod name, the headers in the output show it
+	type logRequest struct {
+		Container string
+		Tail      int64
+	}
+	logRequests := func() []logRequest {
+		requests := []logRequest{}
+		for _, action := range fakeK8sCS.Actions() {
+			if action.GetSubresource() != "log" {
+				continue
+			}
+			opts := action.(k8stesting.GenericAction).GetValue().(*corev1.PodLogOptions)
+			requests = append(requests, logRequest{
+				Container: opts.Container,
+				Tail:      *opts.TailLines,
+			})
+		}
+		return requests
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeK8sCS = kubernetesFake.NewSimpleClientset(
+			deployment(mpcDeploymentName, "controller"),
+			deployment(otpDeploymentName, "otp"),
+			pod("multi-platform-controller-b", "controller", "manager"),
+			pod("multi-platform-controller-a", "controller", "manager"),
+			pod("multi-platform-otp-server-a", "otp", "otp", "proxy"),
+		)
+		reader = &LogReader{client: fakeK8sCS}
+	})
+
+	It("should return the logs of every controller replica with pod-name headers", func() {
+		logs, err := reader.ComponentLogs(ctx, ComponentController, 50)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(logs).To(Equal("==> pod/multi-platform-controller-a <==\nfake logs\n" +
+			"==> pod/multi-platform-controller-b <==\nfake logs\n"))
+		Expect(logRequests()).To(Equal([]logRequest{
+			{Container: "manager", Tail: 50},
+			{Container: "manager", Tail: 50},
+		}))
+	})
+
+	It("should select the OTP server's pod and name each of its containers", func() {
+		logs, err := reader.ComponentLogs(ctx, ComponentOTP, 0)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(logs).To(Equal("==> pod/multi-platform-otp-server-a/otp <==\nfake logs\n" +
+			"==> pod/multi-platform-otp-server-a/proxy <==\nfake logs\n"))
+		Expect(logRequests()).To(Equal([]logRequest{
+			{Container: "otp", Tail: DefaultLogTailLines},
+			{Container: "proxy", Tail: DefaultLogTailLines},
+		}))
+	})
+
+	It("should reject unknown components", func() {
+		_, err := reader.ComponentLogs(ctx, "operator", 10)
+		Expect(errors.Is(err, ErrUnknownComponent)).To(BeTrue())
+		Expect(fakeK8sCS.Actions()).To(BeEmpty())
+	})
+
+	It("should fail when the deployment has no pods", func() {
+		Expect(fakeK8sCS.CoreV1().Pods(mpcNamespace).Delete(ctx, "multi-platform-otp-server-a", metav1.DeleteOptions{})).To(Succeed())
+
+		_, err := reader.ComponentLogs(ctx, ComponentOTP, 10)
+		Expect(err).To(MatchError(ContainSubstring("deployment multi-platform-otp-server has no pods")))
+	})
+})
@@ -5,7 +5,7 @@
 //   - MPC deployment configuration and image patching
 //   - OTP (One-Time Password) server deployment
 //   - Host configuration (host-config.yaml) for build platforms
-//   - AWS secrets deployment for cloud-based builds
+//   - AWS and IBM Cloud secrets deployment for cloud-based builds
 //   - Minimal MPC stack (Tekton Pipelines + MPC Operator + OTP Server)
 //
 // The Manager coordinates kubectl commands to apply manifests, wait for deployments,
@@ -13,15 +13,22 @@
 package deploy
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
 	"time"
 
 	"github.com/meyrevived/mpc-dev-env/internal/config"
+	"github.com/meyrevived/mpc-dev-env/internal/lines"
 	"github.com/meyrevived/mpc-dev-env/internal/logger"
 )
 
@@ -33,6 +40,11 @@ const (
 	hostConfigName      = "host-config"
 	deployTimeout       = 10 * time.Minute
 	deploymentWaitRetry = 60 // 2 minutes with 2 second intervals
+
+	// diagnosticsTailLines bounds each section of the diagnostics attached to a
+	// readiness timeout, keeping the error readable in logs and API responses
+	diagnosticsTailLines = 20
+	diagnosticsTimeout   = 30 * time.Second
 )
 
 // min returns the minimum of two integers
@@ -43,12 +55,240 @@ func min(a, b int) int {
 	return b
 }
 
+// minimalHostConfigTemplate is the built-in minimal host-config with 4 AWS platforms,
+// 1 s390x, and 1 ppc64le host, rendered with config.HostConfigSettings. With IBMEnabled
+// it also has an s390x platform on IBM Z and a ppc64le platform on IBM Power, each only
+// once its image (and, for IBM Power, workspace) is set.
+const minimalHostConfigTemplate = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  labels:
+    build.appstudio.redhat.com/multi-platform-config: hosts
+  name: host-config
+  namespace: multi-platform-controller
+data:
+  local-platforms: "\
+    linux/x86_64,\
+    local,\
+    localhost,\
+    "
+  dynamic-platforms: "\
+    linux/arm64,\
+    linux/amd64,\
+    linux-mlarge/arm64,\
+    linux-mlarge/amd64{{ if .IBMS390XEnabled }},\
+    linux-ibm/s390x{{ end }}{{ if .IBMPPC64LEEnabled }},\
+    linux-ibm/ppc64le{{ end }}\
+    "
+  instance-tag: "mpc-dev-env"
+
+  # ARM64 - Basic ({{ .ARM64InstanceType }})
+  dynamic.linux-arm64.type: "aws"
+  dynamic.linux-arm64.region: "{{ .Region }}"
+  dynamic.linux-arm64.ami: "{{ .ARM64AMI }}"
+  dynamic.linux-arm64.instance-type: "{{ .ARM64InstanceType }}"
+  dynamic.linux-arm64.instance-tag: "dev-arm64"
+  dynamic.linux-arm64.key-name: "mpc-dev-env-us"
+  dynamic.linux-arm64.aws-secret: "aws-account"
+  dynamic.linux-arm64.ssh-secret: "aws-ssh-key"
+  dynamic.linux-arm64.security-group-id: "sg-default"
+  dynamic.linux-arm64.max-instances: "{{ .MaxInstances }}"
+  dynamic.linux-arm64.subnet-id: "subnet-default"
+  dynamic.linux-arm64.allocation-timeout: "600"
+
+  # ARM64 - Medium ({{ .ARM64InstanceType }})
+  dynamic.linux-mlarge-arm64.type: "aws"
+  dynamic.linux-mlarge-arm64.region: "{{ .Region }}"
+  dynamic.linux-mlarge-arm64.ami: "{{ .ARM64AMI }}"
+  dynamic.linux-mlarge-arm64.instance-type: "{{ .ARM64InstanceType }}"
+  dynamic.linux-mlarge-arm64.instance-tag: "dev-arm64-mlarge"
+  dynamic.linux-mlarge-arm64.key-name: "mpc-dev-env-us"
+  dynamic.linux-mlarge-arm64.aws-secret: "aws-account"
+  dynamic.linux-mlarge-arm64.ssh-secret: "aws-ssh-key"
+  dynamic.linux-mlarge-arm64.security-group-id: "sg-default"
+  dynamic.linux-mlarge-arm64.max-instances: "{{ .MaxInstances }}"
+  dynamic.linux-mlarge-arm64.subnet-id: "subnet-default"
+  dynamic.linux-mlarge-arm64.allocation-timeout: "600"
+
+  # AMD64 - Basic ({{ .AMD64InstanceType }})
+  dynamic.linux-amd64.type: "aws"
+  dynamic.linux-amd64.region: "{{ .Region }}"
+  dynamic.linux-amd64.ami: "{{ .AMD64AMI }}"
+  dynamic.linux-amd64.instance-type: "{{ .AMD64InstanceType }}"
+  dynamic.linux-amd64.instance-tag: "dev-amd64"
+  dynamic.linux-amd64.key-name: "mpc-dev-env-us"
+  dynamic.linux-amd64.aws-secret: "aws-account"
+  dynamic.linux-amd64.ssh-secret: "aws-ssh-key"
+  dynamic.linux-amd64.security-group-id: "sg-default"
+  dynamic.linux-amd64.max-instances: "{{ .MaxInstances }}"
+  dynamic.linux-amd64.subnet-id: "subnet-default"
+  dynamic.linux-amd64.allocation-timeout: "600"
+
+  # AMD64 - Medium ({{ .AMD64InstanceType }})
+  dynamic.linux-mlarge-amd64.type: "aws"
+  dynamic.linux-mlarge-amd64.region: "{{ .Region }}"
+  dynamic.linux-mlarge-amd64.ami: "{{ .AMD64AMI }}"
+  dynamic.linux-mlarge-amd64.instance-type: "{{ .AMD64InstanceType }}"
+  dynamic.linux-mlarge-amd64.instance-tag: "dev-amd64-mlarge"
+  dynamic.linux-mlarge-amd64.key-name: "mpc-dev-env-us"
+  dynamic.linux-mlarge-amd64.aws-secret: "aws-account"
+  dynamic.linux-mlarge-amd64.ssh-secret: "aws-ssh-key"
+  dynamic.linux-mlarge-amd64.security-group-id: "sg-default"
+  dynamic.linux-mlarge-amd64.max-instances: "{{ .MaxInstances }}"
+  dynamic.linux-mlarge-amd64.subnet-id: "subnet-default"
+  dynamic.linux-mlarge-amd64.allocation-timeout: "600"
+{{- if .IBMS390XEnabled }}
+
+  # S390X - IBM Z ({{ .IBMZone }})
+  dynamic.linux-ibm-s390x.type: "ibmz"
+  dynamic.linux-ibm-s390x.region: "{{ .IBMZone }}"
+  dynamic.linux-ibm-s390x.url: "https://{{ .IBMRegion }}.iaas.cloud.ibm.com/v1"
+  dynamic.linux-ibm-s390x.image-id: "{{ .IBMS390XImage }}"
+  dynamic.linux-ibm-s390x.profile: "bz2-2x8"
+  dynamic.linux-ibm-s390x.vpc: "{{ .IBMRegion }}-default-vpc"
+  dynamic.linux-ibm-s390x.subnet: "{{ .IBMZone }}-default-subnet"
+  dynamic.linux-ibm-s390x.key: "{{ .IBMKey }}"
+  dynamic.linux-ibm-s390x.secret: "ibmcloud-api-key"
+  dynamic.linux-ibm-s390x.ssh-secret: "ibm-s390x-ssh-key"
+  dynamic.linux-ibm-s390x.max-instances: "{{ .MaxInstances }}"
+  dynamic.linux-ibm-s390x.allocation-timeout: "1200"
+{{- end }}
+{{- if .IBMPPC64LEEnabled }}
+
+  # PPC64LE - IBM Power ({{ .IBMRegion }})
+  dynamic.linux-ibm-ppc64le.type: "ibmp"
+  dynamic.linux-ibm-ppc64le.url: "https://{{ .IBMRegion }}.power-iaas.cloud.ibm.com"
+  dynamic.linux-ibm-ppc64le.crn: "{{ .IBMPowerWorkspace }}"
+  dynamic.linux-ibm-ppc64le.image: "{{ .IBMPPC64LEImage }}"
+  dynamic.linux-ibm-ppc64le.network: "mpc-dev-env-network"
+  dynamic.linux-ibm-ppc64le.system: "e980"
+  dynamic.linux-ibm-ppc64le.cores: "0.25"
+  dynamic.linux-ibm-ppc64le.memory: "2"
+  dynamic.linux-ibm-ppc64le.key: "{{ .IBMKey }}"
+  dynamic.linux-ibm-ppc64le.secret: "ibmcloud-api-key"
+  dynamic.linux-ibm-ppc64le.ssh-secret: "ibm-ppc64le-ssh-key"
+  dynamic.linux-ibm-ppc64le.max-instances: "{{ .MaxInstances }}"
+  dynamic.linux-ibm-ppc64le.allocation-timeout: "1200"
+{{- end }}
+
+  # S390X - Static host for development
+  host.s390x-dev.address: "127.0.0.1"
+  host.s390x-dev.platform: "linux/s390x"
+  host.s390x-dev.user: "root"
+  host.s390x-dev.secret: "ibm-s390x-ssh-key"
+  host.s390x-dev.concurrency: "4"
+
+  # PPC64LE - Static host for development
+  host.ppc64le-dev.address: "127.0.0.1"
+  host.ppc64le-dev.platform: "linux/ppc64le"
+  host.ppc64le-dev.user: "root"
+  host.ppc64le-dev.secret: "ibm-ppc64le-ssh-key"
+  host.ppc64le-dev.concurrency: "4"
+`
+
 // Manager handles deployment operations for the multi-platform-controller.
 //
 // It uses kubectl commands to interact with the Kubernetes cluster and maintains
-// configuration for repository paths and deployment settings.
+// configuration for repository paths and deployment settings. With MPC_DEPLOY_BACKEND
+// set to "client", the MPC deployments are waited for, patched, restarted and verified
+// through the Kubernetes API instead (see deploymentClient).
 type Manager struct {
 	config *config.Config
+
+	// sourceGitHash selects the revision-tagged images produced by the builder.
+	// When empty, the configured image references are deployed as-is.
+	sourceGitHash string
+
+	// ibmEnabled adds the IBM Cloud platforms to a generated host-config
+	// (see DeployOptions.IBMEnabled).
+	ibmEnabled bool
+
+	// dryRun sends every mutating kubectl command with --dry-run=server and skips
+	// the steps that only make sense after real changes (waits, restart, verify).
+	dryRun bool
+
+	// output optionally receives a copy of the kubectl output of each step.
+	output io.Writer
+
+	// previousImages are the images the deployments ran before Deploy last patched
+	// them, and the images Rollback restores. Nil when nothing was recorded.
+	previousImages *Images
+
+	// onStep optionally receives each step Deploy starts (see DeployOptions.OnStep).
+	onStep func(step DeployStep)
+
+	// deployments reads and changes the MPC deployments through the configured
+	// backend; created on first use by deploymentClient.
+	deployments deploymentClient
+
+	// retryDelay is the wait before runKubectl's first retry; kubectlRetryDelay when zero.
+	retryDelay time.Duration
+}
+
+// Images are the container images of the MPC controller and OTP server deployments.
+type Images struct {
+	Controller string
+	OTP        string
+}
+
+// ErrNoPreviousImages is returned by Rollback when no previous images are recorded.
+var ErrNoPreviousImages = errors.New("no previous MPC images recorded to roll back to")
+
+// ErrMutablePreviousImage is returned by Rollback when a previous image is referenced
+// by a mutable tag such as :latest, which a rebuild may since have moved to the very
+// image being rolled back from.
+var ErrMutablePreviousImage = errors.New("previous MPC image uses a mutable tag")
+
+// CheckRollbackTarget returns an error wrapping ErrMutablePreviousImage if any of the
+// images is referenced by a mutable tag: :latest, or no tag at all. Images referenced
+// by digest or by another tag, such as the revision tags of BuildMPCImage, pass.
+func CheckRollbackTarget(images Images) error {
+	for _, image := range []string{images.Controller, images.OTP} {
+		if image != "" && mutableImage(image) {
+			return fmt.Errorf("%w: %s may now refer to a rebuilt image; deploy revision-tagged images, "+
+				"e.g. with POST /api/mpc/rebuild-and-redeploy, to be able to roll back", ErrMutablePreviousImage, image)
+		}
+	}
+	return nil
+}
+
+// mutableImage reports whether image is referenced by the latest tag, explicitly or
+// by having no tag, rather than by a digest or another tag.
+func mutableImage(image string) bool {
+	if strings.Contains(image, "@") {
+		return false
+	}
+	name := image[strings.LastIndex(image, "/")+1:]
+	_, tag, _ := strings.Cut(name, ":")
+	return tag == "" || tag == "latest"
+}
+
+// DeployOptions holds optional settings for DeployMPC.
+type DeployOptions struct {
+	// SourceGitHash is the hash returned by build.BuildMPCImage. When set, the
+	// deployments are patched with the images tagged for that commit instead of
+	// the configured references.
+	SourceGitHash string
+
+	// DryRun validates every change against the API server (kubectl --dry-run=server)
+	// without persisting anything.
+	DryRun bool
+
+	// IBMEnabled adds the IBM Cloud dynamic platforms to the host-config when it is
+	// generated, for environments whose IBM secrets have been deployed.
+	IBMEnabled bool
+
+	// Output, when set, receives a copy of the kubectl output of each deployment step.
+	Output io.Writer
+
+	// OnPreviousImages, when set, is called after a successful deployment that replaced
+	// other images, with the images the deployments ran before, e.g. so they can be
+	// restored later with Manager.Rollback.
+	OnPreviousImages func(previous Images)
+
+	// OnStep, when set, is called as each deployment step starts, e.g. to report the
+	// deployment's progress. It runs synchronously on the deploying goroutine.
+	OnStep func(step DeployStep)
 }
 
 // NewManager creates a new deployment manager instance.
@@ -72,55 +312,161 @@ func NewManager(cfg *config.Config) *Manager {
 //  6. Verifies that the correct images are running
 //
 // This is the primary entry point for MPC deployments, called by API handlers.
-func DeployMPC(ctx context.Context, cfg *config.Config) error {
+// See DeployOptions for deploying revision-tagged images and dry runs.
+func DeployMPC(ctx context.Context, cfg *config.Config, opts DeployOptions) error {
 	manager := NewManager(cfg)
-	return manager.Deploy(ctx)
+	manager.sourceGitHash = opts.SourceGitHash
+	manager.dryRun = opts.DryRun
+	manager.ibmEnabled = opts.IBMEnabled
+	manager.output = opts.Output
+	manager.onStep = opts.OnStep
+	if err := manager.Deploy(ctx); err != nil {
+		return err
+	}
+
+	if previous := manager.PreviousImages(); previous != nil && opts.OnPreviousImages != nil {
+		opts.OnPreviousImages(*previous)
+	}
+	return nil
+}
+
+// PreviousImages returns the images the deployments ran before Deploy last patched
+// them, or nil if Deploy has not replaced any images.
+func (m *Manager) PreviousImages() *Images {
+	return m.previousImages
+}
+
+// SetPreviousImages sets the images Rollback restores, e.g. ones recorded through
+// DeployOptions.OnPreviousImages by an earlier deployment.
+func (m *Manager) SetPreviousImages(images Images) {
+	m.previousImages = &images
+}
+
+// SetOutput makes the manager copy the kubectl output of each step to w, in addition
+// to the daemon's stdout and stderr. A nil w disables the copy.
+func (m *Manager) SetOutput(w io.Writer) {
+	m.output = w
+}
+
+// stdout returns the stdout of a kubectl step: the daemon's stdout plus the output writer.
+func (m *Manager) stdout() io.Writer {
+	if m.output == nil {
+		return os.Stdout
+	}
+	return io.MultiWriter(os.Stdout, m.output)
+}
+
+// stderr returns the stderr of a kubectl step: the daemon's stderr plus the output writer.
+func (m *Manager) stderr() io.Writer {
+	if m.output == nil {
+		return os.Stderr
+	}
+	return io.MultiWriter(os.Stderr, m.output)
+}
+
+// kubectlArgs returns args for a mutating kubectl command, with --dry-run=server
+// appended in dry-run mode.
+func (m *Manager) kubectlArgs(args ...string) []string {
+	if m.dryRun {
+		return append(args, "--dry-run=server")
+	}
+	return args
+}
+
+// controllerImage returns the controller image reference to deploy.
+func (m *Manager) controllerImage() string {
+	return config.RevisionImage(m.config.GetControllerImage(), m.sourceGitHash)
+}
+
+// otpImage returns the OTP server image reference to deploy.
+func (m *Manager) otpImage() string {
+	return config.RevisionImage(m.config.GetOTPImage(), m.sourceGitHash)
+}
+
+// imagePullPolicy returns the pull policy for the deployed images: "Never" for images
+// loaded into the Kind cluster, so Kubernetes never tries to pull them, and "Always"
+// for images pushed to the configured registry, so a rebuilt image under the same tag
+// is picked up on restart.
+func (m *Manager) imagePullPolicy() string {
+	if m.config.GetRegistryURL() != "" {
+		return "Always"
+	}
+	return "Never"
 }
 
 // Deploy executes the full deployment workflow.
 //
 // This is the internal implementation of the deployment sequence, broken down into
-// distinct steps for clarity and error handling. Each step is logged and errors are
-// wrapped with context about which step failed.
+// distinct steps (see the Step* constants) for clarity and error handling. Each step
+// is logged, reported to the step observer as it starts, and errors are wrapped with
+// context about which step failed. Without a reachable cluster it fails
+// up front with ErrClusterNotRunning.
+//
+// In dry-run mode the manifests, ConfigMap, and patches are only validated by the
+// API server. Waiting for the deployments, restarting them, and verifying their
+// images are skipped because a dry run never changes them.
 func (m *Manager) Deploy(ctx context.Context) error {
-	logger.Info("starting MPC deployment")
+	logger.Info("starting MPC deployment", "dryRun", m.dryRun)
+
+	if err := CheckClusterReachable(ctx, m.config.KubeconfigPath, m.config.GetKubeContext()); err != nil {
+		return err
+	}
 
 	// Step 1: Deploy host-config ConfigMap
+	m.startStep(StepHostConfig)
 	if err := m.deployHostConfig(ctx); err != nil {
 		return fmt.Errorf("failed to deploy host-config: %w", err)
 	}
 
 	// Step 2: Apply MPC deployment manifests
+	m.startStep(StepApplyManifests)
 	if err := m.applyMPCManifests(ctx); err != nil {
 		return fmt.Errorf("failed to apply MPC manifests: %w", err)
 	}
 
-	// Step 3: Wait for MPC deployment to be ready
-	if err := m.waitForMPCDeployment(ctx); err != nil {
-		return fmt.Errorf("MPC deployment not ready: %w", err)
+	// Steps 3-4: Wait for the MPC and OTP deployments to be ready
+	if !m.dryRun {
+		m.startStep(StepWaitController)
+		if err := m.waitForMPCDeployment(ctx); err != nil {
+			return fmt.Errorf("MPC deployment not ready: %w", err)
+		}
+
+		m.startStep(StepWaitOTP)
+		if err := m.waitForOTPDeployment(ctx); err != nil {
+			return fmt.Errorf("OTP deployment not ready: %w", err)
+		}
 	}
 
-	// Step 4: Wait for OTP deployment to be ready
-	if err := m.waitForOTPDeployment(ctx); err != nil {
-		return fmt.Errorf("OTP deployment not ready: %w", err)
+	// Record the running images before they are replaced, so they can be rolled back to
+	if !m.dryRun {
+		m.recordPreviousImages(ctx)
 	}
 
 	// Step 5: Patch MPC deployment with custom images
+	m.startStep(StepPatchController)
 	if err := m.patchMPCDeployment(ctx); err != nil {
 		return fmt.Errorf("failed to patch MPC deployment: %w", err)
 	}
 
 	// Step 6: Patch OTP deployment with custom images
+	m.startStep(StepPatchOTP)
 	if err := m.patchOTPDeployment(ctx); err != nil {
 		return fmt.Errorf("failed to patch OTP deployment: %w", err)
 	}
 
+	if m.dryRun {
+		logger.Info("MPC deployment dry run completed successfully, skipping restart and verification")
+		return nil
+	}
+
 	// Step 7: Restart deployments to apply changes
+	m.startStep(StepRestart)
 	if err := m.restartDeployments(ctx); err != nil {
 		return fmt.Errorf("failed to restart deployments: %w", err)
 	}
 
 	// Step 8: Verify deployment images
+	m.startStep(StepVerify)
 	if err := m.verifyDeploymentImages(ctx); err != nil {
 		return fmt.Errorf("image verification failed: %w", err)
 	}
@@ -129,6 +475,164 @@ func (m *Manager) Deploy(ctx context.Context) error {
 	return nil
 }
 
+// Rollback patches the MPC deployments back to the previous images (see
+// PreviousImages and SetPreviousImages), restarts them, and verifies their images.
+// It returns ErrNoPreviousImages when none are recorded, and ErrClusterNotRunning
+// without a reachable cluster.
+//
+// Images referenced by a mutable tag are refused with ErrMutablePreviousImage (see
+// CheckRollbackTarget), as the tag may now point at the image being rolled back from.
+//
+// The images are patched with imagePullPolicy IfNotPresent, as the previous images may
+// have been loaded into Kind, pushed to a registry, or come from the original manifests.
+func (m *Manager) Rollback(ctx context.Context) error {
+	if m.previousImages == nil || m.previousImages.Controller == "" {
+		return ErrNoPreviousImages
+	}
+	previous := *m.previousImages
+	if err := CheckRollbackTarget(previous); err != nil {
+		return err
+	}
+	logger.Info("rolling back MPC deployment", "controllerImage", previous.Controller, "otpImage", previous.OTP)
+
+	if err := CheckClusterReachable(ctx, m.config.KubeconfigPath, m.config.GetKubeContext()); err != nil {
+		return err
+	}
+
+	if err := m.patchDeploymentImage(ctx, mpcDeploymentName, previous.Controller, "IfNotPresent"); err != nil {
+		return fmt.Errorf("failed to patch controller deployment: %w", err)
+	}
+	if previous.OTP != "" {
+		if err := m.patchDeploymentImage(ctx, otpDeploymentName, previous.OTP, "IfNotPresent"); err != nil {
+			return fmt.Errorf("failed to patch OTP deployment: %w", err)
+		}
+	}
+
+	if err := m.restartDeployments(ctx); err != nil {
+		return fmt.Errorf("failed to restart deployments: %w", err)
+	}
+
+	if err := m.verifyImages(ctx, previous); err != nil {
+		return fmt.Errorf("image verification failed: %w", err)
+	}
+
+	logger.Info("MPC rollback completed successfully")
+	return nil
+}
+
+// Restart restarts the MPC deployments without changing their images, e.g. so the
+// controller picks up a changed ConfigMap, and waits for them to be ready. component
+// selects ComponentController or ComponentOTP; empty restarts both. It returns
+// ErrUnknownComponent for any other component and ErrClusterNotRunning if the cluster
+// is not reachable.
+func (m *Manager) Restart(ctx context.Context, component string) error {
+	deployments := []string{mpcDeploymentName, otpDeploymentName}
+	if component != "" {
+		deployment, ok := componentDeployments[component]
+		if !ok {
+			return fmt.Errorf("%w: %q", ErrUnknownComponent, component)
+		}
+		deployments = []string{deployment}
+	}
+
+	if err := CheckClusterReachable(ctx, m.config.KubeconfigPath, m.config.GetKubeContext()); err != nil {
+		return err
+	}
+
+	if err := m.restartNamedDeployments(ctx, deployments...); err != nil {
+		return err
+	}
+
+	logger.Info("MPC restart completed successfully", "deployments", deployments)
+	return nil
+}
+
+// recordPreviousImages records the images the deployments currently run as
+// previousImages, unless they are the images about to be deployed. A failure to
+// read them only costs the rollback target, so it never fails the deployment.
+func (m *Manager) recordPreviousImages(ctx context.Context) {
+	controllerImage, err := m.deploymentImage(ctx, mpcDeploymentName)
+	if err != nil {
+		logger.Info("could not read the running controller image, no rollback target recorded", "error", err.Error())
+		return
+	}
+	if controllerImage == "" || controllerImage == m.controllerImage() {
+		return
+	}
+
+	otpImage, err := m.deploymentImage(ctx, otpDeploymentName)
+	if err != nil {
+		logger.Info("could not read the running OTP image", "error", err.Error())
+	}
+
+	m.previousImages = &Images{Controller: controllerImage, OTP: otpImage}
+	logger.Info("recorded previous images for rollback", "controllerImage", controllerImage, "otpImage", otpImage)
+}
+
+// deploymentImage returns the image of the first container of a deployment in the
+// MPC namespace.
+func (m *Manager) deploymentImage(ctx context.Context, deployment string) (string, error) {
+	client, err := m.deploymentClient()
+	if err != nil {
+		return "", err
+	}
+	return client.image(ctx, deployment)
+}
+
+// Undeploy removes the MPC operator, the OTP server, and the host-config ConfigMap
+// from the cluster while leaving Tekton and the cluster itself running.
+//
+// The operator and OTP server are removed with `kubectl delete -k` on the same
+// kustomize directories used to deploy them. Resources that are already absent are
+// not treated as errors, so Undeploy can safely be run repeatedly.
+func (m *Manager) Undeploy(ctx context.Context) error {
+	logger.Info("starting MPC undeploy")
+
+	mpcRepoPath := m.config.GetMpcRepoPath()
+
+	// Step 1: Remove the OTP server, then the operator
+	for _, component := range []string{"otp", "operator"} {
+		dir := filepath.Join(mpcRepoPath, "deploy", component)
+		if _, err := os.Stat(dir); err != nil {
+			return fmt.Errorf("MPC %s deployment directory not found: %s", component, dir)
+		}
+
+		logger.Info("deleting manifests", "path", dir)
+		if err := m.kubectlDeleteIgnoreNotFound(ctx, "delete", "-k", dir, "--ignore-not-found"); err != nil {
+			return fmt.Errorf("failed to delete MPC %s manifests: %w", component, err)
+		}
+	}
+
+	// Step 2: Remove the host-config ConfigMap
+	if err := m.kubectlDeleteIgnoreNotFound(ctx, "delete", "configmap", hostConfigName, "-n", mpcNamespace, "--ignore-not-found"); err != nil {
+		return fmt.Errorf("failed to delete %s ConfigMap: %w", hostConfigName, err)
+	}
+
+	logger.Info("MPC undeploy completed successfully")
+	return nil
+}
+
+// kubectlDeleteIgnoreNotFound runs a kubectl delete command, treating NotFound errors
+// (e.g. a namespace already removed along with the operator) and kinds whose CRD is
+// no longer installed as success.
+func (m *Manager) kubectlDeleteIgnoreNotFound(ctx context.Context, args ...string) error {
+	cmd := m.kubectl(ctx, args...)
+	output, err := cmd.CombinedOutput()
+	if m.output != nil {
+		_, _ = m.output.Write(output)
+	}
+	if err == nil {
+		return nil
+	}
+
+	if strings.Contains(string(output), "NotFound") || strings.Contains(string(output), "no matches for kind") {
+		logger.Info("resources already absent", "command", strings.Join(args, " "))
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+}
+
 // generateMinimalHostConfig generates a minimal host-config.yaml for local development.
 //
 // This creates a ConfigMap with:
@@ -136,102 +640,38 @@ func (m *Manager) Deploy(ctx context.Context) error {
 //   - 3 local platforms (linux/x86_64, local, localhost)
 //   - 2 static hosts for testing (S390X and PPC64LE pointing to localhost)
 //
+// The region, AMIs, instance types and max-instances of the AWS platforms come from the
+// MPC_HOST_CONFIG_* settings (see config.HostConfigSettings). When a template file is
+// configured it is rendered with the same settings instead of minimalHostConfigTemplate.
+//
 // The generated config is written to the specified outputPath (typically temp/host-config.yaml).
 // This auto-generation allows developers to start testing immediately without manually creating
 // the configuration file.
 func (m *Manager) generateMinimalHostConfig(outputPath string) error {
-	// Minimal host-config with 4 AWS platforms, 1 s390x, and 1 ppc64le host
-	minimalConfig := `apiVersion: v1
-kind: ConfigMap
-metadata:
-  labels:
-    build.appstudio.redhat.com/multi-platform-config: hosts
-  name: host-config
-  namespace: multi-platform-controller
-data:
-  local-platforms: "\
-    linux/x86_64,\
-    local,\
-    localhost,\
-    "
-  dynamic-platforms: "\
-    linux/arm64,\
-    linux/amd64,\
-    linux-mlarge/arm64,\
-    linux-mlarge/amd64\
-    "
-  instance-tag: "mpc-dev-env"
-
-  # ARM64 - Basic (m6g.large)
-  dynamic.linux-arm64.type: "aws"
-  dynamic.linux-arm64.region: "us-east-1"
-  dynamic.linux-arm64.ami: "ami-03d8261904652a19c"
-  dynamic.linux-arm64.instance-type: "m6g.large"
-  dynamic.linux-arm64.instance-tag: "dev-arm64"
-  dynamic.linux-arm64.key-name: "mpc-dev-env-us"
-  dynamic.linux-arm64.aws-secret: "aws-account"
-  dynamic.linux-arm64.ssh-secret: "aws-ssh-key"
-  dynamic.linux-arm64.security-group-id: "sg-default"
-  dynamic.linux-arm64.max-instances: "10"
-  dynamic.linux-arm64.subnet-id: "subnet-default"
-  dynamic.linux-arm64.allocation-timeout: "600"
-
-  # ARM64 - Medium (m6g.large)
-  dynamic.linux-mlarge-arm64.type: "aws"
-  dynamic.linux-mlarge-arm64.region: "us-east-1"
-  dynamic.linux-mlarge-arm64.ami: "ami-03d8261904652a19c"
-  dynamic.linux-mlarge-arm64.instance-type: "m6g.large"
-  dynamic.linux-mlarge-arm64.instance-tag: "dev-arm64-mlarge"
-  dynamic.linux-mlarge-arm64.key-name: "mpc-dev-env-us"
-  dynamic.linux-mlarge-arm64.aws-secret: "aws-account"
-  dynamic.linux-mlarge-arm64.ssh-secret: "aws-ssh-key"
-  dynamic.linux-mlarge-arm64.security-group-id: "sg-default"
-  dynamic.linux-mlarge-arm64.max-instances: "10"
-  dynamic.linux-mlarge-arm64.subnet-id: "subnet-default"
-  dynamic.linux-mlarge-arm64.allocation-timeout: "600"
-
-  # AMD64 - Basic (m6a.large)
-  dynamic.linux-amd64.type: "aws"
-  dynamic.linux-amd64.region: "us-east-1"
-  dynamic.linux-amd64.ami: "ami-0c02fb55b1a47c3c8"
-  dynamic.linux-amd64.instance-type: "m6a.large"
-  dynamic.linux-amd64.instance-tag: "dev-amd64"
-  dynamic.linux-amd64.key-name: "mpc-dev-env-us"
-  dynamic.linux-amd64.aws-secret: "aws-account"
-  dynamic.linux-amd64.ssh-secret: "aws-ssh-key"
-  dynamic.linux-amd64.security-group-id: "sg-default"
-  dynamic.linux-amd64.max-instances: "10"
-  dynamic.linux-amd64.subnet-id: "subnet-default"
-  dynamic.linux-amd64.allocation-timeout: "600"
+	settings := m.config.GetHostConfigSettings()
+	settings.IBMEnabled = settings.IBMEnabled || m.ibmEnabled
+	for _, problem := range settings.MissingIBMSettings() {
+		logger.Info("incomplete IBM host-config settings", "problem", problem)
+	}
 
-  # AMD64 - Medium (m6a.large)
-  dynamic.linux-mlarge-amd64.type: "aws"
-  dynamic.linux-mlarge-amd64.region: "us-east-1"
-  dynamic.linux-mlarge-amd64.ami: "ami-0c02fb55b1a47c3c8"
-  dynamic.linux-mlarge-amd64.instance-type: "m6a.large"
-  dynamic.linux-mlarge-amd64.instance-tag: "dev-amd64-mlarge"
-  dynamic.linux-mlarge-amd64.key-name: "mpc-dev-env-us"
-  dynamic.linux-mlarge-amd64.aws-secret: "aws-account"
-  dynamic.linux-mlarge-amd64.ssh-secret: "aws-ssh-key"
-  dynamic.linux-mlarge-amd64.security-group-id: "sg-default"
-  dynamic.linux-mlarge-amd64.max-instances: "10"
-  dynamic.linux-mlarge-amd64.subnet-id: "subnet-default"
-  dynamic.linux-mlarge-amd64.allocation-timeout: "600"
+	templateText := minimalHostConfigTemplate
+	if settings.TemplatePath != "" {
+		data, err := os.ReadFile(settings.TemplatePath)
+		if err != nil {
+			return fmt.Errorf("failed to read host-config template: %w", err)
+		}
+		templateText = string(data)
+	}
 
-  # S390X - Static host for development
-  host.s390x-dev.address: "127.0.0.1"
-  host.s390x-dev.platform: "linux/s390x"
-  host.s390x-dev.user: "root"
-  host.s390x-dev.secret: "ibm-s390x-ssh-key"
-  host.s390x-dev.concurrency: "4"
+	tmpl, err := template.New("host-config").Parse(templateText)
+	if err != nil {
+		return fmt.Errorf("failed to parse host-config template: %w", err)
+	}
 
-  # PPC64LE - Static host for development
-  host.ppc64le-dev.address: "127.0.0.1"
-  host.ppc64le-dev.platform: "linux/ppc64le"
-  host.ppc64le-dev.user: "root"
-  host.ppc64le-dev.secret: "ibm-ppc64le-ssh-key"
-  host.ppc64le-dev.concurrency: "4"
-`
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, settings); err != nil {
+		return fmt.Errorf("failed to render host-config template: %w", err)
+	}
 
 	// Ensure the temp directory exists
 	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
@@ -239,11 +679,12 @@ data:
 	}
 
 	// Write the minimal config to file
-	if err := os.WriteFile(outputPath, []byte(minimalConfig), 0644); err != nil {
+	if err := os.WriteFile(outputPath, rendered.Bytes(), 0644); err != nil {
 		return fmt.Errorf("failed to write host-config file: %w", err)
 	}
 
-	logger.Info("generated minimal host-config.yaml", "path", outputPath)
+	logger.Info("generated minimal host-config.yaml", "path", outputPath, "region", settings.Region,
+		"ibm", settings.IBMEnabled)
 	return nil
 }
 
@@ -288,26 +729,41 @@ func (m *Manager) deployHostConfig(ctx context.Context) error {
 		logger.Info("minimal host-config.yaml generated successfully")
 	}
 
+	// Catch malformed or incomplete platform settings before MPC reads them
+	content, err := os.ReadFile(hostConfigPath)
+	if err != nil {
+		return fmt.Errorf("failed to read host-config.yaml: %w", err)
+	}
+	if err := validateHostConfig(content); err != nil {
+		return fmt.Errorf("invalid host-config.yaml %s: %w", hostConfigPath, err)
+	}
+
 	// Check if ConfigMap already exists
-	checkCmd := exec.CommandContext(ctx, "kubectl", "get", "configmap", hostConfigName,
-		"-n", mpcNamespace)
-	if err := checkCmd.Run(); err == nil {
+	err = m.runKubectl(ctx, func() *exec.Cmd {
+		return m.kubectl(ctx, "get", "configmap", hostConfigName,
+			"-n", mpcNamespace)
+	})
+	if err == nil {
 		// ConfigMap exists, delete it first
 		logger.Info("ConfigMap host-config already exists, replacing")
-		deleteCmd := exec.CommandContext(ctx, "kubectl", "delete", "configmap", hostConfigName,
-			"-n", mpcNamespace)
-		if err := deleteCmd.Run(); err != nil {
+		err := m.runKubectl(ctx, func() *exec.Cmd {
+			return m.kubectl(ctx, m.kubectlArgs("delete", "configmap", hostConfigName,
+				"-n", mpcNamespace)...)
+		})
+		if err != nil {
 			logger.Error(err, "failed to delete existing ConfigMap")
 		}
 	}
 
 	// Apply the ConfigMap
-	applyCmd := exec.CommandContext(ctx, "kubectl", "apply", "-f", hostConfigPath,
-		"-n", mpcNamespace)
-	applyCmd.Stdout = os.Stdout
-	applyCmd.Stderr = os.Stderr
-
-	if err := applyCmd.Run(); err != nil {
+	err = m.runKubectl(ctx, func() *exec.Cmd {
+		applyCmd := m.kubectl(ctx, m.kubectlArgs("apply", "-f", hostConfigPath,
+			"-n", mpcNamespace)...)
+		applyCmd.Stdout = m.stdout()
+		applyCmd.Stderr = m.stderr()
+		return applyCmd
+	})
+	if err != nil {
 		return fmt.Errorf("failed to apply host-config ConfigMap: %w", err)
 	}
 
@@ -319,6 +775,11 @@ func (m *Manager) deployHostConfig(ctx context.Context) error {
 func (m *Manager) waitForMPCDeployment(ctx context.Context) error {
 	logger.Info("waiting for multi-platform-controller deployment")
 
+	client, err := m.deploymentClient()
+	if err != nil {
+		return err
+	}
+
 	ticker := time.NewTicker(2 * time.Second)
 	defer ticker.Stop()
 
@@ -329,11 +790,10 @@ func (m *Manager) waitForMPCDeployment(ctx context.Context) error {
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-timeout:
-			return errors.New("timeout waiting for multi-platform-controller deployment")
+			return m.withDeploymentDiagnostics(ctx, mpcDeploymentName,
+				errors.New("timeout waiting for multi-platform-controller deployment"))
 		case <-ticker.C:
-			cmd := exec.CommandContext(ctx, "kubectl", "get", "deployment", mpcDeploymentName,
-				"-n", mpcNamespace)
-			if err := cmd.Run(); err == nil {
+			if err := client.exists(ctx, mpcDeploymentName); err == nil {
 				logger.Info("multi-platform-controller deployment found")
 				return nil
 			}
@@ -348,6 +808,11 @@ func (m *Manager) waitForMPCDeployment(ctx context.Context) error {
 func (m *Manager) waitForOTPDeployment(ctx context.Context) error {
 	logger.Info("waiting for OTP server deployment")
 
+	client, err := m.deploymentClient()
+	if err != nil {
+		return err
+	}
+
 	ticker := time.NewTicker(2 * time.Second)
 	defer ticker.Stop()
 
@@ -358,11 +823,10 @@ func (m *Manager) waitForOTPDeployment(ctx context.Context) error {
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-timeout:
-			return errors.New("timeout waiting for OTP server deployment")
+			return m.withDeploymentDiagnostics(ctx, otpDeploymentName,
+				errors.New("timeout waiting for OTP server deployment"))
 		case <-ticker.C:
-			cmd := exec.CommandContext(ctx, "kubectl", "get", "deployment", otpDeploymentName,
-				"-n", mpcNamespace)
-			if err := cmd.Run(); err == nil {
+			if err := client.exists(ctx, otpDeploymentName); err == nil {
 				logger.Info("OTP server deployment found")
 				return nil
 			}
@@ -374,35 +838,9 @@ func (m *Manager) waitForOTPDeployment(ctx context.Context) error {
 func (m *Manager) patchMPCDeployment(ctx context.Context) error {
 	logger.Info("patching multi-platform-controller deployment")
 
-	// Use the locally built image that was loaded into Kind cluster
-	// The image is built as "multi-platform-controller:latest" and Podman tags it as "localhost/multi-platform-controller:latest"
-	controllerImage := "localhost/multi-platform-controller:latest"
-	logger.Info("patching with image", "image", controllerImage)
-
-	// Create JSON patch to update image and imagePullPolicy
-	// Use "Never" to ensure Kubernetes uses the locally loaded image instead of trying to pull
-	patchJSON := fmt.Sprintf(`[
-  {
-    "op": "replace",
-    "path": "/spec/template/spec/containers/0/image",
-    "value": "%s"
-  },
-  {
-    "op": "replace",
-    "path": "/spec/template/spec/containers/0/imagePullPolicy",
-    "value": "Never"
-  }
-]`, controllerImage)
-
-	// Apply the patch
-	cmd := exec.CommandContext(ctx, "kubectl", "patch", "deployment", mpcDeploymentName,
-		"-n", mpcNamespace,
-		"--type=json",
-		"--patch", patchJSON)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	if err := cmd.Run(); err != nil {
+	// Use the locally built image that was loaded into Kind cluster (or pushed to the registry)
+	// The builder tags it with the same configured (or revision) reference
+	if err := m.patchDeploymentImage(ctx, mpcDeploymentName, m.controllerImage(), m.imagePullPolicy()); err != nil {
 		return fmt.Errorf("failed to patch controller deployment: %w", err)
 	}
 
@@ -413,39 +851,14 @@ func (m *Manager) patchMPCDeployment(ctx context.Context) error {
 // patchOTPDeployment patches the OTP server deployment to use custom images.
 //
 // This patches the OTP deployment to use the locally built image with imagePullPolicy: Never
-// so Kubernetes uses the image that was loaded into the Kind cluster.
+// so Kubernetes uses the image that was loaded into the Kind cluster, or with
+// imagePullPolicy: Always when the image was pushed to a registry.
 func (m *Manager) patchOTPDeployment(ctx context.Context) error {
 	logger.Info("patching OTP server deployment")
 
-	// Use the locally built image that was loaded into Kind cluster
-	// The image is built as "multi-platform-otp:latest" and Podman tags it as "localhost/multi-platform-otp:latest"
-	otpImage := "localhost/multi-platform-otp:latest"
-	logger.Info("patching OTP with image", "image", otpImage)
-
-	// Create JSON patch to update image and imagePullPolicy
-	// Use "Never" to ensure Kubernetes uses the locally loaded image instead of trying to pull
-	patchJSON := fmt.Sprintf(`[
-  {
-    "op": "replace",
-    "path": "/spec/template/spec/containers/0/image",
-    "value": "%s"
-  },
-  {
-    "op": "replace",
-    "path": "/spec/template/spec/containers/0/imagePullPolicy",
-    "value": "Never"
-  }
-]`, otpImage)
-
-	// Apply the patch
-	cmd := exec.CommandContext(ctx, "kubectl", "patch", "deployment", otpDeploymentName,
-		"-n", mpcNamespace,
-		"--type=json",
-		"--patch", patchJSON)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	if err := cmd.Run(); err != nil {
+	// Use the locally built image that was loaded into Kind cluster (or pushed to the registry)
+	// The builder tags it with the same configured (or revision) reference
+	if err := m.patchDeploymentImage(ctx, otpDeploymentName, m.otpImage(), m.imagePullPolicy()); err != nil {
 		return fmt.Errorf("failed to patch OTP deployment: %w", err)
 	}
 
@@ -453,117 +866,203 @@ func (m *Manager) patchOTPDeployment(ctx context.Context) error {
 	return nil
 }
 
+// patchDeploymentImage patches the first container of a deployment in the MPC
+// namespace with image and pullPolicy using a JSON patch (see imagePatch).
+func (m *Manager) patchDeploymentImage(ctx context.Context, deployment, image, pullPolicy string) error {
+	logger.Info("patching with image", "deployment", deployment, "image", image, "imagePullPolicy", pullPolicy)
+
+	client, err := m.deploymentClient()
+	if err != nil {
+		return err
+	}
+	return client.patchImage(ctx, deployment, image, pullPolicy)
+}
+
 // restartDeployments restarts the MPC and OTP deployments to apply changes.
 //
 // Both deployments are restarted and we wait for both to be ready before returning.
 func (m *Manager) restartDeployments(ctx context.Context) error {
-	logger.Info("restarting deployments to apply changes")
+	return m.restartNamedDeployments(ctx, mpcDeploymentName, otpDeploymentName)
+}
 
-	// Restart controller deployment
-	restartCmd := exec.CommandContext(ctx, "kubectl", "rollout", "restart",
-		"deployment/"+mpcDeploymentName,
-		"-n", mpcNamespace)
-	restartCmd.Stdout = os.Stdout
-	restartCmd.Stderr = os.Stderr
+// deploymentLabels name the MPC deployments in log messages and errors.
+var deploymentLabels = map[string]string{
+	mpcDeploymentName: "controller",
+	otpDeploymentName: "OTP",
+}
+
+// restartNamedDeployments restarts the given deployments in the MPC namespace, like
+// kubectl rollout restart, then waits for each rollout to finish. A rollout that does
+// not finish comes back with the deployment's diagnostics.
+func (m *Manager) restartNamedDeployments(ctx context.Context, deployments ...string) error {
+	logger.Info("restarting deployments to apply changes", "deployments", deployments)
 
-	if err := restartCmd.Run(); err != nil {
-		return fmt.Errorf("failed to restart controller deployment: %w", err)
+	client, err := m.deploymentClient()
+	if err != nil {
+		return err
 	}
 
-	logger.Info("controller deployment restarted")
+	for _, deployment := range deployments {
+		if err := client.restart(ctx, deployment); err != nil {
+			return fmt.Errorf("failed to restart %s deployment: %w", deploymentLabels[deployment], err)
+		}
 
-	// Restart OTP deployment
-	otpRestartCmd := exec.CommandContext(ctx, "kubectl", "rollout", "restart",
-		"deployment/"+otpDeploymentName,
-		"-n", mpcNamespace)
-	otpRestartCmd.Stdout = os.Stdout
-	otpRestartCmd.Stderr = os.Stderr
+		logger.Info("deployment restarted", "deployment", deployment)
+	}
 
-	if err := otpRestartCmd.Run(); err != nil {
-		return fmt.Errorf("failed to restart OTP deployment: %w", err)
+	for _, deployment := range deployments {
+		logger.Info("waiting for deployment to be ready", "deployment", deployment)
+		if err := client.waitRollout(ctx, deployment, rolloutTimeout); err != nil {
+			return m.withDeploymentDiagnostics(ctx, deployment,
+				fmt.Errorf("failed to wait for %s rollout: %w", deploymentLabels[deployment], err))
+		}
 	}
 
-	logger.Info("OTP server deployment restarted")
+	logger.Info("deployments restarted successfully")
+	return nil
+}
 
-	// Wait for controller to be ready
-	logger.Info("waiting for controller to be ready")
-	waitCmd := exec.CommandContext(ctx, "kubectl", "rollout", "status",
-		"deployment/"+mpcDeploymentName,
-		"-n", mpcNamespace,
-		"--timeout=5m")
-	waitCmd.Stdout = os.Stdout
-	waitCmd.Stderr = os.Stderr
+// withDeploymentDiagnostics appends the diagnostics of a deployment to err, so a
+// readiness timeout says why the pods never became ready.
+func (m *Manager) withDeploymentDiagnostics(ctx context.Context, deployment string, err error) error {
+	diagnostics := m.collectDeploymentDiagnostics(ctx, deployment)
+	if diagnostics == "" {
+		return err
+	}
+	return fmt.Errorf("%w\n%s", err, diagnostics)
+}
+
+// collectDeploymentDiagnostics gathers a truncated summary of why a deployment is
+// not ready: the tail of kubectl describe (conditions and events), recent events
+// of its pods (ImagePullBackOff, CrashLoopBackOff, failed scheduling) and the last
+// log lines of its containers. Sections kubectl cannot provide are left out.
+func (m *Manager) collectDeploymentDiagnostics(ctx context.Context, deployment string) string {
+	if ctx.Err() != nil {
+		return ""
+	}
+	ctx, cancel := context.WithTimeout(ctx, diagnosticsTimeout)
+	defer cancel()
 
-	if err := waitCmd.Run(); err != nil {
-		return fmt.Errorf("failed to wait for controller rollout: %w", err)
+	var sections []string
+	addSection := func(title string, lines []string) {
+		if len(lines) > diagnosticsTailLines {
+			lines = append([]string{"..."}, lines[len(lines)-diagnosticsTailLines:]...)
+		}
+		if len(lines) > 0 {
+			sections = append(sections, fmt.Sprintf("--- %s ---\n%s", title, strings.Join(lines, "\n")))
+		}
 	}
 
-	// Wait for OTP to be ready
-	logger.Info("waiting for OTP server to be ready")
-	otpWaitCmd := exec.CommandContext(ctx, "kubectl", "rollout", "status",
-		"deployment/"+otpDeploymentName,
+	// describe reports NotFound itself when the deployment was never created
+	describe, _ := m.kubectl(ctx, "describe", "deployment", deployment,
+		"-n", mpcNamespace).CombinedOutput()
+	addSection("kubectl describe deployment "+deployment, nonEmptyLines(string(describe)))
+
+	events, err := m.kubectl(ctx, "get", "events",
 		"-n", mpcNamespace,
-		"--timeout=5m")
-	otpWaitCmd.Stdout = os.Stdout
-	otpWaitCmd.Stderr = os.Stderr
+		"--field-selector", "involvedObject.kind=Pod",
+		"--sort-by=.lastTimestamp").Output()
+	if err == nil {
+		var podEvents []string
+		for _, line := range nonEmptyLines(string(events)) {
+			if strings.Contains(line, "pod/"+deployment+"-") {
+				podEvents = append(podEvents, line)
+			}
+		}
+		addSection("recent pod events", podEvents)
+	}
 
-	if err := otpWaitCmd.Run(); err != nil {
-		return fmt.Errorf("failed to wait for OTP rollout: %w", err)
+	logs, err := m.kubectl(ctx, "logs", "deployment/"+deployment,
+		"-n", mpcNamespace,
+		"--all-containers",
+		fmt.Sprintf("--tail=%d", diagnosticsTailLines)).Output()
+	if err == nil {
+		addSection("recent logs", nonEmptyLines(string(logs)))
 	}
 
-	logger.Info("deployments restarted successfully")
-	return nil
+	if len(sections) == 0 {
+		return ""
+	}
+	return "diagnostics for deployment " + deployment + ":\n" + strings.Join(sections, "\n")
+}
+
+// nonEmptyLines splits output into lines, dropping blank ones.
+func nonEmptyLines(output string) []string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, strings.TrimRight(line, " \t\r"))
+		}
+	}
+	return lines
 }
 
 // verifyDeploymentImages verifies that deployments are using the correct images
 func (m *Manager) verifyDeploymentImages(ctx context.Context) error {
+	// The expected images are what we built and patched with
+	return m.verifyImages(ctx, Images{Controller: m.controllerImage(), OTP: m.otpImage()})
+}
+
+// verifyImages verifies that the controller and OTP server deployments use the expected
+// images, and names the mismatched component otherwise. An empty expected OTP image is
+// not checked, e.g. for a rollback target recorded without one.
+func (m *Manager) verifyImages(ctx context.Context, expected Images) error {
 	logger.Info("verifying deployment images")
 
-	// The expected image is what we built and patched with
-	// Builder creates "multi-platform-controller:latest" and Podman tags it as "localhost/multi-platform-controller:latest"
-	expectedControllerImage := "localhost/multi-platform-controller:latest"
+	checks := []struct {
+		deployment, component, image string
+	}{
+		{mpcDeploymentName, "controller", expected.Controller},
+		{otpDeploymentName, "OTP server", expected.OTP},
+	}
+	for _, check := range checks {
+		if check.image == "" {
+			continue
+		}
 
-	// Get actual controller image from deployment
-	cmd := exec.CommandContext(ctx, "kubectl", "get", "deployment", mpcDeploymentName,
-		"-n", mpcNamespace,
-		"-o", "jsonpath={.spec.template.spec.containers[0].image}")
+		// Get actual image from deployment
+		actualImage, err := m.deploymentImage(ctx, check.deployment)
+		if err != nil {
+			return fmt.Errorf("failed to get %s image: %w", check.component, err)
+		}
 
-	output, err := cmd.Output()
-	if err != nil {
-		return fmt.Errorf("failed to get controller image: %w", err)
-	}
+		if actualImage != check.image {
+			return fmt.Errorf("%s using wrong image: %s (expected: %s)", check.component, actualImage, check.image)
+		}
 
-	actualImage := string(output)
-	if actualImage != expectedControllerImage {
-		return fmt.Errorf("controller using wrong image: %s (expected: %s)", actualImage, expectedControllerImage)
+		logger.Info(check.component+" using correct image", "image", actualImage)
 	}
-
-	logger.Info("controller using correct image", "image", actualImage)
 	return nil
 }
 
 // ApplySecrets applies AWS secrets to the Kubernetes cluster
 // This creates the necessary secrets for the multi-platform-controller to access AWS resources
-func (m *Manager) ApplySecrets(ctx context.Context) error {
+// from creds, which are never read from or written to the process environment
+// It returns ErrClusterNotRunning if the cluster is not reachable
+func (m *Manager) ApplySecrets(ctx context.Context, creds AWSCredentials) error {
 	logger.Info("applying AWS secrets to Kubernetes cluster")
 
+	if err := CheckClusterReachable(ctx, m.config.KubeconfigPath, m.config.GetKubeContext()); err != nil {
+		return err
+	}
+
 	// Ensure namespace exists
 	if err := m.ensureNamespace(ctx); err != nil {
 		return fmt.Errorf("failed to ensure namespace exists: %w", err)
 	}
 
 	// Step 1: Create aws-account secret
-	if err := m.createAWSAccountSecret(ctx); err != nil {
+	if err := m.createAWSAccountSecret(ctx, creds); err != nil {
 		return fmt.Errorf("failed to create aws-account secret: %w", err)
 	}
 
 	// Step 2: Create aws-ssh-key secret
-	if err := m.createAWSSSHKeySecret(ctx); err != nil {
+	if err := m.createAWSSSHKeySecret(ctx, creds.SSHKeyPath); err != nil {
 		return fmt.Errorf("failed to create aws-ssh-key secret: %w", err)
 	}
 
 	// Step 3: Verify secrets exist
-	if err := m.verifySecrets(ctx); err != nil {
+	if err := m.verifySecrets(ctx, "aws-account", "aws-ssh-key"); err != nil {
 		return fmt.Errorf("secret verification failed: %w", err)
 	}
 
@@ -576,18 +1075,22 @@ func (m *Manager) ensureNamespace(ctx context.Context) error {
 	logger.Info("ensuring namespace exists", "namespace", mpcNamespace)
 
 	// Check if namespace exists
-	checkCmd := exec.CommandContext(ctx, "kubectl", "get", "namespace", mpcNamespace)
-	if err := checkCmd.Run(); err == nil {
+	err := m.runKubectl(ctx, func() *exec.Cmd {
+		return m.kubectl(ctx, "get", "namespace", mpcNamespace)
+	})
+	if err == nil {
 		logger.Info("namespace already exists", "namespace", mpcNamespace)
 		return nil
 	}
 
 	// Create namespace
-	createCmd := exec.CommandContext(ctx, "kubectl", "create", "namespace", mpcNamespace)
-	createCmd.Stdout = os.Stdout
-	createCmd.Stderr = os.Stderr
-
-	if err := createCmd.Run(); err != nil {
+	err = m.runKubectl(ctx, func() *exec.Cmd {
+		createCmd := m.kubectl(ctx, m.kubectlArgs("create", "namespace", mpcNamespace)...)
+		createCmd.Stdout = m.stdout()
+		createCmd.Stderr = m.stderr()
+		return createCmd
+	})
+	if err != nil {
 		return fmt.Errorf("failed to create namespace: %w", err)
 	}
 
@@ -615,11 +1118,13 @@ func (m *Manager) applyMPCManifests(ctx context.Context) error {
 
 	// Apply using kustomize (kubectl apply -k)
 	logger.Info("applying manifests", "path", operatorDir)
-	applyCmd := exec.CommandContext(ctx, "kubectl", "apply", "-k", operatorDir)
-	applyCmd.Stdout = os.Stdout
-	applyCmd.Stderr = os.Stderr
-
-	if err := applyCmd.Run(); err != nil {
+	err := m.runKubectl(ctx, func() *exec.Cmd {
+		applyCmd := m.kubectl(ctx, m.kubectlArgs("apply", "-k", operatorDir)...)
+		applyCmd.Stdout = m.stdout()
+		applyCmd.Stderr = m.stderr()
+		return applyCmd
+	})
+	if err != nil {
 		return fmt.Errorf("failed to apply MPC manifests: %w", err)
 	}
 
@@ -628,13 +1133,12 @@ func (m *Manager) applyMPCManifests(ctx context.Context) error {
 }
 
 // createAWSAccountSecret creates the aws-account Kubernetes secret
-func (m *Manager) createAWSAccountSecret(ctx context.Context) error {
+func (m *Manager) createAWSAccountSecret(ctx context.Context, creds AWSCredentials) error {
 	logger.Info("creating aws-account secret")
 
-	// Get AWS credentials from environment
-	awsAccessKeyID := os.Getenv("AWS_ACCESS_KEY_ID")
-	awsSecretAccessKey := os.Getenv("AWS_SECRET_ACCESS_KEY")
-	awsSessionToken := os.Getenv("AWS_SESSION_TOKEN")
+	awsAccessKeyID := creds.AccessKeyID
+	awsSecretAccessKey := creds.SecretAccessKey
+	awsSessionToken := creds.SessionToken
 
 	// Log credential presence (not values!)
 	logger.Debug("AWS access key ID check", "present", awsAccessKeyID != "", "length", len(awsAccessKeyID))
@@ -645,77 +1149,36 @@ func (m *Manager) createAWSAccountSecret(ctx context.Context) error {
 	}
 
 	if awsAccessKeyID == "" || awsSecretAccessKey == "" {
-		return errors.New("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables must be set")
+		return errors.New("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set")
 	}
 
-	// Check if secret already exists
-	logger.Debug("checking if aws-account secret exists", "namespace", mpcNamespace)
-	checkCmd := exec.CommandContext(ctx, "kubectl", "get", "secret", "aws-account", "-n", mpcNamespace)
-	if err := checkCmd.Run(); err == nil {
-		logger.Info("secret aws-account already exists, replacing")
-		deleteCmd := exec.CommandContext(ctx, "kubectl", "delete", "secret", "aws-account", "-n", mpcNamespace)
-		if err := deleteCmd.Run(); err != nil {
-			logger.Error(err, "failed to delete existing secret")
-		} else {
-			logger.Debug("old secret deleted successfully")
-		}
-	} else {
-		logger.Debug("secret does not exist yet, creating new")
+	literals := []secretLiteral{
+		{key: "access-key-id", value: awsAccessKeyID},
+		{key: "secret-access-key", value: awsSecretAccessKey},
 	}
-
-	// Create the secret with the required label for controller cache
-	// The label build.appstudio.redhat.com/multi-platform-secret is required for the
-	// controller's informer cache to include this secret (see controller/controller.go:73-77)
-	logger.Debug("creating secret aws-account", "fields", "access-key-id, secret-access-key, session-token", "namespace", mpcNamespace, "label", "build.appstudio.redhat.com/multi-platform-secret=true")
-
-	// Build kubectl args
-	args := []string{
-		"create", "secret", "generic", "aws-account",
-		"--from-literal=access-key-id=" + awsAccessKeyID,
-		"--from-literal=secret-access-key=" + awsSecretAccessKey,
-		"--namespace", mpcNamespace,
-	}
-
 	// Add session token if present (SSO temporary credentials)
 	if awsSessionToken != "" {
-		args = append(args, "--from-literal=session-token="+awsSessionToken)
+		literals = append(literals, secretLiteral{key: "session-token", value: awsSessionToken})
 	}
 
-	// First create the secret
-	createCmd := exec.CommandContext(ctx, "kubectl", args...)
-	createCmd.Stdout = os.Stdout
-	createCmd.Stderr = os.Stderr
-
-	if err := createCmd.Run(); err != nil {
-		return fmt.Errorf("failed to create aws-account secret: %w", err)
-	}
-
-	// Then add the label (kubectl create doesn't support --labels for secrets)
-	labelCmd := exec.CommandContext(ctx, "kubectl", "label", "secret", "aws-account",
-		"build.appstudio.redhat.com/multi-platform-secret=true",
-		"-n", mpcNamespace)
-	labelCmd.Stdout = os.Stdout
-	labelCmd.Stderr = os.Stderr
-
-	if err := labelCmd.Run(); err != nil {
-		return fmt.Errorf("failed to label aws-account secret: %w", err)
+	sources, cleanup, err := literalSources(literals...)
+	if err != nil {
+		return err
 	}
+	defer cleanup()
 
-	logger.Info("aws-account secret created successfully")
-	logger.Debug("label added to ensure secret is cached by controller", "timestamp", time.Now().Format(time.RFC3339))
-	return nil
+	// The label build.appstudio.redhat.com/multi-platform-secret is required for the
+	// controller's informer cache to include this secret (see controller/controller.go:73-77)
+	return m.createMultiPlatformSecret(ctx, "aws-account", sources...)
 }
 
 // createAWSSSHKeySecret creates the aws-ssh-key Kubernetes secret
-func (m *Manager) createAWSSSHKeySecret(ctx context.Context) error {
+func (m *Manager) createAWSSSHKeySecret(ctx context.Context, sshKeyPath string) error {
 	logger.Info("creating aws-ssh-key secret")
-
-	// Get SSH key path from environment
-	sshKeyPath := os.Getenv("SSH_KEY_PATH")
-	logger.Debug("SSH key path from environment", "path", sshKeyPath)
+	logger.Debug("SSH key path", "path", sshKeyPath)
 
 	if sshKeyPath == "" {
-		return errors.New("SSH_KEY_PATH environment variable must be set")
+		return errors.New("SSH_KEY_PATH must be set")
 	}
 
 	// Validate that the SSH key file exists
@@ -729,10 +1192,10 @@ func (m *Manager) createAWSSSHKeySecret(ctx context.Context) error {
 	}
 
 	// Check if secret already exists
-	checkCmd := exec.CommandContext(ctx, "kubectl", "get", "secret", "aws-ssh-key", "-n", mpcNamespace)
+	checkCmd := m.kubectl(ctx, "get", "secret", "aws-ssh-key", "-n", mpcNamespace)
 	if err := checkCmd.Run(); err == nil {
 		logger.Info("secret aws-ssh-key already exists, replacing")
-		deleteCmd := exec.CommandContext(ctx, "kubectl", "delete", "secret", "aws-ssh-key", "-n", mpcNamespace)
+		deleteCmd := m.kubectl(ctx, "delete", "secret", "aws-ssh-key", "-n", mpcNamespace)
 		if err := deleteCmd.Run(); err != nil {
 			logger.Error(err, "failed to delete existing secret")
 		}
@@ -740,22 +1203,22 @@ func (m *Manager) createAWSSSHKeySecret(ctx context.Context) error {
 
 	// Create the secret
 	logger.Debug("adding label", "label", "build.appstudio.redhat.com/multi-platform-secret=true")
-	createCmd := exec.CommandContext(ctx, "kubectl", "create", "secret", "generic", "aws-ssh-key",
+	createCmd := m.kubectl(ctx, "create", "secret", "generic", "aws-ssh-key",
 		"--from-file=id_rsa="+sshKeyPath,
 		"--namespace", mpcNamespace)
-	createCmd.Stdout = os.Stdout
-	createCmd.Stderr = os.Stderr
+	createCmd.Stdout = m.stdout()
+	createCmd.Stderr = m.stderr()
 
 	if err := createCmd.Run(); err != nil {
 		return fmt.Errorf("failed to create aws-ssh-key secret: %w", err)
 	}
 
 	// Add the label so the controller cache will include this secret
-	labelCmd := exec.CommandContext(ctx, "kubectl", "label", "secret", "aws-ssh-key",
+	labelCmd := m.kubectl(ctx, "label", "secret", "aws-ssh-key",
 		"build.appstudio.redhat.com/multi-platform-secret=true",
 		"-n", mpcNamespace)
-	labelCmd.Stdout = os.Stdout
-	labelCmd.Stderr = os.Stderr
+	labelCmd.Stdout = m.stdout()
+	labelCmd.Stderr = m.stderr()
 
 	if err := labelCmd.Run(); err != nil {
 		return fmt.Errorf("failed to label aws-ssh-key secret: %w", err)
@@ -766,23 +1229,151 @@ func (m *Manager) createAWSSSHKeySecret(ctx context.Context) error {
 	return nil
 }
 
+// ApplyIBMSecrets applies IBM Cloud secrets to the Kubernetes cluster
+// This creates the SSH key secrets referenced by the s390x and ppc64le hosts in host-config,
+// plus the ibmcloud-api-key secret used to provision dynamic IBM Cloud hosts, from creds
+func (m *Manager) ApplyIBMSecrets(ctx context.Context, creds IBMCredentials) error {
+	logger.Info("applying IBM secrets to Kubernetes cluster")
+
+	s390xKeyPath := creds.S390XSSHKeyPath
+	ppc64leKeyPath := creds.PPC64LESSHKeyPath
+	apiKey := creds.APIKey
+
+	// Log credential presence (not values!)
+	logger.Debug("IBM s390x SSH key path", "path", s390xKeyPath)
+	logger.Debug("IBM ppc64le SSH key path", "path", ppc64leKeyPath)
+	logger.Debug("IBM Cloud API key check", "present", apiKey != "", "length", len(apiKey))
+
+	if s390xKeyPath == "" || ppc64leKeyPath == "" || apiKey == "" {
+		return errors.New("IBM_S390X_SSH_KEY_PATH, IBM_PPC64LE_SSH_KEY_PATH and IBMCLOUD_API_KEY must be set")
+	}
+
+	// Validate that the SSH key files exist before touching the cluster
+	for _, keyPath := range []string{s390xKeyPath, ppc64leKeyPath} {
+		if _, err := os.Stat(keyPath); err != nil {
+			if os.IsNotExist(err) {
+				return fmt.Errorf("SSH key file not found: %s", keyPath)
+			}
+			return fmt.Errorf("cannot access SSH key file: %w", err)
+		}
+	}
+
+	// Ensure namespace exists
+	if err := m.ensureNamespace(ctx); err != nil {
+		return fmt.Errorf("failed to ensure namespace exists: %w", err)
+	}
+
+	// Step 1: Create the SSH key secrets for the static IBM hosts
+	if err := m.createMultiPlatformSecret(ctx, "ibm-s390x-ssh-key", "--from-file=id_rsa="+s390xKeyPath); err != nil {
+		return err
+	}
+	if err := m.createMultiPlatformSecret(ctx, "ibm-ppc64le-ssh-key", "--from-file=id_rsa="+ppc64leKeyPath); err != nil {
+		return err
+	}
+
+	// Step 2: Create the ibmcloud-api-key secret
+	sources, cleanup, err := literalSources(secretLiteral{key: "api-key", value: apiKey})
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	if err := m.createMultiPlatformSecret(ctx, "ibmcloud-api-key", sources...); err != nil {
+		return err
+	}
+
+	// Step 3: Verify secrets exist
+	if err := m.verifySecrets(ctx, "ibm-s390x-ssh-key", "ibm-ppc64le-ssh-key", "ibmcloud-api-key"); err != nil {
+		return fmt.Errorf("secret verification failed: %w", err)
+	}
+
+	logger.Info("IBM secrets applied successfully")
+	return nil
+}
+
+// createMultiPlatformSecret (re)creates a generic secret from the given kubectl --from-* sources
+// and labels it so the controller cache will include it
+func (m *Manager) createMultiPlatformSecret(ctx context.Context, name string, sources ...string) error {
+	logger.Info("creating secret", "name", name)
+
+	// Check if secret already exists
+	checkCmd := m.kubectl(ctx, "get", "secret", name, "-n", mpcNamespace)
+	if err := checkCmd.Run(); err == nil {
+		logger.Info("secret already exists, replacing", "name", name)
+		deleteCmd := m.kubectl(ctx, "delete", "secret", name, "-n", mpcNamespace)
+		if err := deleteCmd.Run(); err != nil {
+			logger.Error(err, "failed to delete existing secret", "name", name)
+		}
+	}
+
+	args := append([]string{"create", "secret", "generic", name}, sources...)
+	args = append(args, "--namespace", mpcNamespace)
+	createCmd := m.kubectl(ctx, args...)
+	createCmd.Stdout = m.stdout()
+	createCmd.Stderr = m.stderr()
+
+	if err := createCmd.Run(); err != nil {
+		return fmt.Errorf("failed to create %s secret: %w", name, err)
+	}
+
+	// Add the label so the controller cache will include this secret
+	labelCmd := m.kubectl(ctx, "label", "secret", name,
+		"build.appstudio.redhat.com/multi-platform-secret=true",
+		"-n", mpcNamespace)
+	labelCmd.Stdout = m.stdout()
+	labelCmd.Stderr = m.stderr()
+
+	if err := labelCmd.Run(); err != nil {
+		return fmt.Errorf("failed to label %s secret: %w", name, err)
+	}
+
+	logger.Info("secret created successfully", "name", name)
+	return nil
+}
+
+// secretLiteral is one key of a generic secret and its value.
+type secretLiteral struct {
+	key   string
+	value string
+}
+
+// literalSources writes each literal to its own file, readable only by the current user,
+// and returns the kubectl --from-file sources for them. Secret values must never be passed
+// with --from-literal: kubectl's arguments are visible to every local user in the process
+// list. Call cleanup once kubectl has run to remove the files.
+func literalSources(literals ...secretLiteral) (sources []string, cleanup func(), err error) {
+	// MkdirTemp creates the directory with mode 0700
+	dir, err := os.MkdirTemp("", "mpc-secret-*")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create secret directory: %w", err)
+	}
+	cleanup = func() { _ = os.RemoveAll(dir) }
+
+	for _, literal := range literals {
+		path := filepath.Join(dir, literal.key)
+		if err := os.WriteFile(path, []byte(literal.value), 0600); err != nil {
+			cleanup()
+			return nil, nil, fmt.Errorf("failed to write secret key %s: %w", literal.key, err)
+		}
+		sources = append(sources, "--from-file="+literal.key+"="+path)
+	}
+	return sources, cleanup, nil
+}
+
 // verifySecrets verifies that all required secrets exist
-func (m *Manager) verifySecrets(ctx context.Context) error {
+func (m *Manager) verifySecrets(ctx context.Context, requiredSecrets ...string) error {
 	logger.Info("verifying secrets")
 	logger.Debug("verification started", "timestamp", time.Now().Format(time.RFC3339))
 
-	requiredSecrets := []string{"aws-account", "aws-ssh-key"}
-
 	for _, secretName := range requiredSecrets {
 		logger.Debug("checking secret", "name", secretName, "namespace", mpcNamespace)
-		cmd := exec.CommandContext(ctx, "kubectl", "get", "secret", secretName, "-n", mpcNamespace)
+		cmd := m.kubectl(ctx, "get", "secret", secretName, "-n", mpcNamespace)
 		if err := cmd.Run(); err != nil {
 			return fmt.Errorf("secret '%s' not found in namespace %s", secretName, mpcNamespace)
 		}
 		logger.Info("secret exists", "name", secretName)
 
 		// DEBUG: Get detailed secret info
-		detailsCmd := exec.CommandContext(ctx, "kubectl", "get", "secret", secretName, "-n", mpcNamespace, "-o", "yaml")
+		detailsCmd := m.kubectl(ctx, "get", "secret", secretName, "-n", mpcNamespace, "-o", "yaml")
 		if output, err := detailsCmd.CombinedOutput(); err == nil {
 			logger.Debug("secret YAML output", "name", secretName, "length", len(output))
 			// Don't log the full YAML as it contains sensitive data
@@ -794,69 +1385,194 @@ func (m *Manager) verifySecrets(ctx context.Context) error {
 	return nil
 }
 
+// konfluxScripts are the konflux-ci scripts ApplyKonflux runs, in order.
+var konfluxScripts = []string{"deploy-deps.sh", "deploy-konflux.sh", "deploy-test-resources.sh"}
+
+const (
+	// konfluxScriptWaitDelay bounds how long runKonfluxScript waits for a killed script's
+	// output, which the kubectl processes the script started may still be writing.
+	konfluxScriptWaitDelay = 5 * time.Second
+
+	// konfluxScriptTailLines is how many of the last output lines of a failed konflux-ci
+	// script its error includes.
+	konfluxScriptTailLines = 20
+)
+
 // ApplyKonflux deploys Konflux to the Kind cluster
-// This runs the necessary scripts from the konflux-ci repository
+// This runs the necessary scripts from the konflux-ci repository (MPC_KONFLUX_CI_PATH)
 func (m *Manager) ApplyKonflux(ctx context.Context) error {
 	logger.Info("deploying Konflux to Kind cluster")
 
-	// Get the konflux-ci directory path (sibling to mpc_dev_env)
-	konfluxCIDir := filepath.Join(filepath.Dir(m.config.GetMpcDevEnvPath()), "konflux-ci")
+	konfluxCIDir := m.config.GetKonfluxCIPath()
 
 	// Verify konflux-ci directory exists
 	if _, err := os.Stat(konfluxCIDir); err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("konflux-ci directory not found: %s (please clone konflux-ci repository)", konfluxCIDir)
+			return fmt.Errorf("konflux-ci directory not found: %s (please clone konflux-ci repository or set MPC_KONFLUX_CI_PATH)", konfluxCIDir)
 		}
 		return fmt.Errorf("cannot access konflux-ci directory: %w", err)
 	}
+	if err := checkKonfluxScripts(konfluxCIDir); err != nil {
+		return err
+	}
+
+	kubeconfigPath, err := m.writeScriptKubeconfig(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(kubeconfigPath) }()
 
 	// Step 1: Deploy dependencies (Tekton, Argo CD, etc.)
 	logger.Info("deploying Konflux dependencies", "step", "1/3")
-	if err := m.runKonfluxScript(ctx, konfluxCIDir, "deploy-deps.sh"); err != nil {
+	if err := m.runKonfluxScript(ctx, konfluxCIDir, "deploy-deps.sh", kubeconfigPath); err != nil {
 		return fmt.Errorf("failed to deploy Konflux dependencies: %w", err)
 	}
 
 	// Step 2: Deploy Konflux components
 	logger.Info("deploying Konflux components", "step", "2/3")
-	if err := m.runKonfluxScript(ctx, konfluxCIDir, "deploy-konflux.sh"); err != nil {
+	if err := m.runKonfluxScript(ctx, konfluxCIDir, "deploy-konflux.sh", kubeconfigPath); err != nil {
 		return fmt.Errorf("failed to deploy Konflux components: %w", err)
 	}
 
 	// Step 3: Deploy test resources
 	logger.Info("deploying Konflux test resources", "step", "3/3")
-	if err := m.runKonfluxScript(ctx, konfluxCIDir, "deploy-test-resources.sh"); err != nil {
+	if err := m.runKonfluxScript(ctx, konfluxCIDir, "deploy-test-resources.sh", kubeconfigPath); err != nil {
 		return fmt.Errorf("failed to deploy Konflux test resources: %w", err)
 	}
 
-	logger.Info("Konflux deployed successfully", "ui", "https://localhost:9443", "username", "user2@konflux.dev", "password", "password")
+	logger.Info("Konflux deployed successfully", "ui", m.config.GetKonfluxUIURL(), "username", m.config.GetKonfluxUsername())
 
 	return nil
 }
 
-// runKonfluxScript executes a Konflux deployment script in the konflux-ci directory
-func (m *Manager) runKonfluxScript(ctx context.Context, konfluxCIDir, scriptName string) error {
-	scriptPath := filepath.Join(konfluxCIDir, scriptName)
-
-	// Verify script exists
-	if _, err := os.Stat(scriptPath); err != nil {
-		if os.IsNotExist(err) {
-			return fmt.Errorf("script not found: %s", scriptPath)
+// checkKonfluxScripts verifies that konfluxCIDir has all konfluxScripts, so a wrong
+// checkout is reported before any of them runs. The error names every missing script.
+func checkKonfluxScripts(konfluxCIDir string) error {
+	var missing []string
+	for _, script := range konfluxScripts {
+		if _, err := os.Stat(filepath.Join(konfluxCIDir, script)); err != nil {
+			missing = append(missing, script)
 		}
-		return fmt.Errorf("cannot access script: %w", err)
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("konflux-ci directory %s is missing required scripts: %s", konfluxCIDir, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// writeScriptKubeconfig writes the configured kubeconfig, reduced to the context of the
+// configured cluster, to a new temporary file and returns its path; the caller removes
+// it. The konflux-ci scripts run kubectl with the kubeconfig's current context, which
+// creating another Kind cluster switches away from the configured one.
+func (m *Manager) writeScriptKubeconfig(ctx context.Context) (string, error) {
+	var kubeconfig, stderr bytes.Buffer
+	cmd := m.kubectl(ctx, "config", "view", "--minify", "--flatten")
+	cmd.Stdout = &kubeconfig
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("failed to read the kubeconfig of context %s: %w (stderr: %s)", m.config.GetKubeContext(), err, strings.TrimSpace(stderr.String()))
 	}
 
-	logger.Info("running script", "script", scriptName)
+	file, err := os.CreateTemp("", "mpc-konflux-kubeconfig-*.yaml")
+	if err != nil {
+		return "", fmt.Errorf("failed to create the Konflux scripts' kubeconfig: %w", err)
+	}
+	_, err = file.Write(kubeconfig.Bytes())
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return "", fmt.Errorf("failed to write the Konflux scripts' kubeconfig: %w", err)
+	}
+	return file.Name(), nil
+}
+
+// runKonfluxScript executes a Konflux deployment script in the konflux-ci directory,
+// with KUBECONFIG set to kubeconfigPath (see writeScriptKubeconfig). The script is
+// bounded by the KonfluxScript timeout and its output is copied to the output writer;
+// when it fails, the error ends with the last lines of its output.
+func (m *Manager) runKonfluxScript(ctx context.Context, konfluxCIDir, scriptName, kubeconfigPath string) error {
+	scriptPath := filepath.Join(konfluxCIDir, scriptName)
+	timeout := m.config.GetTimeouts().KonfluxScript
+
+	logger.Info("running script", "script", scriptName, "timeout", timeout)
+
+	scriptCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
 	// Execute the script
-	cmd := exec.CommandContext(ctx, "bash", scriptPath)
+	tail := newOutputTail(konfluxScriptTailLines)
+	cmd := exec.CommandContext(scriptCtx, "bash", scriptPath)
 	cmd.Dir = konfluxCIDir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), "KUBECONFIG="+kubeconfigPath)
+	cmd.Stdout = io.MultiWriter(m.stdout(), tail)
+	cmd.Stderr = io.MultiWriter(m.stderr(), tail)
+	cmd.WaitDelay = konfluxScriptWaitDelay
 
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("script %s failed: %w", scriptName, err)
+		if ctx.Err() == nil && errors.Is(scriptCtx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("script %s timed out after %s", scriptName, timeout)
+		} else {
+			err = fmt.Errorf("script %s failed: %w", scriptName, err)
+		}
+		if output := tail.String(); output != "" {
+			return fmt.Errorf("%w\nlast output of %s:\n%s", err, scriptName, output)
+		}
+		return err
 	}
 
 	logger.Info("script completed successfully", "script", scriptName)
 	return nil
 }
+
+// outputTail is an io.Writer that keeps the last lines written to it, e.g. to attach
+// the end of a failed command's output to its error. It is safe for concurrent use,
+// so a command's stdout and stderr can share it.
+type outputTail struct {
+	max int
+
+	mu    sync.Mutex
+	lines []string
+	split *lines.Writer // Splits the output into lines, passing them to addLocked
+}
+
+// newOutputTail creates an outputTail that keeps the last max lines.
+func newOutputTail(max int) *outputTail {
+	t := &outputTail{max: max}
+	t.split = lines.NewWriter(t.addLocked)
+	return t
+}
+
+func (t *outputTail) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.split.Write(p)
+}
+
+// addLocked appends a non-blank line, dropping the oldest once max are kept. t.mu must be held.
+func (t *outputTail) addLocked(line string) {
+	line = strings.TrimRight(line, " \t\r\n")
+	if strings.TrimSpace(line) == "" {
+		return
+	}
+	if len(t.lines) == t.max {
+		t.lines = t.lines[1:]
+	}
+	t.lines = append(t.lines, line)
+}
+
+// String returns the kept lines, including a final line without newline, joined by newlines.
+func (t *outputTail) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	kept := slices.Clone(t.lines)
+	if partial := strings.TrimSpace(t.split.Partial()); partial != "" {
+		kept = append(kept, partial)
+		if len(kept) > t.max {
+			kept = kept[1:]
+		}
+	}
+	return strings.Join(kept, "\n")
+}
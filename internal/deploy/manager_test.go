@@ -2,10 +2,13 @@ package deploy
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/meyrevived/mpc-dev-env/internal/config"
 	. "github.com/onsi/ginkgo/v2"
@@ -39,10 +42,6 @@ var _ = Describe("Deploy Manager", func() {
 
 	AfterEach(func() {
 		_ = os.RemoveAll(tempDir)
-		_ = os.Unsetenv("AWS_ACCESS_KEY_ID")
-		_ = os.Unsetenv("AWS_SECRET_ACCESS_KEY")
-		_ = os.Unsetenv("AWS_SESSION_TOKEN")
-		_ = os.Unsetenv("SSH_KEY_PATH")
 	})
 
 	Describe("generateMinimalHostConfig", func() {
@@ -77,6 +76,225 @@ var _ = Describe("Deploy Manager", func() {
 			Expect(cm.Metadata.Namespace).To(Equal("multi-platform-controller"))
 			Expect(cm.Data).To(HaveKey("dynamic-platforms"))
 		})
+
+		readData := func(path string) map[string]string {
+			content, err := os.ReadFile(path)
+			Expect(err).NotTo(HaveOccurred())
+			var cm struct {
+				Data map[string]string `yaml:"data"`
+			}
+			Expect(yaml.Unmarshal(content, &cm)).To(Succeed())
+			return cm.Data
+		}
+
+		It("should use the default region, AMIs, instance types and max-instances", func() {
+			outputPath := filepath.Join(tempDir, "host-config.yaml")
+			Expect(manager.generateMinimalHostConfig(outputPath)).To(Succeed())
+
+			data := readData(outputPath)
+			Expect(data).To(HaveKeyWithValue("dynamic.linux-arm64.region", "us-east-1"))
+			Expect(data).To(HaveKeyWithValue("dynamic.linux-arm64.ami", "ami-03d8261904652a19c"))
+			Expect(data).To(HaveKeyWithValue("dynamic.linux-arm64.instance-type", "m6g.large"))
+			Expect(data).To(HaveKeyWithValue("dynamic.linux-mlarge-amd64.ami", "ami-0c02fb55b1a47c3c8"))
+			Expect(data).To(HaveKeyWithValue("dynamic.linux-mlarge-amd64.instance-type", "m6a.large"))
+			Expect(data).To(HaveKeyWithValue("dynamic.linux-amd64.max-instances", "10"))
+		})
+
+		It("should render overridden values", func() {
+			cfg.HostConfig = config.HostConfigSettings{
+				Region:            "eu-west-1",
+				ARM64AMI:          "ami-arm",
+				AMD64AMI:          "ami-amd",
+				ARM64InstanceType: "m7g.large",
+				AMD64InstanceType: "m7a.large",
+				MaxInstances:      2,
+			}
+			outputPath := filepath.Join(tempDir, "host-config.yaml")
+			Expect(manager.generateMinimalHostConfig(outputPath)).To(Succeed())
+
+			data := readData(outputPath)
+			for _, platform := range []string{"linux-arm64", "linux-mlarge-arm64", "linux-amd64", "linux-mlarge-amd64"} {
+				Expect(data).To(HaveKeyWithValue("dynamic."+platform+".region", "eu-west-1"))
+				Expect(data).To(HaveKeyWithValue("dynamic."+platform+".max-instances", "2"))
+			}
+			Expect(data).To(HaveKeyWithValue("dynamic.linux-mlarge-arm64.ami", "ami-arm"))
+			Expect(data).To(HaveKeyWithValue("dynamic.linux-mlarge-arm64.instance-type", "m7g.large"))
+			Expect(data).To(HaveKeyWithValue("dynamic.linux-amd64.ami", "ami-amd"))
+			Expect(data).To(HaveKeyWithValue("dynamic.linux-amd64.instance-type", "m7a.large"))
+		})
+
+		ibmPlatforms := []string{"linux-ibm/s390x", "linux-ibm/ppc64le"}
+
+		It("should leave out the IBM platforms by default", func() {
+			outputPath := filepath.Join(tempDir, "host-config.yaml")
+			Expect(manager.generateMinimalHostConfig(outputPath)).To(Succeed())
+
+			data := readData(outputPath)
+			Expect(splitPlatforms(data["dynamic-platforms"])).NotTo(ContainElements(ibmPlatforms))
+			for key := range data {
+				Expect(key).NotTo(HavePrefix("dynamic.linux-ibm-"))
+			}
+		})
+
+		It("should add the IBM platforms when IBM is enabled", func() {
+			cfg.HostConfig = config.HostConfigSettings{IBMRegion: "eu-de", IBMZone: "eu-de-1", IBMKey: "my-key",
+				IBMS390XImage: "r010-s390x", IBMPPC64LEImage: "rhel-ppc64le", IBMPowerWorkspace: "crn:v1:workspace"}
+			manager.ibmEnabled = true
+			outputPath := filepath.Join(tempDir, "host-config.yaml")
+			Expect(manager.generateMinimalHostConfig(outputPath)).To(Succeed())
+
+			data := readData(outputPath)
+			Expect(splitPlatforms(data["dynamic-platforms"])).To(ContainElements(ibmPlatforms))
+			Expect(data).To(HaveKeyWithValue("dynamic.linux-ibm-s390x.type", "ibmz"))
+			Expect(data).To(HaveKeyWithValue("dynamic.linux-ibm-s390x.region", "eu-de-1"))
+			Expect(data).To(HaveKeyWithValue("dynamic.linux-ibm-s390x.url", "https://eu-de.iaas.cloud.ibm.com/v1"))
+			Expect(data).To(HaveKeyWithValue("dynamic.linux-ibm-s390x.key", "my-key"))
+			Expect(data).To(HaveKeyWithValue("dynamic.linux-ibm-s390x.image-id", "r010-s390x"))
+			Expect(data).To(HaveKeyWithValue("dynamic.linux-ibm-s390x.ssh-secret", "ibm-s390x-ssh-key"))
+			Expect(data).To(HaveKeyWithValue("dynamic.linux-ibm-ppc64le.type", "ibmp"))
+			Expect(data).To(HaveKeyWithValue("dynamic.linux-ibm-ppc64le.url", "https://eu-de.power-iaas.cloud.ibm.com"))
+			Expect(data).To(HaveKeyWithValue("dynamic.linux-ibm-ppc64le.key", "my-key"))
+			Expect(data).To(HaveKeyWithValue("dynamic.linux-ibm-ppc64le.crn", "crn:v1:workspace"))
+			Expect(data).To(HaveKeyWithValue("dynamic.linux-ibm-ppc64le.image", "rhel-ppc64le"))
+			Expect(data).To(HaveKeyWithValue("dynamic.linux-ibm-ppc64le.secret", "ibmcloud-api-key"))
+			Expect(data).To(HaveKeyWithValue("dynamic.linux-ibm-ppc64le.ssh-secret", "ibm-ppc64le-ssh-key"))
+		})
+
+		It("should add the IBM platforms when MPC_HOST_CONFIG_IBM enables them", func() {
+			cfg.HostConfig = config.HostConfigSettings{IBMEnabled: true, IBMS390XImage: "r010-s390x",
+				IBMPPC64LEImage: "rhel-ppc64le", IBMPowerWorkspace: "crn:v1:workspace"}
+			outputPath := filepath.Join(tempDir, "host-config.yaml")
+			Expect(manager.generateMinimalHostConfig(outputPath)).To(Succeed())
+
+			data := readData(outputPath)
+			Expect(splitPlatforms(data["dynamic-platforms"])).To(ContainElements(ibmPlatforms))
+			Expect(data).To(HaveKeyWithValue("dynamic.linux-ibm-s390x.image-id", "r010-s390x"))
+			Expect(data).To(HaveKeyWithValue("dynamic.linux-ibm-ppc64le.crn", "crn:v1:workspace"))
+		})
+
+		It("should leave out each IBM platform whose image or workspace is not set", func() {
+			cfg.HostConfig = config.HostConfigSettings{IBMS390XImage: "r010-s390x", IBMPPC64LEImage: "rhel-ppc64le"}
+			manager.ibmEnabled = true
+			outputPath := filepath.Join(tempDir, "host-config.yaml")
+			Expect(manager.generateMinimalHostConfig(outputPath)).To(Succeed())
+
+			data := readData(outputPath)
+			Expect(splitPlatforms(data["dynamic-platforms"])).To(ContainElement("linux-ibm/s390x"))
+			Expect(splitPlatforms(data["dynamic-platforms"])).NotTo(ContainElement("linux-ibm/ppc64le"))
+			Expect(data).To(HaveKeyWithValue("dynamic.linux-ibm-s390x.image-id", "r010-s390x"))
+			for key := range data {
+				Expect(key).NotTo(HavePrefix("dynamic.linux-ibm-ppc64le."))
+			}
+		})
+
+		It("should render a configured template file instead of the built-in one", func() {
+			templatePath := filepath.Join(tempDir, "host-config.tmpl")
+			Expect(os.WriteFile(templatePath, []byte("data:\n  dynamic.linux-arm64.region: \"{{ .Region }}\"\n"), 0644)).To(Succeed())
+			cfg.HostConfig = config.HostConfigSettings{Region: "ap-south-1", TemplatePath: templatePath}
+
+			outputPath := filepath.Join(tempDir, "host-config.yaml")
+			Expect(manager.generateMinimalHostConfig(outputPath)).To(Succeed())
+			Expect(readData(outputPath)).To(Equal(map[string]string{"dynamic.linux-arm64.region": "ap-south-1"}))
+		})
+
+		It("should fail on a template referencing unknown settings", func() {
+			templatePath := filepath.Join(tempDir, "host-config.tmpl")
+			Expect(os.WriteFile(templatePath, []byte("{{ .Zone }}\n"), 0644)).To(Succeed())
+			cfg.HostConfig = config.HostConfigSettings{TemplatePath: templatePath}
+
+			err := manager.generateMinimalHostConfig(filepath.Join(tempDir, "host-config.yaml"))
+			Expect(err).To(MatchError(ContainSubstring("failed to render host-config template")))
+		})
+	})
+
+	Describe("ApplyKonflux", func() {
+		var (
+			konfluxCIDir string
+			originalPath string
+		)
+
+		BeforeEach(func() {
+			konfluxCIDir = filepath.Join(tempDir, "checkouts", "konflux-ci")
+			Expect(os.MkdirAll(konfluxCIDir, 0755)).To(Succeed())
+			for _, script := range konfluxScripts {
+				Expect(os.WriteFile(filepath.Join(konfluxCIDir, script), []byte("pwd >> ran.log\n"), 0755)).To(Succeed())
+			}
+
+			// The mock kubectl prints the minified kubeconfig of the requested context
+			binDir := filepath.Join(tempDir, "bin")
+			Expect(os.MkdirAll(binDir, 0755)).To(Succeed())
+			script := "#!/bin/sh\necho \"current-context: $(echo \"$@\" | sed -n 's/.*--context \\([^ ]*\\).*/\\1/p')\"\n"
+			Expect(os.WriteFile(filepath.Join(binDir, "kubectl"), []byte(script), 0755)).To(Succeed())
+			originalPath = os.Getenv("PATH")
+			_ = os.Setenv("PATH", binDir+":"+originalPath)
+		})
+
+		AfterEach(func() {
+			_ = os.Setenv("PATH", originalPath)
+		})
+
+		It("should run the scripts with a kubeconfig of the configured cluster", func() {
+			cfg.KonfluxCIPath = konfluxCIDir
+			Expect(os.WriteFile(filepath.Join(konfluxCIDir, "deploy-deps.sh"), []byte("cat \"$KUBECONFIG\" > kubeconfig.log\n"), 0755)).To(Succeed())
+
+			Expect(manager.ApplyKonflux(context.Background())).To(Succeed())
+			kubeconfig, err := os.ReadFile(filepath.Join(konfluxCIDir, "kubeconfig.log"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(kubeconfig)).To(Equal("current-context: kind-konflux\n"))
+		})
+
+		It("should run the scripts of the configured konflux-ci directory", func() {
+			cfg.MpcDevEnvPath = filepath.Join(tempDir, "elsewhere", "mpc_dev_env")
+			cfg.KonfluxCIPath = konfluxCIDir
+
+			Expect(manager.ApplyKonflux(context.Background())).To(Succeed())
+			ran, err := os.ReadFile(filepath.Join(konfluxCIDir, "ran.log"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(strings.Count(string(ran), konfluxCIDir)).To(Equal(len(konfluxScripts)))
+		})
+
+		It("should default to the konflux-ci directory next to mpc_dev_env", func() {
+			cfg.MpcDevEnvPath = filepath.Join(tempDir, "checkouts", "mpc_dev_env")
+
+			Expect(manager.ApplyKonflux(context.Background())).To(Succeed())
+			Expect(filepath.Join(konfluxCIDir, "ran.log")).To(BeAnExistingFile())
+		})
+
+		It("should report every missing script before running any", func() {
+			cfg.KonfluxCIPath = konfluxCIDir
+			Expect(os.Remove(filepath.Join(konfluxCIDir, "deploy-deps.sh"))).To(Succeed())
+			Expect(os.Remove(filepath.Join(konfluxCIDir, "deploy-test-resources.sh"))).To(Succeed())
+
+			err := manager.ApplyKonflux(context.Background())
+			Expect(err).To(MatchError(ContainSubstring("missing required scripts: deploy-deps.sh, deploy-test-resources.sh")))
+			Expect(filepath.Join(konfluxCIDir, "ran.log")).NotTo(BeAnExistingFile())
+		})
+
+		It("should fail a script that runs past its own timeout", func() {
+			cfg.KonfluxCIPath = konfluxCIDir
+			cfg.Timeouts.KonfluxScript = 200 * time.Millisecond
+			Expect(os.WriteFile(filepath.Join(konfluxCIDir, "deploy-konflux.sh"), []byte("echo installing operators\nexec sleep 10\n"), 0755)).To(Succeed())
+
+			started := time.Now()
+			err := manager.ApplyKonflux(context.Background())
+			Expect(err).To(MatchError(ContainSubstring("script deploy-konflux.sh timed out after 200ms")))
+			Expect(err).To(MatchError(ContainSubstring("installing operators")))
+			Expect(time.Since(started)).To(BeNumerically("<", 5*time.Second))
+			// The remaining script never ran
+			ran, _ := os.ReadFile(filepath.Join(konfluxCIDir, "ran.log"))
+			Expect(strings.Count(string(ran), konfluxCIDir)).To(Equal(1))
+		})
+
+		It("should end a failed script's error with the tail of its output", func() {
+			cfg.KonfluxCIPath = konfluxCIDir
+			Expect(os.WriteFile(filepath.Join(konfluxCIDir, "deploy-deps.sh"), []byte("exec 2>&1\nfor i in $(seq 1 30); do echo line $i; done\necho tekton not ready >&2\nexit 3\n"), 0755)).To(Succeed())
+
+			err := manager.ApplyKonflux(context.Background())
+			Expect(err).To(MatchError(ContainSubstring("script deploy-deps.sh failed: exit status 3")))
+			Expect(err.Error()).To(HaveSuffix("line 30\ntekton not ready"))
+			Expect(err.Error()).NotTo(ContainSubstring("line 11\n"))
+			Expect(err.Error()).To(ContainSubstring("line 12\n"))
+		})
 	})
 
 	Describe("Functions with kubectl", func() {
@@ -92,6 +310,7 @@ var _ = Describe("Deploy Manager", func() {
 			// Create a mock kubectl script that records its arguments and simulates resource state
 			logFile := filepath.Join(tempDir, "kubectl_calls.log")
 			stateFile := filepath.Join(tempDir, "kubectl_state.log")
+			dataFile := filepath.Join(tempDir, "kubectl_secret_data.log")
 			script := fmt.Sprintf(`#!/bin/sh
 echo "$@" >> %s
 
@@ -169,6 +388,15 @@ fi
 # Handle 'kubectl create secret'
 if [ "$1" = "create" ] && [ "$2" = "secret" ]; then
   SECRET_NAME=$4
+  # Record the secret data read from --from-file sources as "<secret> <key>=<value>"
+  for arg in "$@"; do
+    case "$arg" in
+      --from-file=*)
+        SOURCE=${arg#--from-file=}
+        echo "${SECRET_NAME} ${SOURCE%%%%=*}=$(cat "${SOURCE#*=}")" >> %s
+        ;;
+    esac
+  done
   if ! resource_exists "secrets" "${SECRET_NAME}"; then
     add_resource "secrets" "${SECRET_NAME}"
     echo "secret/${SECRET_NAME} created"
@@ -192,7 +420,7 @@ fi
 
 # Default exit for other commands
 exit 0
-`, logFile, stateFile, stateFile, stateFile, stateFile, stateFile, stateFile, stateFile) //nolint:lll
+`, logFile, stateFile, stateFile, stateFile, stateFile, stateFile, stateFile, stateFile, dataFile) //nolint:lll
 			Expect(os.WriteFile(mockKubectlPath, []byte(script), 0755)).To(Succeed())
 			_ = os.Setenv("PATH", tempDir+":"+originalPath)
 		})
@@ -201,6 +429,118 @@ exit 0
 			_ = os.Setenv("PATH", originalPath)
 		})
 
+		Describe("CheckClusterReachable", func() {
+			BeforeEach(func() {
+				// kubectl as it behaves without a cluster
+				script := fmt.Sprintf(`#!/bin/sh
+echo "$@" >> %s
+echo "The connection to the server localhost:8080 was refused - did you specify the right host or port?" >&2
+exit 1
+`, filepath.Join(tempDir, "kubectl_calls.log"))
+				Expect(os.WriteFile(mockKubectlPath, []byte(script), 0755)).To(Succeed())
+			})
+
+			It("should report a friendly error when there is no cluster", func() {
+				err := CheckClusterReachable(context.Background(), "", "")
+				Expect(err).To(MatchError(ErrClusterNotRunning))
+				Expect(err.Error()).To(ContainSubstring("POST /api/cluster/start"))
+			})
+
+			It("should short-circuit multi-step deployments before their first step", func() {
+				minimal := NewMinimalDeployer(cfg)
+				applySecrets := func(ctx context.Context) error {
+					return manager.ApplySecrets(ctx, AWSCredentials{})
+				}
+				deployMinimalStack := func(ctx context.Context) error {
+					return minimal.DeployMinimalStack(ctx, nil)
+				}
+				for _, deploy := range []func(context.Context) error{manager.Deploy, applySecrets, deployMinimalStack} {
+					_ = os.Remove(filepath.Join(tempDir, "kubectl_calls.log"))
+
+					err := deploy(context.Background())
+					Expect(errors.Is(err, ErrClusterNotRunning)).To(BeTrue(), "got %v", err)
+
+					calls, err := os.ReadFile(filepath.Join(tempDir, "kubectl_calls.log"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(strings.TrimSpace(string(calls))).To(Equal("cluster-info --request-timeout=10s --context kind-konflux"))
+				}
+			})
+		})
+
+		Describe("transient kubectl errors", func() {
+			var callsFile string
+
+			// writeFlakyKubectl makes kubectl fail its first failures calls with message
+			// on stderr, then succeed; the first three arguments of every call are
+			// recorded in callsFile, one call per line
+			writeFlakyKubectl := func(failures int, message string) {
+				counterFile := filepath.Join(tempDir, "kubectl_attempts")
+				script := fmt.Sprintf(`#!/bin/sh
+echo "$1 $2 $3" >> %s
+ATTEMPTS=$(cat %s 2>/dev/null || echo 0)
+ATTEMPTS=$((ATTEMPTS + 1))
+echo $ATTEMPTS > %s
+if [ $ATTEMPTS -le %d ]; then
+  echo "%s" >&2
+  exit 1
+fi
+echo "deployment.apps/multi-platform-controller patched"
+`, callsFile, counterFile, counterFile, failures, message)
+				Expect(os.WriteFile(mockKubectlPath, []byte(script), 0755)).To(Succeed())
+			}
+
+			calls := func() []string {
+				data, err := os.ReadFile(callsFile)
+				Expect(err).NotTo(HaveOccurred())
+				return strings.Split(strings.TrimSpace(string(data)), "\n")
+			}
+
+			BeforeEach(func() {
+				callsFile = filepath.Join(tempDir, "kubectl_calls.log")
+				manager.retryDelay = 10 * time.Millisecond
+			})
+
+			It("should retry until the API server answers", func() {
+				writeFlakyKubectl(2, "The connection to the server 127.0.0.1:6443 was refused - did you specify the right host or port?")
+
+				Expect(manager.patchMPCDeployment(context.Background())).To(Succeed())
+				Expect(calls()).To(HaveLen(3))
+				Expect(calls()[2]).To(Equal("patch deployment multi-platform-controller"))
+			})
+
+			It("should retry applying the MPC manifests", func() {
+				Expect(os.MkdirAll(filepath.Join(tempDir, "mpc", "deploy", "operator"), 0755)).To(Succeed())
+				cfg.MpcRepoPath = filepath.Join(tempDir, "mpc")
+				writeFlakyKubectl(2, "dial tcp 127.0.0.1:6443: connect: connection refused")
+
+				Expect(manager.applyMPCManifests(context.Background())).To(Succeed())
+				Expect(calls()).To(HaveLen(3))
+			})
+
+			It("should give up after the configured number of attempts", func() {
+				cfg.KubectlAttempts = 2
+				writeFlakyKubectl(5, "net/http: TLS handshake timeout")
+
+				Expect(manager.patchMPCDeployment(context.Background())).To(HaveOccurred())
+				Expect(calls()).To(HaveLen(2))
+			})
+
+			It("should not retry rollout waits", func() {
+				writeFlakyKubectl(1, "Unable to connect to the server: net/http: TLS handshake timeout")
+
+				deployments := &kubectlDeployments{manager: manager}
+				Expect(deployments.waitRollout(context.Background(), mpcDeploymentName, time.Minute)).To(HaveOccurred())
+				Expect(calls()).To(Equal([]string{"rollout status deployment/multi-platform-controller"}))
+			})
+
+			It("should not retry errors that are not transient", func() {
+				writeFlakyKubectl(1, `The Deployment "multi-platform-controller" is invalid: spec.template.spec.containers[0].image: Required value`)
+
+				Expect(manager.patchMPCDeployment(context.Background())).To(HaveOccurred())
+				Expect(calls()).To(HaveLen(1))
+			})
+		})
+
 		Describe("deployHostConfig", func() {
 			It("should auto-generate host-config and apply it via kubectl", func() {
 				err := manager.deployHostConfig(context.Background())
@@ -214,55 +554,574 @@ exit 0
 				Expect(string(calls)).To(ContainSubstring("get configmap host-config -n multi-platform-controller"))
 				Expect(string(calls)).To(ContainSubstring("apply -f " + filepath.Join(tempDir, "host-config.yaml") + " -n multi-platform-controller"))
 			})
+
+			It("should refuse an invalid host-config without applying it", func() {
+				invalid := "kind: ConfigMap\ndata:\n  host.s390x-dev.address: \"127.0.0.1\"\n"
+				Expect(os.WriteFile(filepath.Join(tempDir, "host-config.yaml"), []byte(invalid), 0644)).To(Succeed())
+
+				err := manager.deployHostConfig(context.Background())
+				Expect(err).To(MatchError(ContainSubstring("host.s390x-dev.platform is missing")))
+
+				calls, err := os.ReadFile(filepath.Join(tempDir, "kubectl_calls.log"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(calls)).NotTo(ContainSubstring("apply -f"))
+			})
 		})
 
 		Describe("ApplySecrets", func() {
 			var sshKeyPath string
+			var creds AWSCredentials
 
 			BeforeEach(func() {
 				sshKeyPath = filepath.Join(tempDir, "id_rsa")
 				Expect(os.WriteFile(sshKeyPath, []byte("fake-ssh-key"), 0600)).To(Succeed())
-				_ = os.Setenv("AWS_ACCESS_KEY_ID", "test-key-id")
-				_ = os.Setenv("AWS_SECRET_ACCESS_KEY", "test-secret-key")
-				_ = os.Setenv("SSH_KEY_PATH", sshKeyPath)
-			})
-
-			AfterEach(func() {
-				_ = os.Unsetenv("AWS_ACCESS_KEY_ID")
-				_ = os.Unsetenv("AWS_SECRET_ACCESS_KEY")
-				_ = os.Unsetenv("AWS_SESSION_TOKEN")
-				_ = os.Unsetenv("SSH_KEY_PATH")
+				creds = AWSCredentials{
+					AccessKeyID:     "test-key-id",
+					SecretAccessKey: "test-secret-key",
+					SSHKeyPath:      sshKeyPath,
+				}
 			})
 
 			It("should create aws and ssh secrets via kubectl without session token", func() {
-				// Ensure session token is NOT set for this test
-				_ = os.Unsetenv("AWS_SESSION_TOKEN")
-
-				err := manager.ApplySecrets(context.Background())
+				err := manager.ApplySecrets(context.Background(), creds)
 				Expect(err).NotTo(HaveOccurred())
 
 				calls, err := os.ReadFile(filepath.Join(tempDir, "kubectl_calls.log"))
 				Expect(err).NotTo(HaveOccurred())
 
-				Expect(string(calls)).To(ContainSubstring("create secret generic aws-account --from-literal=access-key-id=test-key-id --from-literal=secret-access-key=test-secret-key --namespace multi-platform-controller"))
+				Expect(string(calls)).To(ContainSubstring("create secret generic aws-account --from-file=access-key-id="))
+				Expect(string(calls)).To(ContainSubstring("label secret aws-account build.appstudio.redhat.com/multi-platform-secret=true -n multi-platform-controller"))
 				Expect(string(calls)).To(ContainSubstring("create secret generic aws-ssh-key --from-file=id_rsa=" + sshKeyPath + " --namespace multi-platform-controller"))
 				Expect(string(calls)).To(ContainSubstring("get secret aws-account -n multi-platform-controller"))
-				// Session token should NOT appear when env var is not set
+				// Session token should NOT appear when it is not set
 				Expect(string(calls)).NotTo(ContainSubstring("session-token"))
+
+				data, err := os.ReadFile(filepath.Join(tempDir, "kubectl_secret_data.log"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(data)).To(ContainSubstring("aws-account access-key-id=test-key-id\n"))
+				Expect(string(data)).To(ContainSubstring("aws-account secret-access-key=test-secret-key\n"))
 			})
 
-			It("should include session token in aws-account secret when AWS_SESSION_TOKEN is set", func() {
-				_ = os.Setenv("AWS_SESSION_TOKEN", "test-session-token-value")
+			It("should keep credential values out of kubectl's arguments", func() {
+				creds.SessionToken = "test-session-token-value"
+
+				Expect(manager.ApplySecrets(context.Background(), creds)).To(Succeed())
 
-				err := manager.ApplySecrets(context.Background())
+				// The arguments are visible to every local user in the process list
+				calls, err := os.ReadFile(filepath.Join(tempDir, "kubectl_calls.log"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(calls)).NotTo(ContainSubstring("--from-literal"))
+				for _, value := range []string{"test-key-id", "test-secret-key", "test-session-token-value"} {
+					Expect(string(calls)).NotTo(ContainSubstring(value))
+				}
+
+				// The files holding the values are removed once the secret is created
+				for _, line := range strings.Split(string(calls), "\n") {
+					for _, arg := range strings.Fields(line) {
+						if source, ok := strings.CutPrefix(arg, "--from-file="); ok && !strings.HasPrefix(source, "id_rsa=") {
+							_, path, _ := strings.Cut(source, "=")
+							Expect(path).NotTo(BeAnExistingFile())
+						}
+					}
+				}
+			})
+
+			It("should include session token in aws-account secret when it is set", func() {
+				creds.SessionToken = "test-session-token-value"
+
+				err := manager.ApplySecrets(context.Background(), creds)
 				Expect(err).NotTo(HaveOccurred())
 
 				calls, err := os.ReadFile(filepath.Join(tempDir, "kubectl_calls.log"))
 				Expect(err).NotTo(HaveOccurred())
 
-				Expect(string(calls)).To(ContainSubstring("--from-literal=session-token=test-session-token-value"))
-				Expect(string(calls)).To(ContainSubstring("create secret generic aws-account --from-literal=access-key-id=test-key-id --from-literal=secret-access-key=test-secret-key --namespace multi-platform-controller"))
+				Expect(string(calls)).To(ContainSubstring("--from-file=session-token="))
 				Expect(string(calls)).To(ContainSubstring("create secret generic aws-ssh-key --from-file=id_rsa=" + sshKeyPath + " --namespace multi-platform-controller"))
+
+				data, err := os.ReadFile(filepath.Join(tempDir, "kubectl_secret_data.log"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(data)).To(ContainSubstring("aws-account session-token=test-session-token-value\n"))
+			})
+
+			It("should use only the passed credentials, not the environment", func() {
+				GinkgoT().Setenv("AWS_ACCESS_KEY_ID", "env-key-id")
+				GinkgoT().Setenv("AWS_SECRET_ACCESS_KEY", "env-secret-key")
+
+				Expect(manager.ApplySecrets(context.Background(), creds)).To(Succeed())
+
+				data, err := os.ReadFile(filepath.Join(tempDir, "kubectl_secret_data.log"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(data)).To(ContainSubstring("aws-account access-key-id=test-key-id\n"))
+				Expect(string(data)).NotTo(ContainSubstring("env-key-id"))
+
+				// Credentials in the environment do not stand in for missing ones
+				err = manager.ApplySecrets(context.Background(), AWSCredentials{SSHKeyPath: sshKeyPath})
+				Expect(err).To(MatchError(ContainSubstring("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set")))
+			})
+		})
+
+		Describe("image references", func() {
+			BeforeEach(func() {
+				cfg.ControllerImage = "quay.io/test/multi-platform-controller:dev"
+				cfg.OTPImage = "quay.io/test/multi-platform-otp:dev"
+			})
+
+			// writeImagesKubectl makes kubectl report the given images for the controller and OTP server deployments
+			writeImagesKubectl := func(controllerImage, otpImage string) {
+				script := fmt.Sprintf(`#!/bin/sh
+case "$3" in
+  multi-platform-controller) printf '%s' ;;
+  multi-platform-otp-server) printf '%s' ;;
+esac
+`, controllerImage, otpImage)
+				Expect(os.WriteFile(mockKubectlPath, []byte(script), 0755)).To(Succeed())
+			}
+
+			It("should patch the deployments with the configured images", func() {
+				Expect(manager.patchMPCDeployment(context.Background())).To(Succeed())
+				Expect(manager.patchOTPDeployment(context.Background())).To(Succeed())
+
+				calls, err := os.ReadFile(filepath.Join(tempDir, "kubectl_calls.log"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(calls)).To(ContainSubstring(`"value": "` + cfg.GetControllerImage() + `"`))
+				Expect(string(calls)).To(ContainSubstring(`"value": "` + cfg.GetOTPImage() + `"`))
+				Expect(string(calls)).To(ContainSubstring(`"value": "Never"`))
+			})
+
+			It("should patch the registry images with imagePullPolicy Always when a registry is configured", func() {
+				cfg.RegistryURL = "localhost:5001"
+
+				Expect(manager.patchMPCDeployment(context.Background())).To(Succeed())
+				Expect(manager.patchOTPDeployment(context.Background())).To(Succeed())
+
+				calls, err := os.ReadFile(filepath.Join(tempDir, "kubectl_calls.log"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(calls)).To(ContainSubstring(`"value": "localhost:5001/multi-platform-controller:dev"`))
+				Expect(string(calls)).To(ContainSubstring(`"value": "localhost:5001/multi-platform-otp:dev"`))
+				Expect(string(calls)).To(ContainSubstring(`"value": "Always"`))
+				Expect(string(calls)).NotTo(ContainSubstring(`"value": "Never"`))
+			})
+
+			It("should verify the controller against the configured image", func() {
+				writeImagesKubectl(cfg.GetControllerImage(), cfg.GetOTPImage())
+				Expect(manager.verifyDeploymentImages(context.Background())).To(Succeed())
+
+				cfg.ControllerImage = config.DefaultControllerImage
+				Expect(manager.verifyDeploymentImages(context.Background())).To(MatchError(ContainSubstring("controller using wrong image")))
+			})
+
+			It("should verify the OTP server against the configured image", func() {
+				writeImagesKubectl(cfg.GetControllerImage(), "quay.io/test/multi-platform-otp:stale")
+
+				err := manager.verifyDeploymentImages(context.Background())
+				Expect(err).To(MatchError(ContainSubstring("OTP server using wrong image: quay.io/test/multi-platform-otp:stale (expected: " + cfg.GetOTPImage() + ")")))
+				Expect(err.Error()).NotTo(ContainSubstring("controller"))
+			})
+
+			It("should patch and verify the revision-tagged images when a source git hash is set", func() {
+				manager.sourceGitHash = "0123456789abcdef0123456789abcdef01234567"
+
+				Expect(manager.patchMPCDeployment(context.Background())).To(Succeed())
+				Expect(manager.patchOTPDeployment(context.Background())).To(Succeed())
+
+				calls, err := os.ReadFile(filepath.Join(tempDir, "kubectl_calls.log"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(calls)).To(ContainSubstring(`"value": "quay.io/test/multi-platform-controller:0123456789ab"`))
+				Expect(string(calls)).To(ContainSubstring(`"value": "quay.io/test/multi-platform-otp:0123456789ab"`))
+
+				writeImagesKubectl("quay.io/test/multi-platform-controller:0123456789ab", "quay.io/test/multi-platform-otp:0123456789ab")
+				Expect(manager.verifyDeploymentImages(context.Background())).To(Succeed())
+			})
+		})
+
+		Describe("Rollback", func() {
+			BeforeEach(func() {
+				// Deployments run the images of an earlier deploy; patch JSON is flattened onto one line
+				script := fmt.Sprintf(`#!/bin/sh
+echo "$@" | tr '\n' ' ' >> %[1]s
+echo >> %[1]s
+if [ "$1" = "get" ] && [ "$2" = "deployment" ]; then
+  case "$3" in
+    multi-platform-controller) printf 'localhost/multi-platform-controller:aaaaaaaaaaaa' ;;
+    multi-platform-otp-server) printf 'localhost/multi-platform-otp:aaaaaaaaaaaa' ;;
+  esac
+fi
+exit 0
+`, filepath.Join(tempDir, "kubectl_calls.log"))
+				Expect(os.WriteFile(mockKubectlPath, []byte(script), 0755)).To(Succeed())
+			})
+
+			It("should record the running images before deploying other ones", func() {
+				manager.sourceGitHash = "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+				manager.recordPreviousImages(context.Background())
+
+				Expect(manager.PreviousImages()).To(Equal(&Images{
+					Controller: "localhost/multi-platform-controller:aaaaaaaaaaaa",
+					OTP:        "localhost/multi-platform-otp:aaaaaaaaaaaa",
+				}))
+			})
+
+			It("should not record the images being redeployed as their own rollback target", func() {
+				manager.sourceGitHash = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+				manager.recordPreviousImages(context.Background())
+
+				Expect(manager.PreviousImages()).To(BeNil())
+			})
+
+			It("should patch the deployments back to the previous images and restart them", func() {
+				manager.SetPreviousImages(Images{
+					Controller: "localhost/multi-platform-controller:aaaaaaaaaaaa",
+					OTP:        "localhost/multi-platform-otp:aaaaaaaaaaaa",
+				})
+
+				Expect(manager.Rollback(context.Background())).To(Succeed())
+
+				calls, err := os.ReadFile(filepath.Join(tempDir, "kubectl_calls.log"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(calls)).To(MatchRegexp(`patch deployment multi-platform-controller .*"value": "localhost/multi-platform-controller:aaaaaaaaaaaa"`))
+				Expect(string(calls)).To(MatchRegexp(`patch deployment multi-platform-otp-server .*"value": "localhost/multi-platform-otp:aaaaaaaaaaaa"`))
+				Expect(string(calls)).To(ContainSubstring(`"value": "IfNotPresent"`))
+				Expect(string(calls)).To(ContainSubstring("rollout restart deployment/multi-platform-controller"))
+			})
+
+			It("should refuse to roll back to a mutable tag without calling kubectl", func() {
+				manager.SetPreviousImages(Images{
+					Controller: "localhost/multi-platform-controller:latest",
+					OTP:        "localhost/multi-platform-otp:aaaaaaaaaaaa",
+				})
+
+				err := manager.Rollback(context.Background())
+				Expect(err).To(MatchError(ErrMutablePreviousImage))
+				Expect(err).To(MatchError(ContainSubstring("localhost/multi-platform-controller:latest")))
+				Expect(filepath.Join(tempDir, "kubectl_calls.log")).NotTo(BeAnExistingFile())
+			})
+
+			It("should return ErrNoPreviousImages without calling kubectl when nothing is recorded", func() {
+				Expect(manager.Rollback(context.Background())).To(MatchError(ErrNoPreviousImages))
+				Expect(filepath.Join(tempDir, "kubectl_calls.log")).NotTo(BeAnExistingFile())
+			})
+		})
+
+		Describe("CheckRollbackTarget", func() {
+			It("should accept images referenced by digest or a revision tag", func() {
+				Expect(CheckRollbackTarget(Images{
+					Controller: "localhost:5001/multi-platform-controller:aaaaaaaaaaaa",
+					OTP:        "quay.io/me/multi-platform-otp@sha256:0123456789abcdef",
+				})).To(Succeed())
+			})
+
+			It("should reject images referenced by latest or without a tag", func() {
+				Expect(CheckRollbackTarget(Images{Controller: "localhost:5001/multi-platform-controller"})).
+					To(MatchError(ErrMutablePreviousImage))
+				Expect(CheckRollbackTarget(Images{
+					Controller: "localhost/multi-platform-controller:aaaaaaaaaaaa",
+					OTP:        "localhost/multi-platform-otp:latest",
+				})).To(MatchError(ErrMutablePreviousImage))
+			})
+		})
+
+		Describe("Restart", func() {
+			BeforeEach(func() {
+				script := fmt.Sprintf("#!/bin/sh\necho \"$@\" >> %s\nexit 0\n", filepath.Join(tempDir, "kubectl_calls.log"))
+				Expect(os.WriteFile(mockKubectlPath, []byte(script), 0755)).To(Succeed())
+			})
+
+			rolloutCalls := func() []string {
+				calls, err := os.ReadFile(filepath.Join(tempDir, "kubectl_calls.log"))
+				Expect(err).NotTo(HaveOccurred())
+				var rollouts []string
+				for _, call := range strings.Split(strings.TrimSpace(string(calls)), "\n") {
+					if strings.HasPrefix(call, "rollout ") {
+						rollouts = append(rollouts, call)
+					}
+				}
+				return rollouts
+			}
+
+			It("should restart both deployments and wait for their rollouts", func() {
+				Expect(manager.Restart(context.Background(), "")).To(Succeed())
+
+				Expect(rolloutCalls()).To(Equal([]string{
+					"rollout restart deployment/multi-platform-controller -n multi-platform-controller --context kind-konflux",
+					"rollout restart deployment/multi-platform-otp-server -n multi-platform-controller --context kind-konflux",
+					"rollout status deployment/multi-platform-controller -n multi-platform-controller --timeout=5m0s --context kind-konflux",
+					"rollout status deployment/multi-platform-otp-server -n multi-platform-controller --timeout=5m0s --context kind-konflux",
+				}))
+			})
+
+			It("should restart only the selected component", func() {
+				Expect(manager.Restart(context.Background(), ComponentOTP)).To(Succeed())
+
+				Expect(rolloutCalls()).To(Equal([]string{
+					"rollout restart deployment/multi-platform-otp-server -n multi-platform-controller --context kind-konflux",
+					"rollout status deployment/multi-platform-otp-server -n multi-platform-controller --timeout=5m0s --context kind-konflux",
+				}))
+			})
+
+			It("should pass the rollout timeout to kubectl unchanged", func() {
+				deployments := &kubectlDeployments{manager: manager}
+				Expect(deployments.waitRollout(context.Background(), otpDeploymentName, 30*time.Second)).To(Succeed())
+
+				Expect(rolloutCalls()).To(Equal([]string{
+					"rollout status deployment/multi-platform-otp-server -n multi-platform-controller --timeout=30s --context kind-konflux",
+				}))
+			})
+
+			It("should reject unknown components without calling kubectl", func() {
+				Expect(manager.Restart(context.Background(), "operator")).To(MatchError(ErrUnknownComponent))
+				Expect(filepath.Join(tempDir, "kubectl_calls.log")).NotTo(BeAnExistingFile())
+			})
+		})
+
+		Describe("readiness diagnostics", func() {
+			BeforeEach(func() {
+				// The controller rollout times out because its image cannot be pulled
+				script := `#!/bin/sh
+case "$1 $2" in
+  "rollout status")
+    echo 'error: timed out waiting for the condition' >&2
+    exit 1 ;;
+  "describe deployment")
+    echo 'Name: multi-platform-controller'
+    echo 'Conditions:'
+    echo '  Available  False  MinimumReplicasUnavailable' ;;
+  "get events")
+    echo 'LAST SEEN  TYPE     REASON   OBJECT                                      MESSAGE'
+    echo '10s        Warning  Failed   pod/multi-platform-otp-server-5d8f-abcde    Error: ErrImagePull'
+    echo '5s         Warning  Failed   pod/multi-platform-controller-7c9b-xyz12    Error: ImagePullBackOff' ;;
+  "logs deployment/multi-platform-controller")
+    echo 'Error from server (BadRequest): container "manager" is waiting to start' >&2
+    exit 1 ;;
+esac
+exit 0
+`
+				Expect(os.WriteFile(mockKubectlPath, []byte(script), 0755)).To(Succeed())
+			})
+
+			It("should append the deployment's conditions and pod events to a rollout timeout", func() {
+				err := manager.restartDeployments(context.Background())
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(HavePrefix("failed to wait for controller rollout: exit status 1\n"))
+				Expect(err.Error()).To(ContainSubstring("diagnostics for deployment multi-platform-controller"))
+				Expect(err.Error()).To(ContainSubstring("MinimumReplicasUnavailable"))
+				Expect(err.Error()).To(ContainSubstring("pod/multi-platform-controller-7c9b-xyz12    Error: ImagePullBackOff"))
+				Expect(err.Error()).NotTo(ContainSubstring("multi-platform-otp-server-5d8f"))
+				Expect(err.Error()).NotTo(ContainSubstring("recent logs"))
+			})
+
+			It("should keep only the last lines of each section", func() {
+				lines := make([]string, 50)
+				for i := range lines {
+					lines[i] = fmt.Sprintf("line %d", i)
+				}
+				script := fmt.Sprintf("#!/bin/sh\n[ \"$1\" = \"describe\" ] && printf '%s\\n'\nexit 1\n", strings.Join(lines, `\n`))
+				Expect(os.WriteFile(mockKubectlPath, []byte(script), 0755)).To(Succeed())
+
+				diagnostics := manager.collectDeploymentDiagnostics(context.Background(), mpcDeploymentName)
+				Expect(diagnostics).To(ContainSubstring("...\nline 30\n"))
+				Expect(diagnostics).To(HaveSuffix("line 49"))
+				Expect(diagnostics).NotTo(ContainSubstring("line 29\n"))
+			})
+		})
+
+		Describe("Deploy in dry-run mode", func() {
+			BeforeEach(func() {
+				mpcRepoPath := filepath.Join(tempDir, "multi-platform-controller")
+				Expect(os.MkdirAll(filepath.Join(mpcRepoPath, "deploy", "operator"), 0755)).To(Succeed())
+				cfg.MpcRepoPath = mpcRepoPath
+				cfg.MpcDevEnvPath = tempDir
+
+				// Missing namespace, existing host-config: exercises create, delete, apply, and patch.
+				// Calls are logged one per line, with the multi-line patch JSON flattened.
+				script := fmt.Sprintf(`#!/bin/sh
+echo "$@" | tr '\n' ' ' >> %[1]s
+echo >> %[1]s
+[ "$1 $2" = "get namespace" ] && exit 1
+exit 0
+`, filepath.Join(tempDir, "kubectl_calls.log"))
+				Expect(os.WriteFile(mockKubectlPath, []byte(script), 0755)).To(Succeed())
+
+				manager.dryRun = true
+			})
+
+			It("should send every mutating command with --dry-run=server", func() {
+				Expect(manager.Deploy(context.Background())).To(Succeed())
+
+				calls, err := os.ReadFile(filepath.Join(tempDir, "kubectl_calls.log"))
+				Expect(err).NotTo(HaveOccurred())
+
+				mutating := 0
+				for _, call := range strings.Split(strings.TrimSpace(string(calls)), "\n") {
+					verb := strings.Fields(call)[0]
+					if verb == "get" || verb == "cluster-info" {
+						continue
+					}
+					mutating++
+					Expect(strings.TrimSpace(call)).To(ContainSubstring(" --dry-run=server"), "kubectl %s", call)
+				}
+				// create namespace, delete + apply host-config, apply -k, and two patches
+				Expect(mutating).To(Equal(6))
+			})
+
+			It("should not restart or verify the deployments", func() {
+				Expect(manager.Deploy(context.Background())).To(Succeed())
+
+				calls, err := os.ReadFile(filepath.Join(tempDir, "kubectl_calls.log"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(calls)).NotTo(ContainSubstring("rollout"))
+				Expect(string(calls)).NotTo(ContainSubstring("jsonpath"))
+			})
+
+			It("should report each step it runs as it starts", func() {
+				var steps []DeployStep
+				manager.onStep = func(step DeployStep) { steps = append(steps, step) }
+
+				Expect(manager.Deploy(context.Background())).To(Succeed())
+
+				Expect(steps).To(Equal([]DeployStep{
+					{Index: 1, Total: 4, Name: StepHostConfig},
+					{Index: 2, Total: 4, Name: StepApplyManifests},
+					{Index: 3, Total: 4, Name: StepPatchController},
+					{Index: 4, Total: 4, Name: StepPatchOTP},
+				}))
+			})
+		})
+
+		Describe("Deploy step progress", func() {
+			BeforeEach(func() {
+				mpcRepoPath := filepath.Join(tempDir, "multi-platform-controller")
+				Expect(os.MkdirAll(filepath.Join(mpcRepoPath, "deploy", "operator"), 0755)).To(Succeed())
+				cfg.MpcRepoPath = mpcRepoPath
+				cfg.MpcDevEnvPath = tempDir
+			})
+
+			It("should stop reporting at the step that failed", func() {
+				script := "#!/bin/sh\n[ \"$1 $2\" = \"apply -k\" ] && exit 1\nexit 0\n"
+				Expect(os.WriteFile(mockKubectlPath, []byte(script), 0755)).To(Succeed())
+
+				var steps []DeployStep
+				err := DeployMPC(context.Background(), cfg, DeployOptions{
+					OnStep: func(step DeployStep) { steps = append(steps, step) },
+				})
+
+				Expect(err).To(MatchError(ContainSubstring("failed to apply MPC manifests")))
+				Expect(steps).To(Equal([]DeployStep{
+					{Index: 1, Total: 8, Name: StepHostConfig},
+					{Index: 2, Total: 8, Name: StepApplyManifests},
+				}))
+			})
+		})
+
+		Describe("Undeploy", func() {
+			var mpcRepoPath string
+
+			// writeKubectl replaces the shared mock with one that logs calls and runs body
+			writeKubectl := func(body string) {
+				script := fmt.Sprintf("#!/bin/sh\necho \"$@\" >> %s\n%s\nexit 0\n", filepath.Join(tempDir, "kubectl_calls.log"), body)
+				Expect(os.WriteFile(mockKubectlPath, []byte(script), 0755)).To(Succeed())
+			}
+
+			BeforeEach(func() {
+				mpcRepoPath = filepath.Join(tempDir, "multi-platform-controller")
+				Expect(os.MkdirAll(filepath.Join(mpcRepoPath, "deploy", "operator"), 0755)).To(Succeed())
+				Expect(os.MkdirAll(filepath.Join(mpcRepoPath, "deploy", "otp"), 0755)).To(Succeed())
+				cfg.MpcRepoPath = mpcRepoPath
+			})
+
+			It("should delete the OTP server, operator, and host-config ConfigMap", func() {
+				writeKubectl("")
+
+				Expect(manager.Undeploy(context.Background())).To(Succeed())
+
+				calls, err := os.ReadFile(filepath.Join(tempDir, "kubectl_calls.log"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(strings.Split(strings.TrimSpace(string(calls)), "\n")).To(Equal([]string{
+					"delete -k " + filepath.Join(mpcRepoPath, "deploy", "otp") + " --ignore-not-found --context kind-konflux",
+					"delete -k " + filepath.Join(mpcRepoPath, "deploy", "operator") + " --ignore-not-found --context kind-konflux",
+					"delete configmap host-config -n multi-platform-controller --ignore-not-found --context kind-konflux",
+				}))
+			})
+
+			It("should swallow errors for resources that are already absent", func() {
+				writeKubectl(`echo 'Error from server (NotFound): namespaces "multi-platform-controller" not found' >&2
+exit 1`)
+
+				Expect(manager.Undeploy(context.Background())).To(Succeed())
+
+				calls, err := os.ReadFile(filepath.Join(tempDir, "kubectl_calls.log"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(calls)).To(ContainSubstring("delete configmap host-config"))
+			})
+
+			It("should return other kubectl errors", func() {
+				writeKubectl(`echo 'The connection to the server localhost:8080 was refused' >&2
+exit 1`)
+
+				err := manager.Undeploy(context.Background())
+				Expect(err).To(MatchError(ContainSubstring("connection to the server")))
+				Expect(err).To(MatchError(ContainSubstring("failed to delete MPC otp manifests")))
+			})
+
+			It("should fail when the MPC deploy directories are missing", func() {
+				writeKubectl("")
+				Expect(os.RemoveAll(filepath.Join(mpcRepoPath, "deploy", "otp"))).To(Succeed())
+
+				err := manager.Undeploy(context.Background())
+				Expect(err).To(MatchError(ContainSubstring("deployment directory not found")))
+			})
+		})
+
+		Describe("ApplyIBMSecrets", func() {
+			var s390xKeyPath, ppc64leKeyPath string
+			var creds IBMCredentials
+
+			BeforeEach(func() {
+				s390xKeyPath = filepath.Join(tempDir, "ibm_s390x_id_rsa")
+				ppc64leKeyPath = filepath.Join(tempDir, "ibm_ppc64le_id_rsa")
+				Expect(os.WriteFile(s390xKeyPath, []byte("fake-s390x-key"), 0600)).To(Succeed())
+				Expect(os.WriteFile(ppc64leKeyPath, []byte("fake-ppc64le-key"), 0600)).To(Succeed())
+				creds = IBMCredentials{
+					S390XSSHKeyPath:   s390xKeyPath,
+					PPC64LESSHKeyPath: ppc64leKeyPath,
+					APIKey:            "test-api-key",
+				}
+			})
+
+			It("should create and label the IBM SSH key and API key secrets via kubectl", func() {
+				err := manager.ApplyIBMSecrets(context.Background(), creds)
+				Expect(err).NotTo(HaveOccurred())
+
+				calls, err := os.ReadFile(filepath.Join(tempDir, "kubectl_calls.log"))
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(string(calls)).To(ContainSubstring("create secret generic ibm-s390x-ssh-key --from-file=id_rsa=" + s390xKeyPath + " --namespace multi-platform-controller"))
+				Expect(string(calls)).To(ContainSubstring("create secret generic ibm-ppc64le-ssh-key --from-file=id_rsa=" + ppc64leKeyPath + " --namespace multi-platform-controller"))
+				Expect(string(calls)).To(ContainSubstring("create secret generic ibmcloud-api-key --from-file=api-key="))
+				Expect(string(calls)).NotTo(ContainSubstring("test-api-key"))
+				for _, secret := range []string{"ibm-s390x-ssh-key", "ibm-ppc64le-ssh-key", "ibmcloud-api-key"} {
+					Expect(string(calls)).To(ContainSubstring("label secret " + secret + " build.appstudio.redhat.com/multi-platform-secret=true -n multi-platform-controller"))
+				}
+
+				data, err := os.ReadFile(filepath.Join(tempDir, "kubectl_secret_data.log"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(data)).To(ContainSubstring("ibmcloud-api-key api-key=test-api-key\n"))
+			})
+
+			It("should fail without calling kubectl when the API key is missing", func() {
+				creds.APIKey = ""
+
+				err := manager.ApplyIBMSecrets(context.Background(), creds)
+				Expect(err).To(MatchError(ContainSubstring("IBMCLOUD_API_KEY")))
+
+				_, err = os.Stat(filepath.Join(tempDir, "kubectl_calls.log"))
+				Expect(os.IsNotExist(err)).To(BeTrue())
+			})
+
+			It("should fail when an SSH key file does not exist", func() {
+				creds.PPC64LESSHKeyPath = filepath.Join(tempDir, "missing_id_rsa")
+
+				err := manager.ApplyIBMSecrets(context.Background(), creds)
+				Expect(err).To(MatchError(ContainSubstring("SSH key file not found")))
 			})
 		})
 	})
@@ -0,0 +1,264 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/meyrevived/mpc-dev-env/internal/logger"
+)
+
+const (
+	metricsNamespace = "mpc-metrics"
+	prometheusImage  = "quay.io/prometheus/prometheus:v2.55.1"
+	grafanaImage     = "docker.io/grafana/grafana:11.3.1"
+
+	// MetricsRetentionDays is how long Prometheus keeps samples.
+	MetricsRetentionDays = 7
+)
+
+// MetricsStack describes a deployed Prometheus and Grafana.
+//
+// The URLs are in-cluster service addresses; from the host, reach them with
+// kubectl port-forward (see README).
+type MetricsStack struct {
+	PrometheusURL string
+	GrafanaURL    string
+	RetentionDays int
+}
+
+// metricsManifests is a minimal Prometheus and Grafana deployment for local debugging.
+//
+// Prometheus scrapes itself and every pod annotated with prometheus.io/scrape: "true"
+// (on prometheus.io/port and prometheus.io/path, when set). Grafana comes with
+// Prometheus provisioned as its default data source and allows anonymous admin
+// access, which is fine for a throwaway Kind cluster. Data is kept in emptyDir
+// volumes, so it does not survive pod restarts.
+//
+// The single %d is the retention in days.
+const metricsManifests = `apiVersion: v1
+kind: Namespace
+metadata:
+  name: mpc-metrics
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: prometheus
+  namespace: mpc-metrics
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: mpc-metrics-prometheus
+rules:
+  - apiGroups: [""]
+    resources: ["nodes", "services", "endpoints", "pods"]
+    verbs: ["get", "list", "watch"]
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  name: mpc-metrics-prometheus
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: mpc-metrics-prometheus
+subjects:
+  - kind: ServiceAccount
+    name: prometheus
+    namespace: mpc-metrics
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: prometheus-config
+  namespace: mpc-metrics
+data:
+  prometheus.yml: |
+    global:
+      scrape_interval: 15s
+    scrape_configs:
+      - job_name: prometheus
+        static_configs:
+          - targets: ["localhost:9090"]
+      - job_name: kubernetes-pods
+        kubernetes_sd_configs:
+          - role: pod
+        relabel_configs:
+          - source_labels: [__meta_kubernetes_pod_annotation_prometheus_io_scrape]
+            action: keep
+            regex: "true"
+          - source_labels: [__meta_kubernetes_pod_annotation_prometheus_io_path]
+            action: replace
+            target_label: __metrics_path__
+            regex: (.+)
+          - source_labels: [__address__, __meta_kubernetes_pod_annotation_prometheus_io_port]
+            action: replace
+            regex: ([^:]+)(?::\d+)?;(\d+)
+            replacement: $1:$2
+            target_label: __address__
+          - source_labels: [__meta_kubernetes_namespace]
+            target_label: namespace
+          - source_labels: [__meta_kubernetes_pod_name]
+            target_label: pod
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: prometheus
+  namespace: mpc-metrics
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: prometheus
+  template:
+    metadata:
+      labels:
+        app: prometheus
+    spec:
+      serviceAccountName: prometheus
+      containers:
+        - name: prometheus
+          image: ` + prometheusImage + `
+          args:
+            - --config.file=/etc/prometheus/prometheus.yml
+            - --storage.tsdb.path=/prometheus
+            - --storage.tsdb.retention.time=%dd
+          ports:
+            - containerPort: 9090
+          readinessProbe:
+            httpGet:
+              path: /-/ready
+              port: 9090
+          volumeMounts:
+            - name: config
+              mountPath: /etc/prometheus
+            - name: data
+              mountPath: /prometheus
+      volumes:
+        - name: config
+          configMap:
+            name: prometheus-config
+        - name: data
+          emptyDir: {}
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: prometheus
+  namespace: mpc-metrics
+spec:
+  selector:
+    app: prometheus
+  ports:
+    - port: 9090
+      targetPort: 9090
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: grafana-datasources
+  namespace: mpc-metrics
+data:
+  prometheus.yaml: |
+    apiVersion: 1
+    datasources:
+      - name: Prometheus
+        type: prometheus
+        access: proxy
+        url: http://prometheus.mpc-metrics.svc:9090
+        isDefault: true
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: grafana
+  namespace: mpc-metrics
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: grafana
+  template:
+    metadata:
+      labels:
+        app: grafana
+    spec:
+      containers:
+        - name: grafana
+          image: ` + grafanaImage + `
+          env:
+            - name: GF_AUTH_ANONYMOUS_ENABLED
+              value: "true"
+            - name: GF_AUTH_ANONYMOUS_ORG_ROLE
+              value: Admin
+          ports:
+            - containerPort: 3000
+          readinessProbe:
+            httpGet:
+              path: /api/health
+              port: 3000
+          volumeMounts:
+            - name: datasources
+              mountPath: /etc/grafana/provisioning/datasources
+      volumes:
+        - name: datasources
+          configMap:
+            name: grafana-datasources
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: grafana
+  namespace: mpc-metrics
+spec:
+  selector:
+    app: grafana
+  ports:
+    - port: 3000
+      targetPort: 3000
+`
+
+// DeployMetrics deploys Prometheus and Grafana to the mpc-metrics namespace.
+//
+// This method:
+//  1. Applies the bundled manifests (see metricsManifests) with kubectl
+//  2. Waits for the prometheus and grafana deployments to roll out
+//
+// It is safe to call repeatedly; re-applying leaves an existing deployment in place.
+// The returned MetricsStack holds the service URLs and retention.
+func (m *Manager) DeployMetrics(ctx context.Context) (MetricsStack, error) {
+	logger.Info("deploying Prometheus and Grafana", "namespace", metricsNamespace)
+
+	applyCmd := m.kubectl(ctx, m.kubectlArgs("apply", "-f", "-")...)
+	applyCmd.Stdin = strings.NewReader(fmt.Sprintf(metricsManifests, MetricsRetentionDays))
+	applyCmd.Stdout = m.stdout()
+	applyCmd.Stderr = m.stderr()
+	if err := applyCmd.Run(); err != nil {
+		return MetricsStack{}, fmt.Errorf("failed to apply metrics manifests: %w", err)
+	}
+
+	for _, deployment := range []string{"prometheus", "grafana"} {
+		logger.Info("waiting for deployment", "deployment", deployment)
+		cmd := m.kubectl(ctx, "rollout", "status",
+			"deployment/"+deployment,
+			"-n", metricsNamespace,
+			"--timeout=5m")
+		cmd.Stdout = m.stdout()
+		cmd.Stderr = m.stderr()
+		if err := cmd.Run(); err != nil {
+			return MetricsStack{}, fmt.Errorf("timeout waiting for %s: %w", deployment, err)
+		}
+		logger.Info("deployment is ready", "deployment", deployment)
+	}
+
+	stack := MetricsStack{
+		PrometheusURL: fmt.Sprintf("http://prometheus.%s.svc:9090", metricsNamespace),
+		GrafanaURL:    fmt.Sprintf("http://grafana.%s.svc:3000", metricsNamespace),
+		RetentionDays: MetricsRetentionDays,
+	}
+	logger.Info("Prometheus and Grafana deployed successfully", "prometheus", stack.PrometheusURL, "grafana", stack.GrafanaURL)
+	return stack, nil
+}
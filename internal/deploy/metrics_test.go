@@ -0,0 +1,89 @@
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/meyrevived/mpc-dev-env/internal/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeployMetrics", func() {
+	var (
+		manager      *Manager
+		tempDir      string
+		originalPath string
+	)
+
+	BeforeEach(func() {
+		tempDir = GinkgoT().TempDir()
+		manager = NewManager(&config.Config{})
+
+		// Mock kubectl that records its arguments and saves the manifests it applies
+		originalPath = os.Getenv("PATH")
+		script := `#!/bin/sh
+echo "$@" >> ` + filepath.Join(tempDir, "kubectl_calls.log") + `
+if [ "$1" = "apply" ]; then cat > ` + filepath.Join(tempDir, "applied.yaml") + `; fi
+exit 0
+`
+		Expect(os.WriteFile(filepath.Join(tempDir, "kubectl"), []byte(script), 0755)).To(Succeed())
+		_ = os.Setenv("PATH", tempDir+":"+originalPath)
+	})
+
+	AfterEach(func() {
+		_ = os.Setenv("PATH", originalPath)
+	})
+
+	It("should apply the manifests and wait for Prometheus and Grafana", func() {
+		stack, err := manager.DeployMetrics(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+
+		calls, err := os.ReadFile(filepath.Join(tempDir, "kubectl_calls.log"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(calls)).To(ContainSubstring("apply -f -"))
+		Expect(string(calls)).To(ContainSubstring("rollout status deployment/prometheus -n mpc-metrics"))
+		Expect(string(calls)).To(ContainSubstring("rollout status deployment/grafana -n mpc-metrics"))
+
+		Expect(stack).To(Equal(MetricsStack{
+			PrometheusURL: "http://prometheus.mpc-metrics.svc:9090",
+			GrafanaURL:    "http://grafana.mpc-metrics.svc:3000",
+			RetentionDays: MetricsRetentionDays,
+		}))
+	})
+
+	It("should configure the Prometheus retention", func() {
+		_, err := manager.DeployMetrics(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+
+		applied, err := os.ReadFile(filepath.Join(tempDir, "applied.yaml"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(applied)).To(ContainSubstring("--storage.tsdb.retention.time=7d"))
+		Expect(string(applied)).To(ContainSubstring("name: grafana-datasources"))
+	})
+
+	It("should copy kubectl output to the output writer", func() {
+		script := "#!/bin/sh\necho \"deployment rolled out\"\n"
+		Expect(os.WriteFile(filepath.Join(tempDir, "kubectl"), []byte(script), 0755)).To(Succeed())
+
+		var output bytes.Buffer
+		manager.SetOutput(&output)
+
+		_, err := manager.DeployMetrics(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output.String()).To(ContainSubstring("deployment rolled out"))
+	})
+
+	It("should fail when a deployment does not become ready", func() {
+		script := `#!/bin/sh
+if [ "$1" = "rollout" ]; then exit 1; fi
+exit 0
+`
+		Expect(os.WriteFile(filepath.Join(tempDir, "kubectl"), []byte(script), 0755)).To(Succeed())
+
+		_, err := manager.DeployMetrics(context.Background())
+		Expect(err).To(MatchError(ContainSubstring("timeout waiting for prometheus")))
+	})
+})
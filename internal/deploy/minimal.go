@@ -1,12 +1,13 @@
 package deploy
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -16,16 +17,97 @@ import (
 
 const (
 	tektonNamespace     = "tekton-pipelines"
-	tektonReleaseURL    = "https://storage.googleapis.com/tekton-releases/pipeline/latest/release.yaml"
+	tektonReleasesURL   = "https://storage.googleapis.com/tekton-releases/pipeline"
 	minimalStackTimeout = 10 * time.Minute
 
 	// cert-manager constants for Kind cluster (vanilla Kubernetes)
 	// In OpenShift, the service annotation automatically creates TLS certs,
 	// but Kind needs cert-manager to provide this functionality
-	certManagerNamespace  = "cert-manager"
-	certManagerReleaseURL = "https://github.com/cert-manager/cert-manager/releases/download/v1.16.2/cert-manager.yaml"
+	certManagerNamespace   = "cert-manager"
+	certManagerReleasesURL = "https://github.com/cert-manager/cert-manager/releases"
+
+	// How often and how long waitForCertManagerWebhook probes the webhook
+	certManagerProbeInterval = 2 * time.Second
+	certManagerProbeTimeout  = 2 * time.Minute
+)
+
+// certManagerProbeCertificate is the Certificate waitForCertManagerWebhook submits in a
+// server-side dry run: the API server only accepts it once the cert-manager webhook has
+// validated it, and nothing is created.
+const certManagerProbeCertificate = `apiVersion: cert-manager.io/v1
+kind: Certificate
+metadata:
+  name: webhook-probe
+  namespace: ` + certManagerNamespace + `
+spec:
+  secretName: webhook-probe
+  dnsNames:
+    - webhook-probe.cert-manager.svc
+  issuerRef:
+    name: selfsigned-issuer
+    kind: ClusterIssuer
+`
+
+// tektonReleaseURL returns the URL of the manifests of a Tekton Pipelines release, a
+// version like "v1.6.0" or config.ReleaseLatest.
+func tektonReleaseURL(version string) string {
+	if version == config.ReleaseLatest {
+		return tektonReleasesURL + "/latest/release.yaml"
+	}
+	return tektonReleasesURL + "/previous/" + version + "/release.yaml"
+}
+
+// certManagerReleaseURL returns the URL of the manifests of a cert-manager release, a
+// version like "v1.16.2" or config.ReleaseLatest.
+func certManagerReleaseURL(version string) string {
+	if version == config.ReleaseLatest {
+		return certManagerReleasesURL + "/latest/download/cert-manager.yaml"
+	}
+	return certManagerReleasesURL + "/download/" + version + "/cert-manager.yaml"
+}
+
+// Components of the minimal stack, which DeployMinimalStack can deploy individually.
+const (
+	StackComponentTekton      = "tekton"
+	StackComponentCertManager = "cert-manager"
+	StackComponentOperator    = "operator"
+	StackComponentOTP         = "otp"
 )
 
+// StackComponents lists the components of the minimal stack in the order they are deployed.
+var StackComponents = []string{
+	StackComponentTekton,
+	StackComponentCertManager,
+	StackComponentOperator,
+	StackComponentOTP,
+}
+
+// ErrUnknownStackComponent is returned by ValidateStackComponents for a name that is not
+// one of StackComponents.
+var ErrUnknownStackComponent = errors.New("unknown minimal stack component, expected tekton, cert-manager, operator or otp")
+
+// stackDependencies maps a component to the one it needs in the cluster: the MPC operator
+// creates Tekton Tasks, and the OTP server's TLS certificate is issued by cert-manager.
+// The dependency is identified by the deployment that has to exist for it.
+var stackDependencies = map[string]struct {
+	component  string
+	deployment Deployment
+}{
+	StackComponentOperator: {StackComponentTekton, TektonWebhookDeployment},
+	StackComponentOTP:      {StackComponentCertManager, Deployment{Namespace: certManagerNamespace, Name: "cert-manager-webhook"}},
+}
+
+// ValidateStackComponents returns an error wrapping ErrUnknownStackComponent for the
+// first name that is not one of StackComponents.
+func ValidateStackComponents(components []string) error {
+	for _, component := range components {
+		if !slices.Contains(StackComponents, component) {
+			return fmt.Errorf("%w: %q", ErrUnknownStackComponent, component)
+		}
+	}
+	return nil
+}
+
 // MinimalDeployer handles deployment of the minimal MPC stack.
 //
 // The minimal stack consists of only the essential components needed for MPC to function:
@@ -37,6 +119,10 @@ const (
 // like ArgoCD, Kyverno, Dex, etc. The minimal deployment is faster (~3-5 minutes vs 30-45 minutes).
 type MinimalDeployer struct {
 	config *config.Config
+
+	// probeInterval is the wait between cert-manager webhook probes; zero means
+	// certManagerProbeInterval.
+	probeInterval time.Duration
 }
 
 // NewMinimalDeployer creates a new minimal deployment manager instance.
@@ -61,49 +147,116 @@ func NewMinimalDeployer(cfg *config.Config) *MinimalDeployer {
 // but Kind (vanilla Kubernetes) needs cert-manager to provide this functionality.
 //
 // Each component is deployed sequentially and verified before proceeding to the next.
-// The entire deployment typically completes in 3-5 minutes.
-func (m *MinimalDeployer) DeployMinimalStack(ctx context.Context) error {
-	logger.Info("starting minimal MPC stack deployment")
-	logger.Info("deployment includes: 1. Tekton Pipelines (TaskRun engine), 2. cert-manager (TLS certificates for OTP), 3. MPC Operator (controller), 4. OTP Server (one-time passwords)")
-
-	// Step 1: Deploy Tekton Pipelines
-	if err := m.DeployTekton(ctx); err != nil {
-		return fmt.Errorf("failed to deploy Tekton Pipelines: %w", err)
+// The entire deployment typically completes in 3-5 minutes. Without a reachable
+// cluster it fails up front with ErrClusterNotRunning.
+//
+// components selects which of StackComponents to deploy; nil or empty deploys all of
+// them. Tekton and cert-manager rarely change, so when iterating on the operator only
+// StackComponentOperator needs deploying. The others are skipped, except a dependency
+// of a requested component that is not in the cluster yet (Tekton for the operator,
+// cert-manager for the OTP server), which is deployed as well.
+func (m *MinimalDeployer) DeployMinimalStack(ctx context.Context, components []string) error {
+	if err := ValidateStackComponents(components); err != nil {
+		return err
+	}
+	if len(components) == 0 {
+		components = StackComponents
 	}
 
-	// Step 2: Deploy cert-manager (required for OTP TLS certificates)
-	if err := m.DeployCertManager(ctx); err != nil {
-		return fmt.Errorf("failed to deploy cert-manager: %w", err)
+	logger.Info("starting minimal MPC stack deployment", "components", strings.Join(components, ","))
+
+	if err := CheckClusterReachable(ctx, m.config.KubeconfigPath, m.config.GetKubeContext()); err != nil {
+		return err
 	}
 
-	// Step 3: Deploy MPC Operator
-	if err := m.DeployMPCOperator(ctx); err != nil {
-		return fmt.Errorf("failed to deploy MPC Operator: %w", err)
+	deploySteps, err := m.stackSteps(ctx, components)
+	if err != nil {
+		return err
 	}
 
-	// Step 4: Deploy OTP Server (with TLS certificate)
-	if err := m.DeployOTPServer(ctx); err != nil {
-		return fmt.Errorf("failed to deploy OTP Server: %w", err)
+	steps := []struct {
+		component string
+		name      string
+		deploy    func(context.Context) error
+	}{
+		// Step 1: Deploy Tekton Pipelines
+		{StackComponentTekton, "Tekton Pipelines", m.DeployTekton},
+		// Step 2: Deploy cert-manager (required for OTP TLS certificates)
+		{StackComponentCertManager, "cert-manager", m.DeployCertManager},
+		// Step 3: Deploy MPC Operator
+		{StackComponentOperator, "MPC Operator", m.DeployMPCOperator},
+		// Step 4: Deploy OTP Server (with TLS certificate)
+		{StackComponentOTP, "OTP Server", m.DeployOTPServer},
+	}
+	for _, step := range steps {
+		if !deploySteps[step.component] {
+			logger.Info("skipping minimal stack component", "component", step.component)
+			continue
+		}
+		if err := step.deploy(ctx); err != nil {
+			return fmt.Errorf("failed to deploy %s: %w", step.name, err)
+		}
 	}
 
-	logger.Info("minimal MPC stack deployed successfully", "components", "Tekton Pipelines + cert-manager + MPC Operator + OTP Server")
+	logger.Info("minimal MPC stack deployed successfully", "components", strings.Join(components, ","))
 	return nil
 }
 
+// stackSteps returns the components DeployMinimalStack deploys for the requested ones:
+// those, plus the dependencies of those that are missing from the cluster.
+func (m *MinimalDeployer) stackSteps(ctx context.Context, components []string) (map[string]bool, error) {
+	steps := make(map[string]bool)
+	for _, component := range components {
+		steps[component] = true
+	}
+
+	for _, component := range components {
+		dependency, ok := stackDependencies[component]
+		if !ok || steps[dependency.component] {
+			continue
+		}
+		present, err := m.deploymentExists(ctx, dependency.deployment)
+		if err != nil {
+			return nil, err
+		}
+		if !present {
+			logger.Info("deploying missing dependency", "component", component, "dependency", dependency.component)
+			steps[dependency.component] = true
+		}
+	}
+	return steps, nil
+}
+
+// deploymentExists reports whether kubectl finds the deployment.
+func (m *MinimalDeployer) deploymentExists(ctx context.Context, deployment Deployment) (bool, error) {
+	cmd := m.kubectl(ctx, "get", "deployment", deployment.Name, "-n", deployment.Namespace, "-o", "name")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if strings.Contains(stderr.String(), "NotFound") {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get deployment %s: %w (stderr: %s)", deployment, err, strings.TrimSpace(stderr.String()))
+	}
+	return true, nil
+}
+
 // DeployTekton installs Tekton Pipelines from the official release.
 //
 // This method:
-//  1. Applies the latest Tekton Pipelines release YAML from storage.googleapis.com
+//  1. Applies the release YAML of the configured Tekton Pipelines version
+//     (MPC_TEKTON_VERSION) from storage.googleapis.com
 //  2. Waits for both the controller and webhook deployments to be ready
 //
 // The webhook wait is critical - the MPC operator creates Tekton Tasks which require
 // webhook validation. Without waiting for the webhook, Task creation fails with
 // "connection refused" errors.
 func (m *MinimalDeployer) DeployTekton(ctx context.Context) error {
-	logger.Info("deploying Tekton Pipelines", "releaseURL", tektonReleaseURL)
+	releaseURL := tektonReleaseURL(m.config.GetTektonVersion())
+	logger.Info("deploying Tekton Pipelines", "releaseURL", releaseURL)
 
 	// Apply Tekton release YAML
-	applyCmd := exec.CommandContext(ctx, "kubectl", "apply", "-f", tektonReleaseURL)
+	applyCmd := m.kubectl(ctx, "apply", "-f", releaseURL)
 	applyCmd.Stdout = os.Stdout
 	applyCmd.Stderr = os.Stderr
 
@@ -134,7 +287,7 @@ func (m *MinimalDeployer) waitForTektonReady(ctx context.Context) error {
 	logger.Info("waiting for tekton controller deployment")
 
 	// Wait for tekton-pipelines-controller deployment
-	controllerCmd := exec.CommandContext(ctx, "kubectl", "rollout", "status",
+	controllerCmd := m.kubectl(ctx, "rollout", "status",
 		"deployment/tekton-pipelines-controller",
 		"-n", tektonNamespace,
 		"--timeout=3m")
@@ -151,7 +304,7 @@ func (m *MinimalDeployer) waitForTektonReady(ctx context.Context) error {
 	// This is critical - MPC operator creates Tekton Tasks which need webhook validation
 	logger.Info("waiting for tekton webhook deployment")
 
-	webhookCmd := exec.CommandContext(ctx, "kubectl", "rollout", "status",
+	webhookCmd := m.kubectl(ctx, "rollout", "status",
 		"deployment/tekton-pipelines-webhook",
 		"-n", tektonNamespace,
 		"--timeout=3m")
@@ -177,14 +330,15 @@ func (m *MinimalDeployer) waitForTektonReady(ctx context.Context) error {
 //   - cert-manager provides the same functionality via Certificate resources
 //
 // This method:
-//  1. Applies the cert-manager release manifests
+//  1. Applies the manifests of the configured cert-manager release (MPC_CERT_MANAGER_VERSION)
 //  2. Waits for cert-manager deployments to be ready
 //  3. Waits for the webhook to be ready (required before creating certificates)
 func (m *MinimalDeployer) DeployCertManager(ctx context.Context) error {
-	logger.Info("deploying cert-manager", "releaseURL", certManagerReleaseURL)
+	releaseURL := certManagerReleaseURL(m.config.GetCertManagerVersion())
+	logger.Info("deploying cert-manager", "releaseURL", releaseURL)
 
 	// Apply cert-manager release YAML
-	applyCmd := exec.CommandContext(ctx, "kubectl", "apply", "-f", certManagerReleaseURL)
+	applyCmd := m.kubectl(ctx, "apply", "-f", releaseURL)
 	applyCmd.Stdout = os.Stdout
 	applyCmd.Stderr = os.Stderr
 
@@ -211,7 +365,8 @@ func (m *MinimalDeployer) DeployCertManager(ctx context.Context) error {
 //  3. cert-manager-webhook (validates Certificate resources)
 //
 // The webhook is especially critical - creating Certificate resources before
-// the webhook is ready will fail with validation errors.
+// the webhook is ready will fail with validation errors, so once the deployments are
+// ready it waits for the webhook to serve (see waitForCertManagerWebhook).
 func (m *MinimalDeployer) waitForCertManagerReady(ctx context.Context) error {
 	deployments := []string{
 		"cert-manager",
@@ -221,7 +376,7 @@ func (m *MinimalDeployer) waitForCertManagerReady(ctx context.Context) error {
 
 	for _, deployment := range deployments {
 		logger.Info("waiting for deployment", "deployment", deployment)
-		cmd := exec.CommandContext(ctx, "kubectl", "rollout", "status",
+		cmd := m.kubectl(ctx, "rollout", "status",
 			"deployment/"+deployment,
 			"-n", certManagerNamespace,
 			"--timeout=3m")
@@ -234,15 +389,55 @@ func (m *MinimalDeployer) waitForCertManagerReady(ctx context.Context) error {
 		logger.Info("deployment is ready", "deployment", deployment)
 	}
 
-	// Wait a bit for webhook to be fully operational
 	// The deployment being ready doesn't mean the webhook endpoint is serving
-	logger.Info("waiting for cert-manager webhook to be fully operational")
-	time.Sleep(10 * time.Second)
+	if err := m.waitForCertManagerWebhook(ctx); err != nil {
+		return err
+	}
 
 	logger.Info("cert-manager fully ready")
 	return nil
 }
 
+// waitForCertManagerWebhook waits until the cert-manager webhook validates Certificates.
+//
+// It submits certManagerProbeCertificate with `kubectl apply --dry-run=server` until the
+// API server accepts it. Until the webhook serves, the dry run fails, e.g. with
+// "failed calling webhook" or, right after the install, because the Certificate kind
+// is not known yet. It gives up after certManagerProbeTimeout with the last failure.
+func (m *MinimalDeployer) waitForCertManagerWebhook(ctx context.Context) error {
+	logger.Info("waiting for cert-manager webhook to serve")
+
+	interval := m.probeInterval
+	if interval <= 0 {
+		interval = certManagerProbeInterval
+	}
+	timeout := time.After(certManagerProbeTimeout)
+
+	for {
+		cmd := m.kubectl(ctx, "apply", "--dry-run=server", "-f", "-")
+		cmd.Stdin = strings.NewReader(certManagerProbeCertificate)
+		var output bytes.Buffer
+		cmd.Stdout = &output
+		cmd.Stderr = &output
+
+		err := cmd.Run()
+		if err == nil {
+			logger.Info("cert-manager webhook is serving")
+			return nil
+		}
+		lastFailure := strings.TrimSpace(output.String())
+		logger.Debug("cert-manager webhook is not serving yet", "output", lastFailure)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout:
+			return fmt.Errorf("timeout waiting for cert-manager webhook: %w (output: %s)", err, lastFailure)
+		case <-time.After(interval):
+		}
+	}
+}
+
 // DeployMPCOperator applies MPC operator manifests from the MPC repository.
 //
 // This method applies all manifests in the multi-platform-controller/deploy/operator directory
@@ -267,7 +462,7 @@ func (m *MinimalDeployer) DeployMPCOperator(ctx context.Context) error {
 
 	// Apply using kustomize (kubectl apply -k)
 	logger.Info("applying manifests", "path", operatorDir)
-	applyCmd := exec.CommandContext(ctx, "kubectl", "apply", "-k", operatorDir)
+	applyCmd := m.kubectl(ctx, "apply", "-k", operatorDir)
 	applyCmd.Stdout = os.Stdout
 	applyCmd.Stderr = os.Stderr
 
@@ -305,7 +500,7 @@ func (m *MinimalDeployer) verifyMPCOperatorCreated(ctx context.Context) error {
 		case <-timeout:
 			return errors.New("timeout waiting for multi-platform-controller deployment to be created")
 		case <-ticker.C:
-			cmd := exec.CommandContext(ctx, "kubectl", "get", "deployment", mpcDeploymentName,
+			cmd := m.kubectl(ctx, "get", "deployment", mpcDeploymentName,
 				"-n", mpcNamespace)
 			if err := cmd.Run(); err == nil {
 				logger.Info("multi-platform-controller deployment created successfully")
@@ -354,7 +549,7 @@ func (m *MinimalDeployer) DeployOTPServer(ctx context.Context) error {
 
 	// Apply using kustomize (kubectl apply -k)
 	logger.Info("applying manifests", "path", otpDir)
-	applyCmd := exec.CommandContext(ctx, "kubectl", "apply", "-k", otpDir)
+	applyCmd := m.kubectl(ctx, "apply", "-k", otpDir)
 	applyCmd.Stdout = os.Stdout
 	applyCmd.Stderr = os.Stderr
 
@@ -387,7 +582,7 @@ func (m *MinimalDeployer) createOTPTLSCertificate(ctx context.Context) error {
 	// First, ensure the MPC namespace exists (cert-manager needs the namespace to exist
 	// before it can create the secret there)
 	logger.Info("ensuring multi-platform-controller namespace exists")
-	nsCmd := exec.CommandContext(ctx, "kubectl", "create", "namespace", mpcNamespace)
+	nsCmd := m.kubectl(ctx, "create", "namespace", mpcNamespace)
 	// Ignore error - namespace may already exist
 	_ = nsCmd.Run()
 
@@ -402,7 +597,7 @@ spec:
 `
 
 	logger.Info("creating self-signed ClusterIssuer")
-	issuerCmd := exec.CommandContext(ctx, "kubectl", "apply", "-f", "-")
+	issuerCmd := m.kubectl(ctx, "apply", "-f", "-")
 	issuerCmd.Stdin = strings.NewReader(clusterIssuerYAML)
 	issuerCmd.Stdout = os.Stdout
 	issuerCmd.Stderr = os.Stderr
@@ -437,7 +632,7 @@ spec:
 `, mpcNamespace, mpcNamespace, mpcNamespace, mpcNamespace)
 
 	logger.Info("creating Certificate resource for OTP TLS")
-	certCmd := exec.CommandContext(ctx, "kubectl", "apply", "-f", "-")
+	certCmd := m.kubectl(ctx, "apply", "-f", "-")
 	certCmd.Stdin = strings.NewReader(certificateYAML)
 	certCmd.Stdout = os.Stdout
 	certCmd.Stderr = os.Stderr
@@ -473,7 +668,7 @@ func (m *MinimalDeployer) waitForOTPCertificateReady(ctx context.Context) error
 			return errors.New("timeout waiting for OTP TLS certificate to be ready")
 		case <-ticker.C:
 			// Check if the secret exists (this means the certificate was issued)
-			cmd := exec.CommandContext(ctx, "kubectl", "get", "secret", "otp-tls-secrets",
+			cmd := m.kubectl(ctx, "get", "secret", "otp-tls-secrets",
 				"-n", mpcNamespace)
 			if err := cmd.Run(); err == nil {
 				logger.Info("OTP TLS secret created successfully")
@@ -500,7 +695,7 @@ func (m *MinimalDeployer) verifyOTPServerCreated(ctx context.Context) error {
 		case <-timeout:
 			return errors.New("timeout waiting for OTP server deployment to be created")
 		case <-ticker.C:
-			cmd := exec.CommandContext(ctx, "kubectl", "get", "deployment", otpDeploymentName,
+			cmd := m.kubectl(ctx, "get", "deployment", otpDeploymentName,
 				"-n", mpcNamespace)
 			if err := cmd.Run(); err == nil {
 				logger.Info("OTP server deployment created successfully")
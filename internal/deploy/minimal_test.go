@@ -4,6 +4,8 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/meyrevived/mpc-dev-env/internal/config"
 	. "github.com/onsi/ginkgo/v2"
@@ -59,12 +61,136 @@ exit 0
 			calls, err := os.ReadFile(filepath.Join(tempDir, "kubectl_calls.log"))
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(string(calls)).To(ContainSubstring("apply -f " + tektonReleaseURL))
+			Expect(string(calls)).To(ContainSubstring("apply -f " + tektonReleaseURL(config.DefaultTektonVersion)))
 			Expect(string(calls)).To(ContainSubstring("rollout status deployment/tekton-pipelines-controller -n tekton-pipelines"))
 			Expect(string(calls)).To(ContainSubstring("rollout status deployment/tekton-pipelines-webhook -n tekton-pipelines"))
 		})
 	})
 
+	Describe("DeployCertManager", func() {
+		It("should probe the webhook until it accepts a Certificate", func() {
+			deployer.probeInterval = 10 * time.Millisecond
+			// The dry run fails twice while the webhook starts, then succeeds
+			probes := filepath.Join(tempDir, "probes")
+			script := `#!/bin/sh
+echo "$@" >> ` + filepath.Join(tempDir, "kubectl_calls.log") + `
+if [ "$2" = "--dry-run=server" ]; then
+  cat >> ` + filepath.Join(tempDir, "probe_stdin.log") + `
+  echo probe >> ` + probes + `
+  if [ "$(wc -l < ` + probes + `)" -lt 3 ]; then
+    echo 'Error from server (InternalError): failed calling webhook "webhook.cert-manager.io": connect: connection refused' >&2
+    exit 1
+  fi
+fi
+exit 0
+`
+			Expect(os.WriteFile(mockKubectlPath, []byte(script), 0755)).To(Succeed())
+
+			Expect(deployer.DeployCertManager(context.Background())).To(Succeed())
+
+			calls, err := os.ReadFile(filepath.Join(tempDir, "kubectl_calls.log"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(strings.Count(string(calls), "apply --dry-run=server -f -")).To(Equal(3))
+			// The probe comes after the rollouts
+			Expect(strings.Index(string(calls), "apply --dry-run=server")).To(BeNumerically(">",
+				strings.Index(string(calls), "rollout status deployment/cert-manager-webhook")))
+
+			stdin, err := os.ReadFile(filepath.Join(tempDir, "probe_stdin.log"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(stdin)).To(ContainSubstring("kind: Certificate"))
+		})
+
+		It("should give up when the context ends while the webhook is not serving", func() {
+			deployer.probeInterval = 10 * time.Millisecond
+			script := `#!/bin/sh
+if [ "$2" = "--dry-run=server" ]; then
+  echo 'failed calling webhook "webhook.cert-manager.io"' >&2
+  exit 1
+fi
+exit 0
+`
+			Expect(os.WriteFile(mockKubectlPath, []byte(script), 0755)).To(Succeed())
+
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+			Expect(deployer.DeployCertManager(ctx)).To(MatchError(context.DeadlineExceeded))
+		})
+	})
+
+	Describe("release versions", func() {
+		It("should pin the default Tekton and cert-manager releases", func() {
+			Expect(tektonReleaseURL(cfg.GetTektonVersion())).To(Equal(
+				"https://storage.googleapis.com/tekton-releases/pipeline/previous/" + config.DefaultTektonVersion + "/release.yaml"))
+			Expect(certManagerReleaseURL(cfg.GetCertManagerVersion())).To(Equal(
+				"https://github.com/cert-manager/cert-manager/releases/download/" + config.DefaultCertManagerVersion + "/cert-manager.yaml"))
+		})
+
+		It("should apply the configured releases", func() {
+			cfg.TektonVersion = "v0.65.2"
+			cfg.CertManagerVersion = "v1.17.1"
+
+			Expect(deployer.DeployTekton(context.Background())).To(Succeed())
+			Expect(deployer.DeployCertManager(context.Background())).To(Succeed())
+
+			calls, err := os.ReadFile(filepath.Join(tempDir, "kubectl_calls.log"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(calls)).To(ContainSubstring("apply -f https://storage.googleapis.com/tekton-releases/pipeline/previous/v0.65.2/release.yaml"))
+			Expect(string(calls)).To(ContainSubstring("apply -f https://github.com/cert-manager/cert-manager/releases/download/v1.17.1/cert-manager.yaml"))
+		})
+
+		It("should use the latest releases only when asked to", func() {
+			Expect(tektonReleaseURL(config.ReleaseLatest)).To(Equal(
+				"https://storage.googleapis.com/tekton-releases/pipeline/latest/release.yaml"))
+			Expect(certManagerReleaseURL(config.ReleaseLatest)).To(Equal(
+				"https://github.com/cert-manager/cert-manager/releases/latest/download/cert-manager.yaml"))
+		})
+	})
+
+	Describe("DeployMinimalStack", func() {
+		readCalls := func() string {
+			calls, err := os.ReadFile(filepath.Join(tempDir, "kubectl_calls.log"))
+			Expect(err).NotTo(HaveOccurred())
+			return string(calls)
+		}
+
+		It("should deploy only the operator when Tekton is already in the cluster", func() {
+			Expect(deployer.DeployMinimalStack(context.Background(), []string{StackComponentOperator})).To(Succeed())
+
+			calls := readCalls()
+			Expect(calls).To(ContainSubstring("get deployment tekton-pipelines-webhook -n tekton-pipelines -o name"))
+			Expect(calls).To(ContainSubstring("apply -k " + filepath.Join(cfg.MpcRepoPath, "deploy", "operator")))
+			Expect(calls).NotTo(ContainSubstring("tekton-releases"))
+			Expect(calls).NotTo(ContainSubstring("cert-manager.yaml"))
+			Expect(calls).NotTo(ContainSubstring(filepath.Join(cfg.MpcRepoPath, "deploy", "otp")))
+		})
+
+		It("should deploy a missing dependency of a requested component", func() {
+			// kubectl finds nothing in the tekton-pipelines namespace
+			script := `#!/bin/sh
+echo "$@" >> ` + filepath.Join(tempDir, "kubectl_calls.log") + `
+if [ "$1" = "get" ] && [ "$5" = "tekton-pipelines" ]; then
+  echo 'Error from server (NotFound): deployments.apps "'$3'" not found' >&2
+  exit 1
+fi
+exit 0
+`
+			Expect(os.WriteFile(mockKubectlPath, []byte(script), 0755)).To(Succeed())
+
+			Expect(deployer.DeployMinimalStack(context.Background(), []string{StackComponentOperator})).To(Succeed())
+
+			calls := readCalls()
+			Expect(calls).To(ContainSubstring("apply -f " + tektonReleaseURL(config.DefaultTektonVersion)))
+			Expect(calls).To(ContainSubstring("apply -k " + filepath.Join(cfg.MpcRepoPath, "deploy", "operator")))
+			Expect(calls).NotTo(ContainSubstring("cert-manager.yaml"))
+		})
+
+		It("should reject an unknown component before contacting the cluster", func() {
+			err := deployer.DeployMinimalStack(context.Background(), []string{"tekton", "konflux"})
+			Expect(err).To(MatchError(ErrUnknownStackComponent))
+			Expect(filepath.Join(tempDir, "kubectl_calls.log")).NotTo(BeAnExistingFile())
+		})
+	})
+
 	Describe("DeployMPCOperator", func() {
 		It("should apply manifests from the correct kustomize directory", func() {
 			err := deployer.DeployMPCOperator(context.Background())
@@ -0,0 +1,32 @@
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/meyrevived/mpc-dev-env/internal/logger"
+)
+
+// ErrClusterNotRunning is returned by multi-step deployments that find no reachable
+// cluster before their first step, instead of whatever raw error kubectl would give
+// halfway through.
+var ErrClusterNotRunning = errors.New("cluster not running — start it with POST /api/cluster/start")
+
+// CheckClusterReachable verifies that kubectl can reach the cluster of the kubeContext
+// context (the current one when empty) in the kubeconfig at kubeconfigPath (kubectl's
+// default when empty) with `kubectl cluster-info`, returning ErrClusterNotRunning if it
+// cannot. kubectl's own output is logged for debugging.
+func CheckClusterReachable(ctx context.Context, kubeconfigPath, kubeContext string) error {
+	cmd := KubectlCommand(ctx, kubeconfigPath, kubeContext, "cluster-info", "--request-timeout=10s")
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	if err := cmd.Run(); err != nil {
+		logger.Info("cluster is not reachable", "error", err, "output", strings.TrimSpace(output.String()))
+		return ErrClusterNotRunning
+	}
+	return nil
+}
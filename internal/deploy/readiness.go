@@ -0,0 +1,67 @@
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+)
+
+// ErrDeploymentNotAvailable is returned by DeploymentAvailable for a deployment whose
+// latest rollout has not finished.
+var ErrDeploymentNotAvailable = errors.New("deployment is not available")
+
+// Deployment identifies a Kubernetes deployment.
+type Deployment struct {
+	Namespace string
+	Name      string
+}
+
+// String returns the deployment as "namespace/name".
+func (d Deployment) String() string {
+	return d.Namespace + "/" + d.Name
+}
+
+// Deployments a TaskRun needs: the Tekton webhook validates TaskRuns and the Tasks MPC
+// creates, and the MPC controller and OTP server provision the build hosts.
+var (
+	TektonWebhookDeployment = Deployment{Namespace: tektonNamespace, Name: "tekton-pipelines-webhook"}
+	ControllerDeployment    = Deployment{Namespace: mpcNamespace, Name: mpcDeploymentName}
+	OTPDeployment           = Deployment{Namespace: mpcNamespace, Name: otpDeploymentName}
+)
+
+// ReadinessChecker reads the status of deployments through the Kubernetes API.
+type ReadinessChecker struct {
+	client dynamic.Interface
+}
+
+// NewReadinessChecker creates a ReadinessChecker for the given context of the kubeconfig
+// at kubeconfigPath; empty values use ~/.kube/config and its current context (see RESTConfig).
+func NewReadinessChecker(kubeconfigPath, kubeContext string) (*ReadinessChecker, error) {
+	restConfig, err := RESTConfig(kubeconfigPath, kubeContext)
+	if err != nil {
+		return nil, err
+	}
+	client, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+	return &ReadinessChecker{client: client}, nil
+}
+
+// DeploymentAvailable returns nil when the deployment's latest rollout has finished,
+// by the rules of `kubectl rollout status`, and ErrDeploymentNotAvailable while it has
+// not. A missing deployment yields the API's not found error.
+func (c *ReadinessChecker) DeploymentAvailable(ctx context.Context, deployment Deployment) error {
+	obj, err := c.client.Resource(deploymentsResource).Namespace(deployment.Namespace).
+		Get(ctx, deployment.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if !rolloutComplete(obj) {
+		return fmt.Errorf("%w: %s", ErrDeploymentNotAvailable, deployment)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package deploy
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ReadinessChecker", func() {
+	// deployment returns a deployment with one replica, available when ready is true
+	deployment := func(d Deployment, ready bool) *unstructured.Unstructured {
+		available := int64(0)
+		if ready {
+			available = 1
+		}
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   map[string]interface{}{"name": d.Name, "namespace": d.Namespace, "generation": int64(1)},
+			"spec":       map[string]interface{}{"replicas": int64(1)},
+			"status": map[string]interface{}{
+				"observedGeneration": int64(1),
+				"replicas":           int64(1),
+				"updatedReplicas":    int64(1),
+				"availableReplicas":  available,
+			},
+		}}
+	}
+
+	var checker *ReadinessChecker
+
+	BeforeEach(func() {
+		checker = &ReadinessChecker{client: dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{deploymentsResource: "DeploymentList"},
+			deployment(TektonWebhookDeployment, true),
+			deployment(ControllerDeployment, false),
+		)}
+	})
+
+	It("should report an available deployment", func() {
+		Expect(checker.DeploymentAvailable(context.Background(), TektonWebhookDeployment)).To(Succeed())
+	})
+
+	It("should report a deployment whose pods are not available yet", func() {
+		err := checker.DeploymentAvailable(context.Background(), ControllerDeployment)
+		Expect(err).To(MatchError(ErrDeploymentNotAvailable))
+		Expect(err).To(MatchError(ContainSubstring("multi-platform-controller/multi-platform-controller")))
+	})
+
+	It("should return the API's not found error for a missing deployment", func() {
+		Expect(apierrors.IsNotFound(checker.DeploymentAvailable(context.Background(), OTPDeployment))).To(BeTrue())
+	})
+})
@@ -0,0 +1,80 @@
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/meyrevived/mpc-dev-env/internal/logger"
+)
+
+// kubectlRetryDelay is the wait before the first retry of a kubectl command; it doubles
+// with each further retry.
+const kubectlRetryDelay = 2 * time.Second
+
+// transientKubectlErrors are the kubectl error messages of failures that are expected to
+// go away on their own, e.g. while the API server of a new cluster is still starting.
+// Anything else, such as a manifest rejected by validation, fails the first time.
+var transientKubectlErrors = []string{
+	"connection refused",
+	"was refused - did you specify the right host or port",
+	"connection reset by peer",
+	"i/o timeout",
+	"TLS handshake timeout",
+	"Client.Timeout exceeded",
+	"http2: client connection lost",
+	"the server is currently unable to handle the request",
+	"etcdserver: request timed out",
+}
+
+// isTransientKubectlError reports whether kubectl's stderr describes a transient failure.
+func isTransientKubectlError(stderr string) bool {
+	for _, message := range transientKubectlErrors {
+		if strings.Contains(stderr, message) {
+			return true
+		}
+	}
+	return false
+}
+
+// runKubectl runs the kubectl command newCmd creates, which has not been started. When
+// it fails with a transient error (see transientKubectlErrors), a new command is created
+// and run again after a delay that doubles each time, up to the configured number of
+// attempts (MPC_KUBECTL_ATTEMPTS). The error of the last attempt is returned. Only
+// short commands go through it: a retried wait, such as `kubectl rollout status`,
+// would start its timeout over.
+func (m *Manager) runKubectl(ctx context.Context, newCmd func() *exec.Cmd) error {
+	attempts := m.config.GetKubectlAttempts()
+	delay := m.retryDelay
+	if delay <= 0 {
+		delay = kubectlRetryDelay
+	}
+
+	for attempt := 1; ; attempt++ {
+		cmd := newCmd()
+		var stderr bytes.Buffer
+		if cmd.Stderr != nil {
+			cmd.Stderr = io.MultiWriter(cmd.Stderr, &stderr)
+		} else {
+			cmd.Stderr = &stderr
+		}
+
+		err := cmd.Run()
+		if err == nil || attempt >= attempts || !isTransientKubectlError(stderr.String()) {
+			return err
+		}
+
+		logger.Info("kubectl failed with a transient error, retrying",
+			"args", strings.Join(cmd.Args[1:], " "), "attempt", attempt, "delay", delay,
+			"error", strings.TrimSpace(stderr.String()))
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
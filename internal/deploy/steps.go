@@ -0,0 +1,44 @@
+package deploy
+
+import "slices"
+
+// Names of the steps of Manager.Deploy, in the order they run.
+const (
+	StepHostConfig      = "host_config"      // Deploy the host-config ConfigMap
+	StepApplyManifests  = "apply_manifests"  // Apply the MPC operator manifests
+	StepWaitController  = "wait_controller"  // Wait for the controller deployment
+	StepWaitOTP         = "wait_otp"         // Wait for the OTP server deployment
+	StepPatchController = "patch_controller" // Patch the controller image
+	StepPatchOTP        = "patch_otp"        // Patch the OTP server image
+	StepRestart         = "restart"          // Restart both deployments and wait for their rollouts
+	StepVerify          = "verify"           // Verify the controller and OTP server images
+)
+
+// deploySteps are the steps of a deployment, and dryRunDeploySteps those of a dry run,
+// which skips the ones that only make sense after real changes.
+var (
+	deploySteps = []string{
+		StepHostConfig, StepApplyManifests, StepWaitController, StepWaitOTP,
+		StepPatchController, StepPatchOTP, StepRestart, StepVerify,
+	}
+	dryRunDeploySteps = []string{StepHostConfig, StepApplyManifests, StepPatchController, StepPatchOTP}
+)
+
+// DeployStep is the step a deployment has started, reported through DeployOptions.OnStep.
+type DeployStep struct {
+	Index int    // 1-based position of the step among the deployment's steps
+	Total int    // Number of steps the deployment runs: 8, or 4 for a dry run
+	Name  string // One of the Step* constants
+}
+
+// startStep reports that Deploy started the named step to the step observer, if any.
+func (m *Manager) startStep(name string) {
+	if m.onStep == nil {
+		return
+	}
+	steps := deploySteps
+	if m.dryRun {
+		steps = dryRunDeploySteps
+	}
+	m.onStep(DeployStep{Index: slices.Index(steps, name) + 1, Total: len(steps), Name: name})
+}
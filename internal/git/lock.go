@@ -0,0 +1,32 @@
+package git
+
+import (
+	"path/filepath"
+	"sync"
+)
+
+// repoLocks maps cleaned absolute repository paths to the *sync.Mutex guarding them.
+var repoLocks sync.Map
+
+// LockRepo blocks until no other git-mutating operation holds the repository at
+// repoPath, then holds it until the returned func is called. Both the Syncer and the
+// daemon's GitManager take it, so a manual sync, the background sync and a branch
+// checkout never run git on the same repository at the same time.
+//
+// Args:
+//
+//	repoPath: Path to the Git repository; paths naming the same directory share a lock
+//
+// Returns:
+//
+//	A func that releases the lock
+func LockRepo(repoPath string) func() {
+	key := filepath.Clean(repoPath)
+	if abs, err := filepath.Abs(repoPath); err == nil {
+		key = abs
+	}
+
+	mu, _ := repoLocks.LoadOrStore(key, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
+}
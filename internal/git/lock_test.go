@@ -0,0 +1,90 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/meyrevived/mpc-dev-env/internal/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// Note: Test suite entry point is in syncer_test.go
+
+var _ = Describe("LockRepo", func() {
+	var tempDir string
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = os.MkdirTemp("", "lock-test-*")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(tempDir)
+	})
+
+	It("should make a second locker of the same repository wait", func() {
+		repoPath := filepath.Join(tempDir, "repo")
+		unlock := LockRepo(repoPath)
+
+		acquired := make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			// The same directory spelled differently shares the lock
+			release := LockRepo(filepath.Join(tempDir, "other", "..", "repo") + "/")
+			close(acquired)
+			release()
+		}()
+
+		Consistently(acquired, 200*time.Millisecond).ShouldNot(BeClosed())
+		unlock()
+		Eventually(acquired).Should(BeClosed())
+	})
+
+	It("should not make lockers of different repositories wait", func() {
+		unlock := LockRepo(filepath.Join(tempDir, "repo-a"))
+		defer unlock()
+
+		acquired := make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			release := LockRepo(filepath.Join(tempDir, "repo-b"))
+			close(acquired)
+			release()
+		}()
+
+		Eventually(acquired).Should(BeClosed())
+	})
+
+	It("should serialize simultaneous syncs of one repository", func() {
+		repoPath, originHash := setupRepoBehindOrigin(tempDir, "multi-platform-controller")
+		// Uncommitted changes make every sync hard reset, back up and clean the repository
+		Expect(os.WriteFile(filepath.Join(repoPath, "local-change.txt"), []byte("local change"), 0644)).To(Succeed())
+		syncer := NewSyncer(map[string]string{"multi-platform-controller": repoPath}, config.GitSyncStrategyReset, true)
+
+		const syncs = 4
+		errs := make(chan error, syncs)
+		var wg sync.WaitGroup
+		for range syncs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs <- syncer.SyncRepo(context.Background(), repoPath)
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		// Without the lock, concurrent git commands fail on the repository's index.lock
+		for err := range errs {
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(headHash(repoPath)).To(Equal(originHash))
+		Expect(filepath.Join(repoPath, "local-change.txt")).NotTo(BeAnExistingFile())
+		Expect(filepath.Join(repoPath, ".git", "index.lock")).NotTo(BeAnExistingFile())
+	})
+})
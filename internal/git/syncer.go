@@ -1,8 +1,12 @@
 // Package git provides Git repository synchronization functionality.
 //
-// It handles keeping the local multi-platform-controller repository synchronized
-// with its upstream source. The package performs automatic fetching and merging,
-// with fallback to hard reset when local changes prevent fast-forward merges.
+// It handles keeping the tracked local repositories (multi-platform-controller and
+// any others configured) synchronized with their upstream sources. The package performs automatic fetching and,
+// depending on the configured strategy, fast-forwarding, rebasing local commits, or hard resetting.
+//
+// Like the daemon's GitManager it follows the fork model: 'origin' is the developer's fork
+// and 'upstream' the original repository, so branches are synced to upstream/main. Clones
+// without an 'upstream' remote are synced to origin/<branch> instead.
 //
 // This functionality replaces the Python-based UpstreamChangeDetector and provides
 // automatic repository updates without user intervention.
@@ -13,10 +17,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/meyrevived/mpc-dev-env/internal/config"
+	"github.com/meyrevived/mpc-dev-env/internal/daemon/state"
 	"github.com/meyrevived/mpc-dev-env/internal/logger"
 )
 
@@ -24,30 +33,69 @@ import (
 // It synchronizes repositories with their upstream sources to ensure
 // the local codebase is always up-to-date.
 type Syncer struct {
-	config *config.Config
+	repoPaths map[string]string // map[repoName]repoPath
+	strategy  string            // One of the config.GitSyncStrategy* values
+	backup    bool              // Back up local work before a hard reset
 }
 
+// ErrDetachedHead is returned when syncing a repository whose HEAD is detached, e.g.
+// after checking out a commit or tag: there is no branch to bring up to date.
+var ErrDetachedHead = errors.New("HEAD is detached, check out a branch to sync it")
+
+// backupBranchPrefix prefixes the branches that keep local work a hard reset would
+// otherwise discard; the rest of the name is the time of the reset.
+const backupBranchPrefix = "mpc-dev-backup/"
+
+// upstreamRemote and upstreamBranch name the remote and branch repositories are synced
+// to when they have an 'upstream' remote, the same upstream/main the daemon's GitManager
+// compares against.
+const (
+	upstreamRemote = "upstream"
+	upstreamBranch = "main"
+)
+
+// originRemote is the remote synced to when there is no 'upstream' remote.
+const originRemote = "origin"
+
 // NewSyncer creates a new Git Syncer instance.
 //
 // Args:
 //
-//	cfg: The configuration struct containing repository paths
+//	repoPaths: Repository name to path map of the repositories to keep in sync,
+//	           typically config.GetRepoPaths()
+//	strategy: How local branches are brought up to date, one of the
+//	          config.GitSyncStrategy* values, typically config.GetGitSyncStrategy();
+//	          empty means config.GitSyncStrategyRebase
+//	backup: Whether local commits and changes are saved to a backup branch before a
+//	        hard reset discards them, typically config.GetGitSyncBackup()
 //
 // Returns:
 //
 //	A new Syncer instance
-func NewSyncer(cfg *config.Config) *Syncer {
+func NewSyncer(repoPaths map[string]string, strategy string, backup bool) *Syncer {
+	if strategy == "" {
+		strategy = config.GitSyncStrategyRebase
+	}
 	return &Syncer{
-		config: cfg,
+		repoPaths: repoPaths,
+		strategy:  strategy,
+		backup:    backup,
 	}
 }
 
 // SyncRepo synchronizes a single Git repository with its upstream.
+// It waits for any other git-mutating operation on the repository to finish first (see LockRepo).
 // This function:
 //   - Determines the current branch
-//   - Fetches from origin
-//   - Performs a fast-forward merge to update the local branch
-//   - If fast-forward fails, falls back to git reset --hard origin/<branch>
+//   - Fetches from upstream, or from origin when there is no 'upstream' remote
+//   - Updates the local branch to the sync target, upstream/main or origin/<branch>,
+//     according to the strategy:
+//     config.GitSyncStrategyFFOnly fast-forwards and fails when that is not possible;
+//     config.GitSyncStrategyRebase rebases local commits onto the target, keeping
+//     uncommitted changes, and leaves the branch unchanged when the rebase conflicts;
+//     config.GitSyncStrategyReset fast-forwards when the working tree is clean and
+//     otherwise falls back to git reset --hard to the target, discarding local work
+//     after saving it to a backup branch unless backups are off
 //
 // Args:
 //
@@ -58,23 +106,65 @@ func NewSyncer(cfg *config.Config) *Syncer {
 //
 //	An error if synchronization fails
 func (s *Syncer) SyncRepo(ctx context.Context, repoPath string) error {
-	logger.Info("starting synchronization", "path", repoPath)
+	_, _, err := s.syncRepo(ctx, repoPath)
+	return err
+}
+
+// syncRepo implements SyncRepo, also returning the current branch and the ref it was
+// synced to once they are known. It holds the repository's LockRepo lock throughout.
+func (s *Syncer) syncRepo(ctx context.Context, repoPath string) (string, string, error) {
+	unlock := LockRepo(repoPath)
+	defer unlock()
+
+	logger.Info("starting synchronization", "path", repoPath, "strategy", s.strategy)
 
 	// Step 1: Get current branch
 	currentBranch, err := s.getCurrentBranch(ctx, repoPath)
 	if err != nil {
-		return fmt.Errorf("failed to get current branch: %w", err)
+		return "", "", fmt.Errorf("failed to get current branch: %w", err)
 	}
 	logger.Info("current branch", "branch", currentBranch)
 
-	// Step 2: Fetch from origin
-	logger.Info("fetching from origin")
-	if err := s.fetchOrigin(ctx, repoPath); err != nil {
-		return fmt.Errorf("failed to fetch from origin: %w", err)
+	// Step 2: Fetch from the remote the branch is synced to
+	remote, target := s.syncTarget(ctx, repoPath, currentBranch)
+	logger.Info("fetching from remote", "remote", remote)
+	if err := s.fetchRemote(ctx, repoPath, remote); err != nil {
+		return currentBranch, target, fmt.Errorf("failed to fetch from %s: %w", remote, err)
 	}
 	logger.Info("fetch completed successfully")
 
-	// Step 3: Check for local changes
+	// Step 3: Update the local branch
+	switch s.strategy {
+	case config.GitSyncStrategyFFOnly:
+		logger.Info("attempting fast-forward merge", "target", target)
+		if err := s.fastForwardMerge(ctx, repoPath, target); err != nil {
+			return currentBranch, target, fmt.Errorf("cannot fast-forward %s to %s, leaving it unchanged (strategy %s): %w",
+				currentBranch, target, s.strategy, err)
+		}
+		logger.Info("fast-forward merge completed successfully")
+
+	case config.GitSyncStrategyReset:
+		if err := s.syncByReset(ctx, repoPath, target); err != nil {
+			return currentBranch, target, err
+		}
+
+	default:
+		logger.Info("rebasing local commits", "branch", currentBranch, "target", target)
+		if err := s.rebase(ctx, repoPath, target); err != nil {
+			return currentBranch, target, fmt.Errorf("cannot rebase %s onto %s, leaving it unchanged: %w", currentBranch, target, err)
+		}
+		logger.Info("rebase completed successfully")
+	}
+
+	logger.Info("synchronization completed successfully", "path", repoPath)
+	return currentBranch, target, nil
+}
+
+// syncByReset implements config.GitSyncStrategyReset: a fast-forward merge when the
+// working tree is clean, otherwise (or when the branch has diverged) a hard reset
+// to target. Unless backups are off, the local work is saved to a backup branch
+// first, and the repository is left untouched when that fails.
+func (s *Syncer) syncByReset(ctx context.Context, repoPath, target string) error {
 	hasLocalChanges, err := s.hasLocalChanges(ctx, repoPath)
 	if err != nil {
 		return fmt.Errorf("failed to check for local changes: %w", err)
@@ -82,32 +172,38 @@ func (s *Syncer) SyncRepo(ctx context.Context, repoPath string) error {
 
 	if hasLocalChanges {
 		logger.Info("repository has local changes, using hard reset strategy")
+		if err := s.backupLocalWork(ctx, repoPath, true); err != nil {
+			return fmt.Errorf("failed to back up local changes, not resetting: %w", err)
+		}
 		// Use git reset --hard to forcefully sync with upstream
-		if err := s.resetHard(ctx, repoPath, currentBranch); err != nil {
+		if err := s.resetHard(ctx, repoPath, target); err != nil {
 			return fmt.Errorf("failed to reset repository: %w", err)
 		}
-		logger.Info("repository reset to origin", "branch", currentBranch)
-	} else {
-		// Step 4: Try fast-forward merge
-		logger.Info("attempting fast-forward merge")
-		if err := s.fastForwardMerge(ctx, repoPath, currentBranch); err != nil {
-			// If fast-forward fails, use reset --hard as fallback
-			logger.Info("fast-forward merge failed, falling back to hard reset")
-			if err := s.resetHard(ctx, repoPath, currentBranch); err != nil {
-				return fmt.Errorf("failed to reset repository after merge failure: %w", err)
-			}
-			logger.Info("repository reset to origin", "branch", currentBranch)
-		} else {
-			logger.Info("fast-forward merge completed successfully")
-		}
+		logger.Info("repository reset", "target", target)
+		return nil
 	}
 
-	logger.Info("synchronization completed successfully", "path", repoPath)
+	// Try fast-forward merge
+	logger.Info("attempting fast-forward merge")
+	if err := s.fastForwardMerge(ctx, repoPath, target); err != nil {
+		// If fast-forward fails, use reset --hard as fallback
+		logger.Info("fast-forward merge failed, falling back to hard reset")
+		if err := s.backupLocalWork(ctx, repoPath, false); err != nil {
+			return fmt.Errorf("failed to back up local commits, not resetting: %w", err)
+		}
+		if err := s.resetHard(ctx, repoPath, target); err != nil {
+			return fmt.Errorf("failed to reset repository after merge failure: %w", err)
+		}
+		logger.Info("repository reset", "target", target)
+		return nil
+	}
+	logger.Info("fast-forward merge completed successfully")
 	return nil
 }
 
-// SyncAllRepos synchronizes all configured repositories.
-// Currently, this only includes multi-platform-controller.
+// SyncAllRepos synchronizes all configured repositories in name order.
+// A failure in one repository is logged and does not stop the others from
+// being synced; all failures are reported together in the returned error.
 //
 // Args:
 //
@@ -115,49 +211,81 @@ func (s *Syncer) SyncRepo(ctx context.Context, repoPath string) error {
 //
 // Returns:
 //
+//	The result of each repository's sync, in name order, failed ones included
 //	An error if any synchronization fails
-func (s *Syncer) SyncAllRepos(ctx context.Context) error {
+func (s *Syncer) SyncAllRepos(ctx context.Context) ([]state.RepoSyncResult, error) {
 	logger.Info("starting synchronization for all repositories")
 
-	repos := []struct {
-		name string
-		path string
-	}{
-		{"multi-platform-controller", s.config.GetMpcRepoPath()},
+	// Sort for a deterministic sync order and error message
+	names := make([]string, 0, len(s.repoPaths))
+	for name := range s.repoPaths {
+		names = append(names, name)
 	}
+	sort.Strings(names)
 
+	results := make([]state.RepoSyncResult, 0, len(names))
 	var syncErrors []string
-	for _, repo := range repos {
-		logger.Info("syncing repository", "name", repo.name)
-		if err := s.SyncRepo(ctx, repo.path); err != nil {
-			errMsg := fmt.Sprintf("%s: %v", repo.name, err)
+	for _, name := range names {
+		logger.Info("syncing repository", "name", name)
+		repoPath := s.repoPaths[name]
+		result := state.RepoSyncResult{
+			Name:     name,
+			Path:     repoPath,
+			Strategy: s.strategy,
+			OldHash:  s.headHash(ctx, repoPath),
+		}
+
+		branch, target, err := s.syncRepo(ctx, repoPath)
+		result.Branch = branch
+		result.Target = target
+		result.NewHash = s.headHash(ctx, repoPath)
+		if err != nil {
+			result.Error = err.Error()
+			errMsg := fmt.Sprintf("%s: %v", name, err)
 			syncErrors = append(syncErrors, errMsg)
-			logger.Error(err, "failed to sync repository", "name", repo.name)
+			logger.Error(err, "failed to sync repository", "name", name)
 		} else {
-			logger.Info("successfully synced repository", "name", repo.name)
+			logger.Info("successfully synced repository", "name", name, "old_hash", result.OldHash, "new_hash", result.NewHash)
 		}
+		results = append(results, result)
 	}
 
 	if len(syncErrors) > 0 {
-		return fmt.Errorf("failed to sync repositories: %s", strings.Join(syncErrors, "; "))
+		return results, fmt.Errorf("failed to sync repositories: %s", strings.Join(syncErrors, "; "))
 	}
 
 	logger.Info("all repositories synchronized successfully")
-	return nil
+	return results, nil
+}
+
+// headHash returns the HEAD commit of the repository, or "" when it cannot be
+// determined, e.g. because repoPath is not a git repository.
+func (s *Syncer) headHash(ctx context.Context, repoPath string) string {
+	hash, err := runGit(ctx, repoPath, nil, "rev-parse", "HEAD")
+	if err != nil {
+		logger.Debug("failed to read HEAD", "path", repoPath, "error", err.Error())
+		return ""
+	}
+	return hash
 }
 
 // getCurrentBranch returns the current branch name for the repository.
-// It uses "git rev-parse --abbrev-ref HEAD" to determine the active branch.
-// Returns an error if the repository is in detached HEAD state or if the command fails.
+// It uses "git symbolic-ref -q --short HEAD" to determine the active branch.
+// Returns ErrDetachedHead if the repository is in detached HEAD state, which makes the
+// command exit with status 1, or an error if the command fails otherwise.
 func (s *Syncer) getCurrentBranch(ctx context.Context, repoPath string) (string, error) {
-	cmd := exec.CommandContext(ctx, "git", "-C", repoPath, "rev-parse", "--abbrev-ref", "HEAD")
+	cmd := exec.CommandContext(ctx, "git", "-C", repoPath, "symbolic-ref", "-q", "--short", "HEAD")
 	var stdout bytes.Buffer
 	var stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
 	if err := cmd.Run(); err != nil {
-		return "", fmt.Errorf("git rev-parse failed: %w, stderr: %s", err, stderr.String())
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return "", ErrDetachedHead
+		}
+		return "", fmt.Errorf("git symbolic-ref failed: %w, stderr: %s", err, stderr.String())
 	}
 
 	branch := strings.TrimSpace(stdout.String())
@@ -168,11 +296,23 @@ func (s *Syncer) getCurrentBranch(ctx context.Context, repoPath string) (string,
 	return branch, nil
 }
 
-// fetchOrigin fetches latest changes from the origin remote.
-// It runs "git fetch origin" to download new commits and refs without merging.
+// syncTarget returns the remote to fetch and the ref to bring the current branch up to
+// date with: upstream and upstream/main when the repository has an 'upstream' remote,
+// otherwise origin and origin/<branch>.
+func (s *Syncer) syncTarget(ctx context.Context, repoPath, branch string) (string, string) {
+	cmd := exec.CommandContext(ctx, "git", "-C", repoPath, "remote", "get-url", upstreamRemote)
+	if err := cmd.Run(); err != nil {
+		logger.Debug("no upstream remote, syncing to origin", "path", repoPath)
+		return originRemote, originRemote + "/" + branch
+	}
+	return upstreamRemote, upstreamRemote + "/" + upstreamBranch
+}
+
+// fetchRemote fetches latest changes from the given remote.
+// It runs "git fetch <remote>" to download new commits and refs without merging.
 // Output (both stdout and stderr) is logged for visibility.
-func (s *Syncer) fetchOrigin(ctx context.Context, repoPath string) error {
-	cmd := exec.CommandContext(ctx, "git", "-C", repoPath, "fetch", "origin")
+func (s *Syncer) fetchRemote(ctx context.Context, repoPath, remote string) error {
+	cmd := exec.CommandContext(ctx, "git", "-C", repoPath, "fetch", remote)
 	var stdout bytes.Buffer
 	var stderr bytes.Buffer
 	cmd.Stdout = &stdout
@@ -209,13 +349,12 @@ func (s *Syncer) hasLocalChanges(ctx context.Context, repoPath string) (bool, er
 	return strings.TrimSpace(stdout.String()) != "", nil
 }
 
-// fastForwardMerge attempts a fast-forward only merge with origin/<branch>.
+// fastForwardMerge attempts a fast-forward only merge with target, e.g. upstream/main.
 // It uses "git merge --ff-only" which succeeds only if the local branch can be
 // fast-forwarded (i.e., no divergent commits). This preserves local commit history
 // when possible. If the merge cannot be done with fast-forward, it returns an error.
-func (s *Syncer) fastForwardMerge(ctx context.Context, repoPath, branch string) error {
-	remoteBranch := "origin/" + branch
-	cmd := exec.CommandContext(ctx, "git", "-C", repoPath, "merge", "--ff-only", remoteBranch)
+func (s *Syncer) fastForwardMerge(ctx context.Context, repoPath, target string) error {
+	cmd := exec.CommandContext(ctx, "git", "-C", repoPath, "merge", "--ff-only", target)
 	var stdout bytes.Buffer
 	var stderr bytes.Buffer
 	cmd.Stdout = &stdout
@@ -233,17 +372,114 @@ func (s *Syncer) fastForwardMerge(ctx context.Context, repoPath, branch string)
 	return nil
 }
 
-// resetHard performs a hard reset to origin/<branch>, discarding all local changes.
+// rebase replays the local commits of the current branch onto target, e.g. upstream/main.
+// Uncommitted changes are stashed first and restored afterwards (--autostash).
+// When the rebase fails, e.g. on a conflict, it is aborted so the branch and the
+// working tree are left as they were, and an error is returned.
+func (s *Syncer) rebase(ctx context.Context, repoPath, target string) error {
+	cmd := exec.CommandContext(ctx, "git", "-C", repoPath, "rebase", "--autostash", target)
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		// Nothing to abort if the rebase never started; the error above is what matters
+		abortCmd := exec.CommandContext(ctx, "git", "-C", repoPath, "rebase", "--abort")
+		if abortErr := abortCmd.Run(); abortErr != nil {
+			logger.Debug("git rebase --abort failed", "error", abortErr.Error())
+		}
+		return fmt.Errorf("git rebase failed: %w, stdout: %s, stderr: %s", err, stdout.String(), stderr.String())
+	}
+
+	// Log the rebase output
+	if out := stdout.String(); out != "" {
+		logger.Debug("rebase stdout", "output", out)
+	}
+
+	return nil
+}
+
+// backupLocalWork saves the local work a hard reset would discard to a new
+// mpc-dev-backup/<timestamp> branch and logs its name. The branch points at HEAD,
+// keeping local commits; with uncommitted changes it points at a WIP commit on top
+// of HEAD holding the working tree, untracked files included. The working tree,
+// index and current branch are not touched. It does nothing when backups are off.
+func (s *Syncer) backupLocalWork(ctx context.Context, repoPath string, hasLocalChanges bool) error {
+	if !s.backup {
+		return nil
+	}
+
+	branch := backupBranchPrefix + time.Now().Format("20060102-150405")
+	commit := "HEAD"
+	if hasLocalChanges {
+		var err error
+		commit, err = s.commitWorkingTree(ctx, repoPath, "WIP: local changes before mpc-dev-env sync")
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err := runGit(ctx, repoPath, nil, "branch", branch, commit); err != nil {
+		return err
+	}
+	logger.Info("backed up local work before hard reset", "path", repoPath, "branch", branch)
+	return nil
+}
+
+// commitWorkingTree creates a commit on top of HEAD holding the whole working tree,
+// untracked (but not ignored) files included, and returns its hash. It builds the
+// commit in a temporary index so the repository's own index is left as it is.
+func (s *Syncer) commitWorkingTree(ctx context.Context, repoPath, message string) (string, error) {
+	indexDir, err := os.MkdirTemp("", "mpc-git-backup-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary index directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(indexDir) }()
+	env := []string{"GIT_INDEX_FILE=" + filepath.Join(indexDir, "index")}
+
+	if _, err := runGit(ctx, repoPath, env, "read-tree", "HEAD"); err != nil {
+		return "", err
+	}
+	if _, err := runGit(ctx, repoPath, env, "add", "--all"); err != nil {
+		return "", err
+	}
+	tree, err := runGit(ctx, repoPath, env, "write-tree")
+	if err != nil {
+		return "", err
+	}
+	return runGit(ctx, repoPath, env, "commit-tree", tree, "-p", "HEAD", "-m", message)
+}
+
+// runGit runs git with args in repoPath, adding env to the daemon's environment,
+// and returns its trimmed standard output.
+func runGit(ctx context.Context, repoPath string, env []string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", repoPath}, args...)...)
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s failed: %w, stderr: %s", args[0], err, stderr.String())
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// resetHard performs a hard reset to target, e.g. upstream/main, discarding all local changes.
 // This is a destructive operation that:
-//   - Resets the HEAD to match origin/<branch>
+//   - Resets the HEAD to match target
 //   - Discards all uncommitted changes (staged and unstaged)
 //   - Resets the index to match the remote branch
 //   - Removes all untracked files and directories
 //
-// This is used as a fallback when fast-forward merge fails or when local changes exist.
-func (s *Syncer) resetHard(ctx context.Context, repoPath, branch string) error {
-	remoteBranch := "origin/" + branch
-	cmd := exec.CommandContext(ctx, "git", "-C", repoPath, "reset", "--hard", remoteBranch)
+// This is used by config.GitSyncStrategyReset as a fallback when fast-forward merge
+// fails or when local changes exist, after backupLocalWork.
+func (s *Syncer) resetHard(ctx context.Context, repoPath, target string) error {
+	cmd := exec.CommandContext(ctx, "git", "-C", repoPath, "reset", "--hard", target)
 	var stdout bytes.Buffer
 	var stderr bytes.Buffer
 	cmd.Stdout = &stdout
@@ -5,9 +5,11 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/meyrevived/mpc-dev-env/internal/config"
+	"github.com/meyrevived/mpc-dev-env/internal/daemon/state"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -44,6 +46,69 @@ func setupBareGitRepo(path string) {
 	Expect(cmd.Run()).To(Succeed())
 }
 
+// setupRepoBehindOrigin creates <dir>/<name> with a bare origin that has one more
+// commit than the local repository. It returns the repository path and the hash
+// of origin's HEAD.
+func setupRepoBehindOrigin(dir, name string) (string, string) {
+	repoPath := filepath.Join(dir, name)
+	Expect(os.MkdirAll(repoPath, 0755)).To(Succeed())
+	setupGitRepo(repoPath, "Initial commit")
+
+	originPath := filepath.Join(dir, name+"-origin.git")
+	setupBareGitRepo(originPath)
+	Expect(exec.Command("git", "-C", repoPath, "remote", "add", "origin", originPath).Run()).To(Succeed())
+	Expect(exec.Command("git", "-C", repoPath, "push", "-u", "origin", "HEAD").Run()).To(Succeed())
+
+	clonePath := filepath.Join(dir, name+"-clone")
+	Expect(exec.Command("git", "clone", originPath, clonePath).Run()).To(Succeed())
+	setupGitRepo(clonePath, "Upstream commit")
+	Expect(exec.Command("git", "-C", clonePath, "push", "origin", "HEAD").Run()).To(Succeed())
+
+	originHash, err := exec.Command("git", "-C", clonePath, "rev-parse", "HEAD").Output()
+	Expect(err).NotTo(HaveOccurred())
+	return repoPath, string(originHash)
+}
+
+// headHash returns the HEAD commit of the repository at repoPath
+func headHash(repoPath string) string {
+	return commitHash(repoPath, "HEAD")
+}
+
+// commitHash returns the commit rev resolves to in the repository at repoPath
+func commitHash(repoPath, rev string) string {
+	hash, err := exec.Command("git", "-C", repoPath, "rev-parse", rev).Output()
+	Expect(err).NotTo(HaveOccurred())
+	return string(hash)
+}
+
+// currentBranch returns the current branch of the repository at repoPath
+func currentBranch(repoPath string) string {
+	branch, err := exec.Command("git", "-C", repoPath, "rev-parse", "--abbrev-ref", "HEAD").Output()
+	Expect(err).NotTo(HaveOccurred())
+	return strings.TrimSpace(string(branch))
+}
+
+// commitSubject returns the subject line of the commit rev in the repository at repoPath
+func commitSubject(repoPath, rev string) string {
+	subject, err := exec.Command("git", "-C", repoPath, "log", "-1", "--format=%s", rev).Output()
+	Expect(err).NotTo(HaveOccurred())
+	return strings.TrimSpace(string(subject))
+}
+
+// backupBranches returns the mpc-dev-backup branches of the repository at repoPath
+func backupBranches(repoPath string) []string {
+	out, err := exec.Command("git", "-C", repoPath, "for-each-ref", "--format=%(refname:short)", "refs/heads/"+backupBranchPrefix).Output()
+	Expect(err).NotTo(HaveOccurred())
+	return strings.Fields(string(out))
+}
+
+// commitFile writes content to name in the repository at repoPath and commits it
+func commitFile(repoPath, name, content string) {
+	Expect(os.WriteFile(filepath.Join(repoPath, name), []byte(content), 0644)).To(Succeed())
+	Expect(exec.Command("git", "-C", repoPath, "add", name).Run()).To(Succeed())
+	Expect(exec.Command("git", "-C", repoPath, "commit", "-m", "change "+name).Run()).To(Succeed())
+}
+
 var _ = Describe("Syncer", func() {
 	var (
 		syncer   *Syncer
@@ -62,11 +127,7 @@ var _ = Describe("Syncer", func() {
 		Expect(os.MkdirAll(repoPath, 0755)).To(Succeed())
 		setupGitRepo(repoPath, "Initial commit")
 
-		// Create a mock config
-		cfg := &config.Config{
-			MpcRepoPath: repoPath,
-		}
-		syncer = NewSyncer(cfg)
+		syncer = NewSyncer(map[string]string{"multi-platform-controller": repoPath}, "", true)
 	})
 
 	AfterEach(func() {
@@ -177,36 +238,253 @@ var _ = Describe("Syncer", func() {
 			Expect(localHash).To(Equal(originHash))
 		})
 
-		It("should hard reset when the local branch has diverged", func() {
-			// Make a local commit in the main repo to create divergence
+		It("should keep the local commits on top of origin when rebasing a diverged branch", func() {
+			Expect(syncer.strategy).To(Equal(config.GitSyncStrategyRebase))
 			setupGitRepo(repoPath, "Divergent local commit")
 
-			err := syncer.SyncRepo(ctx, repoPath)
-			Expect(err).NotTo(HaveOccurred())
-
-			// Verify that the local repo is reset to the origin's state
-			cmd := exec.Command("git", "-C", repoPath, "rev-parse", "HEAD")
-			localHash, err := cmd.Output()
-			Expect(err).NotTo(HaveOccurred())
+			Expect(syncer.SyncRepo(ctx, repoPath)).To(Succeed())
 
-			cmd = exec.Command("git", "-C", clonePath, "rev-parse", "HEAD")
-			originHash, err := cmd.Output()
-			Expect(err).NotTo(HaveOccurred())
-			Expect(localHash).To(Equal(originHash))
+			Expect(commitSubject(repoPath, "HEAD")).To(Equal("Divergent local commit"))
+			Expect(commitHash(repoPath, "HEAD~1")).To(Equal(headHash(clonePath)))
 		})
 
-		It("should hard reset when there are local uncommitted changes", func() {
+		It("should keep uncommitted changes when rebasing", func() {
+			setupGitRepo(repoPath, "Divergent local commit")
 			filePath := filepath.Join(repoPath, "local-change.txt")
 			Expect(os.WriteFile(filePath, []byte("local change"), 0644)).To(Succeed())
 
+			Expect(syncer.SyncRepo(ctx, repoPath)).To(Succeed())
+
+			Expect(commitHash(repoPath, "HEAD~1")).To(Equal(headHash(clonePath)))
+			Expect(filePath).To(BeAnExistingFile())
+		})
+
+		It("should leave the branch unchanged when the rebase conflicts", func() {
+			// Origin and the local branch change the same file differently
+			commitFile(clonePath, "conflict.txt", "upstream")
+			Expect(exec.Command("git", "-C", clonePath, "push", "origin", "HEAD").Run()).To(Succeed())
+			commitFile(repoPath, "conflict.txt", "local")
+			localHash := headHash(repoPath)
+
+			err := syncer.SyncRepo(ctx, repoPath)
+			Expect(err).To(MatchError(ContainSubstring("leaving it unchanged")))
+
+			Expect(headHash(repoPath)).To(Equal(localHash))
+			Expect(filepath.Join(repoPath, ".git", "rebase-merge")).NotTo(BeAnExistingFile())
+		})
+
+		It("should fail without changing a diverged branch under the ff-only strategy", func() {
+			syncer = NewSyncer(map[string]string{"multi-platform-controller": repoPath}, config.GitSyncStrategyFFOnly, true)
+			setupGitRepo(repoPath, "Divergent local commit")
+			localHash := headHash(repoPath)
+
+			err := syncer.SyncRepo(ctx, repoPath)
+			Expect(err).To(MatchError(ContainSubstring("cannot fast-forward")))
+
+			Expect(headHash(repoPath)).To(Equal(localHash))
+		})
+
+		It("should fast-forward under the ff-only strategy", func() {
+			syncer = NewSyncer(map[string]string{"multi-platform-controller": repoPath}, config.GitSyncStrategyFFOnly, true)
+
+			Expect(syncer.SyncRepo(ctx, repoPath)).To(Succeed())
+
+			Expect(headHash(repoPath)).To(Equal(headHash(clonePath)))
+		})
+
+		It("should refuse to sync a detached HEAD", func() {
+			Expect(exec.Command("git", "-C", repoPath, "checkout", "--detach").Run()).To(Succeed())
+			localHash := headHash(repoPath)
+
 			err := syncer.SyncRepo(ctx, repoPath)
+			Expect(err).To(MatchError(ErrDetachedHead))
+
+			Expect(headHash(repoPath)).To(Equal(localHash))
+		})
+
+		Context("with an upstream remote", func() {
+			var upstreamClonePath string
+
+			BeforeEach(func() {
+				// The fork model: origin is the fork set up above, upstream the source
+				// repository, whose main has a commit the fork does not
+				upstreamPath := filepath.Join(tempDir, "upstream.git")
+				setupBareGitRepo(upstreamPath)
+				Expect(exec.Command("git", "-C", repoPath, "push", upstreamPath, "HEAD:main").Run()).To(Succeed())
+				Expect(exec.Command("git", "-C", repoPath, "remote", "add", "upstream", upstreamPath).Run()).To(Succeed())
+
+				upstreamClonePath = filepath.Join(tempDir, "upstream-clone")
+				Expect(exec.Command("git", "clone", "--branch", "main", upstreamPath, upstreamClonePath).Run()).To(Succeed())
+				setupGitRepo(upstreamClonePath, "Upstream commit")
+				Expect(exec.Command("git", "-C", upstreamClonePath, "push", "origin", "HEAD:main").Run()).To(Succeed())
+			})
+
+			It("should sync to upstream/main rather than origin", func() {
+				Expect(syncer.SyncRepo(ctx, repoPath)).To(Succeed())
+
+				Expect(headHash(repoPath)).To(Equal(headHash(upstreamClonePath)))
+				Expect(commitHash(repoPath, "upstream/main")).To(Equal(headHash(upstreamClonePath)))
+				Expect(headHash(repoPath)).NotTo(Equal(headHash(clonePath)))
+			})
+
+			It("should rebase a feature branch onto upstream/main", func() {
+				Expect(exec.Command("git", "-C", repoPath, "checkout", "-b", "feature").Run()).To(Succeed())
+				commitFile(repoPath, "feature.txt", "feature")
+
+				Expect(syncer.SyncRepo(ctx, repoPath)).To(Succeed())
+
+				Expect(commitSubject(repoPath, "HEAD")).To(Equal("change feature.txt"))
+				Expect(commitHash(repoPath, "HEAD~1")).To(Equal(headHash(upstreamClonePath)))
+			})
+
+			It("should reset to upstream/main with the reset strategy", func() {
+				syncer = NewSyncer(map[string]string{"multi-platform-controller": repoPath}, config.GitSyncStrategyReset, true)
+				setupGitRepo(repoPath, "Divergent local commit")
+
+				Expect(syncer.SyncRepo(ctx, repoPath)).To(Succeed())
+
+				Expect(headHash(repoPath)).To(Equal(headHash(upstreamClonePath)))
+			})
+		})
+
+		Context("with the reset strategy", func() {
+			BeforeEach(func() {
+				syncer = NewSyncer(map[string]string{"multi-platform-controller": repoPath}, config.GitSyncStrategyReset, true)
+			})
+
+			It("should hard reset when the local branch has diverged", func() {
+				// Make a local commit in the main repo to create divergence
+				setupGitRepo(repoPath, "Divergent local commit")
+
+				err := syncer.SyncRepo(ctx, repoPath)
+				Expect(err).NotTo(HaveOccurred())
+
+				// Verify that the local repo is reset to the origin's state
+				Expect(headHash(repoPath)).To(Equal(headHash(clonePath)))
+			})
+
+			It("should hard reset when there are local uncommitted changes", func() {
+				filePath := filepath.Join(repoPath, "local-change.txt")
+				Expect(os.WriteFile(filePath, []byte("local change"), 0644)).To(Succeed())
+
+				err := syncer.SyncRepo(ctx, repoPath)
+				Expect(err).NotTo(HaveOccurred())
+
+				// After a hard reset, the untracked file should be gone
+				_, err = os.Stat(filePath)
+				Expect(os.IsNotExist(err)).To(BeTrue())
+			})
+
+			It("should back up local commits and changes to a branch before resetting", func() {
+				commitFile(repoPath, "committed.txt", "local commit")
+				localHash := headHash(repoPath)
+				Expect(os.WriteFile(filepath.Join(repoPath, "committed.txt"), []byte("modified"), 0644)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(repoPath, "untracked.txt"), []byte("untracked"), 0644)).To(Succeed())
+
+				Expect(syncer.SyncRepo(ctx, repoPath)).To(Succeed())
+
+				// The working tree matches origin
+				Expect(headHash(repoPath)).To(Equal(headHash(clonePath)))
+				status, err := exec.Command("git", "-C", repoPath, "status", "--porcelain").Output()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(status)).To(BeEmpty())
+
+				// The backup branch holds a WIP commit with the changes on top of the local commit
+				branches := backupBranches(repoPath)
+				Expect(branches).To(HaveLen(1))
+				Expect(commitHash(repoPath, branches[0]+"~1")).To(Equal(localHash))
+				for file, content := range map[string]string{"committed.txt": "modified", "untracked.txt": "untracked"} {
+					backedUp, err := exec.Command("git", "-C", repoPath, "show", branches[0]+":"+file).Output()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(backedUp)).To(Equal(content))
+				}
+			})
+
+			It("should back up a diverged branch without uncommitted changes", func() {
+				setupGitRepo(repoPath, "Divergent local commit")
+				localHash := headHash(repoPath)
+
+				Expect(syncer.SyncRepo(ctx, repoPath)).To(Succeed())
+
+				Expect(headHash(repoPath)).To(Equal(headHash(clonePath)))
+				branches := backupBranches(repoPath)
+				Expect(branches).To(HaveLen(1))
+				Expect(commitHash(repoPath, branches[0])).To(Equal(localHash))
+			})
+
+			It("should not back up when backups are off", func() {
+				syncer = NewSyncer(map[string]string{"multi-platform-controller": repoPath}, config.GitSyncStrategyReset, false)
+				setupGitRepo(repoPath, "Divergent local commit")
+
+				Expect(syncer.SyncRepo(ctx, repoPath)).To(Succeed())
+
+				Expect(headHash(repoPath)).To(Equal(headHash(clonePath)))
+				Expect(backupBranches(repoPath)).To(BeEmpty())
+			})
+
+			It("should not back up when it only fast-forwards", func() {
+				Expect(syncer.SyncRepo(ctx, repoPath)).To(Succeed())
+
+				Expect(backupBranches(repoPath)).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("SyncAllRepos", func() {
+		It("should sync every configured repository", func() {
+			mpcPath, mpcOriginHash := setupRepoBehindOrigin(tempDir, "multi-platform-controller")
+			konfluxPath, konfluxOriginHash := setupRepoBehindOrigin(tempDir, "konflux-ci")
+
+			syncer = NewSyncer(map[string]string{
+				"multi-platform-controller": mpcPath,
+				"konflux-ci":                konfluxPath,
+			}, "", true)
+			oldMPCHash := headHash(mpcPath)
+			results, err := syncer.SyncAllRepos(ctx)
 			Expect(err).NotTo(HaveOccurred())
 
-			// After a hard reset, the untracked file should be gone.
-			// The original test was flawed in its assertion.
-			// We check if the file still exists.
-			_, err = os.Stat(filePath)
-			Expect(os.IsNotExist(err)).To(BeTrue())
+			Expect(headHash(mpcPath)).To(Equal(mpcOriginHash))
+			Expect(headHash(konfluxPath)).To(Equal(konfluxOriginHash))
+
+			// One result per repository, in name order
+			Expect(results).To(HaveLen(2))
+			Expect(results[0].Name).To(Equal("konflux-ci"))
+			Expect(results[1]).To(Equal(state.RepoSyncResult{
+				Name:     "multi-platform-controller",
+				Path:     mpcPath,
+				Branch:   currentBranch(mpcPath),
+				Target:   "origin/" + currentBranch(mpcPath),
+				Strategy: config.GitSyncStrategyRebase,
+				OldHash:  strings.TrimSpace(oldMPCHash),
+				NewHash:  strings.TrimSpace(mpcOriginHash),
+			}))
+		})
+
+		It("should keep syncing the other repositories when one fails", func() {
+			mpcPath, mpcOriginHash := setupRepoBehindOrigin(tempDir, "multi-platform-controller")
+
+			// No origin remote, so fetching fails. The name sorts first, so it is synced first.
+			brokenPath := filepath.Join(tempDir, "broken")
+			Expect(os.MkdirAll(brokenPath, 0755)).To(Succeed())
+			setupGitRepo(brokenPath, "Initial commit")
+
+			syncer = NewSyncer(map[string]string{
+				"broken":                    brokenPath,
+				"multi-platform-controller": mpcPath,
+			}, "", true)
+			results, err := syncer.SyncAllRepos(ctx)
+			Expect(err).To(MatchError(ContainSubstring("broken: failed to fetch from origin")))
+			Expect(err.Error()).NotTo(ContainSubstring("multi-platform-controller:"))
+
+			Expect(headHash(mpcPath)).To(Equal(mpcOriginHash))
+
+			// The failed repository is reported unchanged, with its error
+			Expect(results).To(HaveLen(2))
+			Expect(results[0].Name).To(Equal("broken"))
+			Expect(results[0].Error).To(ContainSubstring("failed to fetch from origin"))
+			Expect(results[0].NewHash).To(Equal(results[0].OldHash))
+			Expect(results[0].OldHash).To(Equal(strings.TrimSpace(headHash(brokenPath))))
+			Expect(results[1].Error).To(BeEmpty())
 		})
 	})
 })
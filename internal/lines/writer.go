@@ -0,0 +1,48 @@
+// Package lines splits the output of external commands into lines, e.g. to log kind's
+// output line by line or to keep the last lines of a failed script's output.
+package lines
+
+import (
+	"bytes"
+	"strings"
+)
+
+// Writer is an io.Writer that passes each complete line written to it, without its
+// newline, to onLine. Output after the last newline is held back until the rest of the
+// line is written, or until Flush. It is not safe for concurrent use.
+type Writer struct {
+	onLine  func(line string)
+	partial bytes.Buffer // Output after the last newline
+}
+
+// NewWriter creates a Writer that passes each line to onLine.
+func NewWriter(onLine func(line string)) *Writer {
+	return &Writer{onLine: onLine}
+}
+
+func (w *Writer) Write(p []byte) (int, error) {
+	w.partial.Write(p)
+	for {
+		line, err := w.partial.ReadString('\n')
+		if err != nil {
+			// No newline yet: keep the incomplete line for the next write
+			w.partial.Reset()
+			w.partial.WriteString(line)
+			return len(p), nil
+		}
+		w.onLine(strings.TrimSuffix(line, "\n"))
+	}
+}
+
+// Partial returns the output written after the last newline.
+func (w *Writer) Partial() string {
+	return w.partial.String()
+}
+
+// Flush passes the output after the last newline, if any, to onLine as the last line.
+func (w *Writer) Flush() {
+	if w.partial.Len() > 0 {
+		w.onLine(w.partial.String())
+		w.partial.Reset()
+	}
+}
@@ -0,0 +1,35 @@
+package lines
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestWriter tests that Writer splits writes into lines, including lines spread
+// across writes and a last line without a newline
+func TestWriter(t *testing.T) {
+	var lines []string
+	w := NewWriter(func(line string) { lines = append(lines, line) })
+	for _, chunk := range []string{"Creating cluster \"konflux\" ...\n ✓ Ensuring", " node image\n\n", " • Preparing nodes"} {
+		if n, err := w.Write([]byte(chunk)); n != len(chunk) || err != nil {
+			t.Fatalf("Write(%q) = %d, %v", chunk, n, err)
+		}
+	}
+	if len(lines) != 3 {
+		t.Fatalf("expected the incomplete last line to be held back, got %q", lines)
+	}
+	if w.Partial() != " • Preparing nodes" {
+		t.Errorf("expected the incomplete last line as Partial, got %q", w.Partial())
+	}
+
+	w.Flush()
+	want := []string{`Creating cluster "konflux" ...`, " ✓ Ensuring node image", "", " • Preparing nodes"}
+	if strings.Join(lines, "|") != strings.Join(want, "|") {
+		t.Errorf("expected lines %q, got %q", want, lines)
+	}
+
+	w.Flush()
+	if len(lines) != len(want) {
+		t.Errorf("expected Flush without pending output to pass no line, got %q", lines)
+	}
+}
@@ -1,31 +1,50 @@
 // Package prereq provides prerequisite checking for the MPC Dev Environment.
 //
 // It verifies that all required tools (Go, kubectl, kind, Docker/Podman, git, helm)
-// are installed and meet minimum version requirements.
+// are installed and meet minimum version requirements, and warns when the machine
+// is short on disk space or memory for image builds.
 //
-// The checker supports Docker/Podman flexibility - if Docker is not available, it
-// will check for Podman as an alternative and accept it if version requirements are met.
+// The checker supports Docker/Podman flexibility - it version-checks whichever
+// container runtime the build package will use, honoring DOCKER_CLI.
 package prereq
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"os/exec"
 	"regexp"
+	"runtime"
 	"strings"
+	"time"
 
 	"github.com/meyrevived/mpc-dev-env/internal/config"
+	"golang.org/x/sync/errgroup"
 )
 
+// defaultToolTimeout is how long a tool's version command may run before the tool is
+// reported as "unknown". Version commands return instantly unless something is wrong,
+// such as a docker CLI waiting for a daemon that is down.
+const defaultToolTimeout = 5 * time.Second
+
+// toolWaitDelay caps the wait for a killed version command's output. Version commands
+// print at once, so anything still holding the pipe is not worth waiting for.
+const toolWaitDelay = time.Second
+
+// versionTimedOut is the Version of a tool whose version command timed out.
+const versionTimedOut = "Timed out"
+
 // PrerequisiteResult represents the result of checking a single prerequisite.
 // It contains the tool name, installation status, version information, and
 // whether it meets the minimum requirement.
 type PrerequisiteResult struct {
-	Name      string `json:"name"`
-	Installed bool   `json:"installed"`
-	Version   string `json:"version"`
-	Required  string `json:"required"`
-	Status    string `json:"status"` // "ok", "missing", "outdated", "unknown"
+	Name        string `json:"name"`
+	Installed   bool   `json:"installed"`
+	Version     string `json:"version"`
+	Required    string `json:"required"`
+	Status      string `json:"status"`                // "ok", "missing", "outdated", "unknown", "down", "warning", "in_use"
+	Remediation string `json:"remediation,omitempty"` // Install or upgrade command, for "missing" and "outdated"
 }
 
 // CheckResult represents the overall result of all prerequisite checks.
@@ -35,6 +54,7 @@ type CheckResult struct {
 	Prerequisites map[string]PrerequisiteResult `json:"prerequisites"`
 	AllMet        bool                          `json:"all_met"`
 	Errors        []string                      `json:"errors,omitempty"`
+	Warnings      []string                      `json:"warnings,omitempty"` // Problems that do not affect AllMet
 }
 
 // Checker performs prerequisite checks for required tools and system configuration.
@@ -42,12 +62,32 @@ type CheckResult struct {
 // MPC Dev Environment daemon and create Kind clusters.
 type Checker struct {
 	config *config.Config
+
+	// diskSpace and systemMemory report the machine's resources. They are fields so
+	// tests can inject fake values.
+	diskSpace    func(path string) (uint64, error)
+	systemMemory func() (total, available uint64, err error)
+
+	// portAvailable reports whether a TCP port is free; skipPorts are not checked.
+	portAvailable func(port int) bool
+	skipPorts     map[int]bool
+
+	// goos selects the platform remediation commands are given for.
+	goos string
+
+	// toolTimeout limits each tool's version command.
+	toolTimeout time.Duration
 }
 
 // NewChecker creates a new prerequisite checker with the provided configuration.
 func NewChecker(cfg *config.Config) *Checker {
 	return &Checker{
-		config: cfg,
+		config:        cfg,
+		diskSpace:     diskSpace,
+		systemMemory:  systemMemory,
+		portAvailable: portAvailable,
+		goos:          runtime.GOOS,
+		toolTimeout:   defaultToolTimeout,
 	}
 }
 
@@ -57,13 +97,23 @@ func NewChecker(cfg *config.Config) *Checker {
 //   - Go (minimum 1.24.0)
 //   - kind (minimum 0.26.0)
 //   - kubectl (minimum 1.31.1)
-//   - Docker or Podman (minimum Docker 27.0.1 or Podman 5.3.1)
+//   - The container runtime builds use (minimum Docker 27.0.1 or Podman 5.3.1)
+//   - The container runtime's server, which must be running and meet the same minimum
 //   - git (minimum 2.46.0)
 //   - helm (minimum 3.0.0)
+//   - Free disk space on the MPC repository's filesystem (config.GetMinDiskSpaceGB)
+//   - Total system memory (config.GetMinMemoryGB)
+//   - Free TCP ports (config.GetCheckPorts, minus those excluded with SkipPort)
 //
-// If Docker is not available but Podman is, Podman will be accepted as an alternative.
-// The function returns a CheckResult with all individual check results and an overall
-// status indicating whether all prerequisites are met.
+// The container runtime is chosen like the build package chooses it (DOCKER_CLI, then
+// Podman, then Docker) and reported under the "container-runtime" key, and its server
+// under "container-runtime-daemon". The tools are
+// checked concurrently, each with its own timeout (defaultToolTimeout), so a tool that
+// hangs is reported as "unknown" without holding up the others. The function returns a
+// CheckResult with all individual check results and an overall
+// status indicating whether all prerequisites are met. Resource shortfalls and ports in
+// use are reported with status "warning" or "in_use" and in Warnings, but do not affect
+// AllMet: a port may well be held by an environment that is already running.
 func (c *Checker) CheckAll(ctx context.Context) (*CheckResult, error) {
 	result := &CheckResult{
 		Prerequisites: make(map[string]PrerequisiteResult),
@@ -100,13 +150,6 @@ func (c *Checker) CheckAll(ctx context.Context) (*CheckResult, error) {
 			required:     "1.31.1",
 			versionRegex: `v?(\d+\.\d+\.\d+)`,
 		},
-		{
-			name:         "docker",
-			command:      "docker",
-			args:         []string{"--version"},
-			required:     "27.0.1",
-			versionRegex: `(\d+\.\d+\.\d+)`,
-		},
 		{
 			name:         "git",
 			command:      "git",
@@ -123,12 +166,33 @@ func (c *Checker) CheckAll(ctx context.Context) (*CheckResult, error) {
 		},
 	}
 
-	// Run each check
-	for _, check := range checks {
-		prereqResult := c.checkTool(ctx, check.name, check.command, check.args, check.required, check.versionRegex)
+	// Run the checks concurrently; the container runtime's results come last
+	toolResults := make([]PrerequisiteResult, len(checks)+1)
+	var daemonResult *PrerequisiteResult
+	var tools errgroup.Group
+	for i, check := range checks {
+		tools.Go(func() error {
+			toolResults[i] = c.checkTool(ctx, check.name, check.command, check.args, check.required, check.versionRegex)
+			return nil
+		})
+	}
+	tools.Go(func() error {
+		runtimeResult, command := c.checkContainerRuntime(ctx)
+		toolResults[len(checks)] = runtimeResult
+		// Only a CLI that works can tell whether the server does
+		if runtimeResult.Status == "ok" || runtimeResult.Status == "outdated" {
+			daemon := c.checkContainerRuntimeDaemon(ctx, command)
+			daemonResult = &daemon
+		}
+		return nil
+	})
+	_ = tools.Wait()
+
+	// Update overall status, in the order of the checks
+	for i, check := range checks {
+		prereqResult := toolResults[i]
 		result.Prerequisites[check.name] = prereqResult
 
-		// Update overall status
 		if prereqResult.Status != "ok" {
 			result.AllMet = false
 			switch prereqResult.Status {
@@ -137,42 +201,75 @@ func (c *Checker) CheckAll(ctx context.Context) (*CheckResult, error) {
 			case "outdated":
 				result.Errors = append(result.Errors, fmt.Sprintf("%s version %s is below minimum requirement %s",
 					check.name, prereqResult.Version, prereqResult.Required))
+			case "unknown":
+				if prereqResult.Version == versionTimedOut {
+					result.Errors = append(result.Errors, fmt.Sprintf("%s did not report its version within %s",
+						check.name, c.toolTimeout))
+				}
 			}
 		}
 	}
 
-	// Special handling for Docker/Podman - check for either one
-	dockerResult := result.Prerequisites["docker"]
-	if dockerResult.Status != "ok" {
-		// Docker is not available, try Podman
-		podmanResult := c.checkTool(ctx, "podman", "podman", []string{"--version"}, "5.3.1", `(\d+\.\d+\.\d+)`)
-
-		if podmanResult.Status == "ok" {
-			// Podman is available, use it instead
-			result.Prerequisites["podman"] = podmanResult
-
-			// Remove Docker error since we have Podman
-			filteredErrors := []string{}
-			for _, err := range result.Errors {
-				if !strings.Contains(err, "docker") {
-					filteredErrors = append(filteredErrors, err)
-				}
-			}
-			result.Errors = filteredErrors
-
-			// If all other prerequisites are met, we're good
-			allOthersOk := true
-			for name, prereq := range result.Prerequisites {
-				if name != "docker" && prereq.Status != "ok" {
-					allOthersOk = false
-					break
-				}
-			}
-			result.AllMet = allOthersOk
-		} else {
-			// Neither Docker nor Podman is available
-			result.Prerequisites["podman"] = podmanResult
-			result.Errors = append(result.Errors, "Neither Docker nor Podman is available")
+	runtimeResult := toolResults[len(checks)]
+	result.Prerequisites[containerRuntimeKey] = runtimeResult
+	switch runtimeResult.Status {
+	case "ok":
+	case "missing":
+		result.AllMet = false
+		result.Errors = append(result.Errors, "Neither Docker nor Podman is available")
+	case "outdated":
+		result.AllMet = false
+		result.Errors = append(result.Errors, fmt.Sprintf("%s version %s is below minimum requirement %s",
+			runtimeResult.Name, runtimeResult.Version, runtimeResult.Required))
+	default:
+		result.AllMet = false
+		if runtimeResult.Version == versionTimedOut {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s did not report its version within %s",
+				runtimeResult.Name, c.toolTimeout))
+		}
+	}
+
+	if daemonResult != nil {
+		result.Prerequisites[containerRuntimeDaemonKey] = *daemonResult
+		switch daemonResult.Status {
+		case "ok":
+		case "down":
+			result.AllMet = false
+			result.Errors = append(result.Errors, fmt.Sprintf("%s server is not running or not reachable",
+				daemonResult.Name))
+		case "outdated":
+			result.AllMet = false
+			result.Errors = append(result.Errors, fmt.Sprintf("%s server version %s is below minimum requirement %s",
+				daemonResult.Name, daemonResult.Version, daemonResult.Required))
+		default:
+			result.AllMet = false
+		}
+	}
+
+	// Resource checks only warn: builds may still succeed, just slowly or after a retry
+	buildPath := c.config.GetMpcRepoPath()
+	if buildPath == "" {
+		buildPath = os.TempDir()
+	}
+	for _, resource := range []PrerequisiteResult{
+		c.checkDiskSpace(buildPath, c.config.GetMinDiskSpaceGB()),
+		c.checkMemory(c.config.GetMinMemoryGB()),
+	} {
+		result.Prerequisites[resource.Name] = resource
+		if resource.Status == "warning" {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s: %s is below the recommended %s",
+				resource.Name, resource.Version, resource.Required))
+		}
+	}
+
+	for _, port := range c.config.GetCheckPorts() {
+		if c.skipPorts[port] {
+			continue
+		}
+		portResult := c.checkPort(port)
+		result.Prerequisites[portResult.Name] = portResult
+		if portResult.Status == "in_use" {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("port %d is already in use", port))
 		}
 	}
 
@@ -187,7 +284,11 @@ func (c *Checker) CheckAll(ctx context.Context) (*CheckResult, error) {
 //   - "ok" if tool is installed and version meets requirement
 //   - "missing" if tool is not found in PATH
 //   - "outdated" if tool version is below requirement
-//   - "unknown" if version cannot be determined
+//   - "unknown" if version cannot be determined, with Version versionTimedOut when the
+//     version command did not finish within the Checker's tool timeout
+//
+// Missing and outdated results carry the platform's install or upgrade command in
+// Remediation.
 func (c *Checker) checkTool(ctx context.Context, name, command string, args []string, requiredVersion, versionRegex string) PrerequisiteResult {
 	result := PrerequisiteResult{
 		Name:      name,
@@ -200,22 +301,25 @@ func (c *Checker) checkTool(ctx context.Context, name, command string, args []st
 	// Check if command exists
 	_, err := exec.LookPath(command)
 	if err != nil {
+		result.Remediation = remediation(name, result.Status, c.goos)
 		return result
 	}
 
 	result.Installed = true
 
 	// Execute version command
-	cmd := exec.CommandContext(ctx, command, args...)
-	output, err := cmd.CombinedOutput()
+	output, timedOut, err := c.toolOutput(ctx, command, args...)
 	if err != nil {
 		result.Status = "unknown"
 		result.Version = "Unknown"
+		if timedOut {
+			result.Version = versionTimedOut
+		}
 		return result
 	}
 
 	// Extract version from output
-	version := extractVersion(string(output), versionRegex)
+	version := extractVersion(output, versionRegex)
 	if version == "" {
 		result.Status = "unknown"
 		result.Version = "Unknown"
@@ -229,11 +333,23 @@ func (c *Checker) checkTool(ctx context.Context, name, command string, args []st
 		result.Status = "ok"
 	} else {
 		result.Status = "outdated"
+		result.Remediation = remediation(name, result.Status, c.goos)
 	}
 
 	return result
 }
 
+// toolOutput runs a tool's command and returns its combined output. The command is
+// killed once the Checker's tool timeout has passed, in which case timedOut is true.
+func (c *Checker) toolOutput(ctx context.Context, command string, args ...string) (output string, timedOut bool, err error) {
+	toolCtx, cancel := context.WithTimeout(ctx, c.toolTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(toolCtx, command, args...)
+	cmd.WaitDelay = toolWaitDelay
+	out, err := cmd.CombinedOutput()
+	return string(out), err != nil && errors.Is(toolCtx.Err(), context.DeadlineExceeded), err
+}
+
 // extractVersion extracts a version string from command output using a regex pattern.
 // It looks for the first capture group in the regex match.
 // Returns an empty string if no match is found.
@@ -1,11 +1,16 @@
 package prereq
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/meyrevived/mpc-dev-env/internal/config"
 	. "github.com/onsi/ginkgo/v2"
@@ -139,6 +144,28 @@ var _ = Describe("Prerequisite Checker", func() {
 					result := checker.checkTool(ctx, "nonexistent", "nonexistent", []string{"version"}, "1.0.0", "")
 					Expect(result.Status).To(Equal("missing"))
 				})
+
+				It("should suggest the install command for a missing tool", func() {
+					checker.goos = "linux"
+					result := checker.checkTool(ctx, "kind", "kind", []string{"--version"}, "0.26.0", `(\d+\.\d+\.\d+)`)
+					Expect(result.Status).To(Equal("missing"))
+					Expect(result.Remediation).To(Equal("go install sigs.k8s.io/kind@latest"))
+				})
+
+				It("should suggest the upgrade command for an outdated tool on macOS", func() {
+					checker.goos = "darwin"
+					createMockTool("kubectl", "Client Version: v1.30.0")
+					result := checker.checkTool(ctx, "kubectl", "kubectl", []string{"version", "--client"}, "1.31.1", `v?(\d+\.\d+\.\d+)`)
+					Expect(result.Status).To(Equal("outdated"))
+					Expect(result.Remediation).To(Equal("brew upgrade kubectl"))
+				})
+
+				It("should not suggest a remediation for a tool that is ok", func() {
+					createMockTool("go", "go version go1.25.0 linux/amd64")
+					result := checker.checkTool(ctx, "go", "go", []string{"version"}, "1.24.0", `go(\d+\.\d+(?:\.\d+)?)`)
+					Expect(result.Status).To(Equal("ok"))
+					Expect(result.Remediation).To(BeEmpty())
+				})
 			})
 
 			Describe("CheckAll", func() {
@@ -182,11 +209,371 @@ var _ = Describe("Prerequisite Checker", func() {
 					Expect(err).NotTo(HaveOccurred())
 					Expect(result.AllMet).To(BeTrue())
 					// Verify podman was detected as fallback
-					podmanResult, exists := result.Prerequisites["podman"]
+					runtimeResult, exists := result.Prerequisites["container-runtime"]
 					Expect(exists).To(BeTrue(), "podman should be checked when docker is missing")
-					Expect(podmanResult.Status).To(Equal("ok"))
+					Expect(runtimeResult.Name).To(Equal("podman"))
+					Expect(runtimeResult.Status).To(Equal("ok"))
+				})
+
+				It("should report a hanging tool as unknown without blocking the others", func() {
+					// Configured, so detecting the runtime does not run it
+					cfg.ContainerRuntime = "docker"
+					checker.toolTimeout = 200 * time.Millisecond
+					createMockTool("go", "go version go1.24.0")
+					createMockTool("kind", "kind v0.26.0")
+					createMockTool("kubectl", "Client Version: v1.31.1")
+					createMockTool("git", "git version 2.46.0")
+					createMockTool("helm", "v3.0.0")
+					// Like a docker CLI waiting for a daemon that is down; PATH has no sleep
+					hanging := "#!/bin/sh\nwhile :; do :; done\n"
+					Expect(os.WriteFile(filepath.Join(tempBinDir, "docker"), []byte(hanging), 0755)).To(Succeed())
+					Expect(os.WriteFile(filepath.Join(tempBinDir, "kubectl"), []byte(hanging), 0755)).To(Succeed())
+
+					start := time.Now()
+					result, err := checker.CheckAll(ctx)
+					Expect(err).NotTo(HaveOccurred())
+					// Both hanging tools time out together, not one after the other
+					Expect(time.Since(start)).To(BeNumerically("<", 2*toolWaitDelay))
+
+					Expect(result.AllMet).To(BeFalse())
+					Expect(result.Prerequisites["kubectl"].Status).To(Equal("unknown"))
+					Expect(result.Prerequisites["kubectl"].Version).To(Equal(versionTimedOut))
+					Expect(result.Prerequisites[containerRuntimeKey].Status).To(Equal("unknown"))
+					Expect(result.Errors).To(ContainElements(
+						"kubectl did not report its version within 200ms",
+						"docker did not report its version within 200ms",
+					))
+					for _, tool := range []string{"go", "kind", "git", "helm"} {
+						Expect(result.Prerequisites[tool].Status).To(Equal("ok"), tool)
+					}
+				})
+			})
+
+			Describe("Container runtime", func() {
+				// createOtherTools creates every required tool except the container runtimes
+				createOtherTools := func() {
+					createMockTool("go", "go version go1.24.0")
+					createMockTool("kind", "kind v0.26.0")
+					createMockTool("kubectl", "Client Version: v1.31.1")
+					createMockTool("git", "git version 2.46.0")
+					createMockTool("helm", "v3.0.0")
+				}
+
+				AfterEach(func() {
+					_ = os.Unsetenv("DOCKER_CLI")
+				})
+
+				It("should check podman when both runtimes are installed, like builds do", func() {
+					createOtherTools()
+					createMockTool("docker", "Docker version 27.0.1")
+					createMockTool("podman", "podman version 5.0.0")
+
+					result, err := checker.CheckAll(ctx)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.AllMet).To(BeFalse())
+					Expect(result.Prerequisites["container-runtime"].Name).To(Equal("podman"))
+					Expect(result.Prerequisites["container-runtime"].Status).To(Equal("outdated"))
+					Expect(result.Errors).To(ContainElement("podman version 5.0.0 is below minimum requirement 5.3.1"))
+				})
+
+				It("should check the runtime named by DOCKER_CLI", func() {
+					createOtherTools()
+					createMockTool("docker", "Docker version 27.0.1")
+					createMockTool("podman", "podman version 5.0.0")
+					_ = os.Setenv("DOCKER_CLI", filepath.Join(tempBinDir, "docker"))
+
+					result, err := checker.CheckAll(ctx)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.AllMet).To(BeTrue())
+					Expect(result.Prerequisites["container-runtime"].Name).To(Equal("docker"))
+					Expect(result.Prerequisites["container-runtime"].Version).To(Equal("27.0.1"))
+					Expect(result.Prerequisites).NotTo(HaveKey("podman"))
+				})
+
+				It("should fail when neither runtime is installed", func() {
+					createOtherTools()
+
+					result, err := checker.CheckAll(ctx)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.AllMet).To(BeFalse())
+					Expect(result.Prerequisites["container-runtime"].Status).To(Equal("missing"))
+					Expect(result.Errors).To(Equal([]string{"Neither Docker nor Podman is available"}))
+					Expect(result.Prerequisites).NotTo(HaveKey("container-runtime-daemon"))
 				})
 			})
+
+			Describe("Container runtime daemon", func() {
+				// createMockRuntime creates a runtime whose CLI is up to date and whose
+				// "info" runs infoScript; it logs the arguments of "info"
+				createMockRuntime := func(name, infoScript string) {
+					script := "#!/bin/sh\n" +
+						"if [ \"$1\" = \"info\" ]; then\n" +
+						"  echo \"$@\" > " + filepath.Join(tempBinDir, "info.args") + "\n" +
+						infoScript + "\n" +
+						"fi\n" +
+						"echo '" + name + " version 27.0.1'\n"
+					if name == "podman" {
+						script = strings.ReplaceAll(script, "27.0.1", "5.3.1")
+					}
+					Expect(os.WriteFile(filepath.Join(tempBinDir, name), []byte(script), 0755)).To(Succeed())
+				}
+
+				BeforeEach(func() {
+					createMockTool("go", "go version go1.24.0")
+					createMockTool("kind", "kind v0.26.0")
+					createMockTool("kubectl", "Client Version: v1.31.1")
+					createMockTool("git", "git version 2.46.0")
+					createMockTool("helm", "v3.0.0")
+					checker.goos = "linux"
+				})
+
+				It("should report a reachable daemon as ok", func() {
+					createMockRuntime("docker", "echo 28.1.1; exit 0")
+
+					result, err := checker.CheckAll(ctx)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.AllMet).To(BeTrue())
+					daemon := result.Prerequisites["container-runtime-daemon"]
+					Expect(daemon.Name).To(Equal("docker"))
+					Expect(daemon.Status).To(Equal("ok"))
+					Expect(daemon.Version).To(Equal("28.1.1"))
+					Expect(daemon.Required).To(Equal("27.0.1"))
+
+					args, err := os.ReadFile(filepath.Join(tempBinDir, "info.args"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(strings.TrimSpace(string(args))).To(Equal("info --format {{.ServerVersion}}"))
+				})
+
+				It("should report a daemon that cannot be reached as down", func() {
+					createMockRuntime("docker",
+						"echo 'Cannot connect to the Docker daemon at unix:///var/run/docker.sock.' >&2; exit 1")
+
+					result, err := checker.CheckAll(ctx)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.AllMet).To(BeFalse())
+					// The CLI itself is fine
+					Expect(result.Prerequisites["container-runtime"].Status).To(Equal("ok"))
+					daemon := result.Prerequisites["container-runtime-daemon"]
+					Expect(daemon.Status).To(Equal("down"))
+					Expect(daemon.Version).To(Equal("Not Running"))
+					Expect(daemon.Remediation).To(Equal("sudo systemctl start docker"))
+					Expect(result.Errors).To(Equal([]string{"docker server is not running or not reachable"}))
+				})
+
+				It("should report an old daemon behind an up-to-date CLI as outdated", func() {
+					createMockRuntime("docker", "echo 24.0.7; exit 0")
+
+					result, err := checker.CheckAll(ctx)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.AllMet).To(BeFalse())
+					Expect(result.Prerequisites["container-runtime-daemon"].Status).To(Equal("outdated"))
+					Expect(result.Errors).To(ContainElement("docker server version 24.0.7 is below minimum requirement 27.0.1"))
+				})
+
+				It("should ask podman for the version of its service", func() {
+					createMockRuntime("podman", "echo 5.4.0; exit 0")
+
+					result, err := checker.CheckAll(ctx)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.AllMet).To(BeTrue())
+					daemon := result.Prerequisites["container-runtime-daemon"]
+					Expect(daemon.Name).To(Equal("podman"))
+					Expect(daemon.Version).To(Equal("5.4.0"))
+
+					args, err := os.ReadFile(filepath.Join(tempBinDir, "info.args"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(strings.TrimSpace(string(args))).To(Equal("info --format {{.Version.Version}}"))
+				})
+			})
+		})
+
+		Describe("Resource checks", func() {
+			const gb = uint64(1 << 30)
+
+			// fakeResources makes the checker see the given free disk space and memory
+			fakeResources := func(freeDisk, totalMemory, availableMemory uint64) {
+				checker.diskSpace = func(string) (uint64, error) { return freeDisk, nil }
+				checker.systemMemory = func() (uint64, uint64, error) { return totalMemory, availableMemory, nil }
+			}
+
+			It("should report ok when disk space and memory meet the defaults", func() {
+				fakeResources(50*gb, 16*gb, 10*gb)
+
+				result, err := checker.CheckAll(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Prerequisites["disk_space"].Status).To(Equal("ok"))
+				Expect(result.Prerequisites["disk_space"].Version).To(Equal("50.0 GB free"))
+				Expect(result.Prerequisites["memory"].Status).To(Equal("ok"))
+				Expect(result.Prerequisites["memory"].Version).To(Equal("16.0 GB total, 10.0 GB available"))
+				Expect(result.Warnings).To(BeEmpty())
+			})
+
+			It("should warn below the default thresholds", func() {
+				fakeResources(19*gb, 7*gb, 3*gb)
+
+				result, err := checker.CheckAll(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Prerequisites["disk_space"].Status).To(Equal("warning"))
+				Expect(result.Prerequisites["disk_space"].Required).To(Equal("20 GB free"))
+				Expect(result.Prerequisites["memory"].Status).To(Equal("warning"))
+				Expect(result.Prerequisites["memory"].Required).To(Equal("8 GB total"))
+				Expect(result.Warnings).To(ConsistOf(
+					ContainSubstring("disk_space: 19.0 GB free"),
+					ContainSubstring("memory: 7.0 GB total"),
+				))
+			})
+
+			It("should use the configured minimums", func() {
+				cfg.MinDiskSpaceGB = 100
+				cfg.MinMemoryGB = 4
+				fakeResources(50*gb, 6*gb, 2*gb)
+
+				result, err := checker.CheckAll(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Prerequisites["disk_space"].Status).To(Equal("warning"))
+				Expect(result.Prerequisites["memory"].Status).To(Equal("ok"))
+			})
+
+			It("should check disk space on the MPC repository's filesystem", func() {
+				cfg.MpcRepoPath = "/src/multi-platform-controller"
+				var checkedPath string
+				checker.diskSpace = func(path string) (uint64, error) {
+					checkedPath = path
+					return 50 * gb, nil
+				}
+
+				_ = checker.checkDiskSpace(cfg.GetMpcRepoPath(), cfg.GetMinDiskSpaceGB())
+				Expect(checkedPath).To(Equal("/src/multi-platform-controller"))
+			})
+
+			It("should report unknown when the resources cannot be read", func() {
+				checker.diskSpace = func(string) (uint64, error) { return 0, errors.New("statfs failed") }
+				checker.systemMemory = func() (uint64, uint64, error) { return 0, 0, errors.New("no /proc/meminfo") }
+
+				result, err := checker.CheckAll(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Prerequisites["disk_space"].Status).To(Equal("unknown"))
+				Expect(result.Prerequisites["memory"].Status).To(Equal("unknown"))
+				Expect(result.Warnings).To(BeEmpty())
+			})
+
+			It("should not affect whether all tool prerequisites are met", func() {
+				fakeResources(1*gb, 1*gb, 1*gb)
+
+				result, err := checker.CheckAll(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Errors).NotTo(ContainElement(ContainSubstring("disk_space")))
+				Expect(result.Errors).NotTo(ContainElement(ContainSubstring("memory")))
+			})
+		})
+	})
+
+	Describe("Port checks", func() {
+		var (
+			checker  *Checker
+			cfg      *config.Config
+			listener net.Listener
+			port     int
+		)
+
+		BeforeEach(func() {
+			var err error
+			listener, err = net.Listen("tcp", ":0")
+			Expect(err).NotTo(HaveOccurred())
+			port = listener.Addr().(*net.TCPAddr).Port
+
+			cfg = &config.Config{CheckPorts: []int{port}}
+			checker = NewChecker(cfg)
+		})
+
+		AfterEach(func() {
+			_ = listener.Close()
+		})
+
+		It("should report a port with a listener as in use", func() {
+			result, err := checker.CheckAll(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+
+			portResult := result.Prerequisites[fmt.Sprintf("port_%d", port)]
+			Expect(portResult.Status).To(Equal("in_use"))
+			Expect(portResult.Version).To(Equal("in use"))
+			Expect(result.Warnings).To(ContainElement(fmt.Sprintf("port %d is already in use", port)))
+		})
+
+		It("should report a free port as available", func() {
+			Expect(listener.Close()).To(Succeed())
+
+			result, err := checker.CheckAll(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+
+			portResult := result.Prerequisites[fmt.Sprintf("port_%d", port)]
+			Expect(portResult.Status).To(Equal("ok"))
+			Expect(portResult.Version).To(Equal("free"))
+			Expect(result.Warnings).NotTo(ContainElement(ContainSubstring("port")))
+		})
+
+		It("should skip ports excluded with SkipPort", func() {
+			checker.SkipPort(port)
+
+			result, err := checker.CheckAll(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Prerequisites).NotTo(HaveKey(fmt.Sprintf("port_%d", port)))
+			Expect(result.Warnings).NotTo(ContainElement(ContainSubstring("port")))
+		})
+
+		It("should check the daemon and Konflux UI ports by default", func() {
+			var checked []int
+			checker = NewChecker(&config.Config{})
+			checker.portAvailable = func(port int) bool {
+				checked = append(checked, port)
+				return true
+			}
+
+			_, err := checker.CheckAll(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checked).To(Equal([]int{8765, 9443}))
+		})
+	})
+
+	Describe("remediation", func() {
+		It("should use the platform's package manager", func() {
+			Expect(remediation("git", "missing", "darwin")).To(Equal("brew install git"))
+			Expect(remediation("git", "missing", "linux")).To(Equal("sudo dnf install git"))
+			Expect(remediation("helm", "outdated", "darwin")).To(Equal("brew upgrade helm"))
+			Expect(remediation("helm", "outdated", "linux")).To(Equal("sudo dnf upgrade helm"))
+		})
+
+		It("should fall back to the install command when it also upgrades", func() {
+			Expect(remediation("kind", "outdated", "darwin")).To(Equal("go install sigs.k8s.io/kind@latest"))
+			Expect(remediation("kubectl", "outdated", "linux")).To(ContainSubstring("dl.k8s.io"))
+		})
+
+		It("should start a container runtime's server that is down", func() {
+			Expect(remediation("docker", "down", "linux")).To(Equal("sudo systemctl start docker"))
+			Expect(remediation("podman", "down", "darwin")).To(Equal("podman machine start"))
+			Expect(remediation("git", "down", "linux")).To(BeEmpty())
+		})
+
+		It("should return nothing for other statuses, tools or platforms", func() {
+			Expect(remediation("git", "ok", "linux")).To(BeEmpty())
+			Expect(remediation("git", "unknown", "linux")).To(BeEmpty())
+			Expect(remediation("nonexistent", "missing", "linux")).To(BeEmpty())
+			Expect(remediation("git", "missing", "windows")).To(BeEmpty())
+		})
+	})
+
+	Describe("parseMeminfo", func() {
+		It("should read MemTotal and MemAvailable in bytes", func() {
+			meminfo := "MemTotal:       16318500 kB\nMemFree:         1203340 kB\nMemAvailable:    9876543 kB\n"
+			total, available, err := parseMeminfo(bufio.NewScanner(strings.NewReader(meminfo)))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(total).To(Equal(uint64(16318500 * 1024)))
+			Expect(available).To(Equal(uint64(9876543 * 1024)))
+		})
+
+		It("should fail without MemTotal", func() {
+			_, _, err := parseMeminfo(bufio.NewScanner(strings.NewReader("MemFree: 1 kB\n")))
+			Expect(err).To(MatchError(ContainSubstring("MemTotal")))
 		})
 	})
 })
@@ -0,0 +1,102 @@
+package prereq
+
+import (
+	"context"
+
+	"github.com/meyrevived/mpc-dev-env/internal/build"
+)
+
+// containerRuntimeKey is the CheckResult.Prerequisites key of the container runtime.
+const containerRuntimeKey = "container-runtime"
+
+// containerRuntimeDaemonKey is the CheckResult.Prerequisites key of the container
+// runtime's server: the Docker daemon, or the Podman service or machine.
+const containerRuntimeDaemonKey = "container-runtime-daemon"
+
+// versionNotRunning is the Version of a container runtime server that is unreachable.
+const versionNotRunning = "Not Running"
+
+// Minimum versions of the supported container runtimes.
+const (
+	minDockerVersion = "27.0.1"
+	minPodmanVersion = "5.3.1"
+)
+
+// checkContainerRuntime version-checks the container runtime that image builds
+// will use, as chosen by build.ContainerRuntime. The result's Name is the
+// runtime's name ("docker" or "podman"), even when DOCKER_CLI gives a full path.
+//
+// When no runtime is found, the result is reported as a missing "podman", the
+// runtime builds prefer, and command is empty.
+func (c *Checker) checkContainerRuntime(ctx context.Context) (result PrerequisiteResult, command string) {
+	command, err := build.ContainerRuntime(c.config)
+	if err != nil {
+		return PrerequisiteResult{
+			Name:        "podman",
+			Version:     "Not Found",
+			Required:    minPodmanVersion,
+			Status:      "missing",
+			Remediation: remediation("podman", "missing", c.goos),
+		}, ""
+	}
+
+	name, required := runtimeRequirement(command)
+	return c.checkTool(ctx, name, command, []string{"--version"}, required, `(\d+\.\d+\.\d+)`), command
+}
+
+// checkContainerRuntimeDaemon checks the server of the container runtime command,
+// which "--version" does not contact: a stopped Docker daemon or Podman machine, or
+// an old one behind an up-to-date CLI, only shows once a build runs. It asks the
+// server for its version with "info" and compares it against the runtime's minimum.
+//
+// Returns a PrerequisiteResult named after the runtime with status:
+//   - "ok" if the server is reachable and its version meets the requirement
+//   - "down" if "info" fails or times out, with Version versionNotRunning
+//   - "outdated" if the server's version is below the requirement
+//   - "unknown" if the server's version cannot be determined
+func (c *Checker) checkContainerRuntimeDaemon(ctx context.Context, command string) PrerequisiteResult {
+	name, required := runtimeRequirement(command)
+	result := PrerequisiteResult{
+		Name:      name,
+		Installed: true,
+		Version:   versionNotRunning,
+		Required:  required,
+		Status:    "down",
+	}
+
+	// Podman reports the version of the service it talks to under Version
+	format := "{{.ServerVersion}}"
+	if build.IsPodman(command) {
+		format = "{{.Version.Version}}"
+	}
+	output, _, err := c.toolOutput(ctx, command, "info", "--format", format)
+	if err != nil {
+		result.Remediation = remediation(name, result.Status, c.goos)
+		return result
+	}
+
+	version := extractVersion(output, `(\d+\.\d+\.\d+)`)
+	if version == "" {
+		result.Status = "unknown"
+		result.Version = "Unknown"
+		return result
+	}
+
+	result.Version = version
+	if compareVersions(version, required) {
+		result.Status = "ok"
+	} else {
+		result.Status = "outdated"
+		result.Remediation = remediation(name, result.Status, c.goos)
+	}
+	return result
+}
+
+// runtimeRequirement returns the name ("docker" or "podman") and minimum version of
+// the container runtime command.
+func runtimeRequirement(command string) (name, required string) {
+	if build.IsPodman(command) {
+		return "podman", minPodmanVersion
+	}
+	return "docker", minDockerVersion
+}
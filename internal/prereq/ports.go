@@ -0,0 +1,46 @@
+package prereq
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+)
+
+// portAvailable reports whether a TCP listener can be opened on port on all interfaces,
+// which is how Kind's port mappings and the daemon would bind it.
+func portAvailable(port int) bool {
+	listener, err := net.Listen("tcp", ":"+strconv.Itoa(port))
+	if err != nil {
+		return false
+	}
+	_ = listener.Close()
+	return true
+}
+
+// SkipPort excludes port from the port check. The daemon uses it for its own API
+// port, which is necessarily in use while the daemon runs the check.
+func (c *Checker) SkipPort(port int) {
+	if c.skipPorts == nil {
+		c.skipPorts = make(map[int]bool)
+	}
+	c.skipPorts[port] = true
+}
+
+// checkPort checks that nothing is listening on the TCP port yet. An occupied port
+// makes Kind or the Konflux UI fail halfway through an operation, so it is reported
+// up front with status "in_use".
+func (c *Checker) checkPort(port int) PrerequisiteResult {
+	result := PrerequisiteResult{
+		Name:      fmt.Sprintf("port_%d", port),
+		Installed: true,
+		Version:   "free",
+		Required:  "free",
+		Status:    "ok",
+	}
+
+	if !c.portAvailable(port) {
+		result.Version = "in use"
+		result.Status = "in_use"
+	}
+	return result
+}
@@ -0,0 +1,72 @@
+package prereq
+
+// remediationCommand is how to install a tool, and how to upgrade it if that differs.
+type remediationCommand struct {
+	install string
+	upgrade string // Empty when install also upgrades
+	start   string // How to start a container runtime's server
+}
+
+// kubectlLinuxInstall downloads the latest stable kubectl release; there is no
+// up-to-date distribution package for it.
+const kubectlLinuxInstall = `curl -LO "https://dl.k8s.io/release/$(curl -Ls https://dl.k8s.io/release/stable.txt)/bin/linux/amd64/kubectl" && sudo install kubectl /usr/local/bin/kubectl`
+
+// remediations maps a tool name and runtime.GOOS to the command that fixes a missing
+// or outdated installation. Linux commands assume Fedora, like the setup instructions
+// in the README.
+var remediations = map[string]map[string]remediationCommand{
+	"go": {
+		"darwin": {install: "brew install go", upgrade: "brew upgrade go"},
+		"linux":  {install: "sudo dnf install golang", upgrade: "sudo dnf upgrade golang"},
+	},
+	"kind": {
+		"darwin": {install: "go install sigs.k8s.io/kind@latest"},
+		"linux":  {install: "go install sigs.k8s.io/kind@latest"},
+	},
+	"kubectl": {
+		"darwin": {install: "brew install kubectl", upgrade: "brew upgrade kubectl"},
+		"linux":  {install: kubectlLinuxInstall},
+	},
+	"docker": {
+		"darwin": {install: "brew install --cask docker", upgrade: "brew upgrade --cask docker", start: "open -a Docker"},
+		"linux":  {install: "sudo dnf install moby-engine", upgrade: "sudo dnf upgrade moby-engine", start: "sudo systemctl start docker"},
+	},
+	"podman": {
+		"darwin": {install: "brew install podman", upgrade: "brew upgrade podman", start: "podman machine start"},
+		"linux":  {install: "sudo dnf install podman", upgrade: "sudo dnf upgrade podman"},
+	},
+	"git": {
+		"darwin": {install: "brew install git", upgrade: "brew upgrade git"},
+		"linux":  {install: "sudo dnf install git", upgrade: "sudo dnf upgrade git"},
+	},
+	"helm": {
+		"darwin": {install: "brew install helm", upgrade: "brew upgrade helm"},
+		"linux":  {install: "sudo dnf install helm", upgrade: "sudo dnf upgrade helm"},
+	},
+}
+
+// remediation returns the command that fixes a prerequisite with the given status on
+// goos: the install command for "missing", the upgrade command for "outdated", and for
+// a container runtime's server, the start command for "down".
+// It returns an empty string for any other status, or for tools and platforms
+// without a known command.
+func remediation(name, status, goos string) string {
+	command, ok := remediations[name][goos]
+	if !ok {
+		return ""
+	}
+
+	switch status {
+	case "missing":
+		return command.install
+	case "outdated":
+		if command.upgrade != "" {
+			return command.upgrade
+		}
+		return command.install
+	case "down":
+		return command.start
+	default:
+		return ""
+	}
+}
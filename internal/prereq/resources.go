@@ -0,0 +1,121 @@
+package prereq
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+const bytesPerGB = 1 << 30
+
+// meminfoPath is where Linux reports system memory.
+const meminfoPath = "/proc/meminfo"
+
+// diskSpace returns the bytes available to unprivileged users on the filesystem
+// holding path.
+func diskSpace(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return stat.Bavail * uint64(stat.Bsize), nil
+}
+
+// systemMemory returns the total and available system memory in bytes, read from
+// /proc/meminfo.
+func systemMemory() (total, available uint64, err error) {
+	f, err := os.Open(meminfoPath)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer func() { _ = f.Close() }()
+
+	return parseMeminfo(bufio.NewScanner(f))
+}
+
+// parseMeminfo extracts MemTotal and MemAvailable, in bytes, from /proc/meminfo
+// lines such as "MemTotal:       16318500 kB".
+func parseMeminfo(scanner *bufio.Scanner) (total, available uint64, err error) {
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok || (key != "MemTotal" && key != "MemAvailable") {
+			continue
+		}
+
+		kb, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimSpace(value), " kB"), 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid %s in %s: %w", key, meminfoPath, err)
+		}
+		if key == "MemTotal" {
+			total = kb * 1024
+		} else {
+			available = kb * 1024
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, 0, err
+	}
+	if total == 0 {
+		return 0, 0, errors.New("MemTotal not found in " + meminfoPath)
+	}
+
+	return total, available, nil
+}
+
+// checkDiskSpace checks that the filesystem holding path has at least minGB free.
+// Image builds are the most disk-hungry step, so too little space is reported as
+// a warning rather than failing the check.
+func (c *Checker) checkDiskSpace(path string, minGB int) PrerequisiteResult {
+	result := PrerequisiteResult{
+		Name:     "disk_space",
+		Version:  "Unknown",
+		Required: fmt.Sprintf("%d GB free", minGB),
+		Status:   "unknown",
+	}
+
+	available, err := c.diskSpace(path)
+	if err != nil {
+		return result
+	}
+
+	result.Installed = true
+	result.Version = fmt.Sprintf("%s free", formatGB(available))
+	result.Status = "ok"
+	if available < uint64(minGB)*bytesPerGB {
+		result.Status = "warning"
+	}
+	return result
+}
+
+// checkMemory checks that the system has at least minGB of memory in total. Go
+// compilation during image builds is what gets OOM-killed on small machines.
+func (c *Checker) checkMemory(minGB int) PrerequisiteResult {
+	result := PrerequisiteResult{
+		Name:     "memory",
+		Version:  "Unknown",
+		Required: fmt.Sprintf("%d GB total", minGB),
+		Status:   "unknown",
+	}
+
+	total, available, err := c.systemMemory()
+	if err != nil {
+		return result
+	}
+
+	result.Installed = true
+	result.Version = fmt.Sprintf("%s total, %s available", formatGB(total), formatGB(available))
+	result.Status = "ok"
+	if total < uint64(minGB)*bytesPerGB {
+		result.Status = "warning"
+	}
+	return result
+}
+
+// formatGB formats a byte count as GB with one decimal, e.g. "15.6 GB".
+func formatGB(bytes uint64) string {
+	return fmt.Sprintf("%.1f GB", float64(bytes)/bytesPerGB)
+}
@@ -1,7 +1,7 @@
 // Package taskrun provides complete TaskRun workflow management using Tekton and Kubernetes client-go.
 //
 // This package encapsulates all Tekton TaskRun operations for the MPC development environment:
-//   - Parsing TaskRun YAML files
+//   - Parsing TaskRun YAML files or inline YAML content
 //   - Creating TaskRuns in the Kubernetes cluster
 //   - Monitoring TaskRun execution status
 //   - Streaming pod logs to files
@@ -17,11 +17,11 @@
 //   - Integration tests: The complete workflow (Manager.RunTaskRunWorkflow) is tested via
 //     'make test-e2e' which runs against a real Kind cluster
 //
-// The Manager struct intentionally uses concrete Kubernetes client types (*Clientset)
-// rather than interfaces because it's a thin orchestration layer with no business logic.
-// Mock-based unit tests would require heavy mocking infrastructure for minimal value,
-// as the code simply chains Kubernetes API calls. The end-to-end test provides better
-// coverage by verifying the actual API interactions work correctly.
+// The Manager struct holds the Kubernetes clients as interfaces so that targeted unit
+// tests (e.g. verifying which namespace is used) can swap in the generated fake
+// clientsets. It remains a thin orchestration layer with no business logic, so
+// full workflow coverage is left to the end-to-end test, which verifies the actual
+// API interactions work correctly.
 //
 // This design follows the pattern used in the actual multi-platform-controller project,
 // which uses controller-runtime's fake client only for testing reconciliation business
@@ -29,32 +29,48 @@
 package taskrun
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"os"
-	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	tektonclient "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
 	tektonscheme "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/scheme"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/tools/clientcmd"
-	"k8s.io/client-go/util/homedir"
-)
+	"knative.dev/pkg/apis"
 
-const (
-	namespace = "multi-platform-controller"
+	"github.com/meyrevived/mpc-dev-env/internal/deploy"
 )
 
+// DefaultNamespace is the namespace TaskRuns are created in when NewManager is given none.
+const DefaultNamespace = "multi-platform-controller"
+
+// DefaultTimeout is how long a TaskRun is monitored when SetTimeout has not been called.
+const DefaultTimeout = 30 * time.Minute
+
+// monitorPollInterval is how often monitorTaskRun checks the TaskRun status.
+var monitorPollInterval = 5 * time.Second
+
 var scheme = runtime.NewScheme()
 
+// ErrTaskRunCancelled is returned by RunTaskRunWorkflow when the workflow is cancelled
+// before the TaskRun completes, either via CancelTaskRun or the caller's context.
+var ErrTaskRunCancelled = errors.New("TaskRun was cancelled")
+
 func init() {
 	_ = tektonscheme.AddToScheme(scheme)
 }
@@ -62,23 +78,44 @@ func init() {
 // Manager handles all TaskRun operations using Tekton and Kubernetes clients.
 //
 // It maintains both a Tekton clientset (for TaskRun API operations) and a Kubernetes
-// clientset (for pod log streaming). Both clients are configured from the default
-// kubeconfig location (~/.kube/config).
+// clientset (for pod log streaming). Both clients are configured from the kubeconfig
+// NewManager is given, by default ~/.kube/config. All TaskRuns and pods are looked up
+// in namespace.
 type Manager struct {
-	tektonClient *tektonclient.Clientset
-	k8sClient    *kubernetes.Clientset
+	tektonClient   tektonclient.Interface
+	k8sClient      kubernetes.Interface
+	kubeconfigPath string            // Kubeconfig of the cluster check, kubectl's default when empty
+	kubeContext    string            // Context of the cluster check, the current one when empty
+	namespace      string            // Namespace TaskRuns are created in, defaults to DefaultNamespace
+	timeout        time.Duration     // How long a TaskRun is monitored, defaults to DefaultTimeout
+	params         map[string]string // Params merged into every TaskRun, see SetParams
+	results        map[string]string // Results of the last TaskRun that succeeded, see Results
+
+	mu       sync.Mutex                    // Guards monitors
+	monitors map[string]context.CancelFunc // Cancels the monitoring goroutine for each running TaskRun
 }
 
 // NewManager creates a new TaskRun manager configured with Tekton and Kubernetes clients.
 //
-// The kubeconfig is loaded from ~/.kube/config. Returns an error if the kubeconfig
-// cannot be loaded or if client creation fails.
-func NewManager() (*Manager, error) {
-	kubeconfigPath := filepath.Join(homedir.HomeDir(), ".kube", "config")
+// TaskRuns are created in the given namespace, or in DefaultNamespace when it is empty.
+// The kubeconfig is loaded from kubeconfigPath (config.Config.KubeconfigPath), or from
+// ~/.kube/config when it is empty. Returns an error if the kubeconfig cannot be loaded
+// or if client creation fails.
+func NewManager(kubeconfigPath, namespace string) (*Manager, error) {
+	return NewManagerForContext(kubeconfigPath, "", namespace)
+}
+
+// NewManagerForContext is like NewManager, but talks to the cluster of the given
+// kubeconfig context (e.g. "kind-konflux") instead of the current one. An empty
+// kubeContext uses the current context.
+func NewManagerForContext(kubeconfigPath, kubeContext, namespace string) (*Manager, error) {
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
 
-	config, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+	config, err := deploy.RESTConfig(kubeconfigPath, kubeContext)
 	if err != nil {
-		return nil, fmt.Errorf("failed to build kubeconfig: %w", err)
+		return nil, err
 	}
 
 	tektonClient, err := tektonclient.NewForConfig(config)
@@ -92,15 +129,69 @@ func NewManager() (*Manager, error) {
 	}
 
 	return &Manager{
-		tektonClient: tektonClient,
-		k8sClient:    k8sClient,
+		tektonClient:   tektonClient,
+		k8sClient:      k8sClient,
+		kubeconfigPath: kubeconfigPath,
+		kubeContext:    kubeContext,
+		namespace:      namespace,
+		timeout:        DefaultTimeout,
+		monitors:       make(map[string]context.CancelFunc),
 	}, nil
 }
 
+// SetTimeout sets how long RunTaskRunWorkflow monitors a TaskRun before giving up
+// with a "Timeout" status. Non-positive values restore DefaultTimeout.
+func (m *Manager) SetTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	m.timeout = timeout
+}
+
+// SetParams sets params to merge into the TaskRun's spec.params before it is created,
+// overriding params of the same name and appending the others. Use ValidateParams
+// to check them first.
+func (m *Manager) SetParams(params map[string]string) {
+	m.params = params
+}
+
+// ValidateParams checks that every param has a name and a non-empty value.
+func ValidateParams(params map[string]string) error {
+	for name, value := range params {
+		if name == "" {
+			return errors.New("param names must not be empty")
+		}
+		if value == "" {
+			return fmt.Errorf("param %q must have a non-empty value", name)
+		}
+	}
+	return nil
+}
+
+// mergeParams sets each of params on taskRun as a string param. Params the TaskRun
+// already has are overridden in place; new ones are appended in name order.
+func mergeParams(taskRun *tektonv1.TaskRun, params map[string]string) {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := *tektonv1.NewStructuredValues(params[name])
+		if i := slices.IndexFunc(taskRun.Spec.Params, func(p tektonv1.Param) bool { return p.Name == name }); i >= 0 {
+			taskRun.Spec.Params[i].Value = value
+			continue
+		}
+		taskRun.Spec.Params = append(taskRun.Spec.Params, tektonv1.Param{Name: name, Value: value})
+	}
+}
+
 // RunTaskRunWorkflow runs the complete TaskRun workflow from start to finish.
 //
 // This method orchestrates the entire TaskRun lifecycle:
-//  1. Parse and validate TaskRun YAML file
+//  1. Parse and validate TaskRun YAML file, merge in the params set with SetParams,
+//     and check that the cluster is reachable (deploy.ErrClusterNotRunning otherwise)
 //  2. Clean up any existing TaskRun with the same name (and its associated secrets)
 //  3. Create TaskRun in the Kubernetes cluster
 //  4. Launch async goroutine to stream logs to file
@@ -110,19 +201,37 @@ func NewManager() (*Manager, error) {
 // The log streaming happens asynchronously to avoid blocking status monitoring.
 // This ensures we can track TaskRun progress while simultaneously capturing all logs.
 //
+// If ctx is cancelled or CancelTaskRun is called while the TaskRun is being monitored,
+// the TaskRun is deleted and ErrTaskRunCancelled is returned.
+//
 // Returns the TaskRun name, final status ("Succeeded", "Failed", "Timeout"), and any error.
 func (m *Manager) RunTaskRunWorkflow(ctx context.Context, yamlPath, logFilePath string) (name, status string, err error) {
-	// Step 1: Parse YAML
 	data, err := os.ReadFile(yamlPath)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to read TaskRun file: %w", err)
 	}
 
+	return m.RunTaskRunWorkflowFromYAML(ctx, data, logFilePath)
+}
+
+// RunTaskRunWorkflowFromYAML runs the complete TaskRun workflow for TaskRun YAML
+// provided in memory, e.g. submitted inline in an API request.
+//
+// It behaves exactly like RunTaskRunWorkflow, minus reading the YAML from disk.
+func (m *Manager) RunTaskRunWorkflowFromYAML(ctx context.Context, data []byte, logFilePath string) (name, status string, err error) {
+	// Step 1: Parse YAML
 	taskRun, err := m.parseTaskRunYAML(data)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to parse TaskRun YAML: %w", err)
 	}
 
+	mergeParams(taskRun, m.params)
+
+	// Fail with a clear message rather than a client-go connection error when there is no cluster
+	if err := deploy.CheckClusterReachable(ctx, m.kubeconfigPath, m.kubeContext); err != nil {
+		return "", "", err
+	}
+
 	// Step 2: Cleanup any existing TaskRun with the same name
 	// This ensures the multi-platform-ssh-* secret is cleaned up via finalizers
 	fmt.Printf("Cleaning up any existing TaskRun '%s'...\n", taskRun.Name)
@@ -130,19 +239,35 @@ func (m *Manager) RunTaskRunWorkflow(ctx context.Context, yamlPath, logFilePath
 		return "", "", fmt.Errorf("failed to cleanup existing TaskRun: %w", err)
 	}
 
-	// Step 3: Apply TaskRun
-	result, err := m.tektonClient.TektonV1().TaskRuns(namespace).Create(ctx, taskRun, metav1.CreateOptions{})
+	// Step 3: Apply TaskRun in the manager's namespace, regardless of metadata.namespace in the YAML
+	taskRun.Namespace = m.namespace
+	result, err := m.tektonClient.TektonV1().TaskRuns(m.namespace).Create(ctx, taskRun, metav1.CreateOptions{})
 	if err != nil {
 		return "", "", fmt.Errorf("failed to create TaskRun: %w", err)
 	}
 
 	name = result.Name
 
+	// Register a cancellable context so CancelTaskRun can stop monitoring and log streaming
+	monitorCtx, cancel := context.WithCancel(ctx)
+	m.trackMonitor(name, cancel)
+	defer m.untrackMonitor(name)
+
 	// Step 4: Wait for pod to be created and stream logs
-	go m.streamLogsAsync(ctx, name, logFilePath)
+	go m.streamLogsAsync(monitorCtx, name, logFilePath)
 
 	// Step 5: Monitor TaskRun until completion
-	status, err = m.monitorTaskRun(ctx, name)
+	status, err = m.monitorTaskRun(monitorCtx, name)
+	if monitorCtx.Err() != nil {
+		// Cancelled by the caller - make sure the TaskRun doesn't keep running in the cluster.
+		// Use a fresh context since ctx may already be cancelled.
+		if ctx.Err() != nil {
+			if cancelErr := m.CancelTaskRun(context.Background(), name); cancelErr != nil {
+				fmt.Printf("Warning: failed to delete cancelled TaskRun %s: %v\n", name, cancelErr)
+			}
+		}
+		return name, "Cancelled", ErrTaskRunCancelled
+	}
 	if err != nil {
 		return name, "", fmt.Errorf("failed to monitor TaskRun: %w", err)
 	}
@@ -155,22 +280,32 @@ func (m *Manager) RunTaskRunWorkflow(ctx context.Context, yamlPath, logFilePath
 
 // monitorTaskRun monitors a TaskRun until it completes.
 //
-// This method polls the TaskRun status every 5 seconds, checking the Tekton condition
-// to determine if the TaskRun has succeeded, failed, or is still running. It has a
-// 30-minute timeout to prevent indefinite waiting.
+// This method polls the TaskRun status every monitorPollInterval (5 seconds), checking
+// the Tekton condition to determine if the TaskRun has succeeded, failed, or is still
+// running. When it succeeds, its results are recorded for Results. It gives up after
+// the manager's timeout (DefaultTimeout unless changed with SetTimeout) to prevent
+// indefinite waiting.
+//
+// Monitoring stops early if ctx is cancelled.
 //
-// Returns "Succeeded", "Failed", or "Timeout" along with any error encountered.
+// Returns "Succeeded", "Failed", "Timeout", or "Cancelled" along with any error encountered.
 func (m *Manager) monitorTaskRun(ctx context.Context, name string) (string, error) {
-	timeout := time.After(30 * time.Minute)
-	ticker := time.NewTicker(5 * time.Second)
+	monitorTimeout := m.timeout
+	if monitorTimeout <= 0 {
+		monitorTimeout = DefaultTimeout
+	}
+	timeout := time.After(monitorTimeout)
+	ticker := time.NewTicker(monitorPollInterval)
 	defer ticker.Stop()
 
 	for {
 		select {
+		case <-ctx.Done():
+			return "Cancelled", ctx.Err()
 		case <-timeout:
-			return "Timeout", errors.New("TaskRun monitoring timed out after 30 minutes")
+			return "Timeout", fmt.Errorf("TaskRun monitoring timed out after %s", monitorTimeout)
 		case <-ticker.C:
-			taskRun, err := m.tektonClient.TektonV1().TaskRuns(namespace).Get(ctx, name, metav1.GetOptions{})
+			taskRun, err := m.tektonClient.TektonV1().TaskRuns(m.namespace).Get(ctx, name, metav1.GetOptions{})
 			if err != nil {
 				continue
 			}
@@ -183,6 +318,7 @@ func (m *Manager) monitorTaskRun(ctx context.Context, name string) (string, erro
 
 			// Check if completed
 			if condition.Status == corev1.ConditionTrue && condition.Type == "Succeeded" {
+				m.results = extractResults(taskRun)
 				return "Succeeded", nil
 			} else if condition.Status == corev1.ConditionFalse {
 				return "Failed", nil
@@ -193,12 +329,41 @@ func (m *Manager) monitorTaskRun(ctx context.Context, name string) (string, erro
 	}
 }
 
+// Results returns the results emitted by the TaskRun, keyed by result name, once
+// RunTaskRunWorkflow has reported "Succeeded". It returns nil otherwise, or when
+// the TaskRun emitted no results.
+func (m *Manager) Results() map[string]string {
+	return m.results
+}
+
+// extractResults converts the results in a TaskRun's status to a map of result name
+// to value. String results are used as-is; array and object results are encoded as JSON.
+func extractResults(taskRun *tektonv1.TaskRun) map[string]string {
+	if len(taskRun.Status.Results) == 0 {
+		return nil
+	}
+
+	results := make(map[string]string, len(taskRun.Status.Results))
+	for _, result := range taskRun.Status.Results {
+		if result.Value.Type == tektonv1.ParamTypeString || result.Value.Type == "" {
+			results[result.Name] = result.Value.StringVal
+			continue
+		}
+		encoded, err := json.Marshal(result.Value)
+		if err != nil {
+			continue
+		}
+		results[result.Name] = string(encoded)
+	}
+	return results
+}
+
 // streamLogsAsync streams logs to a file asynchronously in a goroutine.
 //
 // This method:
 //  1. Waits for the TaskRun's pod to be created and enter Running state
 //  2. Creates the log file at the specified path
-//  3. Streams logs from all containers in the pod to the file
+//  3. Streams logs from all init and step containers in the pod to the file
 //
 // Any errors are printed to stdout but don't stop the workflow, since log streaming
 // is supplementary to TaskRun monitoring.
@@ -220,19 +385,94 @@ func (m *Manager) streamLogsAsync(ctx context.Context, taskRunName, logFilePath
 		_ = logFile.Close()
 	}()
 
-	// Stream logs from all containers
+	m.streamPodLogs(ctx, pod, logFile)
+}
+
+// streamPodLogs writes the logs of every container in pod to w.
+//
+// Tekton's init containers (entrypoint and credential setup) come first, each line
+// prefixed with "[init:<name>] ", followed by the step containers. If an init container
+// failed, the step containers never started, so they are skipped and a note naming
+// the failed init container is written instead.
+func (m *Manager) streamPodLogs(ctx context.Context, pod *corev1.Pod, w io.Writer) {
+	for _, container := range pod.Spec.InitContainers {
+		prefixed := &prefixWriter{w: w, prefix: "[init:" + container.Name + "] "}
+		if err := m.streamContainerLogs(ctx, pod.Name, container.Name, prefixed); err != nil {
+			fmt.Printf("Warning: failed to stream logs from init container %s: %v\n", container.Name, err)
+		}
+		prefixed.finish()
+	}
+
+	if failed := failedInitContainer(pod); failed != "" {
+		_, _ = fmt.Fprintf(w, "[init:%s] failed, step containers were not started\n", failed)
+		return
+	}
+
 	for _, container := range pod.Spec.Containers {
-		if err := m.streamContainerLogs(ctx, pod.Name, container.Name, logFile); err != nil {
+		if err := m.streamContainerLogs(ctx, pod.Name, container.Name, w); err != nil {
 			fmt.Printf("Warning: failed to stream logs from container %s: %v\n", container.Name, err)
 		}
 	}
 }
 
+// failedInitContainer returns the name of the first init container in pod that exited
+// with a non-zero code, or an empty string if none did.
+func failedInitContainer(pod *corev1.Pod) string {
+	for _, status := range pod.Status.InitContainerStatuses {
+		if status.State.Terminated != nil && status.State.Terminated.ExitCode != 0 {
+			return status.Name
+		}
+	}
+	return ""
+}
+
+// prefixWriter writes prefix at the start of every line written through it.
+type prefixWriter struct {
+	w       io.Writer
+	prefix  string
+	midLine bool // Whether the last write ended without a newline
+}
+
+func (p *prefixWriter) Write(data []byte) (int, error) {
+	written := 0
+	for len(data) > 0 {
+		if !p.midLine {
+			if _, err := io.WriteString(p.w, p.prefix); err != nil {
+				return written, err
+			}
+			p.midLine = true
+		}
+
+		line := data
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			line = data[:i+1]
+			p.midLine = false
+		}
+		n, err := p.w.Write(line)
+		written += n
+		if err != nil {
+			return written, err
+		}
+		data = data[len(line):]
+	}
+	return written, nil
+}
+
+// finish terminates a trailing partial line so the next container's output starts on
+// its own line.
+func (p *prefixWriter) finish() {
+	if p.midLine {
+		_, _ = io.WriteString(p.w, "\n")
+		p.midLine = false
+	}
+}
+
 // waitForTaskRunPod waits for the TaskRun's pod to be created AND running.
 //
 // This method polls the Kubernetes API every 2 seconds looking for a pod with the label
-// "tekton.dev/taskRun=<taskRunName>". It only returns when the pod reaches Running phase,
-// not just when it's created, to avoid log streaming errors from pods in Initializing state.
+// "tekton.dev/taskRun=<taskRunName>". It only returns when the pod reaches Running phase
+// (or has already finished), not just when it's created, to avoid log streaming errors
+// from pods in Initializing state.
 //
 // Has a configurable timeout (typically 5 minutes).
 func (m *Manager) waitForTaskRunPod(ctx context.Context, taskRunName string, timeout time.Duration) (*corev1.Pod, error) {
@@ -242,10 +482,12 @@ func (m *Manager) waitForTaskRunPod(ctx context.Context, taskRunName string, tim
 
 	for {
 		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
 		case <-deadline:
 			return nil, errors.New("timeout waiting for TaskRun pod")
 		case <-ticker.C:
-			pods, err := m.k8sClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+			pods, err := m.k8sClient.CoreV1().Pods(m.namespace).List(ctx, metav1.ListOptions{
 				LabelSelector: "tekton.dev/taskRun=" + taskRunName,
 			})
 			if err != nil {
@@ -254,8 +496,10 @@ func (m *Manager) waitForTaskRunPod(ctx context.Context, taskRunName string, tim
 
 			if len(pods.Items) > 0 {
 				pod := &pods.Items[0]
-				// Wait for pod to be running before streaming logs
-				if pod.Status.Phase == corev1.PodRunning {
+				// Wait for pod to be running before streaming logs. A pod that already
+				// finished, e.g. because an init container failed, still has logs to read.
+				switch pod.Status.Phase {
+				case corev1.PodRunning, corev1.PodSucceeded, corev1.PodFailed:
 					return pod, nil
 				}
 				// Continue waiting if pod is still initializing
@@ -271,7 +515,7 @@ func (m *Manager) waitForTaskRunPod(ctx context.Context, taskRunName string, tim
 //
 // The logs are written directly to the provided io.Writer (typically a file).
 func (m *Manager) streamContainerLogs(ctx context.Context, podName, containerName string, logFile io.Writer) error {
-	req := m.k8sClient.CoreV1().Pods(namespace).GetLogs(podName, &corev1.PodLogOptions{
+	req := m.k8sClient.CoreV1().Pods(m.namespace).GetLogs(podName, &corev1.PodLogOptions{
 		Container: containerName,
 		Follow:    true,
 	})
@@ -298,7 +542,7 @@ func (m *Manager) streamContainerLogs(ctx context.Context, podName, containerNam
 // allocation if the secret from a previous run still exists.
 func (m *Manager) CleanupTaskRun(ctx context.Context, name string) error {
 	// Delete the TaskRun
-	err := m.tektonClient.TektonV1().TaskRuns(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	err := m.tektonClient.TektonV1().TaskRuns(m.namespace).Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		// If TaskRun doesn't exist, that's fine - nothing to clean up
 		return nil
@@ -314,7 +558,7 @@ func (m *Manager) CleanupTaskRun(ctx context.Context, name string) error {
 		case <-timeout:
 			return fmt.Errorf("timeout waiting for TaskRun %s to be deleted", name)
 		case <-ticker.C:
-			_, err := m.tektonClient.TektonV1().TaskRuns(namespace).Get(ctx, name, metav1.GetOptions{})
+			_, err := m.tektonClient.TektonV1().TaskRuns(m.namespace).Get(ctx, name, metav1.GetOptions{})
 			if err != nil {
 				// TaskRun is gone, we're done
 				return nil
@@ -324,6 +568,139 @@ func (m *Manager) CleanupTaskRun(ctx context.Context, name string) error {
 	}
 }
 
+// CancelTaskRun stops an in-progress TaskRun.
+//
+// This method deletes the TaskRun via the Tekton client, which makes Tekton stop its pod
+// and lets MPC's finalizers release any allocated host. It also cancels the monitoring
+// goroutine started by RunTaskRunWorkflow for this TaskRun, if any. A TaskRun that no
+// longer exists is not treated as an error.
+func (m *Manager) CancelTaskRun(ctx context.Context, name string) error {
+	m.stopMonitor(name)
+
+	err := m.tektonClient.TektonV1().TaskRuns(m.namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete TaskRun %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// TaskRunSummary describes a TaskRun in the manager's namespace, as returned by ListTaskRuns.
+//
+// Status is "Pending" before Tekton has reported a condition, then "Running",
+// "Succeeded", or "Failed". Times are RFC3339 and empty until they are known.
+type TaskRunSummary struct {
+	Name           string `json:"name"`
+	Status         string `json:"status"`
+	StartTime      string `json:"start_time,omitempty"`
+	CompletionTime string `json:"completion_time,omitempty"`
+}
+
+// ListTaskRuns lists the TaskRuns in the manager's namespace, newest first.
+//
+// TaskRuns are ordered by creation time. A positive limit returns at most that many;
+// zero or a negative limit returns all of them.
+func (m *Manager) ListTaskRuns(ctx context.Context, limit int) ([]TaskRunSummary, error) {
+	list, err := m.tektonClient.TektonV1().TaskRuns(m.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list TaskRuns: %w", err)
+	}
+
+	taskRuns := list.Items
+	sort.SliceStable(taskRuns, func(i, j int) bool {
+		ti, tj := taskRuns[i].CreationTimestamp, taskRuns[j].CreationTimestamp
+		if ti.Equal(&tj) {
+			return taskRuns[i].Name < taskRuns[j].Name
+		}
+		return tj.Before(&ti)
+	})
+	if limit > 0 && len(taskRuns) > limit {
+		taskRuns = taskRuns[:limit]
+	}
+
+	summaries := make([]TaskRunSummary, 0, len(taskRuns))
+	for i := range taskRuns {
+		taskRun := &taskRuns[i]
+		summary := TaskRunSummary{
+			Name:   taskRun.Name,
+			Status: taskRunStatus(taskRun),
+		}
+		if taskRun.Status.StartTime != nil {
+			summary.StartTime = taskRun.Status.StartTime.UTC().Format(time.RFC3339)
+		}
+		if taskRun.Status.CompletionTime != nil {
+			summary.CompletionTime = taskRun.Status.CompletionTime.UTC().Format(time.RFC3339)
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+// taskRunStatus maps the Succeeded condition of a TaskRun to the status reported by ListTaskRuns.
+func taskRunStatus(taskRun *tektonv1.TaskRun) string {
+	condition := taskRun.Status.GetCondition(apis.ConditionSucceeded)
+	switch {
+	case condition == nil:
+		return "Pending"
+	case condition.IsTrue():
+		return "Succeeded"
+	case condition.IsFalse():
+		return "Failed"
+	default:
+		return "Running"
+	}
+}
+
+// trackMonitor registers the cancel func for the monitoring goroutine of a TaskRun.
+func (m *Manager) trackMonitor(name string, cancel context.CancelFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.monitors == nil {
+		m.monitors = make(map[string]context.CancelFunc)
+	}
+	m.monitors[name] = cancel
+}
+
+// untrackMonitor releases the monitoring context of a TaskRun once its workflow returns.
+func (m *Manager) untrackMonitor(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if cancel, ok := m.monitors[name]; ok {
+		cancel()
+		delete(m.monitors, name)
+	}
+}
+
+// stopMonitor cancels the monitoring goroutine of a TaskRun, if one is running.
+// The entry is left in place for RunTaskRunWorkflow to remove when it returns.
+func (m *Manager) stopMonitor(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if cancel, ok := m.monitors[name]; ok {
+		cancel()
+	}
+}
+
+// ParseTaskRunName parses TaskRun YAML and returns the TaskRun's metadata.name,
+// falling back to metadata.generateName (without its trailing dash) when no name is set.
+//
+// Returns an error if the YAML is invalid or doesn't contain a TaskRun.
+func ParseTaskRunName(data []byte) (string, error) {
+	var m Manager
+	taskRun, err := m.parseTaskRunYAML(data)
+	if err != nil {
+		return "", err
+	}
+
+	if taskRun.Name != "" {
+		return taskRun.Name, nil
+	}
+	return strings.TrimSuffix(taskRun.GenerateName, "-"), nil
+}
+
 // parseTaskRunYAML parses YAML data into a Tekton TaskRun object.
 //
 // This method uses the Tekton scheme's universal deserializer to parse the YAML
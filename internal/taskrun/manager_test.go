@@ -1,18 +1,24 @@
 package taskrun
 
 import (
+	"bytes"
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	fakeTekton "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetesFake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/apis"
 )
 
-// monitorTaskRunTimeout allows overriding the default timeout for testing
-// NOTE: This is only used by commented-out tests. Keep it for when they're re-enabled.
-// var monitorTaskRunTimeout = 30 * time.Minute
-
 func TestTaskRun(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "TaskRun Manager Suite")
@@ -89,6 +95,129 @@ spec:
 		})
 	})
 
+	Describe("mergeParams", func() {
+		It("should override existing params by name and append new ones", func() {
+			manager = &Manager{}
+			taskRun, err := manager.parseTaskRunYAML([]byte(`
+apiVersion: tekton.dev/v1
+kind: TaskRun
+metadata:
+  name: my-taskrun
+spec:
+  params:
+    - name: PLATFORM
+      value: linux/amd64
+    - name: IMAGE
+      value: quay.io/old/image:latest
+  taskRef:
+    name: buildah
+`))
+			Expect(err).NotTo(HaveOccurred())
+
+			mergeParams(taskRun, map[string]string{
+				"IMAGE":    "quay.io/new/image:v2",
+				"REVISION": "0123456789ab",
+				"CONTEXT":  "./app",
+			})
+
+			params := map[string]string{}
+			names := []string{}
+			for _, p := range taskRun.Spec.Params {
+				names = append(names, p.Name)
+				params[p.Name] = p.Value.StringVal
+			}
+			// Existing params keep their position, new ones are appended in name order
+			Expect(names).To(Equal([]string{"PLATFORM", "IMAGE", "CONTEXT", "REVISION"}))
+			Expect(params).To(Equal(map[string]string{
+				"PLATFORM": "linux/amd64",
+				"IMAGE":    "quay.io/new/image:v2",
+				"CONTEXT":  "./app",
+				"REVISION": "0123456789ab",
+			}))
+		})
+
+		It("should leave the TaskRun unchanged without params", func() {
+			taskRun := &tektonv1.TaskRun{Spec: tektonv1.TaskRunSpec{Params: tektonv1.Params{
+				{Name: "IMAGE", Value: *tektonv1.NewStructuredValues("quay.io/old/image:latest")},
+			}}}
+
+			mergeParams(taskRun, nil)
+
+			Expect(taskRun.Spec.Params).To(HaveLen(1))
+			Expect(taskRun.Spec.Params[0].Value.StringVal).To(Equal("quay.io/old/image:latest"))
+		})
+	})
+
+	Describe("ValidateParams", func() {
+		It("should accept non-empty values", func() {
+			Expect(ValidateParams(map[string]string{"IMAGE": "quay.io/new/image:v2"})).To(Succeed())
+			Expect(ValidateParams(nil)).To(Succeed())
+		})
+
+		It("should reject empty values and names", func() {
+			Expect(ValidateParams(map[string]string{"IMAGE": ""})).To(MatchError(ContainSubstring(`param "IMAGE"`)))
+			Expect(ValidateParams(map[string]string{"": "value"})).To(MatchError(ContainSubstring("names must not be empty")))
+		})
+	})
+
+	Describe("extractResults", func() {
+		It("should map result names to their values", func() {
+			taskRun := &tektonv1.TaskRun{}
+			taskRun.Status.Results = []tektonv1.TaskRunResult{
+				{Name: "IMAGE_DIGEST", Type: tektonv1.ResultsTypeString, Value: *tektonv1.NewStructuredValues("sha256:0123abcd")},
+				{Name: "IMAGE_URL", Type: tektonv1.ResultsTypeString, Value: *tektonv1.NewStructuredValues("quay.io/test/image:v1")},
+				{Name: "PLATFORMS", Type: tektonv1.ResultsTypeArray, Value: *tektonv1.NewStructuredValues("linux/amd64", "linux/arm64")},
+			}
+
+			Expect(extractResults(taskRun)).To(Equal(map[string]string{
+				"IMAGE_DIGEST": "sha256:0123abcd",
+				"IMAGE_URL":    "quay.io/test/image:v1",
+				"PLATFORMS":    `["linux/amd64","linux/arm64"]`,
+			}))
+		})
+
+		It("should return nil when the TaskRun has no results", func() {
+			Expect(extractResults(&tektonv1.TaskRun{})).To(BeNil())
+		})
+	})
+
+	Describe("ParseTaskRunName", func() {
+		It("should return metadata.name", func() {
+			yamlData := `
+apiVersion: tekton.dev/v1
+kind: TaskRun
+metadata:
+  name: my-taskrun
+spec:
+  taskRef:
+    name: echo
+`
+			name, err := ParseTaskRunName([]byte(yamlData))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(name).To(Equal("my-taskrun"))
+		})
+
+		It("should fall back to metadata.generateName without the trailing dash", func() {
+			yamlData := `
+apiVersion: tekton.dev/v1
+kind: TaskRun
+metadata:
+  generateName: my-taskrun-
+spec:
+  taskRef:
+    name: echo
+`
+			name, err := ParseTaskRunName([]byte(yamlData))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(name).To(Equal("my-taskrun"))
+		})
+
+		It("should return an error for YAML that is not a TaskRun", func() {
+			_, err := ParseTaskRunName([]byte("not: [valid"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
 	Describe("NewManager", func() {
 		var (
 			originalHome string
@@ -109,7 +238,7 @@ spec:
 		})
 
 		It("should fail if kubeconfig does not exist", func() {
-			_, err := NewManager()
+			_, err := NewManager("", "")
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("no such file or directory"))
 		})
@@ -125,86 +254,384 @@ spec:
 			// We expect an error here because the kubeconfig is empty and invalid for creating clients,
 			// but we are testing that the file-loading part of NewManager works.
 			// A full integration test would need a valid kubeconfig.
-			_, err := NewManager()
+			_, err := NewManager("", "")
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("invalid configuration"))
 		})
+
+		It("should default to the multi-platform-controller namespace unless one is given", func() {
+			kubeconfigDir := filepath.Join(tempHome, ".kube")
+			Expect(os.MkdirAll(kubeconfigDir, 0755)).To(Succeed())
+			kubeconfig := `apiVersion: v1
+kind: Config
+clusters:
+- name: kind-konflux
+  cluster:
+    server: https://127.0.0.1:6443
+contexts:
+- name: kind-konflux
+  context:
+    cluster: kind-konflux
+    user: kind-konflux
+current-context: kind-konflux
+users:
+- name: kind-konflux
+  user:
+    token: test
+`
+			Expect(os.WriteFile(filepath.Join(kubeconfigDir, "config"), []byte(kubeconfig), 0644)).To(Succeed())
+
+			defaultManager, err := NewManager("", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(defaultManager.namespace).To(Equal(DefaultNamespace))
+
+			customManager, err := NewManager("", "custom-mpc")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(customManager.namespace).To(Equal("custom-mpc"))
+		})
+
+		It("should load the configured kubeconfig instead of the one in $HOME", func() {
+			// $HOME has no kubeconfig at all; the configured one is elsewhere
+			kubeconfigPath := filepath.Join(GinkgoT().TempDir(), "ci-kubeconfig")
+			kubeconfig := `apiVersion: v1
+kind: Config
+clusters:
+- name: ci
+  cluster:
+    server: https://10.0.0.7:6443
+contexts:
+- name: ci
+  context:
+    cluster: ci
+    user: ci
+current-context: ci
+users:
+- name: ci
+  user:
+    token: test
+`
+			Expect(os.WriteFile(kubeconfigPath, []byte(kubeconfig), 0600)).To(Succeed())
+
+			manager, err := NewManager(kubeconfigPath, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(manager.kubeconfigPath).To(Equal(kubeconfigPath))
+			Expect(manager.k8sClient.Discovery().RESTClient().Get().URL().Host).To(Equal("10.0.0.7:6443"))
+		})
 	})
 
-	// DISABLED: Integration tests requiring heavy mocking (not worth the effort)
-	// These workflows are tested end-to-end via 'make test-e2e' with real cluster
-	/*
-		Describe("monitorTaskRun", func() {
-			var (
-				fakeTektonClientset *fakeTekton.Clientset
-				managerWithFake     *Manager
-				ctx                 context.Context
-			)
+	Describe("SetTimeout", func() {
+		It("should restore the default for non-positive timeouts", func() {
+			m := &Manager{}
+			m.SetTimeout(time.Hour)
+			Expect(m.timeout).To(Equal(time.Hour))
 
-			BeforeEach(func() {
-				ctx = context.Background()
-				fakeTektonClientset = fakeTekton.NewSimpleClientset()
-				managerWithFake = &Manager{tektonClient: fakeTektonClientset}
-			})
+			m.SetTimeout(0)
+			Expect(m.timeout).To(Equal(DefaultTimeout))
+		})
 
-			It("should return Succeeded when TaskRun completes successfully", func() {
-				taskRunName := "test-taskrun-success"
-				testTaskRun := &tektonv1.TaskRun{
-					ObjectMeta: metav1.ObjectMeta{Name: taskRunName, Namespace: namespace},
-				}
-				testTaskRun.Status.SetConditions(apis.Conditions{{
-					Type:   apis.ConditionSucceeded,
-					Status: corev1.ConditionTrue,
-				}})
-				_, err := fakeTektonClientset.TektonV1().TaskRuns(namespace).Create(ctx, testTaskRun, metav1.CreateOptions{})
-				Expect(err).NotTo(HaveOccurred())
+		It("should bound how long a TaskRun is monitored", func() {
+			m := &Manager{tektonClient: fakeTekton.NewSimpleClientset(), namespace: DefaultNamespace}
+			m.SetTimeout(50 * time.Millisecond)
 
-				status, err := managerWithFake.monitorTaskRun(ctx, taskRunName)
-				Expect(err).NotTo(HaveOccurred())
-				Expect(status).To(Equal("Succeeded"))
-			})
+			status, err := m.monitorTaskRun(context.Background(), "never-finishes")
+			Expect(status).To(Equal("Timeout"))
+			Expect(err).To(MatchError(ContainSubstring("50ms")))
+		})
+	})
 
-			It("should return Failed when TaskRun fails", func() {
-				taskRunName := "test-taskrun-fail"
-				testTaskRun := &tektonv1.TaskRun{
-					ObjectMeta: metav1.ObjectMeta{Name: taskRunName, Namespace: namespace},
-				}
-				testTaskRun.Status.SetConditions(apis.Conditions{{
-					Type:   apis.ConditionSucceeded,
-					Status: corev1.ConditionFalse,
-				}})
-				_, err := fakeTektonClientset.TektonV1().TaskRuns(namespace).Create(ctx, testTaskRun, metav1.CreateOptions{})
-				Expect(err).NotTo(HaveOccurred())
+	Describe("namespace", func() {
+		const customNamespace = "custom-mpc"
 
-				status, err := managerWithFake.monitorTaskRun(ctx, taskRunName)
+		var (
+			ctx          context.Context
+			fakeTektonCS *fakeTekton.Clientset
+			fakeK8sCS    *kubernetesFake.Clientset
+			nsManager    *Manager
+		)
+
+		// actionNamespaces returns the namespaces of all recorded client calls
+		actionNamespaces := func(actions []k8stesting.Action) []string {
+			namespaces := make([]string, 0, len(actions))
+			for _, action := range actions {
+				namespaces = append(namespaces, action.GetNamespace())
+			}
+			return namespaces
+		}
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			fakeTektonCS = fakeTekton.NewSimpleClientset()
+			fakeK8sCS = kubernetesFake.NewSimpleClientset()
+			nsManager = &Manager{
+				tektonClient: fakeTektonCS,
+				k8sClient:    fakeK8sCS,
+				namespace:    customNamespace,
+			}
+		})
+
+		It("should delete TaskRuns in the configured namespace", func() {
+			for _, ns := range []string{customNamespace, DefaultNamespace} {
+				_, err := fakeTektonCS.TektonV1().TaskRuns(ns).Create(ctx, &tektonv1.TaskRun{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-taskrun", Namespace: ns},
+				}, metav1.CreateOptions{})
 				Expect(err).NotTo(HaveOccurred())
-				Expect(status).To(Equal("Failed"))
-			})
+			}
+			fakeTektonCS.ClearActions()
+
+			Expect(nsManager.CancelTaskRun(ctx, "my-taskrun")).To(Succeed())
+
+			Expect(actionNamespaces(fakeTektonCS.Actions())).To(ConsistOf(customNamespace))
+			_, err := fakeTektonCS.TektonV1().TaskRuns(customNamespace).Get(ctx, "my-taskrun", metav1.GetOptions{})
+			Expect(err).To(HaveOccurred())
+			_, err = fakeTektonCS.TektonV1().TaskRuns(DefaultNamespace).Get(ctx, "my-taskrun", metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should find the TaskRun pod in the configured namespace", func() {
+			_, err := fakeK8sCS.CoreV1().Pods(customNamespace).Create(ctx, &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-taskrun-pod",
+					Namespace: customNamespace,
+					Labels:    map[string]string{"tekton.dev/taskRun": "my-taskrun"},
+				},
+				Status: corev1.PodStatus{Phase: corev1.PodRunning},
+			}, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			fakeK8sCS.ClearActions()
+
+			pod, err := nsManager.waitForTaskRunPod(ctx, "my-taskrun", 5*time.Second)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pod.Name).To(Equal("my-taskrun-pod"))
+			Expect(actionNamespaces(fakeK8sCS.Actions())).To(ConsistOf(customNamespace))
+		})
+
+		It("should stream container logs from the configured namespace", func() {
+			var logs bytes.Buffer
+			Expect(nsManager.streamContainerLogs(ctx, "my-taskrun-pod", "step-echo", &logs)).To(Succeed())
+
+			Expect(logs.String()).NotTo(BeEmpty())
+			Expect(actionNamespaces(fakeK8sCS.Actions())).To(ConsistOf(customNamespace))
+		})
+	})
+
+	Describe("ListTaskRuns", func() {
+		var (
+			ctx          context.Context
+			fakeTektonCS *fakeTekton.Clientset
+			listManager  *Manager
+			base         time.Time
+		)
+
+		// seedTaskRun creates a TaskRun created minutesAgo before base, with the given Succeeded condition
+		seedTaskRun := func(name string, minutesAgo int, condition *apis.Condition) {
+			created := metav1.NewTime(base.Add(-time.Duration(minutesAgo) * time.Minute))
+			taskRun := &tektonv1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: DefaultNamespace, CreationTimestamp: created},
+			}
+			if condition != nil {
+				taskRun.Status.StartTime = &created
+				taskRun.Status.SetCondition(condition)
+			}
+			if condition != nil && !condition.IsUnknown() {
+				completed := metav1.NewTime(created.Add(2 * time.Minute))
+				taskRun.Status.CompletionTime = &completed
+			}
+			_, err := fakeTektonCS.TektonV1().TaskRuns(DefaultNamespace).Create(ctx, taskRun, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			base = time.Date(2025, 11, 27, 14, 0, 0, 0, time.UTC)
+			fakeTektonCS = fakeTekton.NewSimpleClientset()
+			listManager = &Manager{tektonClient: fakeTektonCS, namespace: DefaultNamespace}
+
+			seedTaskRun("oldest-succeeded", 30, &apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue})
+			seedTaskRun("newest-pending", 0, nil)
+			seedTaskRun("middle-failed", 20, &apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionFalse})
+			seedTaskRun("recent-running", 10, &apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown})
+		})
+
+		It("should map each TaskRun to its status and times, newest first", func() {
+			summaries, err := listManager.ListTaskRuns(ctx, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(summaries).To(Equal([]TaskRunSummary{
+				{Name: "newest-pending", Status: "Pending"},
+				{Name: "recent-running", Status: "Running", StartTime: "2025-11-27T13:50:00Z"},
+				{Name: "middle-failed", Status: "Failed", StartTime: "2025-11-27T13:40:00Z", CompletionTime: "2025-11-27T13:42:00Z"},
+				{Name: "oldest-succeeded", Status: "Succeeded", StartTime: "2025-11-27T13:30:00Z", CompletionTime: "2025-11-27T13:32:00Z"},
+			}))
+		})
+
+		It("should return at most limit TaskRuns", func() {
+			summaries, err := listManager.ListTaskRuns(ctx, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(summaries).To(HaveLen(2))
+			Expect(summaries[0].Name).To(Equal("newest-pending"))
+			Expect(summaries[1].Name).To(Equal("recent-running"))
+		})
+
+		It("should only list TaskRuns in the manager's namespace", func() {
+			otherManager := &Manager{tektonClient: fakeTektonCS, namespace: "custom-mpc"}
+			summaries, err := otherManager.ListTaskRuns(ctx, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(summaries).To(BeEmpty())
+		})
+	})
+
+	Describe("streamPodLogs", func() {
+		var (
+			ctx         context.Context
+			fakeK8sCS   *kubernetesFake.Clientset
+			logsManager *Manager
+			pod         *corev1.Pod
+		)
 
-			It("should timeout if TaskRun does not complete", func() {
-				taskRunName := "test-taskrun-running"
-				testTaskRun := &tektonv1.TaskRun{
-					ObjectMeta: metav1.ObjectMeta{Name: taskRunName, Namespace: namespace},
+		// requestedContainers returns the containers whose logs were requested, in order
+		requestedContainers := func() []string {
+			containers := []string{}
+			for _, action := range fakeK8sCS.Actions() {
+				if action.GetSubresource() != "log" {
+					continue
 				}
-				testTaskRun.Status.SetConditions(apis.Conditions{{
-					Type:   apis.ConditionSucceeded,
-					Status: corev1.ConditionUnknown,
-				}})
-				_, err := fakeTektonClientset.TektonV1().TaskRuns(namespace).Create(ctx, testTaskRun, metav1.CreateOptions{})
-				Expect(err).NotTo(HaveOccurred())
+				opts := action.(k8stesting.GenericAction).GetValue().(*corev1.PodLogOptions)
+				containers = append(containers, opts.Container)
+			}
+			return containers
+		}
 
-				// Temporarily reduce timeout for testing purposes
-				originalTimeout := monitorTaskRunTimeout
-				DeferCleanup(func() { monitorTaskRunTimeout = originalTimeout })
-				monitorTaskRunTimeout = 1 * time.Second
+		BeforeEach(func() {
+			ctx = context.Background()
+			fakeK8sCS = kubernetesFake.NewSimpleClientset()
+			logsManager = &Manager{k8sClient: fakeK8sCS, namespace: DefaultNamespace}
+			pod = &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-taskrun-pod", Namespace: DefaultNamespace},
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{{Name: "prepare"}, {Name: "place-scripts"}},
+					Containers:     []corev1.Container{{Name: "step-build"}},
+				},
+			}
+		})
 
-				status, err := managerWithFake.monitorTaskRun(ctx, taskRunName)
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("timed out"))
-				Expect(status).To(Equal("Timeout"))
+		It("should stream init container logs with a prefix before the step containers", func() {
+			var logs bytes.Buffer
+			logsManager.streamPodLogs(ctx, pod, &logs)
+
+			Expect(requestedContainers()).To(Equal([]string{"prepare", "place-scripts", "step-build"}))
+			Expect(logs.String()).To(Equal("[init:prepare] fake logs\n[init:place-scripts] fake logs\nfake logs"))
+		})
+
+		It("should skip the step containers when an init container failed", func() {
+			pod.Status.Phase = corev1.PodFailed
+			pod.Status.InitContainerStatuses = []corev1.ContainerStatus{
+				{Name: "prepare", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}}},
+				{Name: "place-scripts", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1}}},
+			}
+
+			var logs bytes.Buffer
+			logsManager.streamPodLogs(ctx, pod, &logs)
+
+			Expect(requestedContainers()).To(Equal([]string{"prepare", "place-scripts"}))
+			Expect(logs.String()).To(HaveSuffix("[init:place-scripts] failed, step containers were not started\n"))
+		})
+	})
+
+	Describe("prefixWriter", func() {
+		It("should prefix every line, including lines split across writes", func() {
+			var out bytes.Buffer
+			w := &prefixWriter{w: &out, prefix: "[init:prepare] "}
+
+			_, err := w.Write([]byte("first\nsec"))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = w.Write([]byte("ond\nthird"))
+			Expect(err).NotTo(HaveOccurred())
+			w.finish()
+
+			Expect(out.String()).To(Equal("[init:prepare] first\n[init:prepare] second\n[init:prepare] third\n"))
+		})
+	})
+
+	Describe("monitorTaskRun", func() {
+		var (
+			fakeTektonClientset *fakeTekton.Clientset
+			managerWithFake     *Manager
+			ctx                 context.Context
+		)
+
+		// createTaskRun stores a TaskRun with the given Succeeded condition status in the fake clientset
+		createTaskRun := func(name string, status corev1.ConditionStatus) *tektonv1.TaskRun {
+			testTaskRun := &tektonv1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: DefaultNamespace},
+			}
+			testTaskRun.Status.SetCondition(&apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: status,
 			})
+			return testTaskRun
+		}
+
+		BeforeEach(func() {
+			originalInterval := monitorPollInterval
+			DeferCleanup(func() { monitorPollInterval = originalInterval })
+			monitorPollInterval = 10 * time.Millisecond
+
+			ctx = context.Background()
+			fakeTektonClientset = fakeTekton.NewSimpleClientset()
+			managerWithFake = &Manager{tektonClient: fakeTektonClientset, namespace: DefaultNamespace, timeout: DefaultTimeout}
 		})
-	*/
+
+		It("should return Succeeded and record results when TaskRun completes successfully", func() {
+			taskRunName := "test-taskrun-success"
+			testTaskRun := createTaskRun(taskRunName, corev1.ConditionTrue)
+			testTaskRun.Status.Results = []tektonv1.TaskRunResult{
+				{Name: "IMAGE_DIGEST", Type: tektonv1.ResultsTypeString, Value: *tektonv1.NewStructuredValues("sha256:0123abcd")},
+			}
+			_, err := fakeTektonClientset.TektonV1().TaskRuns(DefaultNamespace).Create(ctx, testTaskRun, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			status, err := managerWithFake.monitorTaskRun(ctx, taskRunName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status).To(Equal("Succeeded"))
+			Expect(managerWithFake.Results()).To(Equal(map[string]string{"IMAGE_DIGEST": "sha256:0123abcd"}))
+		})
+
+		It("should return Failed when TaskRun fails", func() {
+			taskRunName := "test-taskrun-fail"
+			_, err := fakeTektonClientset.TektonV1().TaskRuns(DefaultNamespace).Create(ctx, createTaskRun(taskRunName, corev1.ConditionFalse), metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			status, err := managerWithFake.monitorTaskRun(ctx, taskRunName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status).To(Equal("Failed"))
+			Expect(managerWithFake.Results()).To(BeNil())
+		})
+
+		It("should timeout if TaskRun does not complete", func() {
+			taskRunName := "test-taskrun-running"
+			_, err := fakeTektonClientset.TektonV1().TaskRuns(DefaultNamespace).Create(ctx, createTaskRun(taskRunName, corev1.ConditionUnknown), metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			managerWithFake.SetTimeout(100 * time.Millisecond)
+
+			status, err := managerWithFake.monitorTaskRun(ctx, taskRunName)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("timed out"))
+			Expect(status).To(Equal("Timeout"))
+		})
+
+		It("should stop early when the context is cancelled", func() {
+			taskRunName := "test-taskrun-cancelled"
+			_, err := fakeTektonClientset.TektonV1().TaskRuns(DefaultNamespace).Create(ctx, createTaskRun(taskRunName, corev1.ConditionUnknown), metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			cancelCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+			defer cancel()
+
+			status, err := managerWithFake.monitorTaskRun(cancelCtx, taskRunName)
+			Expect(err).To(MatchError(context.DeadlineExceeded))
+			Expect(status).To(Equal("Cancelled"))
+		})
+	})
 
 	// DISABLED: Integration tests requiring heavy mocking (not worth the effort)
 	// These workflows are tested end-to-end via 'make test-e2e' with real cluster
@@ -219,14 +646,14 @@ spec:
 			BeforeEach(func() {
 				ctx = context.Background()
 				fakeK8sClientset = kubernetesFake.NewSimpleClientset()
-				managerWithFake = &Manager{k8sClient: fakeK8sClientset}
+				managerWithFake = &Manager{k8sClient: fakeK8sClientset, namespace: DefaultNamespace}
 			})
 
 			It("should return the pod when it is created and running", func() {
 				taskRunName := "test-pod-running"
 				podName := "test-pod-running-pod"
-				_, err := fakeK8sClientset.CoreV1().Pods(namespace).Create(ctx, &corev1.Pod{
-					ObjectMeta: metav1.ObjectMeta{Name: podName, Namespace: namespace, Labels: map[string]string{"tekton.dev/taskRun": taskRunName}},
+				_, err := fakeK8sClientset.CoreV1().Pods(DefaultNamespace).Create(ctx, &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: podName, Namespace: DefaultNamespace, Labels: map[string]string{"tekton.dev/taskRun": taskRunName}},
 					Status:     corev1.PodStatus{Phase: corev1.PodRunning},
 				}, metav1.CreateOptions{})
 				Expect(err).NotTo(HaveOccurred())
@@ -249,8 +676,8 @@ spec:
 			It("should timeout if the pod is created but never runs", func() {
 				taskRunName := "test-pod-pending"
 				podName := "test-pod-pending-pod"
-				_, err := fakeK8sClientset.CoreV1().Pods(namespace).Create(ctx, &corev1.Pod{
-					ObjectMeta: metav1.ObjectMeta{Name: podName, Namespace: namespace, Labels: map[string]string{"tekton.dev/taskRun": taskRunName}},
+				_, err := fakeK8sClientset.CoreV1().Pods(DefaultNamespace).Create(ctx, &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: podName, Namespace: DefaultNamespace, Labels: map[string]string{"tekton.dev/taskRun": taskRunName}},
 					Status:     corev1.PodStatus{Phase: corev1.PodPending},
 				}, metav1.CreateOptions{})
 				Expect(err).NotTo(HaveOccurred())
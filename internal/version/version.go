@@ -0,0 +1,20 @@
+// Package version holds the daemon's build information.
+//
+// The values are injected at link time by the Makefile's build target, e.g.:
+//
+//	go build -ldflags "-X github.com/meyrevived/mpc-dev-env/internal/version.Version=v1.2.0" ./cmd/mpc-daemon
+//
+// Binaries built with a plain "go build" report the defaults below.
+package version
+
+// Build information, set via -ldflags "-X".
+var (
+	// Version is the daemon version, typically from "git describe --tags".
+	Version = "dev"
+
+	// GitCommit is the commit of this repository the daemon was built from.
+	GitCommit = "unknown"
+
+	// BuildTime is the UTC build timestamp in RFC 3339 format.
+	BuildTime = "unknown"
+)
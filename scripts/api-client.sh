@@ -20,13 +20,22 @@
 
 readonly DAEMON_URL="http://localhost:8765"
 
+# When the daemon runs with MPC_DAEMON_TOKEN set, every request except the read-only
+# GET /api/health, /api/version, /api/status, /api/status/watch and /api/prerequisites
+# must carry it as a bearer token. The array is expanded with
+# ${arr[@]+...} because bash 3.2 (macOS) treats an empty array as unset under "set -u".
+DAEMON_AUTH_ARGS=()
+if [ -n "${MPC_DAEMON_TOKEN:-}" ]; then
+    DAEMON_AUTH_ARGS=(-H "Authorization: Bearer ${MPC_DAEMON_TOKEN}")
+fi
+
 # daemon_is_running - Check if the daemon is responding to API requests
 #
 # Returns:
-#   0 if daemon is healthy (GET /api/status returns 200)
+#   0 if daemon is healthy (GET /api/health returns 200)
 #   1 if daemon is unreachable or unhealthy
 daemon_is_running() {
-    curl -s -f "${DAEMON_URL}/api/status" >/dev/null 2>&1
+    curl -s -f "${DAEMON_URL}/api/health" >/dev/null 2>&1
 }
 
 # daemon_get_status - Get the current development environment state from daemon
@@ -82,11 +91,11 @@ api_call() {
     local data="${3:-}"
 
     if [ -n "$data" ]; then
-        curl -s -X "$method" "${DAEMON_URL}${endpoint}" \
+        curl -s -X "$method" "${DAEMON_URL}${endpoint}" ${DAEMON_AUTH_ARGS[@]+"${DAEMON_AUTH_ARGS[@]}"} \
             -H "Content-Type: application/json" \
             -d "$data"
     else
-        curl -s -X "$method" "${DAEMON_URL}${endpoint}"
+        curl -s -X "$method" "${DAEMON_URL}${endpoint}" ${DAEMON_AUTH_ARGS[@]+"${DAEMON_AUTH_ARGS[@]}"}
     fi
 }
 
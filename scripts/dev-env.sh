@@ -365,9 +365,19 @@ phase2_daemon_startup() {
             log ERROR "$line"
         done
 
+        # Suggest how to fix each missing or outdated tool
+        echo "$prereq_response" | jq -r '.prerequisites | to_entries[] | select(.value.remediation != null) | "  - \(.key): \(.value.remediation)"' | while IFS= read -r line; do
+            log INFO "To fix: $line"
+        done
+
         exit 1
     fi
 
+    # Low disk space or memory does not block startup, but builds may fail later
+    echo "$prereq_response" | jq -r '.warnings[]? // empty' | while IFS= read -r warning; do
+        log WARN "$warning"
+    done
+
     log SUCCESS "All prerequisites validated successfully"
     log SUCCESS "Phase 2 complete: Daemon is running"
 }